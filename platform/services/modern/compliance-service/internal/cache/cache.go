@@ -0,0 +1,40 @@
+// Package cache - key/value backends behind the compliance response cache:
+// Redis, Memcached and process memory. Values are opaque bytes; encoding,
+// key layout and TTL policy belong to the caller.
+package cache
+
+import (
+    "context"
+    "errors"
+    "time"
+)
+
+// Cache - key/value store behind the response cache. Misses are reported
+// as ErrMiss by Get and as nil by BatchGet.
+type Cache interface {
+    Get(ctx context.Context, key string) ([]byte, error)
+    Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+    Delete(ctx context.Context, keys ...string) error
+    // DeleteByPrefix drops every key under a prefix ending in ':'
+    DeleteByPrefix(ctx context.Context, prefix string) error
+    // BatchGet returns values in key order, nil marking a miss
+    BatchGet(ctx context.Context, keys []string) ([][]byte, error)
+    // BatchSet writes several values in as few round trips as the backend allows
+    BatchSet(ctx context.Context, entries []Entry) error
+}
+
+// Entry - one value of a batch write
+type Entry struct {
+    Key   string
+    Value []byte
+    TTL   time.Duration
+}
+
+// Returned by Cache.Get for absent or expired keys
+var ErrMiss = errors.New("cache miss")
+
+// StatsReporter - implemented by backends that can count their entries and
+// evictions
+type StatsReporter interface {
+    Stats(ctx context.Context) (entries, evictions int64, err error)
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/redis/go-redis/v9"
+)
+
+type backend struct {
+    name string
+    new  func(t *testing.T) (Cache, func(time.Duration))
+}
+
+var backends = []backend{
+    {name: "memory", new: func(t *testing.T) (Cache, func(time.Duration)) {
+        return NewMemory(), time.Sleep
+    }},
+    {name: "redis", new: func(t *testing.T) (Cache, func(time.Duration)) {
+        server := miniredis.RunT(t)
+        client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+        t.Cleanup(func() { client.Close() })
+        return NewRedis(client), server.FastForward
+    }},
+    {name: "memcached", new: func(t *testing.T) (Cache, func(time.Duration)) {
+        // Two nodes, so keys and generations are spread over both
+        first, firstAddr := runFakeMemcached(t)
+        second, secondAddr := runFakeMemcached(t)
+        c, err := NewMemcached(firstAddr + "," + secondAddr)
+        if err != nil {
+            t.Fatal(err)
+        }
+        return c, func(d time.Duration) {
+            first.FastForward(d)
+            second.FastForward(d)
+        }
+    }},
+}
+
+// Every backend behaves the same behind the response cache
+func TestBackendContract(t *testing.T) {
+    ctx := context.Background()
+    for _, b := range backends {
+        t.Run(b.name, func(t *testing.T) {
+            c, advance := b.new(t)
+
+            if _, err := c.Get(ctx, "compliance:missing"); !errors.Is(err, ErrMiss) {
+                t.Errorf("Get of a missing key = %v, want ErrMiss", err)
+            }
+
+            if err := c.Set(ctx, "compliance:org-1", []byte("v1"), time.Hour); err != nil {
+                t.Fatal(err)
+            }
+            if got, err := c.Get(ctx, "compliance:org-1"); err != nil || string(got) != "v1" {
+                t.Errorf("Get = %q, %v, want v1", got, err)
+            }
+
+            err := c.BatchSet(ctx, []Entry{
+                {Key: "framework:org-1:SAMA", Value: []byte("sama"), TTL: time.Hour},
+                {Key: "framework:org-1:NCA", Value: []byte("nca"), TTL: time.Hour},
+                {Key: "framework:org-2:SAMA", Value: []byte("other"), TTL: time.Hour},
+            })
+            if err != nil {
+                t.Fatal(err)
+            }
+            values, err := c.BatchGet(ctx, []string{"framework:org-1:NCA", "framework:org-1:PDPL", "framework:org-1:SAMA"})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if want := [][]byte{[]byte("nca"), nil, []byte("sama")}; !reflect.DeepEqual(values, want) {
+                t.Errorf("BatchGet = %q, want %q", values, want)
+            }
+
+            if err := c.DeleteByPrefix(ctx, "framework:org-1"); err == nil {
+                t.Error("DeleteByPrefix accepted a prefix not ending in ':'")
+            }
+            if err := c.DeleteByPrefix(ctx, "framework:org-1:"); err != nil {
+                t.Fatal(err)
+            }
+            values, err = c.BatchGet(ctx, []string{"framework:org-1:SAMA", "framework:org-2:SAMA"})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if values[0] != nil || string(values[1]) != "other" {
+                t.Errorf("after DeleteByPrefix got %q, want only org-2 left", values)
+            }
+
+            if err := c.Delete(ctx, "compliance:org-1", "compliance:missing"); err != nil {
+                t.Fatal(err)
+            }
+            if _, err := c.Get(ctx, "compliance:org-1"); !errors.Is(err, ErrMiss) {
+                t.Errorf("Get after Delete = %v, want ErrMiss", err)
+            }
+
+            if err := c.Set(ctx, "compliance:short", []byte("v"), 20*time.Millisecond); err != nil {
+                t.Fatal(err)
+            }
+            advance(50 * time.Millisecond)
+            if _, err := c.Get(ctx, "compliance:short"); !errors.Is(err, ErrMiss) {
+                t.Errorf("Get past the TTL = %v, want ErrMiss", err)
+            }
+        })
+    }
+}
+
+// The in-memory backend counts what it drops for expiry as evictions
+func TestMemoryCountsExpired(t *testing.T) {
+    ctx := context.Background()
+    c := NewMemory()
+    c.Set(ctx, "a", []byte("1"), time.Millisecond)
+    time.Sleep(5 * time.Millisecond)
+    if _, err := c.Get(ctx, "a"); !errors.Is(err, ErrMiss) {
+        t.Fatalf("Get = %v, want ErrMiss", err)
+    }
+    entries, expired, _ := c.Stats(ctx)
+    if entries != 0 || expired != 1 {
+        t.Errorf("Stats = %d entries, %d expired, want 0 and 1", entries, expired)
+    }
+}
+
+// Values written are copied, so a caller reusing its buffer cannot change them
+func TestMemoryCopiesValues(t *testing.T) {
+    ctx := context.Background()
+    c := NewMemory()
+    value := []byte("v1")
+    c.Set(ctx, "a", value, 0)
+    value[1] = '2'
+    if got, _ := c.Get(ctx, "a"); string(got) != "v1" {
+        t.Errorf("Get = %q, want v1", got)
+    }
+}
@@ -0,0 +1,265 @@
+package cache
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "hash/fnv"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/bradfitz/gomemcache/memcache"
+)
+
+// Memcached treats expirations beyond 30 days as absolute Unix times
+const memcachedMaxRelativeTTL = 30 * 24 * time.Hour
+
+// Memcached - Cache spread over Memcached nodes. Keys are placed with
+// rendezvous hashing, so adding or removing a node only moves the keys that
+// node owns.
+//
+// Memcached cannot enumerate keys, so DeleteByPrefix bumps a generation
+// counter for the prefix instead. Every ':'-terminated prefix of a key has
+// such a counter and the stored key is a digest of the key and all of its
+// prefixes' generations: bumping one orphans everything under it, and the
+// orphans age out by TTL. A generation is created from the clock the first
+// time it is needed, so an evicted counter never revives old entries.
+type Memcached struct {
+    nodes   []string
+    clients map[string]*memcache.Client
+}
+
+// Connect to Memcached nodes given as "host:port,host:port"
+func NewMemcached(servers string) (*Memcached, error) {
+    c := &Memcached{clients: make(map[string]*memcache.Client)}
+    for _, server := range strings.Split(servers, ",") {
+        if server = strings.TrimSpace(server); server != "" && c.clients[server] == nil {
+            c.nodes = append(c.nodes, server)
+            c.clients[server] = memcache.New(server)
+        }
+    }
+    if len(c.nodes) == 0 {
+        return nil, fmt.Errorf("MEMCACHED_SERVERS is required for the memcached cache backend")
+    }
+    for _, node := range c.nodes {
+        if err := c.clients[node].Ping(); err != nil {
+            return nil, fmt.Errorf("failed to connect to Memcached %s: %v", node, err)
+        }
+    }
+    return c, nil
+}
+
+// Node owning a stored key: the highest hash of node and key
+func (c *Memcached) node(key string) *memcache.Client {
+    var best string
+    var bestScore uint64
+    for _, node := range c.nodes {
+        h := fnv.New64a()
+        h.Write([]byte(node))
+        h.Write([]byte{0})
+        h.Write([]byte(key))
+        if score := h.Sum64(); best == "" || score > bestScore {
+            best, bestScore = node, score
+        }
+    }
+    return c.clients[best]
+}
+
+func (c *Memcached) Get(ctx context.Context, key string) ([]byte, error) {
+    values, err := c.BatchGet(ctx, []string{key})
+    if err != nil {
+        return nil, err
+    }
+    if values[0] == nil {
+        return nil, ErrMiss
+    }
+    return values[0], nil
+}
+
+func (c *Memcached) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    return c.BatchSet(ctx, []Entry{{Key: key, Value: value, TTL: ttl}})
+}
+
+func (c *Memcached) Delete(ctx context.Context, keys ...string) error {
+    stored, err := c.storedKeys(keys)
+    if err != nil {
+        return err
+    }
+    for _, key := range stored {
+        if err := c.node(key).Delete(key); err != nil && err != memcache.ErrCacheMiss {
+            return err
+        }
+    }
+    return nil
+}
+
+// DeleteByPrefix bumps the prefix's generation, orphaning every key under it
+func (c *Memcached) DeleteByPrefix(ctx context.Context, prefix string) error {
+    if !strings.HasSuffix(prefix, ":") {
+        return fmt.Errorf("cache prefix %q must end in ':'", prefix)
+    }
+    key := generationKey(prefix)
+    _, err := c.node(key).Increment(key, 1)
+    if err == memcache.ErrCacheMiss {
+        // Nothing was stored under the current generation; starting a new
+        // one is enough
+        _, err = c.generations([]string{prefix})
+    }
+    return err
+}
+
+// BatchGet reads each node's share of the keys in one round trip
+func (c *Memcached) BatchGet(ctx context.Context, keys []string) ([][]byte, error) {
+    stored, err := c.storedKeys(keys)
+    if err != nil {
+        return nil, err
+    }
+
+    byNode := make(map[*memcache.Client][]string)
+    for _, key := range stored {
+        client := c.node(key)
+        byNode[client] = append(byNode[client], key)
+    }
+    items := make(map[string]*memcache.Item, len(stored))
+    for client, nodeKeys := range byNode {
+        found, err := client.GetMulti(nodeKeys)
+        if err != nil {
+            return nil, err
+        }
+        for key, item := range found {
+            items[key] = item
+        }
+    }
+
+    values := make([][]byte, len(keys))
+    for i, key := range stored {
+        if item, ok := items[key]; ok {
+            values[i] = item.Value
+        }
+    }
+    return values, nil
+}
+
+// BatchSet writes entries one by one; the Memcached protocol has no multi-set
+func (c *Memcached) BatchSet(ctx context.Context, entries []Entry) error {
+    keys := make([]string, len(entries))
+    for i, entry := range entries {
+        keys[i] = entry.Key
+    }
+    stored, err := c.storedKeys(keys)
+    if err != nil {
+        return err
+    }
+    for i, entry := range entries {
+        item := &memcache.Item{Key: stored[i], Value: entry.Value, Expiration: memcachedExpiration(entry.TTL)}
+        if err := c.node(item.Key).Set(item); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// Stored keys for logical keys: a digest of each key and the current
+// generations of its prefixes, which also keeps keys within Memcached's
+// length and character limits
+func (c *Memcached) storedKeys(keys []string) ([]string, error) {
+    var prefixes []string
+    seen := make(map[string]bool)
+    for _, key := range keys {
+        for _, prefix := range keyPrefixes(key) {
+            if !seen[prefix] {
+                seen[prefix] = true
+                prefixes = append(prefixes, prefix)
+            }
+        }
+    }
+    generations, err := c.generations(prefixes)
+    if err != nil {
+        return nil, err
+    }
+
+    stored := make([]string, len(keys))
+    for i, key := range keys {
+        h := sha256.New()
+        h.Write([]byte(key))
+        for _, prefix := range keyPrefixes(key) {
+            h.Write([]byte{0})
+            h.Write([]byte(generations[prefix]))
+        }
+        stored[i] = "cache:" + hex.EncodeToString(h.Sum(nil))
+    }
+    return stored, nil
+}
+
+// Current generation of each prefix, read in one round trip per node and
+// created where missing
+func (c *Memcached) generations(prefixes []string) (map[string]string, error) {
+    byNode := make(map[*memcache.Client][]string)
+    for _, prefix := range prefixes {
+        key := generationKey(prefix)
+        byNode[c.node(key)] = append(byNode[c.node(key)], key)
+    }
+    items := make(map[string]*memcache.Item, len(prefixes))
+    for client, keys := range byNode {
+        found, err := client.GetMulti(keys)
+        if err != nil {
+            return nil, err
+        }
+        for key, item := range found {
+            items[key] = item
+        }
+    }
+
+    generations := make(map[string]string, len(prefixes))
+    for _, prefix := range prefixes {
+        key := generationKey(prefix)
+        item, ok := items[key]
+        if !ok {
+            client := c.node(key)
+            created := &memcache.Item{Key: key, Value: []byte(strconv.FormatInt(time.Now().UnixNano(), 10))}
+            if err := client.Add(created); err != nil && err != memcache.ErrNotStored {
+                return nil, err
+            }
+            // Lost a race to create it: read the winner's generation
+            var err error
+            if item, err = client.Get(key); err != nil {
+                return nil, err
+            }
+        }
+        generations[prefix] = string(item.Value)
+    }
+    return generations, nil
+}
+
+// Every ':'-terminated prefix of a key, shortest first
+func keyPrefixes(key string) []string {
+    var prefixes []string
+    for i := 0; i < len(key); i++ {
+        if key[i] == ':' {
+            prefixes = append(prefixes, key[:i+1])
+        }
+    }
+    return prefixes
+}
+
+func generationKey(prefix string) string {
+    sum := sha256.Sum256([]byte(prefix))
+    return "gen:" + hex.EncodeToString(sum[:])
+}
+
+// Expiration in Memcached's form; sub-second TTLs round up, since 0 would
+// never expire
+func memcachedExpiration(ttl time.Duration) int32 {
+    if ttl <= 0 {
+        return 0
+    }
+    if ttl > memcachedMaxRelativeTTL {
+        return int32(time.Now().Add(ttl).Unix())
+    }
+    if ttl < time.Second {
+        return 1
+    }
+    return int32(ttl / time.Second)
+}
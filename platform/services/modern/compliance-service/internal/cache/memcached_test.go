@@ -0,0 +1,172 @@
+package cache
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "net"
+    "strconv"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+)
+
+// In-process Memcached speaking the text protocol commands the client
+// uses. Its clock only moves on FastForward and, like Memcached's, ticks in
+// whole seconds.
+type fakeMemcached struct {
+    mu    sync.Mutex
+    now   time.Time
+    cas   uint64
+    items map[string]fakeItem
+}
+
+type fakeItem struct {
+    flags   uint32
+    value   []byte
+    expires time.Time // Zero never expires
+    cas     uint64
+}
+
+// Start a fake Memcached node that stops with the test
+func runFakeMemcached(t *testing.T) (*fakeMemcached, string) {
+    t.Helper()
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { listener.Close() })
+    m := &fakeMemcached{now: time.Now().Truncate(time.Second), items: make(map[string]fakeItem)}
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil {
+                return
+            }
+            go m.serve(conn)
+        }
+    }()
+    return m, listener.Addr().String()
+}
+
+// FastForward moves the clock on by d, rounded up to a whole second
+func (m *fakeMemcached) FastForward(d time.Duration) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.now = m.now.Add((d + time.Second - 1).Truncate(time.Second))
+}
+
+func (m *fakeMemcached) serve(conn net.Conn) {
+    defer conn.Close()
+    r := bufio.NewReader(conn)
+    w := bufio.NewWriter(conn)
+    for {
+        line, err := r.ReadString('\n')
+        if err != nil {
+            return
+        }
+        args := strings.Fields(line)
+        if len(args) == 0 {
+            continue
+        }
+        if err := m.handle(r, w, args); err != nil {
+            return
+        }
+        if err := w.Flush(); err != nil {
+            return
+        }
+    }
+}
+
+func (m *fakeMemcached) handle(r *bufio.Reader, w *bufio.Writer, args []string) error {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    switch args[0] {
+    case "version":
+        w.WriteString("VERSION fake\r\n")
+    case "get", "gets":
+        for _, key := range args[1:] {
+            if item, ok := m.lookup(key); ok {
+                fmt.Fprintf(w, "VALUE %s %d %d %d\r\n", key, item.flags, len(item.value), item.cas)
+                w.Write(item.value)
+                w.WriteString("\r\n")
+            }
+        }
+        w.WriteString("END\r\n")
+    case "set", "add":
+        if len(args) < 5 {
+            w.WriteString("ERROR\r\n")
+            return nil
+        }
+        flags, _ := strconv.ParseUint(args[2], 10, 32)
+        exptime, _ := strconv.ParseInt(args[3], 10, 64)
+        size, err := strconv.Atoi(args[4])
+        if err != nil {
+            w.WriteString("CLIENT_ERROR bad data chunk\r\n")
+            return nil
+        }
+        value := make([]byte, size+2)
+        if _, err := io.ReadFull(r, value); err != nil {
+            return err
+        }
+        if _, exists := m.lookup(args[1]); args[0] == "add" && exists {
+            w.WriteString("NOT_STORED\r\n")
+            return nil
+        }
+        m.cas++
+        m.items[args[1]] = fakeItem{flags: uint32(flags), value: value[:size], expires: m.expiry(exptime), cas: m.cas}
+        w.WriteString("STORED\r\n")
+    case "delete":
+        if _, ok := m.lookup(args[1]); !ok {
+            w.WriteString("NOT_FOUND\r\n")
+            return nil
+        }
+        delete(m.items, args[1])
+        w.WriteString("DELETED\r\n")
+    case "incr":
+        item, ok := m.lookup(args[1])
+        if !ok {
+            w.WriteString("NOT_FOUND\r\n")
+            return nil
+        }
+        current, err := strconv.ParseUint(string(item.value), 10, 64)
+        if err != nil {
+            w.WriteString("CLIENT_ERROR cannot increment or decrement non-numeric value\r\n")
+            return nil
+        }
+        delta, _ := strconv.ParseUint(args[2], 10, 64)
+        m.cas++
+        item.value = []byte(strconv.FormatUint(current+delta, 10))
+        item.cas = m.cas
+        m.items[args[1]] = item
+        fmt.Fprintf(w, "%s\r\n", item.value)
+    default:
+        w.WriteString("ERROR\r\n")
+    }
+    return nil
+}
+
+// An unexpired item; expired ones are dropped. Caller holds mu.
+func (m *fakeMemcached) lookup(key string) (fakeItem, bool) {
+    item, ok := m.items[key]
+    if ok && !item.expires.IsZero() && !m.now.Before(item.expires) {
+        delete(m.items, key)
+        return fakeItem{}, false
+    }
+    return item, ok
+}
+
+// Expiry of a relative TTL in seconds, or of an absolute Unix time past
+// 30 days. Caller holds mu.
+func (m *fakeMemcached) expiry(exptime int64) time.Time {
+    switch {
+    case exptime <= 0:
+        return time.Time{}
+    case exptime > int64(memcachedMaxRelativeTTL/time.Second):
+        return time.Unix(exptime, 0)
+    default:
+        return m.now.Add(time.Duration(exptime) * time.Second)
+    }
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Expired entries are swept after this many writes
+const memorySweepEvery = 1024
+
+// Memory - Cache held in process memory, for single-replica and
+// development deployments. Entries are not shared between replicas.
+type Memory struct {
+    mu      sync.Mutex
+    entries map[string]memoryEntry
+    writes  int
+    expired int64 // Expired entries dropped on read or swept
+}
+
+type memoryEntry struct {
+    value     []byte
+    expiresAt time.Time // Zero never expires
+}
+
+// Create an in-memory cache backend
+func NewMemory() *Memory {
+    return &Memory{entries: make(map[string]memoryEntry)}
+}
+
+func (c *Memory) Get(ctx context.Context, key string) ([]byte, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    value, ok := c.getLocked(key, time.Now())
+    if !ok {
+        return nil, ErrMiss
+    }
+    return value, nil
+}
+
+func (c *Memory) getLocked(key string, now time.Time) ([]byte, bool) {
+    entry, ok := c.entries[key]
+    if !ok {
+        return nil, false
+    }
+    if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+        delete(c.entries, key)
+        c.expired++
+        return nil, false
+    }
+    return entry.value, true
+}
+
+func (c *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.setLocked(key, value, ttl, time.Now())
+    return nil
+}
+
+func (c *Memory) setLocked(key string, value []byte, ttl time.Duration, now time.Time) {
+    entry := memoryEntry{value: append([]byte(nil), value...)}
+    if ttl > 0 {
+        entry.expiresAt = now.Add(ttl)
+    }
+    c.entries[key] = entry
+
+    c.writes++
+    if c.writes%memorySweepEvery == 0 {
+        for k, e := range c.entries {
+            if !e.expiresAt.IsZero() && now.After(e.expiresAt) {
+                delete(c.entries, k)
+                c.expired++
+            }
+        }
+    }
+}
+
+func (c *Memory) Delete(ctx context.Context, keys ...string) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for _, key := range keys {
+        delete(c.entries, key)
+    }
+    return nil
+}
+
+func (c *Memory) DeleteByPrefix(ctx context.Context, prefix string) error {
+    if !strings.HasSuffix(prefix, ":") {
+        return fmt.Errorf("cache prefix %q must end in ':'", prefix)
+    }
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for key := range c.entries {
+        if strings.HasPrefix(key, prefix) {
+            delete(c.entries, key)
+        }
+    }
+    return nil
+}
+
+func (c *Memory) BatchGet(ctx context.Context, keys []string) ([][]byte, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    now := time.Now()
+    values := make([][]byte, len(keys))
+    for i, key := range keys {
+        values[i], _ = c.getLocked(key, now)
+    }
+    return values, nil
+}
+
+func (c *Memory) BatchSet(ctx context.Context, entries []Entry) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    now := time.Now()
+    for _, entry := range entries {
+        c.setLocked(entry.Key, entry.Value, entry.TTL, now)
+    }
+    return nil
+}
+
+// Entries held, expired ones included until swept, and expired entries
+// dropped so far
+func (c *Memory) Stats(ctx context.Context) (int64, int64, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return int64(len(c.entries)), c.expired, nil
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis - Cache backed by Redis
+type Redis struct {
+    client *redis.Client
+}
+
+// Create a Redis cache backend on an existing connection
+func NewRedis(client *redis.Client) *Redis {
+    return &Redis{client: client}
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+    data, err := c.client.Get(ctx, key).Bytes()
+    if err == redis.Nil {
+        return nil, ErrMiss
+    }
+    return data, err
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *Redis) Delete(ctx context.Context, keys ...string) error {
+    if len(keys) == 0 {
+        return nil
+    }
+    return c.client.Del(ctx, keys...).Err()
+}
+
+// DeleteByPrefix SCANs for matching keys, so it is proportional to the
+// keyspace; it backs rare operations such as organization merges
+func (c *Redis) DeleteByPrefix(ctx context.Context, prefix string) error {
+    if !strings.HasSuffix(prefix, ":") {
+        return fmt.Errorf("cache prefix %q must end in ':'", prefix)
+    }
+    iter := c.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+    var keys []string
+    for iter.Next(ctx) {
+        keys = append(keys, iter.Val())
+    }
+    if err := iter.Err(); err != nil {
+        return err
+    }
+    return c.Delete(ctx, keys...)
+}
+
+// BatchGet reads every key in one MGET round trip
+func (c *Redis) BatchGet(ctx context.Context, keys []string) ([][]byte, error) {
+    if len(keys) == 0 {
+        return nil, nil
+    }
+    values, err := c.client.MGet(ctx, keys...).Result()
+    if err != nil {
+        return nil, err
+    }
+    raw := make([][]byte, len(values))
+    for i, value := range values {
+        if s, ok := value.(string); ok {
+            raw[i] = []byte(s)
+        }
+    }
+    return raw, nil
+}
+
+// BatchSet writes every entry in one pipelined round trip
+func (c *Redis) BatchSet(ctx context.Context, entries []Entry) error {
+    pipe := c.client.Pipeline()
+    for _, entry := range entries {
+        pipe.Set(ctx, entry.Key, entry.Value, entry.TTL)
+    }
+    if pipe.Len() == 0 {
+        return nil
+    }
+    _, err := pipe.Exec(ctx)
+    return err
+}
+
+// Keys in the Redis database and keys Redis evicted under memory pressure.
+// Both cover the whole database, which the service's other state shares.
+func (c *Redis) Stats(ctx context.Context) (int64, int64, error) {
+    entries, err := c.client.DBSize(ctx).Result()
+    if err != nil {
+        return 0, 0, err
+    }
+    info, err := c.client.Info(ctx, "stats").Result()
+    if err != nil {
+        return 0, 0, err
+    }
+    var evictions int64
+    for _, line := range strings.Split(info, "\n") {
+        if value, ok := strings.CutPrefix(strings.TrimSpace(line), "evicted_keys:"); ok {
+            evictions, _ = strconv.ParseInt(value, 10, 64)
+        }
+    }
+    return entries, evictions, nil
+}
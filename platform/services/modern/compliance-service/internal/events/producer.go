@@ -0,0 +1,54 @@
+package events
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/segmentio/kafka-go"
+)
+
+// Producer - publishes compliance events to Kafka
+type Producer struct {
+    writer *kafka.Writer
+}
+
+// Connect a producer to the given comma-separated brokers
+func NewProducer(addr string) (*Producer, error) {
+    if addr == "" {
+        return nil, fmt.Errorf("no Kafka brokers configured")
+    }
+    writer := &kafka.Writer{
+        Addr:                   kafka.TCP(strings.Split(addr, ",")...),
+        Balancer:               &kafka.Hash{},
+        BatchTimeout:           10 * time.Millisecond,
+        RequiredAcks:           kafka.RequireAll,
+        AllowAutoTopicCreation: false,
+    }
+    return &Producer{writer: writer}, nil
+}
+
+// Publish a value as JSON
+func (p *Producer) Publish(topic string, v interface{}) error {
+    value, err := json.Marshal(v)
+    if err != nil {
+        return fmt.Errorf("failed to encode message: %v", err)
+    }
+    return p.PublishMessage(context.Background(), topic, nil, value, nil)
+}
+
+// PublishMessage writes a pre-encoded message with a key and headers
+func (p *Producer) PublishMessage(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+    msg := kafka.Message{Topic: topic, Key: key, Value: value}
+    for name, v := range headers {
+        msg.Headers = append(msg.Headers, kafka.Header{Key: name, Value: []byte(v)})
+    }
+    return p.writer.WriteMessages(ctx, msg)
+}
+
+// Close flushes pending messages and closes the writer
+func (p *Producer) Close() error {
+    return p.writer.Close()
+}
@@ -0,0 +1,449 @@
+package events
+
+import (
+    "context"
+    "encoding/binary"
+    "encoding/json"
+    "fmt"
+    "hash/crc32"
+    "io"
+    "log"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+    // Length and CRC-32C of the payload precede each segment record
+    spoolRecordHeaderSize = 8
+    // Larger lengths can only come from a corrupted header
+    spoolMaxRecordSize = 16 << 20
+
+    spoolSegmentPrefix = "events-"
+    spoolSegmentSuffix = ".seg"
+)
+
+var spoolCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Message - an event whose publish failed, awaiting replay
+type Message struct {
+    Topic      string            `json:"topic"`
+    Key        []byte            `json:"key"`
+    Value      []byte            `json:"value"`
+    Headers    map[string]string `json:"headers,omitempty"`
+    EnqueuedAt time.Time         `json:"enqueued_at"`
+}
+
+// Spool - unpublished events held for replay in order. The newest
+// memoryLimit events are kept in memory; older ones spill to append-only
+// segment files under dir, each record a length, a CRC-32C and the JSON
+// event, and segments are deleted as they drain. Without a dir, or once
+// the disk limit is reached, events that do not fit are dropped. Replay is
+// at least once: segments left by a previous process replay from their
+// start.
+type Spool struct {
+    mu          sync.Mutex
+    memory      []*Message // All newer than anything on disk
+    memoryLimit int
+    dir         string
+    segmentSize int64
+    maxDisk     int64
+    segments    []*spoolSegment // Oldest first; the last is appended to
+    nextSegment int
+    diskEvents  int
+    diskBytes   int64
+}
+
+// spoolSegment - one segment file and how far replay has consumed it
+type spoolSegment struct {
+    path   string
+    size   int64
+    offset int64
+    writer *os.File // Set on the segment being appended to
+    reader *os.File // Opened when replay reaches the segment
+}
+
+// Open a spool, picking up segments a previous process left under dir
+func NewSpool(memoryLimit int, dir string, segmentSize, maxDisk int64) (*Spool, error) {
+    s := &Spool{memoryLimit: memoryLimit, dir: dir, segmentSize: segmentSize, maxDisk: maxDisk}
+    if dir == "" {
+        return s, nil
+    }
+    if err := os.MkdirAll(dir, 0o750); err != nil {
+        return nil, fmt.Errorf("failed to create event spool directory: %v", err)
+    }
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read event spool directory: %v", err)
+    }
+    var names []string
+    for _, entry := range entries {
+        if strings.HasPrefix(entry.Name(), spoolSegmentPrefix) && strings.HasSuffix(entry.Name(), spoolSegmentSuffix) {
+            names = append(names, entry.Name())
+        }
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        segment := &spoolSegment{path: filepath.Join(dir, name)}
+        events, size, err := countSpoolRecords(segment.path)
+        if err != nil {
+            return nil, fmt.Errorf("failed to read event spool segment %s: %v", name, err)
+        }
+        segment.size = size
+        s.segments = append(s.segments, segment)
+        s.diskEvents += events
+        s.diskBytes += size
+        var seq int
+        fmt.Sscanf(strings.TrimPrefix(name, spoolSegmentPrefix), "%d", &seq)
+        s.nextSegment = seq + 1
+    }
+    if s.diskEvents > 0 {
+        log.Printf("Event spool holds %d unpublished events from a previous run", s.diskEvents)
+    }
+    s.observeDepth()
+    return s, nil
+}
+
+// Count the records of a segment by walking their headers; a corrupted
+// length ends the count, as replay skips the rest of the segment there
+func countSpoolRecords(path string) (int, int64, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return 0, 0, err
+    }
+    defer f.Close()
+    info, err := f.Stat()
+    if err != nil {
+        return 0, 0, err
+    }
+    var events int
+    var offset int64
+    header := make([]byte, spoolRecordHeaderSize)
+    for offset+spoolRecordHeaderSize <= info.Size() {
+        if _, err := f.ReadAt(header, offset); err != nil {
+            return 0, 0, err
+        }
+        length := int64(binary.BigEndian.Uint32(header))
+        if length == 0 || length > spoolMaxRecordSize || offset+spoolRecordHeaderSize+length > info.Size() {
+            break
+        }
+        offset += spoolRecordHeaderSize + length
+        events++
+    }
+    return events, info.Size(), nil
+}
+
+// Pending - whether any event awaits replay. New events queue behind them
+// so each organization's events stay in order.
+func (s *Spool) Pending() bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return len(s.memory) > 0 || s.onDisk()
+}
+
+// Whether any segment holds unconsumed bytes
+func (s *Spool) onDisk() bool {
+    for _, segment := range s.segments {
+        if segment.offset < segment.size {
+            return true
+        }
+    }
+    return false
+}
+
+// Enqueue an event, spilling the oldest in memory to disk past the limit
+func (s *Spool) Enqueue(event *Message) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.memory = append(s.memory, event)
+    for len(s.memory) > s.memoryLimit {
+        oldest := s.memory[0]
+        s.memory[0] = nil
+        s.memory = s.memory[1:]
+        if s.dir == "" {
+            eventSpoolDropped.WithLabelValues("memory_full").Inc()
+            continue
+        }
+        if err := s.spill(oldest); err != nil {
+            log.Printf("Dropped unpublished event for %s: %v", oldest.Topic, err)
+        }
+    }
+    s.observeDepth()
+}
+
+// Append an event to the newest segment, rotating when it is full
+func (s *Spool) spill(event *Message) error {
+    payload, err := json.Marshal(event)
+    if err != nil {
+        eventSpoolDropped.WithLabelValues("encode").Inc()
+        return err
+    }
+    size := int64(spoolRecordHeaderSize + len(payload))
+    if s.maxDisk > 0 && s.diskBytes+size > s.maxDisk {
+        eventSpoolDropped.WithLabelValues("disk_full").Inc()
+        return fmt.Errorf("event spool disk limit of %d bytes reached", s.maxDisk)
+    }
+
+    tail := s.tail()
+    if tail == nil || tail.writer == nil || tail.size >= s.segmentSize {
+        if tail != nil && tail.writer != nil {
+            tail.writer.Close()
+            tail.writer = nil
+        }
+        path := filepath.Join(s.dir, fmt.Sprintf("%s%020d%s", spoolSegmentPrefix, s.nextSegment, spoolSegmentSuffix))
+        writer, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+        if err != nil {
+            eventSpoolDropped.WithLabelValues("disk_error").Inc()
+            return err
+        }
+        s.nextSegment++
+        tail = &spoolSegment{path: path, writer: writer}
+        s.segments = append(s.segments, tail)
+    }
+
+    record := make([]byte, size)
+    binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+    binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(payload, spoolCRCTable))
+    copy(record[spoolRecordHeaderSize:], payload)
+    if _, err := tail.writer.Write(record); err != nil {
+        eventSpoolDropped.WithLabelValues("disk_error").Inc()
+        return err
+    }
+    tail.size += size
+    s.diskEvents++
+    s.diskBytes += size
+    return nil
+}
+
+func (s *Spool) tail() *spoolSegment {
+    if len(s.segments) == 0 {
+        return nil
+    }
+    return s.segments[len(s.segments)-1]
+}
+
+// Oldest event, nil when the spool is empty. Corrupted records at the head
+// are skipped and counted; a corrupted length skips the rest of its
+// segment, as the next record cannot be found.
+func (s *Spool) peek() (*Message, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    for len(s.segments) > 0 {
+        head := s.segments[0]
+        if head.offset >= head.size {
+            s.dropHead()
+            continue
+        }
+        event, length, err := s.readHead(head)
+        if err != nil {
+            return nil, err
+        }
+        if event == nil {
+            eventSpoolCorrupt.Inc()
+            log.Printf("Skipping corrupted event spool record in %s at offset %d", filepath.Base(head.path), head.offset)
+            s.skip(head, length)
+            continue
+        }
+        return event, nil
+    }
+    if len(s.memory) > 0 {
+        return s.memory[0], nil
+    }
+    return nil, nil
+}
+
+// Read the record at a segment's replay offset: the event and the bytes it
+// spans, or a nil event for a corrupted record
+func (s *Spool) readHead(head *spoolSegment) (*Message, int64, error) {
+    if head.reader == nil {
+        reader, err := os.Open(head.path)
+        if err != nil {
+            return nil, 0, err
+        }
+        head.reader = reader
+    }
+    remaining := head.size - head.offset
+    header := make([]byte, spoolRecordHeaderSize)
+    if remaining < spoolRecordHeaderSize {
+        return nil, remaining, nil
+    }
+    if _, err := head.reader.ReadAt(header, head.offset); err != nil {
+        return nil, 0, err
+    }
+    length := int64(binary.BigEndian.Uint32(header[0:4]))
+    if length == 0 || length > spoolMaxRecordSize || spoolRecordHeaderSize+length > remaining {
+        return nil, remaining, nil
+    }
+    payload := make([]byte, length)
+    if _, err := head.reader.ReadAt(payload, head.offset+spoolRecordHeaderSize); err != nil && err != io.EOF {
+        return nil, 0, err
+    }
+    span := spoolRecordHeaderSize + length
+    if crc32.Checksum(payload, spoolCRCTable) != binary.BigEndian.Uint32(header[4:8]) {
+        return nil, span, nil
+    }
+    event := &Message{}
+    if err := json.Unmarshal(payload, event); err != nil {
+        return nil, span, nil
+    }
+    return event, span, nil
+}
+
+// Advance past bytes of the head segment that held an event
+func (s *Spool) skip(head *spoolSegment, length int64) {
+    head.offset += length
+    s.diskBytes -= length
+    if s.diskEvents > 0 {
+        s.diskEvents--
+    }
+}
+
+// Delete the drained head segment
+func (s *Spool) dropHead() {
+    head := s.segments[0]
+    if head.reader != nil {
+        head.reader.Close()
+    }
+    if head.writer != nil {
+        head.writer.Close()
+    }
+    if err := os.Remove(head.path); err != nil && !os.IsNotExist(err) {
+        log.Printf("Failed to delete drained event spool segment %s: %v", filepath.Base(head.path), err)
+    }
+    s.segments[0] = nil
+    s.segments = s.segments[1:]
+    if len(s.segments) == 0 {
+        s.diskEvents, s.diskBytes = 0, 0
+    }
+}
+
+// Discard the oldest event once published. Only the replay loop consumes,
+// and spilling keeps the order, so the head is the event peek returned.
+func (s *Spool) discard() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if len(s.segments) > 0 && s.segments[0].offset < s.segments[0].size {
+        head := s.segments[0]
+        _, length, err := s.readHead(head)
+        if err == nil {
+            s.skip(head, length)
+        }
+    } else if len(s.memory) > 0 {
+        s.memory[0] = nil
+        s.memory = s.memory[1:]
+    }
+    s.observeDepth()
+}
+
+// Spill every event still in memory to disk, so a restart replays them
+func (s *Spool) persist() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if s.dir == "" {
+        if len(s.memory) > 0 {
+            log.Printf("Dropping %d unpublished events on shutdown: no event spool directory", len(s.memory))
+        }
+        return
+    }
+    for _, event := range s.memory {
+        if err := s.spill(event); err != nil {
+            log.Printf("Dropped unpublished event for %s on shutdown: %v", event.Topic, err)
+        }
+    }
+    s.memory = nil
+    if tail := s.tail(); tail != nil && tail.writer != nil {
+        tail.writer.Sync()
+    }
+    s.observeDepth()
+}
+
+func (s *Spool) observeDepth() {
+    eventSpoolDepth.WithLabelValues("memory").Set(float64(len(s.memory)))
+    eventSpoolDepth.WithLabelValues("disk").Set(float64(s.diskEvents))
+}
+
+// Replay spooled events in order whenever the broker takes them, checking
+// every interval; on shutdown events still in memory are spilled to disk
+func (s *Spool) Run(ctx context.Context, interval time.Duration, publish func(ctx context.Context, event *Message) error) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    for {
+        for ctx.Err() == nil {
+            event, err := s.peek()
+            if err != nil {
+                log.Printf("Event spool unreadable, retrying: %v", err)
+                break
+            }
+            if event == nil {
+                eventSpoolOldestAge.Set(0)
+                break
+            }
+            eventSpoolOldestAge.Set(time.Since(event.EnqueuedAt).Seconds())
+            if err := publish(ctx, event); err != nil {
+                break
+            }
+            s.discard()
+            eventSpoolReplayed.Inc()
+        }
+
+        select {
+        case <-ctx.Done():
+            s.persist()
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// Event spool metrics
+var (
+    eventSpoolDepth = prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "compliance_event_spool_depth",
+            Help: "Unpublished events awaiting replay, by tier (memory or disk)",
+        },
+        []string{"tier"},
+    )
+
+    eventSpoolOldestAge = prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "compliance_event_spool_oldest_age_seconds",
+            Help: "Age of the oldest unpublished event, 0 when the spool is empty",
+        },
+    )
+
+    eventSpoolReplayed = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_event_spool_replayed_total",
+            Help: "Spooled events published on replay; its rate is the replay throughput",
+        },
+    )
+
+    eventSpoolCorrupt = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_event_spool_corrupt_records_total",
+            Help: "Spool segment records skipped for a bad length, checksum or encoding",
+        },
+    )
+
+    eventSpoolDropped = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_event_spool_dropped_total",
+            Help: "Unpublished events dropped, by reason: memory_full without a spool directory, disk_full, disk_error or encode",
+        },
+        []string{"reason"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(eventSpoolDepth)
+    prometheus.MustRegister(eventSpoolOldestAge)
+    prometheus.MustRegister(eventSpoolReplayed)
+    prometheus.MustRegister(eventSpoolCorrupt)
+    prometheus.MustRegister(eventSpoolDropped)
+}
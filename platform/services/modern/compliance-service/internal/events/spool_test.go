@@ -0,0 +1,196 @@
+package events
+
+import (
+    "context"
+    "encoding/binary"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strconv"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func testMessage(i int) *Message {
+    return &Message{Topic: "compliance-results", Key: []byte(strconv.Itoa(i)), Value: []byte(`{"n":` + strconv.Itoa(i) + `}`), EnqueuedAt: time.Now()}
+}
+
+// Replay order of everything in the spool, consuming it
+func drain(t *testing.T, s *Spool) []string {
+    t.Helper()
+    var keys []string
+    for {
+        event, err := s.peek()
+        if err != nil {
+            t.Fatal(err)
+        }
+        if event == nil {
+            return keys
+        }
+        keys = append(keys, string(event.Key))
+        s.discard()
+    }
+}
+
+func TestSpoolReplaysInOrderAcrossTiers(t *testing.T) {
+    // Two events fit in memory; the rest spill, one segment each
+    s, err := NewSpool(2, t.TempDir(), 1, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for i := 0; i < 5; i++ {
+        s.Enqueue(testMessage(i))
+    }
+    if len(s.segments) != 3 {
+        t.Fatalf("%d segments on disk, want 3", len(s.segments))
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    var mu sync.Mutex
+    var published []string
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        s.Run(ctx, time.Hour, func(ctx context.Context, event *Message) error {
+            mu.Lock()
+            defer mu.Unlock()
+            published = append(published, string(event.Key))
+            if len(published) == 5 {
+                cancel()
+            }
+            return nil
+        })
+    }()
+    <-done
+
+    if want := []string{"0", "1", "2", "3", "4"}; !reflect.DeepEqual(published, want) {
+        t.Errorf("published %v, want %v", published, want)
+    }
+    if s.Pending() {
+        t.Error("spool still pending after replay")
+    }
+    if entries, _ := os.ReadDir(s.dir); len(entries) != 0 {
+        t.Errorf("%d drained segments left on disk", len(entries))
+    }
+}
+
+func TestSpoolSurvivesRestart(t *testing.T) {
+    dir := t.TempDir()
+    s, err := NewSpool(1, dir, 1<<20, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for i := 0; i < 3; i++ {
+        s.Enqueue(testMessage(i))
+    }
+    // Shutdown spills what is still in memory
+    s.persist()
+
+    reopened, err := NewSpool(1, dir, 1<<20, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !reopened.Pending() {
+        t.Fatal("reopened spool has nothing pending")
+    }
+    if got, want := drain(t, reopened), []string{"0", "1", "2"}; !reflect.DeepEqual(got, want) {
+        t.Errorf("replayed %v after restart, want %v", got, want)
+    }
+}
+
+func TestSpoolSkipsCorruptRecords(t *testing.T) {
+    dir := t.TempDir()
+    s, err := NewSpool(0, dir, 1<<20, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for i := 0; i < 3; i++ {
+        s.Enqueue(testMessage(i))
+    }
+
+    // Flip a payload byte of the middle record, leaving its length intact
+    path := s.segments[0].path
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    first := spoolRecordHeaderSize + int(binary.BigEndian.Uint32(data[0:4]))
+    data[first+spoolRecordHeaderSize] ^= 0xff
+    if err := os.WriteFile(path, data, 0o640); err != nil {
+        t.Fatal(err)
+    }
+
+    before := testutil.ToFloat64(eventSpoolCorrupt)
+    reopened, err := NewSpool(0, dir, 1<<20, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got, want := drain(t, reopened), []string{"0", "2"}; !reflect.DeepEqual(got, want) {
+        t.Errorf("replayed %v, want %v", got, want)
+    }
+    if got := testutil.ToFloat64(eventSpoolCorrupt) - before; got != 1 {
+        t.Errorf("%v corrupt records counted, want 1", got)
+    }
+}
+
+func TestSpoolDropsPastLimits(t *testing.T) {
+    tests := []struct {
+        name   string
+        dir    bool
+        reason string
+        drops  float64
+        want   []string
+    }{
+        // Without a directory every event beyond memory is dropped, oldest first
+        {name: "memory full", reason: "memory_full", drops: 2, want: []string{"2"}},
+        // With room on disk for one record, the second spill is refused
+        {name: "disk full", dir: true, reason: "disk_full", drops: 1, want: []string{"0", "2"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var dir string
+            var maxDisk int64
+            if tt.dir {
+                dir = t.TempDir()
+                maxDisk = 200
+            }
+            s, err := NewSpool(1, dir, 1<<20, maxDisk)
+            if err != nil {
+                t.Fatal(err)
+            }
+            before := testutil.ToFloat64(eventSpoolDropped.WithLabelValues(tt.reason))
+            for i := 0; i < 3; i++ {
+                s.Enqueue(testMessage(i))
+            }
+            if got := testutil.ToFloat64(eventSpoolDropped.WithLabelValues(tt.reason)) - before; got != tt.drops {
+                t.Errorf("%v events dropped as %s, want %v", got, tt.reason, tt.drops)
+            }
+            if got := drain(t, s); !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("replayed %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestSpoolIgnoresForeignFiles(t *testing.T) {
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a segment"), 0o640); err != nil {
+        t.Fatal(err)
+    }
+    s, err := NewSpool(1, dir, 1<<20, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if s.Pending() {
+        t.Error("spool picked up a file that is not a segment")
+    }
+}
+
+func TestNewProducerRequiresBrokers(t *testing.T) {
+    if _, err := NewProducer(""); err == nil {
+        t.Error("producer created without brokers")
+    }
+}
@@ -0,0 +1,186 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "strconv"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+)
+
+// Response metadata recording how long a response is cached and how that
+// was chosen
+const (
+    metadataCacheTTL     = "cache_ttl"
+    metadataCacheTTLMode = "cache_ttl_mode"
+)
+
+// Cache TTL modes
+const (
+    cacheTTLStatic   = "static"
+    cacheTTLAdaptive = "adaptive"
+)
+
+const (
+    adaptiveTTLDefaultRuns    = 10
+    adaptiveTTLDefaultMinRuns = 3
+    adaptiveTTLDefaultStdDev  = 5.0
+)
+
+// AdaptiveTTL - a tenant's opt-in to cache TTLs scaled by how much its
+// organizations' scores move. The standard deviation of each framework's
+// last runs scores, the current one included, is taken; the most volatile
+// framework places the TTL between max (scores never change) and min
+// (deviation at or above volatile_stddev). Organizations with fewer than
+// min_runs scores for a framework get the static TTL.
+type AdaptiveTTL struct {
+    Min            time.Duration `yaml:"min_ttl" json:"min_ttl"`
+    Max            time.Duration `yaml:"max_ttl" json:"max_ttl"`
+    Runs           int           `yaml:"runs" json:"runs"`                       // Default 10, at most the score history kept
+    MinRuns        int           `yaml:"min_runs" json:"min_runs"`               // Default 3
+    VolatileStdDev float64       `yaml:"volatile_stddev" json:"volatile_stddev"` // Score points, default 5
+}
+
+// Validate an adaptive TTL config
+func (a *AdaptiveTTL) Validate(tenant string) error {
+    if a.Min <= 0 || a.Max < a.Min {
+        return fmt.Errorf("tenant %s adaptive_ttl must satisfy 0 < min_ttl <= max_ttl", tenant)
+    }
+    if a.Runs != 0 && (a.Runs < 2 || a.Runs > scoreHistoryMaxPoints) {
+        return fmt.Errorf("tenant %s adaptive_ttl runs must be between 2 and %d", tenant, scoreHistoryMaxPoints)
+    }
+    if minRuns := a.minRuns(); minRuns < 2 || minRuns > a.runs() {
+        return fmt.Errorf("tenant %s adaptive_ttl min_runs must be between 2 and runs", tenant)
+    }
+    if a.VolatileStdDev < 0 {
+        return fmt.Errorf("tenant %s adaptive_ttl volatile_stddev must not be negative", tenant)
+    }
+    return nil
+}
+
+func (a *AdaptiveTTL) runs() int {
+    if a.Runs == 0 {
+        return adaptiveTTLDefaultRuns
+    }
+    return a.Runs
+}
+
+func (a *AdaptiveTTL) minRuns() int {
+    if a.MinRuns == 0 {
+        return adaptiveTTLDefaultMinRuns
+    }
+    return a.MinRuns
+}
+
+// TTL for a score standard deviation
+func (a *AdaptiveTTL) ttl(stddev float64) time.Duration {
+    volatile := a.VolatileStdDev
+    if volatile == 0 {
+        volatile = adaptiveTTLDefaultStdDev
+    }
+    ratio := math.Min(1, stddev/volatile)
+    return a.Max - time.Duration(ratio*float64(a.Max-a.Min))
+}
+
+// Largest standard deviation among the response's scored frameworks over
+// the recorded scores and the response's own. ok is false when a framework
+// has fewer than minRuns scores.
+func (h *ScoreHistory) Volatility(ctx context.Context, response *ComplianceResponse, runs, minRuns int) (float64, bool, error) {
+    var results []*FrameworkResult
+    for _, result := range response.FrameworkResults {
+        if scoredResult(result) && result.Outcome != frameworkStale {
+            results = append(results, result)
+        }
+    }
+    if len(results) == 0 {
+        return 0, false, nil
+    }
+
+    pipe := h.redis.Pipeline()
+    cmds := make([]*redis.StringSliceCmd, len(results))
+    for i, result := range results {
+        cmds[i] = pipe.LRange(ctx, scoreHistoryKey(response.OrganizationId, result.Framework), 0, int64(runs-2))
+    }
+    if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+        return 0, false, err
+    }
+
+    var volatility float64
+    for i, result := range results {
+        scores := []float64{result.Score}
+        for _, value := range cmds[i].Val() {
+            if score, err := strconv.ParseFloat(value, 64); err == nil {
+                scores = append(scores, score)
+            }
+        }
+        if len(scores) < minRuns {
+            return 0, false, nil
+        }
+        volatility = math.Max(volatility, stddev(scores))
+    }
+    return volatility, true, nil
+}
+
+// Population standard deviation
+func stddev(values []float64) float64 {
+    var sum float64
+    for _, value := range values {
+        sum += value
+    }
+    mean := sum / float64(len(values))
+    var squares float64
+    for _, value := range values {
+        squares += (value - mean) * (value - mean)
+    }
+    return math.Sqrt(squares / float64(len(values)))
+}
+
+// Base cache TTL of a response before evidence and attestation expiry cut
+// it short: adaptive when the tenant opted in and the organization has
+// enough history, else the static aggregate
+func (s *ComplianceService) baseResponseTTL(ctx context.Context, runtime *RuntimeConfig, response *ComplianceResponse) (time.Duration, string) {
+    static := runtime.Cache.Aggregate(response.FrameworkResults)
+    adaptive := runtime.Cache.Adaptive
+    if adaptive == nil {
+        return static, cacheTTLStatic
+    }
+    volatility, ok, err := s.history.Volatility(ctx, response, adaptive.runs(), adaptive.minRuns())
+    if err != nil {
+        markTraceDegraded(ctx, "score history unavailable for adaptive TTL")
+        return static, cacheTTLStatic
+    }
+    if !ok {
+        return static, cacheTTLStatic
+    }
+    return adaptive.ttl(volatility), cacheTTLAdaptive
+}
+
+// Record the TTL a response is cached with in its metadata, outside the
+// content hash
+func recordCacheTTL(response *ComplianceResponse, ttl time.Duration, mode string) {
+    if response.Metadata == nil {
+        response.Metadata = make(map[string]string)
+    }
+    response.Metadata[metadataCacheTTL] = strconv.FormatInt(int64(ttl/time.Second), 10)
+    response.Metadata[metadataCacheTTLMode] = mode
+    assignedCacheTTLs.WithLabelValues(mode).Observe(ttl.Seconds())
+}
+
+// Adaptive TTL metrics
+var (
+    assignedCacheTTLs = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "compliance_cache_ttl_assigned_seconds",
+            Help:    "Cache TTLs assigned to fresh responses, by mode (static or adaptive)",
+            Buckets: prometheus.ExponentialBuckets(60, 2, 12),
+        },
+        []string{"mode"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(assignedCacheTTLs)
+}
@@ -0,0 +1,277 @@
+package compliance
+
+import (
+    "context"
+    "crypto/sha256"
+    "crypto/subtle"
+    "crypto/tls"
+    "crypto/x509"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "log"
+    "net/http"
+    "net/http/pprof"
+    "os"
+    "strings"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/encoding/protojson"
+)
+
+// Placeholder for secret values in config dumps
+const redactedValue = "[REDACTED]"
+
+// Admin listener settings
+type AdminConfig struct {
+    Port         string `env:"ADMIN_PORT"`
+    Token        string `env:"ADMIN_TOKEN" secret:"true"`    // Bearer token required on every admin request
+    TLSCert      string `env:"ADMIN_TLS_CERT"`
+    TLSKey       string `env:"ADMIN_TLS_KEY" secret:"false"` // A file path, not the key
+    ClientCAFile string `env:"ADMIN_CLIENT_CA"`              // When set, callers must present a client certificate
+    Open         bool   `env:"ADMIN_OPEN"`                   // Without a token or client CA, serve admin methods and endpoints to anyone; development only
+}
+
+// AdminServer - operator endpoints kept off the metrics port
+type AdminServer struct {
+    service *ComplianceService
+    config  AdminConfig
+    mux     *http.ServeMux
+}
+
+// Create the admin server and register its handlers on an explicit mux
+func NewAdminServer(service *ComplianceService, config AdminConfig) *AdminServer {
+    a := &AdminServer{service: service, config: config, mux: http.NewServeMux()}
+
+    a.mux.HandleFunc("/debug/pprof/", pprof.Index)
+    a.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+    a.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+    a.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+    a.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+    a.mux.HandleFunc("/faults", a.handleFaults)
+    a.mux.HandleFunc("/queues", a.handleQueues)
+    a.mux.HandleFunc("/config", a.handleConfig)
+    a.mux.HandleFunc("/config/effective", a.handleEffectiveConfig)
+    a.mux.HandleFunc("/ruleset/reload", a.handleRulesetReload)
+
+    return a
+}
+
+// ListenAndServe starts the admin listener, with mTLS if a client CA is set.
+// It refuses to start unauthenticated: a token or client CA is required
+// unless the deployment opted into open admin access.
+func (a *AdminServer) ListenAndServe() error {
+    if a.config.Token == "" && !a.mutualTLS() && !a.config.Open {
+        return fmt.Errorf("refusing to serve admin endpoints without authentication: set ADMIN_TOKEN, ADMIN_TLS_CERT with ADMIN_CLIENT_CA, or ADMIN_OPEN for development")
+    }
+    server := &http.Server{
+        Addr:    ":" + a.config.Port,
+        Handler: a.authenticate(a.mux),
+    }
+
+    if a.config.TLSCert == "" {
+        return server.ListenAndServe()
+    }
+
+    if a.config.ClientCAFile != "" {
+        pem, err := os.ReadFile(a.config.ClientCAFile)
+        if err != nil {
+            return fmt.Errorf("failed to read admin client CA: %v", err)
+        }
+        pool := x509.NewCertPool()
+        if !pool.AppendCertsFromPEM(pem) {
+            return fmt.Errorf("no certificates found in %s", a.config.ClientCAFile)
+        }
+        server.TLSConfig = &tls.Config{
+            ClientAuth: tls.RequireAndVerifyClientCert,
+            ClientCAs:  pool,
+            MinVersion: tls.VersionTLS12,
+        }
+    }
+    return server.ListenAndServeTLS(a.config.TLSCert, a.config.TLSKey)
+}
+
+// Whether callers must present a client certificate the admin CA verifies
+func (a *AdminServer) mutualTLS() bool {
+    return a.config.TLSCert != "" && a.config.ClientCAFile != ""
+}
+
+// Authenticate the caller and audit-log every admin request. A caller is
+// authenticated by the token when one is configured, else by a verified
+// client certificate; anyone else is refused unless admin access is open.
+func (a *AdminServer) authenticate(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        caller := adminCaller(r)
+        recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+        switch {
+        case a.config.Token != "":
+            token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+            if subtle.ConstantTimeCompare([]byte(token), []byte(a.config.Token)) != 1 {
+                http.Error(recorder, "unauthorized", http.StatusUnauthorized)
+                log.Printf("Admin audit: caller=%s method=%s path=%s status=%d", caller, r.Method, r.URL.Path, recorder.status)
+                return
+            }
+            if !strings.HasPrefix(caller, "cn:") {
+                caller = "token:" + fingerprint(token)[:12] + "@" + r.RemoteAddr
+            }
+        case r.TLS != nil && len(r.TLS.VerifiedChains) > 0:
+            // Client certificate verified against the admin CA
+        case !a.config.Open:
+            // Fail closed: nothing authenticated the caller
+            http.Error(recorder, "unauthorized", http.StatusUnauthorized)
+            log.Printf("Admin audit: caller=%s method=%s path=%s status=%d", caller, r.Method, r.URL.Path, recorder.status)
+            return
+        }
+
+        next.ServeHTTP(recorder, r)
+        log.Printf("Admin audit: caller=%s method=%s path=%s status=%d", caller, r.Method, r.URL.Path, recorder.status)
+    })
+}
+
+// Admin-scoped gRPC methods require the admin token as a bearer credential.
+// Without a configured token they are refused, unless the deployment opted
+// into open admin access.
+func (s *ComplianceService) requireAdmin(ctx context.Context) error {
+    if s.config.Admin.Token == "" {
+        if s.config.Admin.Open {
+            return nil
+        }
+        return status.Error(codes.PermissionDenied, "admin methods are disabled: no admin token is configured")
+    }
+    md, _ := metadata.FromIncomingContext(ctx)
+    for _, value := range md.Get("authorization") {
+        token := strings.TrimPrefix(value, "Bearer ")
+        if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.Admin.Token)) == 1 {
+            return nil
+        }
+    }
+    return status.Error(codes.PermissionDenied, "admin credentials required")
+}
+
+// Identify the caller by client certificate if present, else remote address
+func adminCaller(r *http.Request) string {
+    if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+        return "cn:" + r.TLS.PeerCertificates[0].Subject.CommonName
+    }
+    return r.RemoteAddr
+}
+
+// GET lists active faults, POST sets one: {"framework":"SAMA","delay":2000000000,"fail":false}
+func (a *AdminServer) handleFaults(w http.ResponseWriter, r *http.Request) {
+    switch r.Method {
+    case http.MethodGet:
+        writeJSON(w, a.service.faults.Active())
+    case http.MethodPost:
+        var body struct {
+            Framework string `json:"framework"`
+            Fault
+        }
+        if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Framework == "" {
+            http.Error(w, "expected {\"framework\":..., \"delay\":..., \"fail\":...}", http.StatusBadRequest)
+            return
+        }
+        a.service.faults.Set(body.Framework, body.Fault)
+        writeJSON(w, a.service.faults.Active())
+    default:
+        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+    }
+}
+
+// Depth and capacity of internal queues
+func (a *AdminServer) handleQueues(w http.ResponseWriter, r *http.Request) {
+    waiting, inUse, capacity := a.service.engine.scheduler.Stats()
+    writeJSON(w, map[string]interface{}{
+        "scheduler": map[string]int{
+            "waiting":  waiting,
+            "in_use":   inUse,
+            "capacity": capacity,
+        },
+        "usage_events": map[string]int{
+            "depth":    len(a.service.usage.events),
+            "capacity": cap(a.service.usage.events),
+        },
+        "check_jobs": map[string]int{
+            "depth":   a.service.jobs.Depth(),
+            "workers": a.service.jobs.workers,
+        },
+    })
+}
+
+// Effective configuration with secrets redacted
+func (a *AdminServer) handleConfig(w http.ResponseWriter, r *http.Request) {
+    writeJSON(w, redactedServiceConfig(a.service.config))
+}
+
+// Fully resolved configuration, each value with its source, as served by
+// GetEffectiveConfig
+func (a *AdminServer) handleEffectiveConfig(w http.ResponseWriter, r *http.Request) {
+    effective, err := a.service.effectiveConfig()
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    data, err := protojson.Marshal(effective)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    w.Write(data)
+}
+
+// Drop memoized evaluations and re-validate the rules engine
+func (a *AdminServer) handleRulesetReload(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+    if err := a.service.reloadRuleset(); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    writeJSON(w, map[string]string{"status": "reloaded", "ruleset_version": a.service.engine.rulesetVersion})
+}
+
+// Probe and metrics endpoints only, without /metrics when metrics is nil;
+// everything operational lives on the admin mux
+func newMetricsMux(service *ComplianceService, metrics http.Handler) *http.ServeMux {
+    mux := http.NewServeMux()
+    if metrics != nil {
+        mux.Handle("/metrics", metrics)
+    }
+    mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusOK)
+    })
+    mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+        if !service.ready.Load() {
+            w.WriteHeader(http.StatusServiceUnavailable)
+            return
+        }
+        w.WriteHeader(http.StatusOK)
+    })
+    return mux
+}
+
+type statusRecorder struct {
+    http.ResponseWriter
+    status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+    r.status = status
+    r.ResponseWriter.WriteHeader(status)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(v)
+}
+
+func fingerprint(value string) string {
+    sum := sha256.Sum256([]byte(value))
+    return hex.EncodeToString(sum[:])
+}
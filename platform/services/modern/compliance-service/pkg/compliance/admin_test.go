@@ -0,0 +1,62 @@
+package compliance
+
+import (
+    "crypto/tls"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+// The admin listener fails closed: without a token, a verified client
+// certificate or open access configured, every request is refused
+func TestAdminAuthenticate(t *testing.T) {
+    verified := &tls.ConnectionState{
+        PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "operator"}}},
+    }
+    verified.VerifiedChains = [][]*x509.Certificate{verified.PeerCertificates}
+
+    tests := []struct {
+        name   string
+        config AdminConfig
+        token  string
+        tls    *tls.ConnectionState
+        want   int
+    }{
+        {name: "nothing configured", want: http.StatusUnauthorized},
+        {name: "open", config: AdminConfig{Open: true}, want: http.StatusOK},
+        {name: "missing token", config: AdminConfig{Token: "admin-secret"}, want: http.StatusUnauthorized},
+        {name: "wrong token", config: AdminConfig{Token: "admin-secret"}, token: "guess", want: http.StatusUnauthorized},
+        {name: "token", config: AdminConfig{Token: "admin-secret"}, token: "admin-secret", want: http.StatusOK},
+        {name: "token required besides certificate", config: AdminConfig{Token: "admin-secret"}, tls: verified, want: http.StatusUnauthorized},
+        {name: "verified certificate", config: AdminConfig{TLSCert: "cert.pem", ClientCAFile: "ca.pem"}, tls: verified, want: http.StatusOK},
+        {name: "unverified certificate", config: AdminConfig{TLSCert: "cert.pem"}, tls: &tls.ConnectionState{}, want: http.StatusUnauthorized},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            admin := &AdminServer{config: tt.config}
+            handler := admin.authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+            r := httptest.NewRequest(http.MethodGet, "/queues", nil)
+            r.TLS = tt.tls
+            if tt.token != "" {
+                r.Header.Set("Authorization", "Bearer "+tt.token)
+            }
+            w := httptest.NewRecorder()
+            handler.ServeHTTP(w, r)
+            if w.Code != tt.want {
+                t.Errorf("status %d, want %d", w.Code, tt.want)
+            }
+        })
+    }
+}
+
+// Without any authentication configured the listener does not start
+func TestAdminListenerRefusesUnauthenticated(t *testing.T) {
+    admin := NewAdminServer(nil, AdminConfig{Port: "0"})
+    err := admin.ListenAndServe()
+    if err == nil || !strings.Contains(err.Error(), "without authentication") {
+        t.Errorf("ListenAndServe() = %v, want a refusal", err)
+    }
+}
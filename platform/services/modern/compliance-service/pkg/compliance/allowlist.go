@@ -0,0 +1,56 @@
+package compliance
+
+import (
+    "fmt"
+    "path"
+    "strings"
+
+    "google.golang.org/grpc/codes"
+)
+
+// OrgAllowlist - organizations a deployment serves. Entries are exact IDs or
+// glob patterns such as "acme-*" for a tenant prefix. An empty list allows
+// every organization.
+type OrgAllowlist []string
+
+// Parse a comma-separated allow-list such as "acme-*,org-1234"
+func parseOrgAllowlist(value string) OrgAllowlist {
+    var allowlist OrgAllowlist
+    for _, entry := range strings.Split(value, ",") {
+        if entry = strings.TrimSpace(entry); entry != "" {
+            allowlist = append(allowlist, entry)
+        }
+    }
+    return allowlist
+}
+
+// Validate every entry is a well-formed pattern
+func (a OrgAllowlist) Validate() error {
+    for _, pattern := range a {
+        if _, err := path.Match(pattern, ""); err != nil {
+            return fmt.Errorf("invalid org allow-list pattern %q: %v", pattern, err)
+        }
+    }
+    return nil
+}
+
+// Allows reports whether an organization matches the list
+func (a OrgAllowlist) Allows(organizationID string) bool {
+    if len(a) == 0 {
+        return true
+    }
+    for _, pattern := range a {
+        if matched, _ := path.Match(pattern, organizationID); matched {
+            return true
+        }
+    }
+    return false
+}
+
+// Reject organizations this deployment does not serve
+func (s *ComplianceService) checkOrgAllowed(organizationID string) error {
+    if !s.runtimeConfig().OrgAllowlist.Allows(organizationID) {
+        return reasonError(codes.PermissionDenied, reasonOrgNotAllowed, fmt.Sprintf("organization %s is not served by this deployment", organizationID))
+    }
+    return nil
+}
@@ -0,0 +1,74 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+func TestOrgAllowlistAllows(t *testing.T) {
+    allowlist := parseOrgAllowlist(" org-1234, acme-* ,,")
+    tests := []struct {
+        name           string
+        organizationID string
+        want           bool
+    }{
+        {name: "exact ID", organizationID: "org-1234", want: true},
+        {name: "tenant prefix", organizationID: "acme-riyadh", want: true},
+        {name: "prefix alone is not a tenant", organizationID: "acme", want: false},
+        {name: "similar ID", organizationID: "org-12345", want: false},
+        {name: "other tenant", organizationID: "globex-1", want: false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := allowlist.Allows(tt.organizationID); got != tt.want {
+                t.Errorf("Allows(%q) = %t, want %t", tt.organizationID, got, tt.want)
+            }
+        })
+    }
+
+    if !OrgAllowlist(nil).Allows("anyone") {
+        t.Error("empty allow-list denied an organization, want every organization served")
+    }
+    if err := parseOrgAllowlist("acme-[").Validate(); err == nil {
+        t.Error("Validate() accepted a malformed pattern")
+    }
+}
+
+// Requests for organizations off the list are refused; the list applies to
+// the canonical ID an alias resolves to
+func TestCheckComplianceEnforcesOrgAllowlist(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.OrgAllowlist = "org-1,acme-*"
+    })
+    ctx := context.Background()
+    if err := service.registry.Register(ctx, "acme-jeddah", &OrganizationAlias{Type: AliasType_CR, Value: "1010123456"}); err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        name           string
+        organizationID string
+        code           codes.Code
+    }{
+        {name: "allowed", organizationID: "org-1", code: codes.OK},
+        {name: "wildcard matched", organizationID: "acme-riyadh", code: codes.OK},
+        {name: "alias of an allowed organization", organizationID: "CR:1010123456", code: codes.OK},
+        {name: "denied", organizationID: "org-2", code: codes.PermissionDenied},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: tt.organizationID})
+            if status.Code(err) != tt.code {
+                t.Fatalf("CheckCompliance(%s) = %v, want %v", tt.organizationID, err, tt.code)
+            }
+            if err != nil {
+                if reason := errorReason(status.Convert(err)); reason != reasonOrgNotAllowed {
+                    t.Errorf("reason %q, want %s", reason, reasonOrgNotAllowed)
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,291 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "math"
+    "os"
+    "strconv"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/events"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+)
+
+// Alert events for operators, never for customers
+const alertsTopic = "compliance-alerts"
+
+const (
+    anomalyLeaderKey  = "anomaly-detector:leader"
+    anomalySuspectKey = "framework-suspect"
+
+    // Marker stored in a score window for an evaluation that failed
+    anomalyErrorSample = "error"
+)
+
+// AnomalyConfig - outlier detection on per-framework scores
+type AnomalyConfig struct {
+    Interval     time.Duration `env:"ANOMALY_CHECK_INTERVAL"` // How often the leader checks, 0 disables detection
+    Window       int           `env:"ANOMALY_WINDOW"`         // Samples kept per framework
+    Recent       int           `env:"ANOMALY_RECENT"`         // Newest samples compared against the rest
+    StdDevs      float64       `env:"ANOMALY_STDDEVS"`        // Mean shift, in baseline standard deviations, that flags a framework
+    MaxErrorRate float64       `env:"ANOMALY_MAX_ERROR_RATE"` // Recent error rate that flags a framework
+}
+
+// SuspectFramework - why and since when a framework is flagged
+type SuspectFramework struct {
+    Reason string    `json:"reason"`
+    Since  time.Time `json:"since"`
+}
+
+// AnomalyAlert - internal alert event published when a framework is flagged
+type AnomalyAlert struct {
+    Framework    string    `json:"framework"`
+    Reason       string    `json:"reason"`
+    BaselineMean float64   `json:"baseline_mean"`
+    RecentMean   float64   `json:"recent_mean"`
+    ErrorRate    float64   `json:"error_rate"`
+    DetectedAt   time.Time `json:"detected_at"`
+}
+
+// AnomalyDetector - every replica records framework outcomes into shared
+// Redis windows; one replica at a time holds a leader lease and compares the
+// newest samples of each window against the older ones. A flagged framework
+// stays suspect until an admin clears it.
+type AnomalyDetector struct {
+    redis    *redis.Client
+    producer *events.Producer
+    node     string
+    config   AnomalyConfig
+}
+
+// Create an anomaly detector; node identifies this replica for the lease
+func NewAnomalyDetector(client *redis.Client, producer *events.Producer, node string, config AnomalyConfig) *AnomalyDetector {
+    if node == "" {
+        node, _ = os.Hostname()
+    }
+    if config.Window <= 0 {
+        config.Window = 500
+    }
+    if config.Recent <= 0 || config.Recent >= config.Window {
+        config.Recent = config.Window / 10
+    }
+    return &AnomalyDetector{redis: client, producer: producer, node: node, config: config}
+}
+
+// Record the outcome of fresh framework evaluations. Frameworks without a
+// result count as errors.
+func (d *AnomalyDetector) Record(ctx context.Context, frameworks []string, results []*FrameworkResult) {
+    scores := make(map[string]float64, len(results))
+    for _, result := range results {
+        scores[result.Framework] = result.Score
+    }
+
+    pipe := d.redis.Pipeline()
+    for _, framework := range frameworks {
+        sample := anomalyErrorSample
+        if score, ok := scores[framework]; ok {
+            sample = strconv.FormatFloat(score, 'f', -1, 64)
+        }
+        key := anomalyWindowKey(framework)
+        pipe.LPush(ctx, key, sample)
+        pipe.LTrim(ctx, key, 0, int64(d.config.Window-1))
+    }
+    if _, err := pipe.Exec(ctx); err != nil {
+        log.Printf("Failed to record framework outcomes: %v", err)
+    }
+}
+
+// Run checks every interval while this replica holds the leader lease
+func (d *AnomalyDetector) Run(ctx context.Context, frameworks []string) {
+    if d.config.Interval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(d.config.Interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if d.lead(ctx) {
+                d.check(ctx, frameworks)
+            }
+        }
+    }
+}
+
+// Acquire or renew the leader lease; it outlives two intervals so a crashed
+// leader is replaced promptly
+func (d *AnomalyDetector) lead(ctx context.Context) bool {
+    lease := 2 * d.config.Interval
+    acquired, err := d.redis.SetNX(ctx, anomalyLeaderKey, d.node, lease).Result()
+    if err != nil {
+        log.Printf("Anomaly detector lease failed: %v", err)
+        return false
+    }
+    if acquired {
+        return true
+    }
+    holder, err := d.redis.Get(ctx, anomalyLeaderKey).Result()
+    if err != nil || holder != d.node {
+        return false
+    }
+    d.redis.Expire(ctx, anomalyLeaderKey, lease)
+    return true
+}
+
+func (d *AnomalyDetector) check(ctx context.Context, frameworks []string) {
+    suspects, err := d.Suspects(ctx)
+    if err != nil {
+        log.Printf("Anomaly detector failed to read suspects: %v", err)
+        return
+    }
+
+    for _, framework := range frameworks {
+        if _, flagged := suspects[framework]; flagged {
+            continue
+        }
+        samples, err := d.redis.LRange(ctx, anomalyWindowKey(framework), 0, -1).Result()
+        if err != nil {
+            log.Printf("Anomaly detector failed to read %s window: %v", framework, err)
+            continue
+        }
+        if alert := d.evaluate(framework, samples); alert != nil {
+            d.flag(ctx, alert)
+        }
+    }
+}
+
+// Compare the newest samples against the older ones; nil when normal or
+// when there is not enough history yet
+func (d *AnomalyDetector) evaluate(framework string, samples []string) *AnomalyAlert {
+    if len(samples) < d.config.Recent*2 {
+        return nil
+    }
+
+    // Samples are newest first
+    recentScores, recentErrors := parseAnomalySamples(samples[:d.config.Recent])
+    baselineScores, _ := parseAnomalySamples(samples[d.config.Recent:])
+
+    alert := &AnomalyAlert{
+        Framework:  framework,
+        ErrorRate:  float64(recentErrors) / float64(d.config.Recent),
+        DetectedAt: time.Now(),
+    }
+    if alert.ErrorRate > d.config.MaxErrorRate {
+        alert.Reason = fmt.Sprintf("error rate %.0f%% over the last %d evaluations", alert.ErrorRate*100, d.config.Recent)
+        return alert
+    }
+
+    if len(recentScores) == 0 || len(baselineScores) < 2 {
+        return nil
+    }
+    mean, stddev := meanStdDev(baselineScores)
+    alert.BaselineMean = mean
+    alert.RecentMean, _ = meanStdDev(recentScores)
+
+    // A perfectly stable baseline still tolerates a one-point wobble
+    stddev = math.Max(stddev, 1)
+    if shift := math.Abs(alert.RecentMean-mean) / stddev; shift > d.config.StdDevs {
+        alert.Reason = fmt.Sprintf("mean score moved from %.1f to %.1f (%.1f standard deviations)", mean, alert.RecentMean, shift)
+        return alert
+    }
+    return nil
+}
+
+func (d *AnomalyDetector) flag(ctx context.Context, alert *AnomalyAlert) {
+    data, _ := json.Marshal(SuspectFramework{Reason: alert.Reason, Since: alert.DetectedAt})
+    if err := d.redis.HSet(ctx, anomalySuspectKey, alert.Framework, data).Err(); err != nil {
+        log.Printf("Failed to flag framework %s as suspect: %v", alert.Framework, err)
+        return
+    }
+    anomalyAlerts.WithLabelValues(alert.Framework).Inc()
+    log.Printf("WARN framework %s flagged as suspect: %s", alert.Framework, alert.Reason)
+
+    if err := d.producer.Publish(alertsTopic, alert); err != nil {
+        log.Printf("Failed to publish anomaly alert for %s: %v", alert.Framework, err)
+    }
+}
+
+// Suspects returns every flagged framework
+func (d *AnomalyDetector) Suspects(ctx context.Context) (map[string]SuspectFramework, error) {
+    values, err := d.redis.HGetAll(ctx, anomalySuspectKey).Result()
+    if err != nil {
+        return nil, err
+    }
+    suspects := make(map[string]SuspectFramework, len(values))
+    for framework, value := range values {
+        var suspect SuspectFramework
+        if err := json.Unmarshal([]byte(value), &suspect); err != nil {
+            continue
+        }
+        suspects[framework] = suspect
+    }
+    return suspects, nil
+}
+
+// Clear a suspect flag. The framework's window is dropped as well so the
+// acknowledged distribution becomes the new baseline instead of re-flagging.
+func (d *AnomalyDetector) Clear(ctx context.Context, framework string) error {
+    pipe := d.redis.TxPipeline()
+    pipe.HDel(ctx, anomalySuspectKey, framework)
+    pipe.Del(ctx, anomalyWindowKey(framework))
+    _, err := pipe.Exec(ctx)
+    return err
+}
+
+func anomalyWindowKey(framework string) string {
+    return "framework-scores:" + framework
+}
+
+func parseAnomalySamples(samples []string) ([]float64, int) {
+    scores := make([]float64, 0, len(samples))
+    errors := 0
+    for _, sample := range samples {
+        if sample == anomalyErrorSample {
+            errors++
+            continue
+        }
+        if score, err := strconv.ParseFloat(sample, 64); err == nil {
+            scores = append(scores, score)
+        }
+    }
+    return scores, errors
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+    if len(values) == 0 {
+        return 0, 0
+    }
+    sum := 0.0
+    for _, v := range values {
+        sum += v
+    }
+    mean := sum / float64(len(values))
+
+    variance := 0.0
+    for _, v := range values {
+        variance += (v - mean) * (v - mean)
+    }
+    return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// Anomaly detection metrics
+var (
+    anomalyAlerts = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_framework_anomalies_total",
+            Help: "Frameworks flagged as suspect by the anomaly detector",
+        },
+        []string{"framework"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(anomalyAlerts)
+}
@@ -0,0 +1,392 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+    "sort"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Outcome of a framework an organization is attested to have no obligation under
+const frameworkNotApplicable = "NOT_APPLICABLE"
+
+// Topic for attestations nearing expiry
+const attestationExpiryTopic = "attestation-expiry"
+
+// Attestation states. EXPIRED is derived when read, never stored.
+const (
+    attestationActive  = "ACTIVE"
+    attestationRevoked = "REVOKED"
+    attestationExpired = "EXPIRED"
+)
+
+// Audit trail actions
+const (
+    auditRecorded = "RECORDED"
+    auditRevoked  = "REVOKED"
+)
+
+// Attestations are kept forever so the trail stays auditable: revoking
+// marks an attestation, it never deletes one.
+func attestationsKey(organizationID string) string {
+    return "attestations:" + organizationID
+}
+
+func attestationAuditKey(organizationID string) string {
+    return "attestation-audit:" + organizationID
+}
+
+// AttestationStore - framework-level not-applicable attestations per
+// organization, in Redis
+type AttestationStore struct {
+    redis *redis.Client
+}
+
+// Create an attestation store
+func NewAttestationStore(client *redis.Client) *AttestationStore {
+    return &AttestationStore{redis: client}
+}
+
+// State of an attestation at a point in time
+func attestationState(attestation *Attestation, now time.Time) string {
+    if attestation.State == attestationRevoked {
+        return attestationRevoked
+    }
+    if !now.Before(attestation.ExpiresAt.AsTime()) {
+        return attestationExpired
+    }
+    return attestationActive
+}
+
+// All of an organization's attestations with their current state, newest first
+func (a *AttestationStore) List(ctx context.Context, organizationID string, now time.Time) ([]*Attestation, error) {
+    values, err := a.redis.HGetAll(ctx, attestationsKey(organizationID)).Result()
+    if err != nil {
+        return nil, err
+    }
+    attestations := make([]*Attestation, 0, len(values))
+    for id, data := range values {
+        attestation := &Attestation{}
+        if err := proto.Unmarshal([]byte(data), attestation); err != nil {
+            log.Printf("Ignoring undecodable attestation %s: %v", id, err)
+            continue
+        }
+        attestation.State = attestationState(attestation, now)
+        attestations = append(attestations, attestation)
+    }
+    sort.Slice(attestations, func(i, j int) bool {
+        return attestations[i].AttestedAt.AsTime().After(attestations[j].AttestedAt.AsTime())
+    })
+    return attestations, nil
+}
+
+// The active attestation of each framework that has one
+func (a *AttestationStore) Active(ctx context.Context, organizationID string, now time.Time) ([]*Attestation, error) {
+    attestations, err := a.List(ctx, organizationID, now)
+    if err != nil {
+        return nil, err
+    }
+    var active []*Attestation
+    for _, attestation := range attestations {
+        if attestation.State == attestationActive {
+            active = append(active, attestation)
+        }
+    }
+    return active, nil
+}
+
+// The active attestation for a framework, if any
+func activeAttestation(active []*Attestation, framework string) (*Attestation, bool) {
+    for _, attestation := range active {
+        if attestation.Framework == framework {
+            return attestation, true
+        }
+    }
+    return nil, false
+}
+
+// Record an attestation, revoking any active one for the same framework
+func (a *AttestationStore) Record(ctx context.Context, attestation *Attestation) error {
+    now := time.Now()
+    current, err := a.Active(ctx, attestation.OrganizationId, now)
+    if err != nil {
+        return err
+    }
+
+    _, err = a.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+        if previous, ok := activeAttestation(current, attestation.Framework); ok {
+            a.revoke(ctx, pipe, previous, attestation.AttestedBy, "superseded by "+attestation.AttestationId, now)
+        }
+        data, err := proto.Marshal(attestation)
+        if err != nil {
+            return err
+        }
+        pipe.HSet(ctx, attestationsKey(attestation.OrganizationId), attestation.AttestationId, data)
+        a.audit(ctx, pipe, attestation.OrganizationId, &AttestationAuditEntry{
+            Action:        auditRecorded,
+            AttestationId: attestation.AttestationId,
+            Framework:     attestation.Framework,
+            Actor:         attestation.AttestedBy,
+            Reason:        attestation.Justification,
+            At:            attestation.AttestedAt,
+        })
+        return nil
+    })
+    return err
+}
+
+// Revoke the active attestation for a framework; NotFound when there is none
+func (a *AttestationStore) Revoke(ctx context.Context, organizationID, framework, actor, reason string) (*Attestation, error) {
+    now := time.Now()
+    current, err := a.Active(ctx, organizationID, now)
+    if err != nil {
+        return nil, storeError(err, "attestations unavailable")
+    }
+    attestation, ok := activeAttestation(current, framework)
+    if !ok {
+        return nil, status.Errorf(codes.NotFound, "no active %s attestation", framework)
+    }
+
+    _, err = a.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+        a.revoke(ctx, pipe, attestation, actor, reason, now)
+        return nil
+    })
+    if err != nil {
+        return nil, storeError(err, "failed to revoke attestation")
+    }
+    return attestation, nil
+}
+
+// Queue the revocation of an attestation and its audit entry
+func (a *AttestationStore) revoke(ctx context.Context, pipe redis.Pipeliner, attestation *Attestation, actor, reason string, now time.Time) {
+    attestation.State = attestationRevoked
+    attestation.RevokedBy = actor
+    attestation.RevokedAt = timestamppb.New(now)
+    attestation.RevocationReason = reason
+    data, _ := proto.Marshal(attestation)
+    pipe.HSet(ctx, attestationsKey(attestation.OrganizationId), attestation.AttestationId, data)
+    a.audit(ctx, pipe, attestation.OrganizationId, &AttestationAuditEntry{
+        Action:        auditRevoked,
+        AttestationId: attestation.AttestationId,
+        Framework:     attestation.Framework,
+        Actor:         actor,
+        Reason:        reason,
+        At:            attestation.RevokedAt,
+    })
+}
+
+func (a *AttestationStore) audit(ctx context.Context, pipe redis.Pipeliner, organizationID string, entry *AttestationAuditEntry) {
+    data, _ := proto.Marshal(entry)
+    pipe.RPush(ctx, attestationAuditKey(organizationID), data)
+}
+
+// Audit trail of an organization's attestations, oldest first
+func (a *AttestationStore) AuditTrail(ctx context.Context, organizationID string) ([]*AttestationAuditEntry, error) {
+    values, err := a.redis.LRange(ctx, attestationAuditKey(organizationID), 0, -1).Result()
+    if err != nil {
+        return nil, err
+    }
+    entries := make([]*AttestationAuditEntry, 0, len(values))
+    for _, data := range values {
+        entry := &AttestationAuditEntry{}
+        if err := proto.Unmarshal([]byte(data), entry); err != nil {
+            log.Printf("Ignoring undecodable attestation audit entry for %s: %v", redact(fieldOrganizationID, organizationID), err)
+            continue
+        }
+        entries = append(entries, entry)
+    }
+    return entries, nil
+}
+
+// Results standing in for frameworks attested not applicable, by framework.
+// They carry no score or weight; checkers depending on such a framework see
+// the outcome.
+func notApplicableResults(attestations []*Attestation) map[string]*FrameworkResult {
+    results := make(map[string]*FrameworkResult, len(attestations))
+    for _, attestation := range attestations {
+        results[attestation.Framework] = &FrameworkResult{
+            Framework:   attestation.Framework,
+            Outcome:     frameworkNotApplicable,
+            Attestation: attestation,
+        }
+    }
+    return results
+}
+
+// Results that count towards scores, gates and remediation
+func scoredResult(result *FrameworkResult) bool {
+    return result.Outcome != frameworkShortCircuited && result.Outcome != frameworkNotApplicable && result.Outcome != frameworkInsufficientData
+}
+
+// Attestations in effect in a response
+func responseAttestations(response *ComplianceResponse) []*Attestation {
+    var attestations []*Attestation
+    for _, result := range response.FrameworkResults {
+        if result.Outcome == frameworkNotApplicable && result.Attestation != nil {
+            attestations = append(attestations, result.Attestation)
+        }
+    }
+    return attestations
+}
+
+// Cache TTL cut short so a response never outlives an attestation in it
+func attestationTTL(ttl time.Duration, response *ComplianceResponse, now time.Time) time.Duration {
+    for _, attestation := range responseAttestations(response) {
+        if remaining := attestation.ExpiresAt.AsTime().Sub(now); remaining < ttl {
+            ttl = remaining
+        }
+    }
+    if ttl < 0 {
+        ttl = 0
+    }
+    return ttl
+}
+
+// AttestationExpiryEvent - published when an evaluation applies an
+// attestation that expires within the warning window, so it can be renewed
+// or allowed to lapse deliberately. Consumers dedupe on attestation_id.
+type AttestationExpiryEvent struct {
+    OrganizationID string    `json:"organization_id"`
+    AttestationID  string    `json:"attestation_id"`
+    Framework      string    `json:"framework"`
+    ExpiresAt      time.Time `json:"expires_at"`
+    DaysRemaining  int32     `json:"days_remaining"`
+}
+
+// Publish an expiry notice for every attestation in a response expiring
+// within the evidence expiry warning window
+func (s *ComplianceService) publishAttestationExpiry(ctx context.Context, response *ComplianceResponse) {
+    now := time.Now()
+    for _, attestation := range responseAttestations(response) {
+        remaining := attestation.ExpiresAt.AsTime().Sub(now)
+        if remaining > s.config.EvidenceExpiryWarning {
+            continue
+        }
+        value, _ := json.Marshal(AttestationExpiryEvent{
+            OrganizationID: response.OrganizationId,
+            AttestationID:  attestation.AttestationId,
+            Framework:      attestation.Framework,
+            ExpiresAt:      attestation.ExpiresAt.AsTime().UTC(),
+            DaysRemaining:  int32(remaining / (24 * time.Hour)),
+        })
+        if err := s.kafkaProducer.PublishMessage(ctx, attestationExpiryTopic, []byte(response.OrganizationId), value, nil); err != nil {
+            log.Printf("Failed to publish attestation expiry for %s: %v", redact(fieldOrganizationID, response.OrganizationId), err)
+        }
+    }
+}
+
+// RecordAttestation - admin RPC attesting that an organization has no
+// obligation under a framework. Cached responses are dropped so the next
+// check applies it.
+func (s *ComplianceService) RecordAttestation(ctx context.Context, req *RecordAttestationRequest) (*Attestation, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if req.OrganizationId == "" || req.AttestedBy == "" || req.Justification == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id, attested_by and justification are required")
+    }
+    if framework := s.resolveFramework(req.Framework); framework != req.Framework {
+        req = proto.Clone(req).(*RecordAttestationRequest)
+        req.Framework = framework
+    }
+    if !containsString(s.engine.Frameworks(), req.Framework) {
+        return nil, status.Errorf(codes.NotFound, "framework %s not registered", req.Framework)
+    }
+    now := time.Now()
+    if req.ExpiresAt == nil || !req.ExpiresAt.AsTime().After(now) {
+        return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+    }
+    organizationID, err := s.attestedOrganization(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+
+    attestation := &Attestation{
+        AttestationId:  "att-" + newRequestID(),
+        OrganizationId: organizationID,
+        Framework:      req.Framework,
+        Justification:  req.Justification,
+        AttestedBy:     req.AttestedBy,
+        AttestedAt:     timestamppb.New(now),
+        ExpiresAt:      req.ExpiresAt,
+        State:          attestationActive,
+    }
+    if err := s.attestations.Record(ctx, attestation); err != nil {
+        return nil, storeError(err, "failed to record attestation")
+    }
+    if err := s.cache.Invalidate(ctx, organizationID); err != nil {
+        log.Printf("Failed to invalidate cache for %s after attestation: %v", redact(fieldOrganizationID, organizationID), err)
+    }
+    log.Printf("Audit: rpc=RecordAttestation attestation=%s org=%s framework=%s attested_by=%s expires_at=%s",
+        attestation.AttestationId, redact(fieldOrganizationID, organizationID), req.Framework, redact(fieldPrincipal, req.AttestedBy), req.ExpiresAt.AsTime().Format(time.RFC3339))
+    return attestation, nil
+}
+
+// RevokeAttestation - admin RPC ending an organization's active attestation
+// for a framework; the framework is scored again from the next check
+func (s *ComplianceService) RevokeAttestation(ctx context.Context, req *RevokeAttestationRequest) (*Attestation, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if req.OrganizationId == "" || req.Framework == "" || req.RevokedBy == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id, framework and revoked_by are required")
+    }
+    if framework := s.resolveFramework(req.Framework); framework != req.Framework {
+        req = proto.Clone(req).(*RevokeAttestationRequest)
+        req.Framework = framework
+    }
+
+    organizationID, err := s.attestedOrganization(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    attestation, err := s.attestations.Revoke(ctx, organizationID, req.Framework, req.RevokedBy, req.Reason)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.cache.Invalidate(ctx, organizationID); err != nil {
+        log.Printf("Failed to invalidate cache for %s after revocation: %v", redact(fieldOrganizationID, organizationID), err)
+    }
+    log.Printf("Audit: rpc=RevokeAttestation attestation=%s org=%s framework=%s revoked_by=%s",
+        attestation.AttestationId, redact(fieldOrganizationID, organizationID), req.Framework, redact(fieldPrincipal, req.RevokedBy))
+    return attestation, nil
+}
+
+// ListAttestations - an organization's attestations and their audit trail
+func (s *ComplianceService) ListAttestations(ctx context.Context, req *ListAttestationsRequest) (*ListAttestationsResponse, error) {
+    if req.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+    }
+    organizationID, err := s.attestedOrganization(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    attestations, err := s.attestations.List(ctx, organizationID, time.Now())
+    if err != nil {
+        return nil, storeError(err, "attestations unavailable")
+    }
+    trail, err := s.attestations.AuditTrail(ctx, organizationID)
+    if err != nil {
+        return nil, storeError(err, "attestations unavailable")
+    }
+    return &ListAttestationsResponse{Attestations: attestations, AuditTrail: trail}, nil
+}
+
+// Attestations live under the canonical organization ID, where checks look
+// for them
+func (s *ComplianceService) attestedOrganization(ctx context.Context, organizationID string) (string, error) {
+    canonical, err := s.registry.ResolveID(ctx, organizationID)
+    if status.Code(err) == codes.NotFound {
+        return "", err
+    } else if err != nil {
+        return "", storeError(err, "registry unavailable")
+    }
+    return canonical, nil
+}
@@ -0,0 +1,388 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/cache"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/protobuf/proto"
+)
+
+// Version of the ComplianceResponse contract. Bump it whenever fields are
+// added, removed or change meaning; clients branch on it and cached
+// responses of any other version are treated as misses.
+const responseSchemaVersion = 15
+
+// Key prefixes for cached responses and per-framework results
+const (
+    responseKeyPrefix  = "compliance:"
+    frameworkKeyPrefix = "framework:"
+)
+
+// Cache - key/value store behind the response cache, for WithCacheBackend
+type Cache = cache.Cache
+
+// CacheEntry - one value of a Cache batch write
+type CacheEntry = cache.Entry
+
+// NewRedisCache - cache backend on an existing Redis connection
+func NewRedisCache(client *redis.Client) Cache {
+    return cache.NewRedis(client)
+}
+
+// NewMemcachedCache - cache backend over Memcached nodes given as
+// "host:port,host:port"
+func NewMemcachedCache(servers string) (Cache, error) {
+    return cache.NewMemcached(servers)
+}
+
+// NewMemoryCache - cache backend in process memory, for embedding without
+// Redis or Memcached
+func NewMemoryCache() Cache {
+    return cache.NewMemory()
+}
+
+// Cache backends selectable with CACHE_BACKEND
+const (
+    cacheBackendRedis     = "redis"
+    cacheBackendMemcached = "memcached"
+    cacheBackendMemory    = "memory"
+)
+
+// Create the configured cache backend. The Redis backend shares the
+// service's Redis connection.
+func newCacheBackend(config ServiceConfig, redisClient *redis.Client) (cache.Cache, error) {
+    switch config.CacheBackend {
+    case "", cacheBackendRedis:
+        return cache.NewRedis(redisClient), nil
+    case cacheBackendMemcached:
+        return cache.NewMemcached(config.MemcachedServers)
+    case cacheBackendMemory:
+        return cache.NewMemory(), nil
+    }
+    return nil, fmt.Errorf("unknown cache backend %q", config.CacheBackend)
+}
+
+// ResponseCache - compliance responses and framework results over any
+// cache backend
+type ResponseCache struct {
+    backend      cache.Cache
+    maxValueSize int // Encoded values above this many bytes are not cached, 0 disables the guard
+    stats        cacheCounters
+}
+
+// Create a response cache over a backend
+func NewResponseCache(backend cache.Cache, maxValueSize int) *ResponseCache {
+    c := &ResponseCache{backend: backend, maxValueSize: maxValueSize}
+    c.stats.since = time.Now()
+    return c
+}
+
+// Get a cached response by response key
+func (c *ResponseCache) Get(ctx context.Context, key string) (*ComplianceResponse, error) {
+    data, err := c.backend.Get(ctx, responseKeyPrefix+key)
+    if err == cache.ErrMiss {
+        c.stats.read(false)
+    }
+    if err != nil {
+        return nil, err
+    }
+    response := &ComplianceResponse{}
+    if err := proto.Unmarshal(data, response); err != nil {
+        c.stats.read(false)
+        return nil, fmt.Errorf("failed to decode cached response: %v", err)
+    }
+    if response.SchemaVersion != responseSchemaVersion {
+        c.stats.read(false)
+        return nil, fmt.Errorf("cached response has schema version %d, want %d", response.SchemaVersion, responseSchemaVersion)
+    }
+    c.stats.read(true)
+    markReused(response)
+    return response, nil
+}
+
+// Set caches a response under a response key
+func (c *ResponseCache) Set(ctx context.Context, key string, response *ComplianceResponse, ttl time.Duration) error {
+    data, err := proto.Marshal(response)
+    if err != nil {
+        return fmt.Errorf("failed to encode response: %v", err)
+    }
+    if c.oversized("response", key, data) {
+        return nil
+    }
+    c.stats.wrote(len(data))
+    return c.backend.Set(ctx, responseKeyPrefix+key, data, ttl)
+}
+
+// GetMany fetches several cached responses in one batch read. Misses and
+// values that fail to decode are left out of the result.
+func (c *ResponseCache) GetMany(ctx context.Context, keys []string) (map[string]*ComplianceResponse, error) {
+    backendKeys := make([]string, len(keys))
+    for i, key := range keys {
+        backendKeys[i] = responseKeyPrefix + key
+    }
+    values, err := c.backend.BatchGet(ctx, backendKeys)
+    if err != nil {
+        return nil, err
+    }
+
+    responses := make(map[string]*ComplianceResponse, len(keys))
+    for i, data := range values {
+        if data == nil {
+            c.stats.read(false)
+            continue
+        }
+        response := &ComplianceResponse{}
+        if err := proto.Unmarshal(data, response); err != nil {
+            log.Printf("Ignoring undecodable cached response %s: %v", keys[i], err)
+            c.stats.read(false)
+            continue
+        }
+        if response.SchemaVersion != responseSchemaVersion {
+            c.stats.read(false)
+            continue
+        }
+        c.stats.read(true)
+        markReused(response)
+        responses[keys[i]] = response
+    }
+    return responses, nil
+}
+
+// A response to cache under a response key for ttl
+type cachedResponse struct {
+    key      string
+    response *ComplianceResponse
+    ttl      time.Duration
+}
+
+// SetMany caches several responses in one batch write
+func (c *ResponseCache) SetMany(ctx context.Context, responses []cachedResponse) error {
+    entries := make([]cache.Entry, 0, len(responses))
+    for _, cached := range responses {
+        data, err := proto.Marshal(cached.response)
+        if err != nil {
+            return fmt.Errorf("failed to encode response: %v", err)
+        }
+        if c.oversized("response", cached.key, data) {
+            continue
+        }
+        c.stats.wrote(len(data))
+        entries = append(entries, cache.Entry{Key: responseKeyPrefix + cached.key, Value: data, TTL: cached.ttl})
+    }
+    if len(entries) == 0 {
+        return nil
+    }
+    return c.backend.BatchSet(ctx, entries)
+}
+
+// Cache access of a batch group's evaluations: the group reads their
+// responses up front in one batch read, and the fresh responses are held
+// back to be cached in one batch write when the group is done
+type cacheBatch struct {
+    mu     sync.Mutex
+    writes []cachedResponse
+}
+
+type cacheBatchKey struct{}
+
+// Evaluate under ctx as part of a batch group
+func withCacheBatch(ctx context.Context, batch *cacheBatch) context.Context {
+    return context.WithValue(ctx, cacheBatchKey{}, batch)
+}
+
+// Read a cached response; a miss for a batch group's evaluation, as the
+// group read its responses already
+func (s *ComplianceService) cachedResponse(ctx context.Context, key string) (*ComplianceResponse, error) {
+    if _, ok := ctx.Value(cacheBatchKey{}).(*cacheBatch); ok {
+        return nil, cache.ErrMiss
+    }
+    return s.cache.Get(ctx, key)
+}
+
+// Cache a fresh response, or hold it for a batch group's write
+func (s *ComplianceService) cacheResponse(ctx context.Context, key string, response *ComplianceResponse, ttl time.Duration) {
+    if batch, ok := ctx.Value(cacheBatchKey{}).(*cacheBatch); ok {
+        batch.mu.Lock()
+        defer batch.mu.Unlock()
+        // Cloned as it is now, before per-request extras are attached
+        batch.writes = append(batch.writes, cachedResponse{key, proto.Clone(response).(*ComplianceResponse), ttl})
+        return
+    }
+    s.cache.Set(ctx, key, response, ttl)
+}
+
+// GetFrameworks fetches cached framework results by their engine input keys
+// (framework -> key) in one batch read. Results are keyed on inputs, so
+// they are shared by every organization with the same relevant evidence,
+// and come back marked reused.
+func (c *ResponseCache) GetFrameworks(ctx context.Context, keys map[string]string) (map[string]*FrameworkResult, error) {
+    frameworks := make([]string, 0, len(keys))
+    backendKeys := make([]string, 0, len(keys))
+    for framework, key := range keys {
+        frameworks = append(frameworks, framework)
+        backendKeys = append(backendKeys, frameworkKey(key, framework))
+    }
+    values, err := c.backend.BatchGet(ctx, backendKeys)
+    if err != nil {
+        return nil, err
+    }
+
+    results := make(map[string]*FrameworkResult, len(keys))
+    for i, data := range values {
+        if data == nil {
+            c.stats.read(false)
+            continue
+        }
+        result := &FrameworkResult{}
+        if err := proto.Unmarshal(data, result); err != nil {
+            log.Printf("Ignoring undecodable cached %s result: %v", frameworks[i], err)
+            c.stats.read(false)
+            continue
+        }
+        c.stats.read(true)
+        result.Reused = true
+        results[frameworks[i]] = result
+    }
+    return results, nil
+}
+
+// SetFrameworks caches framework results under their engine input keys
+// (framework -> key), each with its own TTL and stamped with when it was
+// evaluated, in one batch write
+func (c *ResponseCache) SetFrameworks(ctx context.Context, keys map[string]string, results []*FrameworkResult, ttls CacheTTLs) error {
+    now := time.Now().Unix()
+    entries := make([]cache.Entry, 0, len(results))
+    for _, result := range results {
+        cached := proto.Clone(result).(*FrameworkResult)
+        cached.EvaluatedAt = now
+        data, err := proto.Marshal(cached)
+        if err != nil {
+            return fmt.Errorf("failed to encode framework result: %v", err)
+        }
+        if c.oversized("framework", result.Framework, data) {
+            continue
+        }
+        c.stats.wrote(len(data))
+        entries = append(entries, cache.Entry{
+            Key:   frameworkKey(keys[result.Framework], result.Framework),
+            Value: data,
+            TTL:   ttls.For(result.Framework),
+        })
+    }
+    if len(entries) == 0 {
+        return nil
+    }
+    return c.backend.BatchSet(ctx, entries)
+}
+
+// Invalidate drops every cached response for an organization. Framework
+// results are keyed on their inputs, not the organization, and stay valid.
+func (c *ResponseCache) Invalidate(ctx context.Context, organizationID string) error {
+    return c.backend.DeleteByPrefix(ctx, responseKeyPrefix+organizationID+":")
+}
+
+// Every evaluated result of a cached response is reused when served from
+// cache; those not already reused were evaluated when the response was
+func markReused(response *ComplianceResponse) {
+    for _, result := range response.FrameworkResults {
+        if result.Outcome == "" && !result.Reused {
+            result.Reused = true
+            result.EvaluatedAt = response.Timestamp
+        }
+    }
+}
+
+// Response cache key: the organization and a digest of the request evidence
+// and its provenance, so a check with changed evidence never returns a
+// stale response, and of its framework scope, region and locale, so checks
+// scoped differently never share a response
+func responseKey(req *ComplianceRequest) string {
+    scope := strings.Join([]string{provenanceScope(req.Evidence), evaluationScope(req), req.Region, req.Locale}, "\x00")
+    return req.OrganizationId + ":" + evidenceHash(scope, "", req.Evidence)[:16]
+}
+
+// Report and count a value too large to cache. The caller still returns the
+// result; it is just recomputed next time instead of bloating Redis.
+func (c *ResponseCache) oversized(kind, key string, data []byte) bool {
+    if c.maxValueSize <= 0 || len(data) <= c.maxValueSize {
+        return false
+    }
+    cacheOversizedSkips.WithLabelValues(kind).Inc()
+    c.stats.oversized.Add(1)
+    log.Printf("Not caching %s %s: %d bytes exceeds the %d byte limit", kind, key, len(data), c.maxValueSize)
+    return true
+}
+
+func frameworkKey(key, framework string) string {
+    return frameworkKeyPrefix + key + ":" + framework
+}
+
+// CacheTTLs - response cache TTL with per-framework overrides, and the
+// tenant's adaptive TTL when it opted in
+type CacheTTLs struct {
+    Default    time.Duration            `yaml:"default_ttl" json:"default_ttl"`
+    Frameworks map[string]time.Duration `yaml:"framework_ttls" json:"framework_ttls"`
+    Adaptive   *AdaptiveTTL             `yaml:"-" json:"adaptive,omitempty"`
+}
+
+// For returns the TTL of a framework's cached result
+func (t CacheTTLs) For(framework string) time.Duration {
+    if ttl, ok := t.Frameworks[framework]; ok {
+        return ttl
+    }
+    return t.Default
+}
+
+// Aggregate TTL of a response: the minimum of its constituents
+func (t CacheTTLs) Aggregate(results []*FrameworkResult) time.Duration {
+    ttl := t.Default
+    for i, result := range results {
+        if frameworkTTL := t.For(result.Framework); i == 0 || frameworkTTL < ttl {
+            ttl = frameworkTTL
+        }
+    }
+    return ttl
+}
+
+// Parse per-framework TTLs such as "SAMA:1h,ISO27001:720h"
+func parseFrameworkTTLs(value string) (map[string]time.Duration, error) {
+    ttls := make(map[string]time.Duration)
+    for _, entry := range strings.Split(value, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        parts := strings.SplitN(entry, ":", 2)
+        if len(parts) != 2 {
+            return nil, fmt.Errorf("invalid framework TTL %q", entry)
+        }
+        ttl, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+        if err != nil || ttl <= 0 {
+            return nil, fmt.Errorf("invalid framework TTL %q", entry)
+        }
+        ttls[strings.TrimSpace(parts[0])] = ttl
+    }
+    return ttls, nil
+}
+
+// Cache metrics
+var (
+    cacheOversizedSkips = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_cache_oversized_skips_total",
+            Help: "Values not cached because they exceeded the maximum value size",
+        },
+        []string{"kind"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(cacheOversizedSkips)
+}
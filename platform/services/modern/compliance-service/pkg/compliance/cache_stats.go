@@ -0,0 +1,68 @@
+package compliance
+
+import (
+    "context"
+    "sync/atomic"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/cache"
+    "google.golang.org/protobuf/types/known/emptypb"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// cacheCounters - response cache reads and writes made by this replica
+// since it started
+type cacheCounters struct {
+    hits         atomic.Int64
+    misses       atomic.Int64
+    writes       atomic.Int64
+    bytesWritten atomic.Int64
+    oversized    atomic.Int64
+    since        time.Time
+}
+
+func (c *cacheCounters) read(hit bool) {
+    if hit {
+        c.hits.Add(1)
+    } else {
+        c.misses.Add(1)
+    }
+}
+
+func (c *cacheCounters) wrote(size int) {
+    c.writes.Add(1)
+    c.bytesWritten.Add(int64(size))
+}
+
+// GetCacheStats - admin RPC snapshotting the response cache: this replica's
+// hit rate and writes since it started, and the backend's entry and
+// eviction counts where it reports them
+func (s *ComplianceService) GetCacheStats(ctx context.Context, _ *emptypb.Empty) (*CacheStats, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    counters := &s.cache.stats
+    stats := &CacheStats{
+        Backend:        defaultString(s.config.CacheBackend, cacheBackendRedis),
+        Hits:           counters.hits.Load(),
+        Misses:         counters.misses.Load(),
+        Writes:         counters.writes.Load(),
+        OversizedSkips: counters.oversized.Load(),
+        Since:          timestamppb.New(counters.since),
+    }
+    if reads := stats.Hits + stats.Misses; reads > 0 {
+        stats.HitRate = float64(stats.Hits) / float64(reads)
+    }
+    if stats.Writes > 0 {
+        stats.AverageValueBytes = float64(counters.bytesWritten.Load()) / float64(stats.Writes)
+    }
+
+    if reporter, ok := s.cache.backend.(cache.StatsReporter); ok {
+        entries, evictions, err := reporter.Stats(ctx)
+        if err != nil {
+            return nil, storeError(err, "cache backend stats unavailable")
+        }
+        stats.Entries, stats.Evictions, stats.BackendStatsAvailable = entries, evictions, true
+    }
+    return stats, nil
+}
@@ -0,0 +1,75 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+func TestGetCacheStatsRequiresAdmin(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+        config.CacheBackend = cacheBackendMemory
+    })
+    for _, ctx := range []context.Context{context.Background(), adminContext("guess")} {
+        if _, err := service.GetCacheStats(ctx, nil); status.Code(err) != codes.PermissionDenied {
+            t.Errorf("GetCacheStats() = %v, want PermissionDenied", err)
+        }
+    }
+}
+
+// The snapshot reflects a known sequence of cache operations
+func TestGetCacheStatsReflectsOperations(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+        config.CacheBackend = cacheBackendMemory
+    })
+    ctx := context.Background()
+    responses := []*ComplianceResponse{
+        {OrganizationId: "org-1", OverallScore: 80, SchemaVersion: responseSchemaVersion},
+        {OrganizationId: "org-2", OverallScore: 60, Status: "PARTIALLY_COMPLIANT", SchemaVersion: responseSchemaVersion},
+    }
+    var written int
+    for _, response := range responses {
+        data, _ := proto.Marshal(response)
+        written += len(data)
+        if err := service.cache.Set(ctx, response.OrganizationId+":k", response, time.Minute); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if err := service.cache.Set(ctx, "org-3:k", responses[0], time.Millisecond); err != nil {
+        t.Fatal(err)
+    }
+    data, _ := proto.Marshal(responses[0])
+    written += len(data)
+    time.Sleep(5 * time.Millisecond)
+
+    service.cache.Get(ctx, "org-1:k")       // hit
+    service.cache.Get(ctx, "org-2:k")       // hit
+    service.cache.Get(ctx, "org-3:k")       // miss, expired
+    service.cache.Get(ctx, "org-missing:k") // miss
+
+    stats, err := service.GetCacheStats(adminContext("admin-secret"), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stats.Backend != cacheBackendMemory || !stats.BackendStatsAvailable {
+        t.Errorf("backend %s, stats available %t, want memory with backend stats", stats.Backend, stats.BackendStatsAvailable)
+    }
+    if stats.Hits != 2 || stats.Misses != 2 || stats.HitRate != 0.5 {
+        t.Errorf("%d hits %d misses, hit rate %v, want 2, 2 and 0.5", stats.Hits, stats.Misses, stats.HitRate)
+    }
+    if stats.Writes != 3 || stats.AverageValueBytes != float64(written)/3 {
+        t.Errorf("%d writes averaging %v bytes, want 3 averaging %v", stats.Writes, stats.AverageValueBytes, float64(written)/3)
+    }
+    if stats.Entries != 2 || stats.Evictions != 1 {
+        t.Errorf("%d entries, %d evictions, want 2 and the expired 1", stats.Entries, stats.Evictions)
+    }
+    if stats.Since.AsTime().After(time.Now()) {
+        t.Errorf("counters since %v, in the future", stats.Since.AsTime())
+    }
+}
@@ -0,0 +1,367 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/protobuf/proto"
+)
+
+func newTestRedisCache(tb testing.TB) (*ResponseCache, *miniredis.Miniredis) {
+    server := miniredis.RunT(tb)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    tb.Cleanup(func() { client.Close() })
+    return NewResponseCache(NewRedisCache(client), 0), server
+}
+
+// Each framework entry expires per its configured TTL, the rest on the default
+func TestFrameworkEntriesExpirePerTTL(t *testing.T) {
+    ctx := context.Background()
+    cache, server := newTestRedisCache(t)
+    ttls := CacheTTLs{Default: 5 * time.Minute, Frameworks: map[string]time.Duration{"SAMA": time.Hour, "ISO27001": 720 * time.Hour}}
+    keys := map[string]string{"SAMA": "k1", "NCA": "k2", "ISO27001": "k3"}
+    results := []*FrameworkResult{{Framework: "SAMA", Score: 80}, {Framework: "NCA", Score: 90}, {Framework: "ISO27001", Score: 70}}
+    if err := cache.SetFrameworks(ctx, keys, results, ttls); err != nil {
+        t.Fatal(err)
+    }
+
+    for framework, want := range map[string]time.Duration{"SAMA": time.Hour, "NCA": 5 * time.Minute, "ISO27001": 720 * time.Hour} {
+        if got := server.TTL(frameworkKey(keys[framework], framework)); got != want {
+            t.Errorf("%s cached for %v, want %v", framework, got, want)
+        }
+    }
+
+    tests := []struct {
+        elapsed time.Duration
+        want    []string
+    }{
+        {elapsed: 10 * time.Minute, want: []string{"ISO27001", "SAMA"}},
+        {elapsed: 2 * time.Hour, want: []string{"ISO27001"}},
+        {elapsed: 720 * time.Hour, want: nil},
+    }
+    for _, tt := range tests {
+        server.FastForward(tt.elapsed)
+        cached, err := cache.GetFrameworks(ctx, keys)
+        if err != nil {
+            t.Fatal(err)
+        }
+        var got []string
+        for framework := range cached {
+            got = append(got, framework)
+        }
+        sort.Strings(got)
+        if !reflect.DeepEqual(got, tt.want) {
+            t.Errorf("after another %v cached %v, want %v", tt.elapsed, got, tt.want)
+        }
+    }
+}
+
+func TestAggregateTTLIsMinimum(t *testing.T) {
+    ttls := CacheTTLs{Default: 5 * time.Minute, Frameworks: map[string]time.Duration{"SAMA": time.Hour, "NCA": time.Minute}}
+    tests := []struct {
+        name       string
+        frameworks []string
+        want       time.Duration
+    }{
+        {name: "no results", want: 5 * time.Minute},
+        {name: "longer than the default", frameworks: []string{"SAMA"}, want: time.Hour},
+        {name: "default constituent", frameworks: []string{"SAMA", "PDPL"}, want: 5 * time.Minute},
+        {name: "shortest override", frameworks: []string{"SAMA", "PDPL", "NCA"}, want: time.Minute},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var results []*FrameworkResult
+            for _, framework := range tt.frameworks {
+                results = append(results, &FrameworkResult{Framework: framework})
+            }
+            if got := ttls.Aggregate(results); got != tt.want {
+                t.Errorf("Aggregate() = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestParseFrameworkTTLs(t *testing.T) {
+    tests := []struct {
+        value   string
+        want    map[string]time.Duration
+        wantErr bool
+    }{
+        {value: "", want: map[string]time.Duration{}},
+        {value: "SAMA:1h, ISO27001:720h", want: map[string]time.Duration{"SAMA": time.Hour, "ISO27001": 720 * time.Hour}},
+        {value: "SAMA", wantErr: true},
+        {value: "SAMA:soon", wantErr: true},
+        {value: "SAMA:-1h", wantErr: true},
+    }
+    for _, tt := range tests {
+        got, err := parseFrameworkTTLs(tt.value)
+        if (err != nil) != tt.wantErr {
+            t.Errorf("parseFrameworkTTLs(%q) error = %v, want error %t", tt.value, err, tt.wantErr)
+            continue
+        }
+        if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+            t.Errorf("parseFrameworkTTLs(%q) = %v, want %v", tt.value, got, tt.want)
+        }
+    }
+}
+
+// A checked response is cached for the shortest TTL among its frameworks
+func TestResponseCachedForShortestFrameworkTTL(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.FrameworkCacheTTLs = "SAMA:1m,ISO27001:720h"
+    })
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got := response.Metadata["cache_ttl"]; got != "60" {
+        t.Errorf("response cache_ttl %s, want 60", got)
+    }
+    keys := server.Keys()
+    found := false
+    for _, key := range keys {
+        if strings.HasPrefix(key, responseKeyPrefix+"org-1:") {
+            found = true
+            if ttl := server.TTL(key); ttl != time.Minute {
+                t.Errorf("response %s cached for %v, want 1m", key, ttl)
+            }
+        }
+    }
+    if !found {
+        t.Errorf("no cached response among %v", keys)
+    }
+}
+
+func TestGetFrameworksSkipsUndecodableValues(t *testing.T) {
+    ctx := context.Background()
+    cache, server := newTestRedisCache(t)
+    keys := map[string]string{"SAMA": "k1", "NCA": "k2", "PDPL": "k3"}
+    results := []*FrameworkResult{{Framework: "SAMA", Score: 80}, {Framework: "NCA", Score: 90}}
+    if err := cache.SetFrameworks(ctx, keys, results, CacheTTLs{Default: time.Hour}); err != nil {
+        t.Fatal(err)
+    }
+    server.Set(frameworkKey("k2", "NCA"), "not a protobuf \xff\xff")
+
+    cached, err := cache.GetFrameworks(ctx, keys)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(cached) != 1 || cached["SAMA"] == nil {
+        t.Fatalf("got %v, want only SAMA", cached)
+    }
+    if !cached["SAMA"].Reused || cached["SAMA"].Score != 80 {
+        t.Errorf("SAMA = %v, want score 80 marked reused", cached["SAMA"])
+    }
+}
+
+// Reading 500 organizations' results one GET at a time against one MGET
+func BenchmarkFrameworkReads(b *testing.B) {
+    ctx := context.Background()
+    cache, server := newTestRedisCache(b)
+    keys := make(map[string]string, 500)
+    for i := 0; i < 500; i++ {
+        framework := fmt.Sprintf("org-%d", i)
+        keys[framework] = "k"
+        data, _ := proto.Marshal(&FrameworkResult{Framework: framework, Score: 75})
+        server.Set(frameworkKey("k", framework), string(data))
+    }
+
+    b.Run("sequential", func(b *testing.B) {
+        start := server.CommandCount()
+        for i := 0; i < b.N; i++ {
+            for framework, key := range keys {
+                if _, err := cache.backend.Get(ctx, frameworkKey(key, framework)); err != nil {
+                    b.Fatal(err)
+                }
+            }
+        }
+        b.ReportMetric(float64(server.CommandCount()-start)/float64(b.N), "roundtrips/op")
+    })
+    b.Run("batched", func(b *testing.B) {
+        start := server.CommandCount()
+        for i := 0; i < b.N; i++ {
+            if _, err := cache.GetFrameworks(ctx, keys); err != nil {
+                b.Fatal(err)
+            }
+        }
+        b.ReportMetric(float64(server.CommandCount()-start)/float64(b.N), "roundtrips/op")
+    })
+}
+
+// Values over the size limit are skipped and counted; smaller ones in the
+// same batch are still written
+func TestOversizedValuesNotCached(t *testing.T) {
+    ctx := context.Background()
+    cache, server := newTestRedisCache(t)
+    cache.maxValueSize = 1024
+    missing := make([]string, 200)
+    for i := range missing {
+        missing[i] = fmt.Sprintf("control_%03d", i)
+    }
+
+    before := testutil.ToFloat64(cacheOversizedSkips.WithLabelValues("response"))
+    huge := &ComplianceResponse{OrganizationId: "org-1", FrameworkResults: []*FrameworkResult{{Framework: "NCA", MissingInputs: missing}}}
+    if err := cache.Set(ctx, "org-1:abc", huge, time.Hour); err != nil {
+        t.Fatalf("Set() = %v, want the skip to be silent", err)
+    }
+    if server.Exists(responseKeyPrefix + "org-1:abc") {
+        t.Error("oversized response cached")
+    }
+    if got := testutil.ToFloat64(cacheOversizedSkips.WithLabelValues("response")) - before; got != 1 {
+        t.Errorf("%v response skips counted, want 1", got)
+    }
+
+    keys := map[string]string{"NCA": "k1", "SAMA": "k2"}
+    results := []*FrameworkResult{{Framework: "NCA", MissingInputs: missing}, {Framework: "SAMA", Score: 80}}
+    if err := cache.SetFrameworks(ctx, keys, results, CacheTTLs{Default: time.Hour}); err != nil {
+        t.Fatal(err)
+    }
+    if server.Exists(frameworkKey("k1", "NCA")) || !server.Exists(frameworkKey("k2", "SAMA")) {
+        t.Errorf("cached %v, want only SAMA's framework result", server.Keys())
+    }
+    if got := cache.stats.oversized.Load(); got != 2 {
+        t.Errorf("%d oversized skips in stats, want 2", got)
+    }
+}
+
+// A response too large to cache is still computed and returned, and the
+// next check evaluates it again
+func TestOversizedResponseReturnedButNotCached(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.CacheMaxValueSize = 1
+    })
+    for i := 0; i < 2; i++ {
+        response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1"})
+        if err != nil {
+            t.Fatal(err)
+        }
+        if len(response.FrameworkResults) == 0 || response.Status == "" {
+            t.Fatalf("check %d returned %v, want a full response", i+1, response)
+        }
+        for _, result := range response.FrameworkResults {
+            if result.Reused {
+                t.Errorf("check %d: %s served from cache", i+1, result.Framework)
+            }
+        }
+    }
+    for _, key := range server.Keys() {
+        if strings.HasPrefix(key, responseKeyPrefix) || strings.HasPrefix(key, frameworkKeyPrefix) {
+            t.Errorf("%s cached despite the size limit", key)
+        }
+    }
+}
+
+// Framework results are keyed on the evidence each framework reads: the
+// same evidence, in any order and for any organization, reuses the cached
+// result, while changed evidence re-evaluates only the frameworks reading it
+func TestFrameworkCacheKeyedOnEvidence(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    evaluated := func(organizationID string, evidence ...*EvidenceItem) map[string]int64 {
+        t.Helper()
+        before := spy.runs()
+        if _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: organizationID, Evidence: evidence}); err != nil {
+            t.Fatal(err)
+        }
+        runs := make(map[string]int64)
+        for framework, n := range spy.runs() {
+            if n > before[framework] {
+                runs[framework] = n - before[framework]
+            }
+        }
+        return runs
+    }
+    mfa := &EvidenceItem{Key: "mfa_enforced", Value: "true"}
+    aml := &EvidenceItem{Key: "aml_program", Value: "true"}
+
+    if runs := evaluated("org-1", mfa, aml); runs["NCA"] != 1 || runs["SAMA"] != 1 {
+        t.Fatalf("first evaluation ran %v, want every framework", runs)
+    }
+    if runs := evaluated("org-2", aml, mfa); len(runs) != 0 {
+        t.Errorf("same evidence ran %v, want every framework from cache", runs)
+    }
+    if runs := evaluated("org-3", mfa, aml, &EvidenceItem{Key: "badge_color", Value: "red"}); len(runs) != 0 {
+        t.Errorf("evidence no framework reads ran %v, want every framework from cache", runs)
+    }
+    runs := evaluated("org-4", &EvidenceItem{Key: "mfa_enforced", Value: "false"}, aml)
+    if runs["NCA"] != 1 || runs["SAMA"] != 0 {
+        t.Errorf("changed NCA evidence ran %v, want NCA and not SAMA", runs)
+    }
+}
+
+// Changing any one evidence key re-evaluates exactly the frameworks whose
+// requirements read it; every other result comes back reused
+func TestChangedEvidenceInvalidatesExactlyItsReaders(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+
+    // Two valid values of each evidence type: the baseline and its change
+    today := time.Now().UTC()
+    values := map[string][2]string{
+        evidenceBool:   {"true", "false"},
+        evidenceNumber: {"2", "3"},
+        evidenceDate:   {today.AddDate(0, 0, -1).Format("2006-01-02"), today.AddDate(0, 0, -2).Format("2006-01-02")},
+        evidenceString: {"documented", "under review"},
+    }
+    readers := make(map[string][]string)
+    types := make(map[string]string)
+    for _, framework := range service.engine.Frameworks() {
+        for _, requirement := range service.engine.requirements[framework] {
+            readers[requirement.Key] = append(readers[requirement.Key], framework)
+            types[requirement.Key] = requirement.Type
+        }
+    }
+    evidence := func(changed string) []*EvidenceItem {
+        items := make([]*EvidenceItem, 0, len(types))
+        for key, valueType := range types {
+            value := values[valueType][0]
+            if key == changed {
+                value = values[valueType][1]
+            }
+            items = append(items, &EvidenceItem{Key: key, Value: value})
+        }
+        return items
+    }
+
+    if _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "baseline", Evidence: evidence("")}); err != nil {
+        t.Fatal(err)
+    }
+    for key, frameworks := range readers {
+        t.Run(key, func(t *testing.T) {
+            before := spy.runs()
+            response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-" + key, Evidence: evidence(key)})
+            if err != nil {
+                t.Fatal(err)
+            }
+            var ran []string
+            for framework, n := range spy.runs() {
+                if n > before[framework] {
+                    ran = append(ran, framework)
+                }
+            }
+            sort.Strings(ran)
+            want := append([]string(nil), frameworks...)
+            sort.Strings(want)
+            if !reflect.DeepEqual(ran, want) {
+                t.Errorf("changed %s re-evaluated %v, want %v", key, ran, want)
+            }
+            for _, result := range response.FrameworkResults {
+                if reads := containsString(frameworks, result.Framework); result.Reused == reads {
+                    t.Errorf("%s reused = %v, want %v", result.Framework, result.Reused, !reads)
+                }
+            }
+        })
+    }
+}
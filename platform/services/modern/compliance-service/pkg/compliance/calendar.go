@@ -0,0 +1,181 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "sort"
+    "strings"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "gopkg.in/yaml.v3"
+)
+
+// Kinds of regulatory milestone
+const (
+    milestoneDeadline      = "DEADLINE"       // A submission or remediation window closes
+    milestoneVersionChange = "VERSION_CHANGE" // A new framework version takes effect
+)
+
+// Milestone - one dated entry of the regulatory calendar
+type Milestone struct {
+    ID          string    `yaml:"id" json:"id"`
+    Framework   string    `yaml:"framework" json:"framework"`
+    Date        time.Time `yaml:"date" json:"date"`
+    Kind        string    `yaml:"kind" json:"kind"`
+    Title       string    `yaml:"title" json:"title"`
+    Description string    `yaml:"description" json:"description"`
+    Sectors     []string  `yaml:"sectors" json:"sectors"` // Empty applies to every sector
+}
+
+// RegulatoryCalendar - framework milestones, soonest first
+type RegulatoryCalendar []Milestone
+
+// Load the calendar from a YAML/JSON file of the form {milestones: [...]};
+// empty means no calendar
+func loadRegulatoryCalendar(path string, frameworks []string) (RegulatoryCalendar, error) {
+    if path == "" {
+        return nil, nil
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read regulatory calendar: %v", err)
+    }
+    var file struct {
+        Milestones RegulatoryCalendar `yaml:"milestones"`
+    }
+    if err := yaml.Unmarshal(data, &file); err != nil {
+        return nil, fmt.Errorf("failed to parse regulatory calendar: %v", err)
+    }
+    if err := file.Milestones.Validate(frameworks); err != nil {
+        return nil, fmt.Errorf("invalid regulatory calendar: %v", err)
+    }
+    calendar := file.Milestones
+    sort.SliceStable(calendar, func(i, j int) bool { return calendar[i].Date.Before(calendar[j].Date) })
+    return calendar, nil
+}
+
+// Validate milestones name registered frameworks, a known kind and a date,
+// with unique IDs
+func (c RegulatoryCalendar) Validate(frameworks []string) error {
+    seen := make(map[string]bool, len(c))
+    for i, milestone := range c {
+        if milestone.ID == "" {
+            return fmt.Errorf("milestone at position %d has no id", i+1)
+        }
+        if seen[milestone.ID] {
+            return fmt.Errorf("duplicate milestone %s", milestone.ID)
+        }
+        seen[milestone.ID] = true
+        if !containsString(frameworks, milestone.Framework) {
+            return fmt.Errorf("milestone %s names unknown framework %s", milestone.ID, milestone.Framework)
+        }
+        if milestone.Kind != milestoneDeadline && milestone.Kind != milestoneVersionChange {
+            return fmt.Errorf("milestone %s has kind %q, expected %s or %s", milestone.ID, milestone.Kind, milestoneDeadline, milestoneVersionChange)
+        }
+        if milestone.Date.IsZero() || milestone.Title == "" {
+            return fmt.Errorf("milestone %s needs a date and a title", milestone.ID)
+        }
+    }
+    return nil
+}
+
+// Whether a milestone concerns an organization in sector; an unknown
+// sector matches every milestone
+func (m Milestone) appliesTo(sector string) bool {
+    if sector == "" || len(m.Sectors) == 0 {
+        return true
+    }
+    for _, s := range m.Sectors {
+        if strings.EqualFold(s, sector) {
+            return true
+        }
+    }
+    return false
+}
+
+// Milestones of the frameworks dated from the start of today through
+// horizon from now, soonest first
+func (c RegulatoryCalendar) upcoming(frameworks []string, sector string, now time.Time, horizon time.Duration) []*RegulatoryMilestone {
+    today := now.UTC().Truncate(24 * time.Hour)
+    cutoff := now.Add(horizon)
+    var upcoming []*RegulatoryMilestone
+    for _, milestone := range c {
+        if milestone.Date.Before(today) {
+            continue
+        }
+        if milestone.Date.After(cutoff) {
+            break
+        }
+        if containsString(frameworks, milestone.Framework) && milestone.appliesTo(sector) {
+            upcoming = append(upcoming, milestone.proto(now))
+        }
+    }
+    return upcoming
+}
+
+func (m Milestone) proto(now time.Time) *RegulatoryMilestone {
+    milestone := &RegulatoryMilestone{
+        Id:          m.ID,
+        Framework:   m.Framework,
+        Date:        m.Date.Unix(),
+        Kind:        m.Kind,
+        Title:       m.Title,
+        Description: m.Description,
+        Sectors:     m.Sectors,
+    }
+    if !m.Date.Before(now) {
+        milestone.DaysRemaining = int32(m.Date.Sub(now) / (24 * time.Hour))
+    }
+    return milestone
+}
+
+// Annotate a response with the upcoming obligations of the frameworks in
+// scope for the requested sector. Like trends they are per-request extras,
+// never cached, hashed or published, so the days remaining stay current.
+func (s *ComplianceService) attachObligations(req *ComplianceRequest, response *ComplianceResponse) {
+    runtime := s.runtimeConfig()
+    if runtime.ObligationHorizon <= 0 || len(runtime.Calendar) == 0 {
+        return
+    }
+    var frameworks []string
+    for _, result := range response.FrameworkResults {
+        if result.Outcome != frameworkNotApplicable {
+            frameworks = append(frameworks, result.Framework)
+        }
+    }
+    response.UpcomingObligations = runtime.Calendar.upcoming(frameworks, req.Sector, time.Now(), runtime.ObligationHorizon)
+}
+
+// ListRegulatoryMilestones - the regulatory calendar, optionally narrowed to
+// frameworks, a sector and a date range
+func (s *ComplianceService) ListRegulatoryMilestones(ctx context.Context, req *ListRegulatoryMilestonesRequest) (*ListRegulatoryMilestonesResponse, error) {
+    for _, framework := range req.Frameworks {
+        if !containsString(s.engine.Frameworks(), framework) {
+            return nil, status.Errorf(codes.InvalidArgument, "unknown framework %s", framework)
+        }
+    }
+    if req.From > 0 && req.To > 0 && req.From > req.To {
+        return nil, status.Error(codes.InvalidArgument, "from must not be after to")
+    }
+
+    runtime := s.runtimeConfig()
+    now := time.Now()
+    response := &ListRegulatoryMilestonesResponse{ConfigVersion: runtime.Version}
+    for _, milestone := range runtime.Calendar {
+        date := milestone.Date.Unix()
+        if (req.From > 0 && date < req.From) || (req.To > 0 && date > req.To) {
+            continue
+        }
+        if len(req.Frameworks) > 0 && !containsString(req.Frameworks, milestone.Framework) {
+            continue
+        }
+        if !milestone.appliesTo(req.Sector) {
+            continue
+        }
+        response.Milestones = append(response.Milestones, milestone.proto(now))
+    }
+    return response, nil
+}
@@ -0,0 +1,119 @@
+package compliance
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "sort"
+    "strconv"
+
+    "google.golang.org/protobuf/encoding/protojson"
+    "google.golang.org/protobuf/proto"
+)
+
+// CanonicalJSON renders a message deterministically: proto field names,
+// object keys sorted, no insignificant whitespace and numbers in shortest
+// fixed-point form. Equal messages always render to identical bytes,
+// whichever encoder produced them elsewhere.
+func CanonicalJSON(m proto.Message) ([]byte, error) {
+    data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal message: %v", err)
+    }
+
+    decoder := json.NewDecoder(bytes.NewReader(data))
+    decoder.UseNumber()
+    var value interface{}
+    if err := decoder.Decode(&value); err != nil {
+        return nil, fmt.Errorf("failed to decode message JSON: %v", err)
+    }
+
+    var buf bytes.Buffer
+    if err := writeCanonical(&buf, value); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value interface{}) error {
+    switch v := value.(type) {
+    case nil:
+        buf.WriteString("null")
+    case bool:
+        buf.WriteString(strconv.FormatBool(v))
+    case json.Number:
+        f, err := v.Float64()
+        if err != nil {
+            return fmt.Errorf("invalid number %s: %v", v, err)
+        }
+        buf.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+    case string:
+        encoded, _ := json.Marshal(v)
+        buf.Write(encoded)
+    case []interface{}:
+        buf.WriteByte('[')
+        for i, item := range v {
+            if i > 0 {
+                buf.WriteByte(',')
+            }
+            if err := writeCanonical(buf, item); err != nil {
+                return err
+            }
+        }
+        buf.WriteByte(']')
+    case map[string]interface{}:
+        keys := make([]string, 0, len(v))
+        for k := range v {
+            keys = append(keys, k)
+        }
+        sort.Strings(keys)
+        buf.WriteByte('{')
+        for i, k := range keys {
+            if i > 0 {
+                buf.WriteByte(',')
+            }
+            encoded, _ := json.Marshal(k)
+            buf.Write(encoded)
+            buf.WriteByte(':')
+            if err := writeCanonical(buf, v[k]); err != nil {
+                return err
+            }
+        }
+        buf.WriteByte('}')
+    default:
+        return fmt.Errorf("unsupported JSON value %T", value)
+    }
+    return nil
+}
+
+// Hash of a response's canonical content
+func responseContentHash(response *ComplianceResponse) (string, error) {
+    data, err := canonicalContent(response)
+    if err != nil {
+        return "", err
+    }
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:]), nil
+}
+
+// Canonical form of a response's content. The timestamp, the cache TTL it
+// was given, whether each result was reused and when it was evaluated, and
+// the hash itself are excluded so re-evaluating unchanged inputs yields the
+// same content.
+func canonicalContent(response *ComplianceResponse) ([]byte, error) {
+    stripped := proto.Clone(response).(*ComplianceResponse)
+    stripped.Timestamp = 0
+    stripped.ContentHash = ""
+    stripped.RunId = ""
+    delete(stripped.Metadata, metadataCacheTTL)
+    delete(stripped.Metadata, metadataCacheTTLMode)
+    for _, result := range stripped.FrameworkResults {
+        if result.Reused {
+            result.Reused = false
+            result.EvaluatedAt = 0
+        }
+    }
+    return CanonicalJSON(stripped)
+}
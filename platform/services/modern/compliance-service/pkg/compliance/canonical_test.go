@@ -0,0 +1,67 @@
+package compliance
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+// A response exercising what the canonical form normalizes: map keys in
+// any order, fractional, integral and large scores, escaped strings, and
+// the fields the content hash leaves out
+func canonicalFixture() *ComplianceResponse {
+    return &ComplianceResponse{
+        OrganizationId: "org-1",
+        Timestamp:      1767225600,
+        OverallScore:   82.35,
+        Status:         "PARTIALLY_COMPLIANT",
+        RunId:          "run-1",
+        ContentHash:    "stale",
+        Metadata: map[string]string{
+            "zone":               "riyadh-1",
+            "locale_note":        "تقرير \"الامتثال\" <draft>",
+            metadataCacheTTL:     "300",
+            metadataCacheTTLMode: "adaptive",
+        },
+        FrameworkResults: []*FrameworkResult{
+            {Framework: "SAMA", Score: 0.1, EvaluatedAt: 1767225000, Reused: true, RulesetVersion: "2025.1"},
+            {Framework: "NCA", Score: 100, EvaluatedAt: 1767225600, RulesetVersion: "2025.1", RequirementsMet: 3, RequirementsTotal: 4},
+            {Framework: "NIST", Score: 1e21},
+        },
+    }
+}
+
+// The canonical form keeps its exact bytes; a change to the rendering
+// would silently change every content hash
+func TestCanonicalJSONGolden(t *testing.T) {
+    data, err := CanonicalJSON(canonicalFixture())
+    if err != nil {
+        t.Fatal(err)
+    }
+    compareGolden(t, "canonical_response.golden.json", data)
+}
+
+// The content hash is the SHA-256 of the golden content form, which leaves
+// out the run, its timestamp, cache TTLs and reuse
+func TestResponseContentHashGolden(t *testing.T) {
+    data, err := canonicalContent(canonicalFixture())
+    if err != nil {
+        t.Fatal(err)
+    }
+    compareGolden(t, "canonical_content.golden.json", data)
+
+    golden, err := os.ReadFile(filepath.Join("testdata", "canonical_content.golden.json"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    sum := sha256.Sum256(golden)
+    hash, err := responseContentHash(canonicalFixture())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := hex.EncodeToString(sum[:]); hash != want {
+        t.Errorf("content hash %s, want the golden content's %s", hash, want)
+    }
+}
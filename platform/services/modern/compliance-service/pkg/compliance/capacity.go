@@ -0,0 +1,37 @@
+package compliance
+
+import (
+    "context"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/orca"
+    "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetCapacity - current load of this replica, read from in-memory scheduler
+// and job queue state so polling it is cheap and never touches Redis or Kafka
+func (s *ComplianceService) GetCapacity(ctx context.Context, _ *emptypb.Empty) (*CapacityResponse, error) {
+    scheduler := s.engine.scheduler
+    waiting, inUse, capacity := scheduler.Stats()
+    return &CapacityResponse{
+        InFlightEvaluations:  int32(inUse),
+        WorkerPoolSize:       int32(capacity),
+        WorkerUtilization:    float64(inUse) / float64(capacity),
+        QueuedEvaluations:    int32(waiting),
+        MaxQueuedEvaluations: int32(scheduler.maxQueue),
+        QueuedJobs:           int32(s.jobs.Depth()),
+        Saturation:           scheduler.Saturation(),
+    }, nil
+}
+
+// Unary interceptor attaching ORCA per-call load reports, so load-aware
+// gRPC balancers see saturation on every response without polling
+func (s *ComplianceService) loadReportInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    response, err := handler(ctx, req)
+    if recorder := orca.CallMetricsRecorderFromContext(ctx); recorder != nil {
+        saturation := s.engine.scheduler.Saturation()
+        recorder.SetApplicationUtilization(saturation)
+        recorder.SetNamedUtilization("saturation", saturation)
+    }
+    return response, err
+}
@@ -0,0 +1,88 @@
+// Package checkertest - conformance harness for FrameworkChecker
+// implementations, for teams registering their own checkers:
+//
+//    func TestConformance(t *testing.T) {
+//        checkertest.RunConformance(t, checkSOC2, checkertest.WithFramework("SOC2"))
+//    }
+//
+// The battery is the one the rules engine runs against every registered
+// checker at startup, so a checker passing it here will not stop the service.
+package checkertest
+
+import (
+    "context"
+    "testing"
+
+    "github.com/doganai/platform/services/modern/compliance-service/pkg/compliance"
+)
+
+// Option - customizes a conformance run
+type Option func(*options)
+
+type options struct {
+    framework      string
+    rulesetVersion string
+    notApplicable  []string
+}
+
+// WithFramework - the name the checker is registered under; by default the
+// framework its first result names
+func WithFramework(framework string) Option {
+    return func(o *options) {
+        o.framework = framework
+    }
+}
+
+// WithRulesetVersion - the ruleset version the checker is registered with;
+// compliance.DefaultRulesetVersion by default
+func WithRulesetVersion(version string) Option {
+    return func(o *options) {
+        o.rulesetVersion = version
+    }
+}
+
+// WithNotApplicable - frameworks the checker reads through
+// compliance.DependencyResult, offered to it as attested NOT_APPLICABLE
+func WithNotApplicable(frameworks ...string) Option {
+    return func(o *options) {
+        o.notApplicable = append(o.notApplicable, frameworks...)
+    }
+}
+
+// RunConformance runs a checker through the standard battery, reporting
+// each failed property as a test error:
+//
+//   - the ruleset version and framework name are set
+//   - a result is produced without evidence, for the registered framework
+//   - the score is within [0, 100]
+//   - the checker never reports NOT_APPLICABLE, and still produces a scored
+//     result with the WithNotApplicable frameworks attested not applicable
+//   - identical input yields identical output
+//   - control and sub-domain identifiers in the details are unique
+//   - a cancelled context makes the checker return promptly
+//   - the checker does not panic
+func RunConformance(t testing.TB, checker compliance.FrameworkChecker, opts ...Option) {
+    t.Helper()
+    o := options{rulesetVersion: compliance.DefaultRulesetVersion}
+    for _, opt := range opts {
+        opt(&o)
+    }
+    if o.framework == "" {
+        o.framework = reportedFramework(checker)
+    }
+    for _, failure := range compliance.CheckConformance(o.rulesetVersion, o.framework, checker, o.notApplicable...) {
+        t.Error(failure)
+    }
+}
+
+// Framework a checker's result names; empty when it returns none or panics,
+// which the battery then reports
+func reportedFramework(checker compliance.FrameworkChecker) (framework string) {
+    defer func() {
+        recover()
+    }()
+    if result := checker(context.Background(), &compliance.ComplianceRequest{OrganizationId: "conformance-check"}); result != nil {
+        return result.Framework
+    }
+    return ""
+}
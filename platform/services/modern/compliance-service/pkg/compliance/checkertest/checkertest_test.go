@@ -0,0 +1,155 @@
+package checkertest_test
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/doganai/platform/services/modern/compliance-service/pkg/compliance"
+    "github.com/doganai/platform/services/modern/compliance-service/pkg/compliance/checkertest"
+)
+
+func TestBuiltinCheckers(t *testing.T) {
+    config := compliance.ConfigFromEnv()
+    config.RedisAddr = miniredis.RunT(t).Addr()
+    config.KafkaAddr = "127.0.0.1:1"
+    service, err := compliance.New(config)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    engine := service.Engine()
+    frameworks := engine.Frameworks()
+    if len(frameworks) != 5 {
+        t.Fatalf("%d built-in checkers registered, want 5: %v", len(frameworks), frameworks)
+    }
+    for _, framework := range frameworks {
+        checker, _ := engine.Checker(framework)
+        var others []string
+        for _, other := range frameworks {
+            if other != framework {
+                others = append(others, other)
+            }
+        }
+        t.Run(framework, func(t *testing.T) {
+            checkertest.RunConformance(t, checker, checkertest.WithFramework(framework), checkertest.WithNotApplicable(others...))
+        })
+    }
+}
+
+// Records the errors RunConformance reports instead of failing the test
+type recorder struct {
+    testing.TB
+    mu     sync.Mutex
+    errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...interface{}) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func TestRunConformanceReportsFailures(t *testing.T) {
+    good := func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+        if ctx.Err() != nil {
+            return nil
+        }
+        return &compliance.FrameworkResult{Framework: "SOC2", Score: 70}
+    }
+
+    tests := []struct {
+        name    string
+        checker compliance.FrameworkChecker
+        opts    []checkertest.Option
+        want    string
+    }{
+        {name: "conforming checker", checker: good},
+        {
+            name:    "registered under another name",
+            checker: good,
+            opts:    []checkertest.Option{checkertest.WithFramework("HIPAA")},
+            want:    `result names framework "SOC2"`,
+        },
+        {
+            name: "score out of range",
+            checker: func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+                return &compliance.FrameworkResult{Framework: "SOC2", Score: 120}
+            },
+            want: "outside [0, 100]",
+        },
+        {
+            name: "NaN score",
+            checker: func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+                return &compliance.FrameworkResult{Framework: "SOC2", Score: math.NaN()}
+            },
+            want: "outside [0, 100]",
+        },
+        {
+            name: "reports itself not applicable",
+            checker: func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+                return &compliance.FrameworkResult{Framework: "SOC2", Outcome: "NOT_APPLICABLE"}
+            },
+            want: "reported itself NOT_APPLICABLE",
+        },
+        {
+            name: "gives up when a prerequisite is not applicable",
+            checker: func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+                if nca, ok := compliance.DependencyResult(ctx, "NCA"); ok && nca.Outcome == "NOT_APPLICABLE" {
+                    return nil
+                }
+                return &compliance.FrameworkResult{Framework: "SOC2", Score: 70}
+            },
+            opts: []checkertest.Option{checkertest.WithNotApplicable("NCA")},
+            want: "no result with [NCA] not applicable",
+        },
+        {
+            name: "nondeterministic",
+            checker: func() compliance.FrameworkChecker {
+                calls := 0
+                return func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+                    calls++
+                    return &compliance.FrameworkResult{Framework: "SOC2", Score: float64(calls)}
+                }
+            }(),
+            want: "identical input produced different results",
+        },
+        {
+            name: "panics",
+            checker: func(ctx context.Context, req *compliance.ComplianceRequest) *compliance.FrameworkResult {
+                panic("boom")
+            },
+            want: "panicked: boom",
+        },
+        {
+            name:    "no ruleset version",
+            checker: good,
+            opts:    []checkertest.Option{checkertest.WithRulesetVersion("")},
+            want:    "ruleset version is empty",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            r := &recorder{TB: t}
+            checkertest.RunConformance(r, tt.checker, tt.opts...)
+            if tt.want == "" {
+                if len(r.errors) > 0 {
+                    t.Errorf("unexpected failures: %v", r.errors)
+                }
+                return
+            }
+            for _, failure := range r.errors {
+                if strings.Contains(failure, tt.want) {
+                    return
+                }
+            }
+            t.Errorf("failures %v, want one containing %q", r.errors, tt.want)
+        })
+    }
+}
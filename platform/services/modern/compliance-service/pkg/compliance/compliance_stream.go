@@ -0,0 +1,59 @@
+package compliance
+
+import (
+    "context"
+
+    "google.golang.org/protobuf/proto"
+)
+
+type resultObserverKey struct{}
+
+// Context whose evaluation reports each framework result as it completes,
+// from the engine's collecting goroutine
+func withResultObserver(ctx context.Context, observe func(*FrameworkResult)) context.Context {
+    return context.WithValue(ctx, resultObserverKey{}, observe)
+}
+
+func observeResult(ctx context.Context, result *FrameworkResult) {
+    if observe, ok := ctx.Value(resultObserverKey{}).(func(*FrameworkResult)); ok {
+        observe(result)
+    }
+}
+
+// CheckComplianceStream - CheckCompliance streaming each framework result
+// as soon as it is evaluated or reused, then the aggregate response.
+// Results the engine did not produce (a cached response, stale fallbacks,
+// short-circuited frameworks) are sent just before the aggregate. Streamed
+// results are rounded and shaped as in the aggregate, whose results alone
+// carry supported_by and trends.
+func (s *ComplianceService) CheckComplianceStream(req *ComplianceRequest, stream Compliance_CheckComplianceStreamServer) error {
+    runtime := s.runtimeConfig()
+    sent := make(map[string]bool)
+    var sendErr error
+    send := func(result *FrameworkResult) {
+        if sendErr != nil || sent[result.Framework] {
+            return
+        }
+        single := &ComplianceResponse{FrameworkResults: []*FrameworkResult{proto.Clone(result).(*FrameworkResult)}}
+        applyPrecisionPolicy(single)
+        shaped := shapeResponse(single, runtime.ResultSchema)
+        sendErr = stream.Send(&ComplianceStreamMessage{Payload: &ComplianceStreamMessage_FrameworkResult{FrameworkResult: shaped.FrameworkResults[0]}})
+        sent[result.Framework] = true
+    }
+
+    response, err := s.checkCompliance(withResultObserver(stream.Context(), send), req)
+    if err != nil {
+        return err
+    }
+    for _, result := range response.FrameworkResults {
+        send(result)
+    }
+    if sendErr != nil {
+        return sendErr
+    }
+    shaped := shapeResponse(response, runtime.ResultSchema)
+    if runtime.ResultSchema == resultSchemaDual {
+        go verifyDualWritten(shaped, runtime.SchemaValidationRate)
+    }
+    return stream.Send(&ComplianceStreamMessage{Payload: &ComplianceStreamMessage_Aggregate{Aggregate: shaped}})
+}
@@ -0,0 +1,106 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Stream recording each message and when it was sent
+type recordingStream struct {
+    grpc.ServerStream
+    ctx      context.Context
+    messages []*ComplianceStreamMessage
+    sentAt   []time.Time
+}
+
+func (s *recordingStream) Context() context.Context { return s.ctx }
+
+func (s *recordingStream) Send(message *ComplianceStreamMessage) error {
+    s.messages = append(s.messages, message)
+    s.sentAt = append(s.sentAt, time.Now())
+    return nil
+}
+
+// Framework results streamed ahead of the aggregate, which must come last
+func streamedResults(t *testing.T, stream *recordingStream) (map[string]*FrameworkResult, *ComplianceResponse) {
+    t.Helper()
+    results := make(map[string]*FrameworkResult)
+    for i, message := range stream.messages {
+        if aggregate := message.GetAggregate(); aggregate != nil {
+            if i != len(stream.messages)-1 {
+                t.Fatalf("aggregate sent as message %d of %d, want it last", i+1, len(stream.messages))
+            }
+            return results, aggregate
+        }
+        result := message.GetFrameworkResult()
+        if results[result.Framework] != nil {
+            t.Errorf("%s streamed twice", result.Framework)
+        }
+        results[result.Framework] = result
+    }
+    t.Fatal("no aggregate streamed")
+    return nil, nil
+}
+
+// Each framework result is streamed as it completes, before a slow
+// framework holds up the aggregate
+func TestCheckComplianceStreamSendsResultsAsTheyComplete(t *testing.T) {
+    const delay = 200 * time.Millisecond
+    service, _ := newTestService(t)
+    service.faults.Set("SAMA", Fault{Delay: delay})
+
+    stream := &recordingStream{ctx: context.Background()}
+    start := time.Now()
+    if err := service.CheckComplianceStream(&ComplianceRequest{OrganizationId: "org-1"}, stream); err != nil {
+        t.Fatal(err)
+    }
+
+    results, aggregate := streamedResults(t, stream)
+    if len(results) != len(aggregate.FrameworkResults) {
+        t.Fatalf("%d results streamed, want all %d of the aggregate", len(results), len(aggregate.FrameworkResults))
+    }
+    for _, want := range aggregate.FrameworkResults {
+        if got := results[want.Framework]; got == nil || got.Score != want.Score || got.Outcome != want.Outcome {
+            t.Errorf("streamed %s %v, want the aggregate's %v", want.Framework, got, want)
+        }
+    }
+    if first := stream.sentAt[0].Sub(start); first >= delay {
+        t.Errorf("first result streamed after %v, want it before SAMA's %v delay", first, delay)
+    }
+    last := stream.messages[len(stream.messages)-2].GetFrameworkResult()
+    if last.Framework != "SAMA" {
+        t.Errorf("%s streamed last, want the delayed SAMA", last.Framework)
+    }
+}
+
+// A cached response streams its results, then the aggregate
+func TestCheckComplianceStreamFromCache(t *testing.T) {
+    service, _ := newTestService(t)
+    req := &ComplianceRequest{OrganizationId: "org-1"}
+    if err := service.CheckComplianceStream(req, &recordingStream{ctx: context.Background()}); err != nil {
+        t.Fatal(err)
+    }
+
+    stream := &recordingStream{ctx: context.Background()}
+    if err := service.CheckComplianceStream(req, stream); err != nil {
+        t.Fatal(err)
+    }
+    results, aggregate := streamedResults(t, stream)
+    if len(results) != len(aggregate.FrameworkResults) {
+        t.Errorf("%d results streamed from the cache, want %d", len(results), len(aggregate.FrameworkResults))
+    }
+}
+
+func TestCheckComplianceStreamRejectsInvalidRequest(t *testing.T) {
+    service, _ := newTestService(t)
+    stream := &recordingStream{ctx: context.Background()}
+    err := service.CheckComplianceStream(&ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"GDPR"}}, stream)
+    if status.Code(err) != codes.InvalidArgument || len(stream.messages) != 0 {
+        t.Errorf("CheckComplianceStream() = %v after %d messages, want InvalidArgument and none", err, len(stream.messages))
+    }
+}
@@ -0,0 +1,23 @@
+package compliance
+
+import (
+    "context"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/encoding"
+    _ "google.golang.org/grpc/encoding/gzip" // Registers the gzip compressor
+)
+
+// The gRPC server answers a request compressed with a registered compressor
+// using the same one, so gzip-requesting clients get gzip responses. With
+// compression disabled these interceptors send every response uncompressed;
+// compressed requests are still accepted.
+func uncompressedUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    grpc.SetSendCompressor(ctx, encoding.Identity)
+    return handler(ctx, req)
+}
+
+func uncompressedStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+    grpc.SetSendCompressor(stream.Context(), encoding.Identity)
+    return handler(srv, stream)
+}
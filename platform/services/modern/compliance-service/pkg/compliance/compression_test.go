@@ -0,0 +1,122 @@
+package compliance
+
+import (
+    "context"
+    "net"
+    "sync"
+    "testing"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/encoding/gzip"
+    "google.golang.org/grpc/stats"
+)
+
+// Client stats handler recording the encoding of each response and the
+// size of its payload before and after decompression
+type payloadSizes struct {
+    mu        sync.Mutex
+    encodings []string
+    payloads  []*stats.InPayload
+}
+
+func (p *payloadSizes) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context   { return ctx }
+func (p *payloadSizes) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context { return ctx }
+func (p *payloadSizes) HandleConn(context.Context, stats.ConnStats)                       {}
+
+func (p *payloadSizes) HandleRPC(_ context.Context, s stats.RPCStats) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    switch s := s.(type) {
+    case *stats.InHeader:
+        p.encodings = append(p.encodings, s.Compression)
+    case *stats.InPayload:
+        p.payloads = append(p.payloads, s)
+    }
+}
+
+// Encoding and payload of the last response; nil if none arrived
+func (p *payloadSizes) last() (string, *stats.InPayload) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if len(p.encodings) == 0 || len(p.payloads) == 0 {
+        return "", nil
+    }
+    return p.encodings[len(p.encodings)-1], p.payloads[len(p.payloads)-1]
+}
+
+// Serve the service over gRPC with its own server options and dial it
+func dialTestService(t *testing.T, service *ComplianceService, sizes *payloadSizes) ComplianceClient {
+    t.Helper()
+    lis, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    server := grpc.NewServer(service.ServerOptions()...)
+    service.RegisterGRPC(server)
+    go server.Serve(lis)
+    t.Cleanup(server.Stop)
+
+    conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithStatsHandler(sizes))
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { conn.Close() })
+    return NewComplianceClient(conn)
+}
+
+// A client asking for gzip gets a gzip response that decodes to the full
+// result, unless compression is turned off; one not asking gets it plain
+func TestResponseCompressionNegotiated(t *testing.T) {
+    tests := []struct {
+        name         string
+        compression  bool
+        clientGzip   bool
+        wantEncoding string
+    }{
+        {name: "gzip client", compression: true, clientGzip: true, wantEncoding: gzip.Name},
+        {name: "plain client", compression: true},
+        {name: "gzip client, compression off", clientGzip: true},
+    }
+    // Every framework with a verdict per evidence item makes a response
+    // worth compressing
+    var evidence []*EvidenceItem
+    for _, key := range []string{"asset_inventory", "mfa_enforced", "aml_program", "dpo_appointed", "third_party_register", "breach_notification_process", "incident_response_plan"} {
+        evidence = append(evidence, &EvidenceItem{Key: key, Value: "true"})
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service, _ := newTestService(t, func(config *ServiceConfig) {
+                config.GRPCCompression = tt.compression
+            })
+            sizes := &payloadSizes{}
+            client := dialTestService(t, service, sizes)
+
+            var opts []grpc.CallOption
+            if tt.clientGzip {
+                opts = append(opts, grpc.UseCompressor(gzip.Name))
+            }
+            response, err := client.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Evidence: evidence}, opts...)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(response.FrameworkResults) != len(service.engine.Frameworks()) {
+                t.Errorf("%d framework results, want %d", len(response.FrameworkResults), len(service.engine.Frameworks()))
+            }
+
+            encoding, payload := sizes.last()
+            if payload == nil {
+                t.Fatal("no response observed")
+            }
+            if encoding == "identity" {
+                encoding = ""
+            }
+            if encoding != tt.wantEncoding {
+                t.Errorf("response encoding %q, want %q", encoding, tt.wantEncoding)
+            }
+            if compressed := payload.CompressedLength < payload.Length; compressed != (tt.wantEncoding != "") {
+                t.Errorf("%d bytes on the wire for a %d byte response, want compressed %v", payload.CompressedLength, payload.Length, !compressed)
+            }
+        })
+    }
+}
@@ -0,0 +1,97 @@
+package compliance
+
+import (
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// Upper bound on memoized entries before expired ones are swept
+const computeCacheMaxEntries = 10000
+
+// ComputeCache - short-lived in-process memo of framework evaluations.
+// Distinct from the Redis response cache: it is keyed on rule inputs,
+// not on the organization, and never leaves the process.
+type ComputeCache struct {
+    mu      sync.Mutex
+    ttl     time.Duration
+    entries map[string]computeEntry
+}
+
+type computeEntry struct {
+    result    *FrameworkResult
+    expiresAt time.Time
+}
+
+// Create a compute cache with the given TTL
+func NewComputeCache(ttl time.Duration) *ComputeCache {
+    return &ComputeCache{
+        ttl:     ttl,
+        entries: make(map[string]computeEntry),
+    }
+}
+
+// Get a memoized result if present and not expired
+func (c *ComputeCache) Get(key string) (*FrameworkResult, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    entry, ok := c.entries[key]
+    if !ok {
+        return nil, false
+    }
+    if time.Now().After(entry.expiresAt) {
+        delete(c.entries, key)
+        return nil, false
+    }
+    return entry.result, true
+}
+
+// Set memoizes a result for the configured TTL
+func (c *ComputeCache) Set(key string, result *FrameworkResult) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    now := time.Now()
+    if len(c.entries) >= computeCacheMaxEntries {
+        for k, entry := range c.entries {
+            if now.After(entry.expiresAt) {
+                delete(c.entries, k)
+            }
+        }
+        if len(c.entries) >= computeCacheMaxEntries {
+            return
+        }
+    }
+    c.entries[key] = computeEntry{result: result, expiresAt: now.Add(c.ttl)}
+}
+
+// Purge drops every memoized result
+func (c *ComputeCache) Purge() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.entries = make(map[string]computeEntry)
+}
+
+// Compute cache metrics
+var (
+    computeCacheHits = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_compute_cache_hits_total",
+            Help: "Framework evaluations served from the compute cache",
+        },
+    )
+
+    computeCacheMisses = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_compute_cache_misses_total",
+            Help: "Framework evaluations that ran the rules",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(computeCacheHits)
+    prometheus.MustRegister(computeCacheMisses)
+}
@@ -0,0 +1,77 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// Parse per-framework concurrency limits such as "SAMA:2,NCA:8"
+func parseFrameworkConcurrency(value string) (map[string]int, error) {
+    limits := make(map[string]int)
+    for _, entry := range strings.Split(value, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        parts := strings.SplitN(entry, ":", 2)
+        if len(parts) != 2 {
+            return nil, fmt.Errorf("invalid framework concurrency %q", entry)
+        }
+        limit, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+        if err != nil || limit < 1 {
+            return nil, fmt.Errorf("invalid framework concurrency %q", entry)
+        }
+        limits[strings.TrimSpace(parts[0])] = limit
+    }
+    return limits, nil
+}
+
+// SetConcurrencyLimit caps how many evaluations of a framework run at once
+// across all requests, e.g. for checkers behind a rate-limited downstream
+func (e *RulesEngine) SetConcurrencyLimit(framework string, limit int) error {
+    if _, ok := e.checkers[framework]; !ok {
+        return fmt.Errorf("concurrency limit configured for unregistered framework %s", framework)
+    }
+    e.limits[framework] = make(chan struct{}, limit)
+    return nil
+}
+
+// Wait for a framework's concurrency limit, if it has one. The returned
+// release must be called once the evaluation is done.
+func (e *RulesEngine) acquireFramework(ctx context.Context, framework string) (func(), error) {
+    limit, ok := e.limits[framework]
+    if !ok {
+        return func() {}, nil
+    }
+    startTime := time.Now()
+    select {
+    case limit <- struct{}{}:
+        frameworkLimitWaits.get(framework).Observe(time.Since(startTime).Seconds())
+        return func() { <-limit }, nil
+    case <-ctx.Done():
+        return nil, ctx.Err()
+    }
+}
+
+// Framework concurrency metrics
+var (
+    frameworkLimitWait = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name: "compliance_framework_limit_wait_seconds",
+            Help: "Time framework evaluations waited for their per-framework concurrency limit",
+        },
+        []string{"framework"},
+    )
+
+    // Children per framework, created as frameworks register
+    frameworkLimitWaits = newObserverCache(frameworkLimitWait)
+)
+
+func init() {
+    prometheus.MustRegister(frameworkLimitWait)
+}
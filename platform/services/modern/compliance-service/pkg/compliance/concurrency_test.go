@@ -0,0 +1,57 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+// Concurrent requests never run more SAMA checks at once than its limit,
+// and with enough load reach it; a limit of 1 runs them one at a time
+func TestFrameworkConcurrencyLimit(t *testing.T) {
+    const requests = 20
+    for _, limit := range []int{1, 3} {
+        t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+            service, _ := newTestService(t, func(config *ServiceConfig) {
+                config.ComputeCacheTTL = 0
+                config.WorkerPoolSize = requests * 5
+                config.FrameworkConcurrency = fmt.Sprintf("SAMA:%d", limit)
+            })
+            var inFlight, peak atomic.Int64
+            checker, _ := service.engine.Checker("SAMA")
+            service.engine.Register("SAMA", func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+                n := inFlight.Add(1)
+                defer inFlight.Add(-1)
+                for {
+                    seen := peak.Load()
+                    if n <= seen || peak.CompareAndSwap(seen, n) {
+                        break
+                    }
+                }
+                time.Sleep(5 * time.Millisecond)
+                return checker(ctx, req)
+            })
+
+            // Distinct SAMA evidence, so no request reuses another's result
+            var wg sync.WaitGroup
+            for i := 0; i < requests; i++ {
+                wg.Add(1)
+                go func(organizationID string) {
+                    defer wg.Done()
+                    req := &ComplianceRequest{OrganizationId: organizationID, Evidence: []*EvidenceItem{{Key: "third_party_register", Value: organizationID}}}
+                    if _, err := service.CheckCompliance(context.Background(), req); err != nil {
+                        t.Error(err)
+                    }
+                }(fmt.Sprintf("org-%d", i))
+            }
+            wg.Wait()
+
+            if n := peak.Load(); n != int64(limit) {
+                t.Errorf("%d SAMA checks ran at once, want %d", n, limit)
+            }
+        })
+    }
+}
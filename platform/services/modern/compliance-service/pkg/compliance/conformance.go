@@ -0,0 +1,177 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "time"
+
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// How long a checker may take to return once its context is cancelled
+const conformanceCancelTimeout = time.Second
+
+// CheckConformance runs a checker against the standard battery every
+// framework checker must pass, returning one error per failed property:
+//
+//   - the engine has a ruleset version
+//   - a result is produced without evidence, for the registered framework
+//   - the score is within [0, 100]
+//   - the checker never reports NOT_APPLICABLE, which only attestations set
+//   - with the notApplicable frameworks attested not applicable, a scored
+//     result is still produced
+//   - identical input yields identical output
+//   - control and sub-domain identifiers in the details are unique
+//   - a cancelled context makes the checker return promptly
+//   - the checker does not panic
+func CheckConformance(rulesetVersion, framework string, checker FrameworkChecker, notApplicable ...string) []error {
+    var failures []error
+    fail := func(format string, args ...interface{}) {
+        failures = append(failures, fmt.Errorf("%s: "+format, append([]interface{}{framework}, args...)...))
+    }
+
+    if rulesetVersion == "" {
+        fail("ruleset version is empty")
+    }
+    if framework == "" {
+        fail("framework name is empty")
+    }
+
+    req := &ComplianceRequest{OrganizationId: "conformance-check"}
+    first, err := runChecker(context.Background(), checker, req)
+    if err != nil {
+        fail("%v", err)
+        return failures
+    }
+    if first == nil {
+        fail("no result without evidence")
+        return failures
+    }
+    if first.Framework != framework {
+        fail("result names framework %q", first.Framework)
+    }
+    if math.IsNaN(first.Score) || first.Score < 0 || first.Score > 100 {
+        fail("score %v outside [0, 100]", first.Score)
+    }
+    if first.Outcome == frameworkNotApplicable {
+        fail("reported itself %s", frameworkNotApplicable)
+    }
+    for _, duplicate := range duplicateIdentifiers(first.ProtoReflect()) {
+        fail("duplicate identifier %s", duplicate)
+    }
+
+    // Frameworks attested not applicable reach dependents as results with
+    // that outcome and no score
+    attested := &dependencyResults{results: make(map[string]*FrameworkResult, len(notApplicable))}
+    for _, other := range notApplicable {
+        attested.set(other, &FrameworkResult{Framework: other, Outcome: frameworkNotApplicable})
+    }
+    withAttested, err := runChecker(context.WithValue(context.Background(), dependencyResultsKey{}, attested), checker, req)
+    switch {
+    case err != nil:
+        fail("with %v not applicable: %v", notApplicable, err)
+    case withAttested == nil:
+        fail("no result with %v not applicable", notApplicable)
+    case withAttested.Outcome == frameworkNotApplicable:
+        fail("reported itself %s with %v not applicable", frameworkNotApplicable, notApplicable)
+    case math.IsNaN(withAttested.Score) || withAttested.Score < 0 || withAttested.Score > 100:
+        fail("score %v outside [0, 100] with %v not applicable", withAttested.Score, notApplicable)
+    }
+
+    second, err := runChecker(context.Background(), checker, proto.Clone(req).(*ComplianceRequest))
+    if err != nil {
+        fail("%v", err)
+    } else if !proto.Equal(first, second) {
+        fail("identical input produced different results")
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    done := make(chan error, 1)
+    go func() {
+        _, err := runChecker(ctx, checker, req)
+        done <- err
+    }()
+    select {
+    case err := <-done:
+        if err != nil {
+            fail("with cancelled context: %v", err)
+        }
+    case <-time.After(conformanceCancelTimeout):
+        fail("did not return within %s of cancellation", conformanceCancelTimeout)
+    }
+
+    return failures
+}
+
+// Run a checker, converting a panic into an error
+func runChecker(ctx context.Context, checker FrameworkChecker, req *ComplianceRequest) (result *FrameworkResult, err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("panicked: %v", r)
+        }
+    }()
+    return checker(ctx, req), nil
+}
+
+// Repeated identifiers within a result: duplicate entries of repeated string
+// fields, and duplicate names among repeated messages with a name field
+func duplicateIdentifiers(msg protoreflect.Message) []string {
+    var duplicates []string
+    msg.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+        switch {
+        case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+            list := value.List()
+            seen := make(map[string]bool, list.Len())
+            for i := 0; i < list.Len(); i++ {
+                id := list.Get(i).String()
+                if seen[id] {
+                    duplicates = append(duplicates, fmt.Sprintf("%s in %s", id, fd.Name()))
+                }
+                seen[id] = true
+            }
+        case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+            list := value.List()
+            seen := make(map[string]bool, list.Len())
+            for i := 0; i < list.Len(); i++ {
+                item := list.Get(i).Message()
+                if name := item.Descriptor().Fields().ByName("name"); name != nil {
+                    id := item.Get(name).String()
+                    if seen[id] {
+                        duplicates = append(duplicates, fmt.Sprintf("%s in %s", id, fd.Name()))
+                    }
+                    seen[id] = true
+                }
+                duplicates = append(duplicates, duplicateIdentifiers(item)...)
+            }
+        case !fd.IsList() && !fd.IsMap() && fd.Kind() == protoreflect.MessageKind:
+            duplicates = append(duplicates, duplicateIdentifiers(value.Message())...)
+        }
+        return true
+    })
+    return duplicates
+}
+
+// CheckConformance runs the battery against every registered checker,
+// shadow rulesets included, with every other framework not applicable
+func (e *RulesEngine) CheckConformance() error {
+    var failures []error
+    for _, framework := range e.frameworks {
+        var others []string
+        for _, other := range e.frameworks {
+            if other != framework {
+                others = append(others, other)
+            }
+        }
+        failures = append(failures, CheckConformance(e.rulesetVersion, framework, e.checkers[framework], others...)...)
+        if shadow, ok := e.shadows[framework]; ok {
+            failures = append(failures, CheckConformance(shadow.version, framework, shadow.checker, others...)...)
+        }
+    }
+    if len(failures) > 0 {
+        return fmt.Errorf("checker conformance failed: %v", failures)
+    }
+    return nil
+}
@@ -0,0 +1,275 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "os"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/protobuf/encoding/protojson"
+    "gopkg.in/yaml.v3"
+)
+
+const (
+    // Delay before the first retry of a connector, doubling per retry
+    connectorRetryBackoff = 200 * time.Millisecond
+    // Largest connector response read
+    connectorMaxResponseSize = 10 << 20
+)
+
+// ConnectorConfig - evidence connectors queried for each organization's
+// evidence on every check, alongside inline and uploaded evidence
+type ConnectorConfig struct {
+    // YAML list of connectors; empty disables them
+    File string `env:"EVIDENCE_CONNECTORS_FILE"`
+
+    // Retries of a connector after a 5xx, 408, 429, timeout or connection
+    // failure, before its evidence is treated as missing
+    Retries int `env:"EVIDENCE_CONNECTOR_RETRIES"`
+
+    // Longest each attempt may take, unless the connector sets its own
+    Timeout time.Duration `env:"EVIDENCE_CONNECTOR_TIMEOUT"`
+}
+
+// EvidenceConnector - an HTTP source of evidence. A GET of the URL, with
+// {organization_id} replaced, answers {"evidence": [EvidenceItem, ...]} in
+// proto JSON; items outside the connector's keys are ignored.
+type EvidenceConnector struct {
+    Name string   `yaml:"name"`
+    URL  string   `yaml:"url"`
+    Keys []string `yaml:"keys"`
+
+    // A required connector that stays unavailable fails the check instead
+    // of scoring its keys as missing
+    Required bool `yaml:"required"`
+
+    // Longest each attempt may take, e.g. "2s"; 0 uses the service-wide
+    // connector timeout
+    Timeout time.Duration `yaml:"timeout"`
+}
+
+// EvidenceConnectors - the configured connectors and how they are called
+type EvidenceConnectors struct {
+    connectors []EvidenceConnector
+    client     *http.Client
+    retries    int
+    timeout    time.Duration
+}
+
+// Load connectors from the configured file; keys must be evidence some
+// registered framework reads
+func loadEvidenceConnectors(config ConnectorConfig, engine *RulesEngine, client *http.Client) (*EvidenceConnectors, error) {
+    connectors := &EvidenceConnectors{client: client, retries: config.Retries, timeout: config.Timeout}
+    if config.File == "" {
+        return connectors, nil
+    }
+    data, err := os.ReadFile(config.File)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read evidence connectors: %v", err)
+    }
+    if err := yaml.Unmarshal(data, &connectors.connectors); err != nil {
+        return nil, fmt.Errorf("failed to parse evidence connectors: %v", err)
+    }
+
+    declared := make(map[string]bool)
+    for _, framework := range engine.Frameworks() {
+        for _, requirement := range engine.requirements[framework] {
+            declared[requirement.Key] = true
+        }
+    }
+    names := make(map[string]bool)
+    for _, connector := range connectors.connectors {
+        if connector.Name == "" || names[connector.Name] {
+            return nil, fmt.Errorf("invalid evidence connectors: names must be unique and non-empty")
+        }
+        names[connector.Name] = true
+        if u, err := url.Parse(strings.ReplaceAll(connector.URL, "{organization_id}", "x")); err != nil || u.Scheme == "" || u.Host == "" {
+            return nil, fmt.Errorf("invalid evidence connector %s: url must be absolute", connector.Name)
+        }
+        if len(connector.Keys) == 0 {
+            return nil, fmt.Errorf("invalid evidence connector %s: keys are required", connector.Name)
+        }
+        if connector.Timeout < 0 {
+            return nil, fmt.Errorf("invalid evidence connector %s: timeout must not be negative", connector.Name)
+        }
+        for _, key := range connector.Keys {
+            if !declared[key] {
+                return nil, fmt.Errorf("invalid evidence connector %s: no framework reads %s", connector.Name, key)
+            }
+        }
+    }
+    return connectors, nil
+}
+
+// Fetch an organization's evidence from every connector supplying a key not
+// already given. Connectors that supply nothing are reported as degraded
+// and their keys scored as missing. A connector rejecting our credentials
+// fails the check with FailedPrecondition naming it, as does a required
+// connector that stays unavailable, with Unavailable.
+func (c *EvidenceConnectors) Fetch(ctx context.Context, organizationID string, given map[string]bool) ([]*EvidenceItem, []*EvidenceDegradation, error) {
+    type fetched struct {
+        items       []*EvidenceItem
+        degradation *EvidenceDegradation
+        err         error
+    }
+    results := make([]fetched, len(c.connectors))
+    var wg sync.WaitGroup
+    for i, connector := range c.connectors {
+        needed := false
+        for _, key := range connector.Keys {
+            needed = needed || !given[key]
+        }
+        if !needed {
+            continue
+        }
+        wg.Add(1)
+        go func(i int, connector EvidenceConnector) {
+            defer wg.Done()
+            items, degradation, err := c.fetch(ctx, connector, organizationID)
+            results[i] = fetched{items, degradation, err}
+        }(i, connector)
+    }
+    wg.Wait()
+
+    var items []*EvidenceItem
+    var degraded []*EvidenceDegradation
+    for _, result := range results {
+        if result.err != nil {
+            return nil, nil, result.err
+        }
+        items = append(items, result.items...)
+        if result.degradation != nil {
+            degraded = append(degraded, result.degradation)
+        }
+    }
+    return items, degraded, nil
+}
+
+// Fetch from one connector, retrying what a retry can fix
+func (c *EvidenceConnectors) fetch(ctx context.Context, connector EvidenceConnector, organizationID string) ([]*EvidenceItem, *EvidenceDegradation, error) {
+    degrade := func(code int, reason, outcome string) ([]*EvidenceItem, *EvidenceDegradation, error) {
+        connectorFetches.WithLabelValues(connector.Name, outcome).Inc()
+        return nil, &EvidenceDegradation{Connector: connector.Name, Keys: connector.Keys, Reason: reason, HttpStatus: int32(code)}, nil
+    }
+
+    var code int
+    var failure string
+    var timedOut bool
+    for attempt := 0; attempt <= c.retries; attempt++ {
+        if attempt > 0 {
+            select {
+            case <-ctx.Done():
+                return nil, nil, ctx.Err()
+            case <-time.After(connectorRetryBackoff << (attempt - 1)):
+            }
+        }
+        var items []*EvidenceItem
+        var err error
+        items, code, err = c.get(ctx, connector, organizationID)
+        switch {
+        case ctx.Err() != nil:
+            return nil, nil, ctx.Err()
+        case err == nil:
+            connectorFetches.WithLabelValues(connector.Name, "ok").Inc()
+            return items, nil, nil
+        case code == http.StatusNotFound:
+            return degrade(code, "no evidence for the organization", "not_found")
+        case code == http.StatusUnauthorized || code == http.StatusForbidden:
+            connectorFetches.WithLabelValues(connector.Name, "unauthorized").Inc()
+            return nil, nil, reasonErrorWithMetadata(codes.FailedPrecondition, reasonConnectorMisconfigured,
+                fmt.Sprintf("evidence connector %s rejected the service's credentials (%d)", connector.Name, code),
+                map[string]string{"connector": connector.Name, "http_status": strconv.Itoa(code)})
+        case code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests:
+            return degrade(code, fmt.Sprintf("request rejected: %v", err), "rejected")
+        case code == http.StatusOK:
+            // Undecodable response; retrying would get the same
+            return degrade(code, err.Error(), "invalid")
+        }
+        failure = err.Error()
+        timedOut = errors.Is(err, context.DeadlineExceeded)
+    }
+
+    if connector.Required {
+        connectorFetches.WithLabelValues(connector.Name, "unavailable").Inc()
+        return nil, nil, reasonErrorWithMetadata(codes.Unavailable, reasonUnavailable,
+            fmt.Sprintf("required evidence connector %s unavailable: %s", connector.Name, failure),
+            map[string]string{"connector": connector.Name})
+    }
+    if timedOut {
+        return degrade(code, fmt.Sprintf("timed out after %s on %d attempts", c.attemptTimeout(connector), c.retries+1), "timeout")
+    }
+    return degrade(code, fmt.Sprintf("unavailable after %d attempts: %s", c.retries+1, failure), "unavailable")
+}
+
+// Longest one attempt of a connector may take; 0 for no limit
+func (c *EvidenceConnectors) attemptTimeout(connector EvidenceConnector) time.Duration {
+    if connector.Timeout > 0 {
+        return connector.Timeout
+    }
+    return c.timeout
+}
+
+// One attempt; the status code is 0 when no response arrived
+func (c *EvidenceConnectors) get(ctx context.Context, connector EvidenceConnector, organizationID string) ([]*EvidenceItem, int, error) {
+    if timeout := c.attemptTimeout(connector); timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, timeout)
+        defer cancel()
+    }
+    source := strings.ReplaceAll(connector.URL, "{organization_id}", url.PathEscape(organizationID))
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+    if err != nil {
+        return nil, 0, err
+    }
+    resp, err := c.client.Do(req)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, resp.StatusCode, fmt.Errorf("GET returned %s", resp.Status)
+    }
+
+    var body struct {
+        Evidence []json.RawMessage `json:"evidence"`
+    }
+    if err := json.NewDecoder(io.LimitReader(resp.Body, connectorMaxResponseSize)).Decode(&body); err != nil {
+        return nil, resp.StatusCode, fmt.Errorf("invalid response: %v", err)
+    }
+    items := make([]*EvidenceItem, 0, len(body.Evidence))
+    for _, raw := range body.Evidence {
+        item := &EvidenceItem{}
+        if err := protojson.Unmarshal(raw, item); err != nil {
+            return nil, resp.StatusCode, fmt.Errorf("invalid evidence item: %v", err)
+        }
+        if containsString(connector.Keys, item.Key) {
+            items = append(items, item)
+        }
+    }
+    return items, resp.StatusCode, nil
+}
+
+// Evidence connector metrics
+var (
+    connectorFetches = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_evidence_connector_fetches_total",
+            Help: "Evidence connector fetches by connector and outcome, after retries",
+        },
+        []string{"connector", "outcome"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(connectorFetches)
+}
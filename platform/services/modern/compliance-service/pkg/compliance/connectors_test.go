@@ -0,0 +1,203 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Connector answering with one evidence item after a delay, or as soon as
+// the caller gives up
+func newTestConnectorServer(t *testing.T, key string, delay time.Duration) *httptest.Server {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        select {
+        case <-time.After(delay):
+        case <-r.Context().Done():
+            return
+        }
+        fmt.Fprintf(w, `{"evidence": [{"key": %q, "value": "true"}]}`, key)
+    }))
+    t.Cleanup(server.Close)
+    return server
+}
+
+// Connectors file with a fast connector on the service-wide timeout and a
+// slow one with its own, shorter timeout
+func writeConnectors(t *testing.T, required bool) string {
+    fast := newTestConnectorServer(t, "asset_inventory", 0)
+    slow := newTestConnectorServer(t, "mfa_enforced", 2*time.Second)
+    connectors := fmt.Sprintf(`
+- name: inventory
+  url: %s/{organization_id}
+  keys: [asset_inventory]
+- name: identity
+  url: %s/{organization_id}
+  keys: [mfa_enforced]
+  timeout: 50ms
+  required: %t
+`, fast.URL, slow.URL, required)
+    path := filepath.Join(t.TempDir(), "connectors.yaml")
+    if err := os.WriteFile(path, []byte(connectors), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    return path
+}
+
+func newConnectorService(t *testing.T, required bool) *ComplianceService {
+    file := writeConnectors(t, required)
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Connectors = ConnectorConfig{File: file, Retries: 0, Timeout: 5 * time.Second}
+    })
+    return service
+}
+
+// A slow connector is cut off at its own timeout; its keys score as
+// missing while the fast connector's evidence is used
+func TestSlowConnectorTimesOut(t *testing.T) {
+    service := newConnectorService(t, false)
+    before := testutil.ToFloat64(connectorFetches.WithLabelValues("identity", "timeout"))
+
+    start := time.Now()
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"NCA"}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if elapsed := time.Since(start); elapsed > time.Second {
+        t.Errorf("check took %v behind a connector with a 50ms timeout", elapsed)
+    }
+
+    if len(response.EvidenceDegradation) != 1 {
+        t.Fatalf("degradation %v, want the identity connector only", response.EvidenceDegradation)
+    }
+    degraded := response.EvidenceDegradation[0]
+    if degraded.Connector != "identity" || !strings.Contains(degraded.Reason, "timed out after 50ms") || degraded.Keys[0] != "mfa_enforced" {
+        t.Errorf("degradation %v, want identity timed out after 50ms", degraded)
+    }
+    if got := testutil.ToFloat64(connectorFetches.WithLabelValues("identity", "timeout")) - before; got != 1 {
+        t.Errorf("%v timeouts counted, want 1", got)
+    }
+
+    // The same check with the fast connector's evidence alone scores alike
+    service.connectors.connectors = service.connectors.connectors[:1]
+    fastOnly, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-2", Frameworks: []string{"NCA"}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.OverallScore != fastOnly.OverallScore {
+        t.Errorf("overall %v with the identity connector timed out, want %v as if its keys were missing", response.OverallScore, fastOnly.OverallScore)
+    }
+}
+
+// A required connector that times out fails the check
+func TestRequiredConnectorTimeoutFailsCheck(t *testing.T) {
+    service := newConnectorService(t, true)
+    _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"NCA"}})
+    if status.Code(err) != codes.Unavailable || !strings.Contains(err.Error(), "identity") {
+        t.Errorf("CheckCompliance() = %v, want Unavailable naming identity", err)
+    }
+}
+
+func TestLoadEvidenceConnectorsRejectsNegativeTimeout(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "connectors.yaml")
+    connectors := "- name: identity\n  url: http://identity/{organization_id}\n  keys: [mfa_enforced]\n  timeout: -1s\n"
+    if err := os.WriteFile(path, []byte(connectors), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    engine := NewRulesEngine(DefaultRulesetVersion, nil)
+    engine.Register("NCA", countingChecker("NCA", nil))
+    engine.RequireEvidence("NCA", EvidenceRequirement{Key: "mfa_enforced", Type: evidenceBool})
+    if _, err := loadEvidenceConnectors(ConnectorConfig{File: path}, engine, http.DefaultClient); err == nil || !strings.Contains(err.Error(), "timeout") {
+        t.Errorf("loadEvidenceConnectors() = %v, want the negative timeout rejected", err)
+    }
+}
+
+// Connector answering each attempt with the next status code, repeating
+// the last; a 200 carries one evidence item
+func newScriptedConnectorServer(t *testing.T, key string, statuses []int, attempts *atomic.Int64) *httptest.Server {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        code := statuses[min(int(attempts.Add(1)), len(statuses))-1]
+        if code != http.StatusOK {
+            http.Error(w, http.StatusText(code), code)
+            return
+        }
+        fmt.Fprintf(w, `{"evidence": [{"key": %q, "value": "true"}]}`, key)
+    }))
+    t.Cleanup(server.Close)
+    return server
+}
+
+// Each connector failure maps to its outcome: 404 and other rejections
+// score its keys as missing at once, 401/403 fail the check naming the
+// connector, and 5xx is retried, then degraded unless the connector is
+// required
+func TestConnectorFailureMapping(t *testing.T) {
+    tests := []struct {
+        name         string
+        statuses     []int
+        required     bool
+        wantAttempts int64
+        wantCode     codes.Code
+        wantReason   string // Of the error
+        wantDegraded int32  // HTTP status the degradation reports; 0 for none
+    }{
+        {name: "not found", statuses: []int{404}, wantAttempts: 1, wantDegraded: 404},
+        {name: "not found, required", statuses: []int{404}, required: true, wantAttempts: 1, wantDegraded: 404},
+        {name: "bad request", statuses: []int{400}, wantAttempts: 1, wantDegraded: 400},
+        {name: "unauthorized", statuses: []int{401}, wantAttempts: 1, wantCode: codes.FailedPrecondition, wantReason: reasonConnectorMisconfigured},
+        {name: "forbidden", statuses: []int{403}, wantAttempts: 1, wantCode: codes.FailedPrecondition, wantReason: reasonConnectorMisconfigured},
+        {name: "server error, then ok", statuses: []int{500, 200}, wantAttempts: 2},
+        {name: "too many requests, then ok", statuses: []int{429, 200}, wantAttempts: 2},
+        {name: "server error throughout", statuses: []int{503}, wantAttempts: 2, wantDegraded: 503},
+        {name: "server error throughout, required", statuses: []int{503}, required: true, wantAttempts: 2, wantCode: codes.Unavailable, wantReason: reasonUnavailable},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var attempts atomic.Int64
+            server := newScriptedConnectorServer(t, "mfa_enforced", tt.statuses, &attempts)
+            path := filepath.Join(t.TempDir(), "connectors.yaml")
+            writeConfigFile(t, path, fmt.Sprintf("- name: identity\n  url: %s/{organization_id}\n  keys: [mfa_enforced]\n  required: %t\n", server.URL, tt.required))
+            service, _ := newTestService(t, func(config *ServiceConfig) {
+                config.Connectors = ConnectorConfig{File: path, Retries: 1, Timeout: 5 * time.Second}
+            })
+
+            response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"NCA"}})
+            if n := attempts.Load(); n != tt.wantAttempts {
+                t.Errorf("%d attempts, want %d", n, tt.wantAttempts)
+            }
+            if tt.wantCode != codes.OK {
+                st := status.Convert(err)
+                if st.Code() != tt.wantCode || errorReason(st) != tt.wantReason || errorMetadata(st)["connector"] != "identity" {
+                    t.Errorf("CheckCompliance() = %v, want %s %s naming identity", err, tt.wantCode, tt.wantReason)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if tt.wantDegraded == 0 {
+                if len(response.EvidenceDegradation) != 0 {
+                    t.Errorf("degradation %v, want the connector's evidence used", response.EvidenceDegradation)
+                }
+                return
+            }
+            if len(response.EvidenceDegradation) != 1 {
+                t.Fatalf("degradation %v, want the identity connector", response.EvidenceDegradation)
+            }
+            degraded := response.EvidenceDegradation[0]
+            if degraded.Connector != "identity" || degraded.HttpStatus != tt.wantDegraded || len(degraded.Keys) != 1 || degraded.Keys[0] != "mfa_enforced" {
+                t.Errorf("degradation %v, want identity's mfa_enforced missing after %d", degraded, tt.wantDegraded)
+            }
+        })
+    }
+}
@@ -0,0 +1,356 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "github.com/segmentio/kafka-go"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/encoding/protojson"
+    "google.golang.org/protobuf/proto"
+)
+
+// Consumer group shared by all replicas
+const requestConsumerGroup = "compliance-service"
+
+// Header carrying a producer-chosen idempotency key; messages without one
+// are identified by topic, partition and offset, which redelivery preserves
+const idempotencyKeyHeader = "idempotency-key"
+
+// How often a paused consumer rechecks the evaluation queue
+const consumerPausePoll = 100 * time.Millisecond
+
+// Backoff between attempts at a request that failed transiently, doubling
+// up to the maximum
+const (
+    consumerRetryBackoff    = time.Second
+    consumerRetryMaxBackoff = time.Minute
+)
+
+// ConsumerConfig - Kafka-driven asynchronous evaluation
+type ConsumerConfig struct {
+    RequestTopic  string `env:"KAFKA_REQUEST_TOPIC"`  // Disabled when empty
+    ResponseTopic string `env:"KAFKA_RESPONSE_TOPIC"`
+
+    // Intake pauses while this many evaluations wait for a scheduler slot
+    // and resumes below half of it; 0 never pauses
+    PauseHighWater int `env:"CONSUMER_PAUSE_HIGH_WATER"`
+    // How long the message in flight at shutdown may take to finish
+    DrainTimeout time.Duration `env:"CONSUMER_DRAIN_TIMEOUT"`
+    // How long evaluated responses are kept by idempotency key, so that a
+    // redelivered message is answered without evaluating it again
+    DedupeTTL time.Duration `env:"CONSUMER_DEDUPE_TTL"`
+}
+
+// RequestConsumer - reads ComplianceRequest messages, evaluates them and
+// publishes the ComplianceResponse. Offsets are committed only after the
+// response was published, so delivery is at-least-once; redeliveries are
+// answered from the stored response instead of being evaluated again.
+// Transient failures are retried with backoff and never committed; a
+// request that fails for good is answered with a response carrying only
+// its request_error.
+type RequestConsumer struct {
+    service  *ComplianceService
+    reader   messageReader
+    producer messagePublisher
+    redis    *redis.Client
+    config   ConsumerConfig
+}
+
+// Where requests are read and committed; a *kafka.Reader in the service
+// consumer group outside tests
+type messageReader interface {
+    FetchMessage(ctx context.Context) (kafka.Message, error)
+    CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+    Close() error
+}
+
+// Create a request consumer joined to the service consumer group
+func NewRequestConsumer(service *ComplianceService, brokers string, config ConsumerConfig) *RequestConsumer {
+    reader := kafka.NewReader(kafka.ReaderConfig{
+        Brokers: strings.Split(brokers, ","),
+        GroupID: requestConsumerGroup,
+        Topic:   config.RequestTopic,
+    })
+    return &RequestConsumer{
+        service:  service,
+        reader:   reader,
+        producer: service.kafkaProducer,
+        redis:    service.redis,
+        config:   config,
+    }
+}
+
+// Run consumes until ctx is done. The message in flight then gets up to the
+// drain timeout to be published and committed before the reader closes,
+// leaving the group so its partitions are reassigned promptly.
+func (c *RequestConsumer) Run(ctx context.Context) {
+    work, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+    defer cancelWork()
+    stopDrain := context.AfterFunc(ctx, func() {
+        time.AfterFunc(c.config.DrainTimeout, cancelWork)
+    })
+    defer stopDrain()
+    defer func() {
+        if err := c.reader.Close(); err != nil {
+            log.Printf("Request consumer failed to leave the group: %v", err)
+        }
+        log.Printf("Request consumer stopped")
+    }()
+
+    for {
+        if !c.waitForCapacity(ctx) {
+            return
+        }
+        msg, err := c.reader.FetchMessage(ctx)
+        if err != nil {
+            if ctx.Err() != nil {
+                return
+            }
+            log.Printf("Request consumer fetch failed: %v", err)
+            time.Sleep(time.Second)
+            continue
+        }
+
+        if !c.handle(work, msg) {
+            // Not committed: redelivered after restart or rebalance
+            return
+        }
+        if err := c.reader.CommitMessages(work, msg); err != nil {
+            log.Printf("Request consumer commit failed at offset %d: %v", msg.Offset, err)
+        }
+    }
+}
+
+// Block while the evaluation queue is above the high-water mark; false if
+// ctx ended first
+func (c *RequestConsumer) waitForCapacity(ctx context.Context) bool {
+    if c.config.PauseHighWater <= 0 {
+        return ctx.Err() == nil
+    }
+    waiting, _, _ := c.service.engine.scheduler.Stats()
+    if waiting < c.config.PauseHighWater {
+        return ctx.Err() == nil
+    }
+
+    consumerPaused.Set(1)
+    defer consumerPaused.Set(0)
+    log.Printf("Request consumer paused: %d evaluations queued", waiting)
+    ticker := time.NewTicker(consumerPausePoll)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return false
+        case <-ticker.C:
+            if waiting, _, _ := c.service.engine.scheduler.Stats(); waiting < c.config.PauseHighWater/2 {
+                log.Printf("Request consumer resumed")
+                return true
+            }
+        }
+    }
+}
+
+// Idempotency key of a message
+func messageKey(msg kafka.Message) string {
+    for _, header := range msg.Headers {
+        if strings.EqualFold(header.Key, idempotencyKeyHeader) && len(header.Value) > 0 {
+            return string(header.Value)
+        }
+    }
+    return fmt.Sprintf("%s:%d:%d", msg.Topic, msg.Partition, msg.Offset)
+}
+
+func consumerResultKey(key string) string {
+    return "consumer-result:" + key
+}
+
+// Response already produced for a message, if any. Lookup failures count as
+// misses: evaluating twice beats not answering.
+func (c *RequestConsumer) storedResponse(ctx context.Context, key string) (*ComplianceResponse, bool) {
+    if c.config.DedupeTTL <= 0 {
+        return nil, false
+    }
+    data, err := c.redis.Get(ctx, consumerResultKey(key)).Bytes()
+    if err != nil {
+        if err != redis.Nil {
+            log.Printf("Request consumer dedupe lookup failed: %v", err)
+        }
+        return nil, false
+    }
+    response := &ComplianceResponse{}
+    if err := proto.Unmarshal(data, response); err != nil {
+        return nil, false
+    }
+    return response, true
+}
+
+// Keep a response by idempotency key before it is published
+func (c *RequestConsumer) storeResponse(ctx context.Context, key string, response *ComplianceResponse) {
+    if c.config.DedupeTTL <= 0 {
+        return
+    }
+    data, err := proto.Marshal(response)
+    if err == nil {
+        err = c.redis.Set(ctx, consumerResultKey(key), data, c.config.DedupeTTL).Err()
+    }
+    if err != nil {
+        log.Printf("Request consumer failed to store response for dedupe: %v", err)
+    }
+}
+
+// Handle one message; returns false only if ctx ended before the response
+// could be published
+func (c *RequestConsumer) handle(ctx context.Context, msg kafka.Message) bool {
+    req := &ComplianceRequest{}
+    key := messageKey(msg)
+    response, redelivered := c.storedResponse(ctx, key)
+    if err := protojson.Unmarshal(msg.Value, req); err != nil {
+        // Poison message: answering it with the error is the only way to
+        // make progress
+        log.Printf("Undecodable compliance request at offset %d: %v", msg.Offset, err)
+        response = c.errorResponse(ctx, req, status.Errorf(codes.InvalidArgument, "undecodable compliance request: %v", err))
+    } else if redelivered {
+        consumerRedeliveries.Inc()
+        log.Printf("Redelivered compliance request at offset %d answered from its stored response", msg.Offset)
+    } else {
+        var err error
+        response, err = c.evaluate(ctx, msg, req)
+        switch {
+        case ctx.Err() != nil:
+            return false
+        case err != nil:
+            consumerFailures.Inc()
+            log.Printf("Compliance request for %s at offset %d failed permanently: %v", redact(fieldOrganizationID, req.OrganizationId), msg.Offset, err)
+            response = c.errorResponse(ctx, req, err)
+        default:
+            c.storeResponse(ctx, key, response)
+        }
+    }
+
+    value, err := protojson.Marshal(response)
+    if err != nil {
+        log.Printf("Failed to encode response for %s: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        return true
+    }
+
+    headers := map[string]string{}
+    for _, header := range msg.Headers {
+        if strings.EqualFold(header.Key, "x-request-id") {
+            headers["x-request-id"] = string(header.Value)
+        }
+    }
+
+    backoff := 100 * time.Millisecond
+    for {
+        err := c.producer.PublishMessage(ctx, c.config.ResponseTopic, []byte(response.OrganizationId), value, headers)
+        if err == nil {
+            return true
+        }
+        log.Printf("Failed to publish response for %s, retrying in %s: %v", redact(fieldOrganizationID, req.OrganizationId), backoff, err)
+        select {
+        case <-ctx.Done():
+            return false
+        case <-time.After(backoff):
+        }
+        if backoff < 10*time.Second {
+            backoff *= 2
+        }
+    }
+}
+
+// Evaluate a request, retrying failures a later attempt can fix with
+// backoff. Returns the first permanent error, or ctx's once it is done.
+func (c *RequestConsumer) evaluate(ctx context.Context, msg kafka.Message, req *ComplianceRequest) (*ComplianceResponse, error) {
+    md := metadata.MD{}
+    for _, header := range msg.Headers {
+        md.Append(strings.ToLower(header.Key), string(header.Value))
+    }
+    evalCtx := withBackgroundEvaluation(metadata.NewIncomingContext(ctx, md))
+
+    backoff := consumerRetryBackoff
+    for {
+        response, err := c.service.CheckCompliance(evalCtx, req)
+        if err == nil || !transientConsumerError(err) {
+            return response, err
+        }
+        consumerRetries.Inc()
+        log.Printf("Compliance request for %s at offset %d failed, retrying in %s: %v", redact(fieldOrganizationID, req.OrganizationId), msg.Offset, backoff, err)
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case <-time.After(backoff):
+        }
+        if backoff *= 2; backoff > consumerRetryMaxBackoff {
+            backoff = consumerRetryMaxBackoff
+        }
+    }
+}
+
+// Failures a later attempt can succeed on: load shedding, rate limits and
+// quotas, unavailable dependencies, an evaluation of the organization
+// already in progress and timeouts
+func transientConsumerError(err error) bool {
+    switch status.Code(err) {
+    case codes.ResourceExhausted, codes.Unavailable, codes.Aborted, codes.DeadlineExceeded, codes.Canceled:
+        return true
+    }
+    return false
+}
+
+// Response answering a request that failed for good, with the error as a
+// gRPC caller would see it
+func (c *RequestConsumer) errorResponse(ctx context.Context, req *ComplianceRequest, err error) *ComplianceResponse {
+    st := status.Convert(c.service.publicError(ctx, "CheckCompliance", err))
+    return &ComplianceResponse{
+        OrganizationId: req.OrganizationId,
+        Timestamp:      time.Now().Unix(),
+        SchemaVersion:  responseSchemaVersion,
+        RequestError:   &RequestError{Code: st.Code().String(), Reason: errorReason(st), Message: st.Message()},
+    }
+}
+
+// Request consumer metrics
+var (
+    consumerPaused = prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "compliance_consumer_paused",
+            Help: "1 while Kafka request intake is paused on evaluation queue depth",
+        },
+    )
+
+    consumerRedeliveries = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_consumer_redeliveries_total",
+            Help: "Redelivered Kafka requests answered from their stored response",
+        },
+    )
+
+    consumerRetries = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_consumer_retries_total",
+            Help: "Kafka request evaluations retried after a transient failure",
+        },
+    )
+
+    consumerFailures = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_consumer_failures_total",
+            Help: "Kafka requests answered with a request_error after failing permanently",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(consumerPaused)
+    prometheus.MustRegister(consumerRedeliveries)
+    prometheus.MustRegister(consumerFailures)
+    prometheus.MustRegister(consumerRetries)
+}
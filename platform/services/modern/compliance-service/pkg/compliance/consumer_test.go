@@ -0,0 +1,359 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "reflect"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/segmentio/kafka-go"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/encoding/protojson"
+)
+
+type publishedMessage struct {
+    topic   string
+    key     string
+    value   []byte
+    headers map[string]string
+}
+
+// Records published messages, failing the first failures attempts
+type fakePublisher struct {
+    mu       sync.Mutex
+    failures int
+    attempts int
+    messages []publishedMessage
+}
+
+func (p *fakePublisher) PublishMessage(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.attempts++
+    if p.attempts <= p.failures {
+        return errors.New("broker unavailable")
+    }
+    p.messages = append(p.messages, publishedMessage{topic: topic, key: string(key), value: value, headers: headers})
+    return nil
+}
+
+// Response published as the last message
+func (p *fakePublisher) lastResponse(t *testing.T) (publishedMessage, *ComplianceResponse) {
+    t.Helper()
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if len(p.messages) == 0 {
+        t.Fatal("no response published")
+    }
+    msg := p.messages[len(p.messages)-1]
+    response := &ComplianceResponse{}
+    if err := protojson.Unmarshal(msg.value, response); err != nil {
+        t.Fatalf("published value is not a ComplianceResponse: %v", err)
+    }
+    return msg, response
+}
+
+func newTestConsumer(t *testing.T, publisher *fakePublisher) *RequestConsumer {
+    service, _ := newTestService(t)
+    return &RequestConsumer{
+        service:  service,
+        producer: publisher,
+        redis:    service.redis,
+        config:   ConsumerConfig{RequestTopic: "compliance-requests", ResponseTopic: "compliance-responses", DedupeTTL: time.Hour},
+    }
+}
+
+func requestMessage(offset int64, value string, headers ...kafka.Header) kafka.Message {
+    return kafka.Message{Topic: "compliance-requests", Partition: 0, Offset: offset, Value: []byte(value), Headers: headers}
+}
+
+// A request message is evaluated and answered on the response topic
+func TestConsumerPublishesResponse(t *testing.T) {
+    publisher := &fakePublisher{}
+    consumer := newTestConsumer(t, publisher)
+
+    msg := requestMessage(7, `{"organization_id":"org-1"}`, kafka.Header{Key: "X-Request-ID", Value: []byte("req-1")})
+    if !consumer.handle(context.Background(), msg) {
+        t.Fatal("handle() = false, want the message committed")
+    }
+    published, response := publisher.lastResponse(t)
+    if published.topic != "compliance-responses" || published.key != "org-1" {
+        t.Errorf("published to %s keyed %q, want compliance-responses keyed org-1", published.topic, published.key)
+    }
+    if published.headers["x-request-id"] != "req-1" {
+        t.Errorf("headers %v, want the request ID forwarded", published.headers)
+    }
+    if response.OrganizationId != "org-1" || len(response.FrameworkResults) == 0 || response.RequestError != nil {
+        t.Errorf("response %v, want org-1's results", response)
+    }
+}
+
+// A redelivered message is answered with the stored response, not evaluated
+// again
+func TestConsumerAnswersRedeliveryFromStoredResponse(t *testing.T) {
+    publisher := &fakePublisher{}
+    consumer := newTestConsumer(t, publisher)
+    key := kafka.Header{Key: idempotencyKeyHeader, Value: []byte("job-42")}
+
+    consumer.handle(context.Background(), requestMessage(1, `{"organization_id":"org-1"}`, key))
+    _, first := publisher.lastResponse(t)
+
+    before := testutil.ToFloat64(consumerRedeliveries)
+    consumer.handle(context.Background(), requestMessage(9, `{"organization_id":"org-1"}`, key))
+    _, second := publisher.lastResponse(t)
+    if got := testutil.ToFloat64(consumerRedeliveries) - before; got != 1 {
+        t.Errorf("%v redeliveries counted, want 1", got)
+    }
+    if second.ContentHash != first.ContentHash || second.Timestamp != first.Timestamp {
+        t.Errorf("redelivery answered with %s at %d, want the stored %s at %d", second.ContentHash, second.Timestamp, first.ContentHash, first.Timestamp)
+    }
+}
+
+// Requests that can never succeed are answered with a request_error and
+// committed, so they do not block the partition
+func TestConsumerAnswersPermanentFailures(t *testing.T) {
+    tests := []struct {
+        name  string
+        value string
+        code  string
+    }{
+        {name: "undecodable", value: `{"organization_id":`, code: "InvalidArgument"},
+        {name: "unknown framework", value: `{"organization_id":"org-1","frameworks":["GDPR"]}`, code: "InvalidArgument"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            publisher := &fakePublisher{}
+            consumer := newTestConsumer(t, publisher)
+            if !consumer.handle(context.Background(), requestMessage(3, tt.value)) {
+                t.Fatal("handle() = false, want the message committed")
+            }
+            _, response := publisher.lastResponse(t)
+            if response.RequestError == nil || response.RequestError.Code != tt.code || response.RequestError.Message == "" {
+                t.Errorf("request_error %v, want %s with a message", response.RequestError, tt.code)
+            }
+            if len(response.FrameworkResults) != 0 {
+                t.Errorf("%d results set on a failed request", len(response.FrameworkResults))
+            }
+        })
+    }
+}
+
+// The offset is committed only once the response is out: publishing is
+// retried, and shutdown before it succeeds leaves the message uncommitted
+func TestConsumerCommitsOnlyAfterPublish(t *testing.T) {
+    publisher := &fakePublisher{failures: 2}
+    consumer := newTestConsumer(t, publisher)
+    if !consumer.handle(context.Background(), requestMessage(1, `{"organization_id":"org-1"}`)) {
+        t.Fatal("handle() = false after the publish recovered")
+    }
+    if publisher.attempts != 3 || len(publisher.messages) != 1 {
+        t.Errorf("%d attempts, %d published, want 3 and 1", publisher.attempts, len(publisher.messages))
+    }
+
+    publisher = &fakePublisher{failures: 1 << 30}
+    consumer.producer = publisher
+    ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+    defer cancel()
+    if consumer.handle(ctx, requestMessage(2, `{"organization_id":"org-2"}`)) {
+        t.Error("handle() = true although the response was never published")
+    }
+}
+
+func TestTransientConsumerError(t *testing.T) {
+    tests := []struct {
+        err  error
+        want bool
+    }{
+        {err: status.Error(codes.ResourceExhausted, "rate limit exceeded"), want: true},
+        {err: status.Error(codes.Unavailable, "redis down"), want: true},
+        {err: status.Error(codes.Aborted, "evaluation in progress"), want: true},
+        {err: status.Error(codes.DeadlineExceeded, "timeout"), want: true},
+        {err: status.Error(codes.InvalidArgument, "unknown framework"), want: false},
+        {err: status.Error(codes.PermissionDenied, "organization not allowed"), want: false},
+        {err: errors.New("unclassified"), want: false},
+    }
+    for _, tt := range tests {
+        if got := transientConsumerError(tt.err); got != tt.want {
+            t.Errorf("transientConsumerError(%v) = %t, want %t", tt.err, got, tt.want)
+        }
+    }
+}
+
+// One partition of the request topic shared by a consumer group. A member
+// joining, as after a rebalance, starts at the group's committed offset.
+type fakeGroup struct {
+    mu        sync.Mutex
+    messages  []kafka.Message
+    committed int64
+    fetched   chan int64
+}
+
+func newFakeGroup(values ...string) *fakeGroup {
+    group := &fakeGroup{fetched: make(chan int64, len(values)*2)}
+    for i, value := range values {
+        group.messages = append(group.messages, requestMessage(int64(i), value))
+    }
+    return group
+}
+
+func (g *fakeGroup) join() *fakeReader {
+    g.mu.Lock()
+    defer g.mu.Unlock()
+    return &fakeReader{group: g, next: g.committed}
+}
+
+func (g *fakeGroup) committedOffset() int64 {
+    g.mu.Lock()
+    defer g.mu.Unlock()
+    return g.committed
+}
+
+// A group member's reader; fetching past the end blocks until ctx is done
+type fakeReader struct {
+    group  *fakeGroup
+    next   int64
+    closed bool
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+    r.group.mu.Lock()
+    if r.next < int64(len(r.group.messages)) {
+        msg := r.group.messages[r.next]
+        r.next++
+        r.group.mu.Unlock()
+        r.group.fetched <- msg.Offset
+        return msg, nil
+    }
+    r.group.mu.Unlock()
+    <-ctx.Done()
+    return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+    r.group.mu.Lock()
+    defer r.group.mu.Unlock()
+    for _, msg := range msgs {
+        r.group.committed = max(r.group.committed, msg.Offset+1)
+    }
+    return nil
+}
+
+func (r *fakeReader) Close() error {
+    r.closed = true
+    return nil
+}
+
+// Run a consumer until stopped, then wait for it to leave the group
+func runConsumer(consumer *RequestConsumer) (stop func()) {
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan struct{})
+    go func() {
+        consumer.Run(ctx)
+        close(done)
+    }()
+    return func() {
+        cancel()
+        <-done
+    }
+}
+
+// Organizations answered on the response topic, in order
+func answered(t *testing.T, publisher *fakePublisher) []string {
+    t.Helper()
+    publisher.mu.Lock()
+    defer publisher.mu.Unlock()
+    var organizations []string
+    for _, msg := range publisher.messages {
+        organizations = append(organizations, msg.key)
+    }
+    return organizations
+}
+
+// A member leaving the group mid-evaluation finishes and commits the
+// message in flight; the member taking over the partition resumes after it
+func TestConsumerRebalanceDrainsMessageInFlight(t *testing.T) {
+    group := newFakeGroup(`{"organization_id":"org-1"}`, `{"organization_id":"org-2"}`)
+    publisher := &fakePublisher{}
+    first := newTestConsumer(t, publisher)
+    first.config.DrainTimeout = 5 * time.Second
+    first.service.faults.Set("SAMA", Fault{Delay: 100 * time.Millisecond})
+    reader := group.join()
+    first.reader = reader
+
+    stop := runConsumer(first)
+    <-group.fetched
+    stop()
+    if !reader.closed {
+        t.Error("the leaving member never closed its reader")
+    }
+    if got := group.committedOffset(); got != 1 {
+        t.Fatalf("committed offset %d after draining, want 1", got)
+    }
+
+    second := newTestConsumer(t, publisher)
+    second.reader = group.join()
+    stop = runConsumer(second)
+    if offset := <-group.fetched; offset != 1 {
+        t.Errorf("new member fetched offset %d, want 1", offset)
+    }
+    for group.committedOffset() < 2 {
+        time.Sleep(time.Millisecond)
+    }
+    stop()
+    if got := fmt.Sprint(answered(t, publisher)); got != "[org-1 org-2]" {
+        t.Errorf("answered %s, want each organization once", got)
+    }
+}
+
+// A member cut off before publishing leaves its message uncommitted; the
+// member it is redelivered to answers from the stored response rather than
+// evaluating it again
+func TestConsumerRebalanceRedeliversUncommitted(t *testing.T) {
+    group := newFakeGroup(`{"organization_id":"org-1"}`)
+    first := newTestConsumer(t, &fakePublisher{failures: 1 << 30})
+    first.config.DrainTimeout = 50 * time.Millisecond
+    spy := spyOnCheckers(first.service)
+    first.reader = group.join()
+
+    stop := runConsumer(first)
+    <-group.fetched
+    for {
+        if _, stored := first.storedResponse(context.Background(), messageKey(group.messages[0])); stored {
+            break
+        }
+        time.Sleep(time.Millisecond)
+    }
+    stop()
+    if got := group.committedOffset(); got != 0 {
+        t.Fatalf("committed offset %d without publishing, want 0", got)
+    }
+    evaluated := spy.runs()
+
+    // The new member shares the state store, as replicas do
+    publisher := &fakePublisher{}
+    second := &RequestConsumer{service: first.service, producer: publisher, redis: first.redis, config: first.config, reader: group.join()}
+    before := testutil.ToFloat64(consumerRedeliveries)
+    stop = runConsumer(second)
+    if offset := <-group.fetched; offset != 0 {
+        t.Errorf("redelivered offset %d, want 0", offset)
+    }
+    for group.committedOffset() < 1 {
+        time.Sleep(time.Millisecond)
+    }
+    stop()
+
+    if got := fmt.Sprint(answered(t, publisher)); got != "[org-1]" {
+        t.Errorf("answered %s, want org-1 once", got)
+    }
+    if got := testutil.ToFloat64(consumerRedeliveries) - before; got != 1 {
+        t.Errorf("%v redeliveries counted, want 1", got)
+    }
+    if runs := spy.runs(); !reflect.DeepEqual(runs, evaluated) {
+        t.Errorf("checker runs %v after redelivery, want %v as before", runs, evaluated)
+    }
+}
@@ -0,0 +1,88 @@
+package compliance
+
+import (
+    "fmt"
+    "time"
+)
+
+// Control severities
+const (
+    severityCritical = "CRITICAL"
+    severityHigh     = "HIGH"
+    severityMedium   = "MEDIUM"
+    severityLow      = "LOW"
+)
+
+// Weight of a control of each severity in severity-weighted scores; a
+// control without a severity is MEDIUM
+var severityWeights = map[string]float64{
+    severityCritical: 8,
+    severityHigh:     4,
+    severityMedium:   2,
+    severityLow:      1,
+}
+
+// Weight of a control: its own weight when set, else its severity's
+func (r EvidenceRequirement) weight() float64 {
+    if r.Weight > 0 {
+        return r.Weight
+    }
+    return severityWeights[defaultString(r.Severity, severityMedium)]
+}
+
+// Validate the severities and weights of a framework's controls
+func validateControlWeights(framework string, requirements []EvidenceRequirement) error {
+    for _, requirement := range requirements {
+        if _, ok := severityWeights[defaultString(requirement.Severity, severityMedium)]; !ok {
+            return fmt.Errorf("control %s of %s has unknown severity %q", requirement.Key, framework, requirement.Severity)
+        }
+        if requirement.Weight < 0 {
+            return fmt.Errorf("control %s of %s has a negative weight", requirement.Key, framework)
+        }
+    }
+    return nil
+}
+
+// Weight of a framework's control; 0 for a key it does not declare
+func (e *RulesEngine) controlWeight(framework, key string) float64 {
+    for _, requirement := range e.requirements[framework] {
+        if requirement.Key == key {
+            return requirement.weight()
+        }
+    }
+    return 0
+}
+
+// Severity-weighted pass ratio of a framework's controls as a 0-100 score,
+// with the controls met and declared. A control passes when its evidence
+// is present and usable; a framework declaring none scores 100.
+func (e *RulesEngine) controlScore(framework string, evidence []*EvidenceItem, now time.Time) (float64, int, int) {
+    set, _ := e.assembleEvidence([]string{framework}, evidence, now)
+    requirements := e.requirements[framework]
+    var passed, total float64
+    met := 0
+    for _, requirement := range requirements {
+        weight := requirement.weight()
+        total += weight
+        if _, ok := set[requirement.Key]; ok {
+            passed += weight
+            met++
+        }
+    }
+    if total == 0 {
+        return 100, met, len(requirements)
+    }
+    return 100 * passed / total, met, len(requirements)
+}
+
+// Critical controls of a framework without usable evidence
+func (e *RulesEngine) failedCritical(framework string, evidence []*EvidenceItem, now time.Time) int {
+    set, _ := e.assembleEvidence([]string{framework}, evidence, now)
+    failed := 0
+    for _, requirement := range e.requirements[framework] {
+        if _, ok := set[requirement.Key]; !ok && requirement.Severity == severityCritical {
+            failed++
+        }
+    }
+    return failed
+}
@@ -0,0 +1,168 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+// NCA's controls as built in, and with every control of equal weight
+func newControlFixtureEngine(weighted bool) *RulesEngine {
+    engine := NewRulesEngine(DefaultRulesetVersion, nil)
+    engine.Register("NCA", countingChecker("NCA", nil))
+    requirements := append([]EvidenceRequirement(nil), builtinEvidenceRequirements["NCA"]...)
+    if !weighted {
+        for i := range requirements {
+            requirements[i].Severity = ""
+        }
+    }
+    engine.RequireEvidence("NCA", requirements...)
+    return engine
+}
+
+// NCA evidence for every control but those missing
+func ncaEvidence(missing ...string) []*EvidenceItem {
+    values := map[string]string{
+        "asset_inventory":         "true",
+        "mfa_enforced":            "true",
+        "vulnerability_scan_date": time.Now().Format("2006-01-02"),
+        "incident_response_plan":  "true",
+    }
+    var items []*EvidenceItem
+    for _, requirement := range builtinEvidenceRequirements["NCA"] {
+        if !containsString(missing, requirement.Key) {
+            items = append(items, &EvidenceItem{Key: requirement.Key, Value: values[requirement.Key]})
+        }
+    }
+    return items
+}
+
+// A failed critical control costs more than a medium one once weighted;
+// unweighted, every failed control costs the same
+func TestControlScoreWeightedBySeverity(t *testing.T) {
+    weighted := newControlFixtureEngine(true)
+    unweighted := newControlFixtureEngine(false)
+    tests := []struct {
+        name       string
+        missing    []string
+        weighted   float64
+        unweighted float64
+        met        int
+    }{
+        {name: "all controls met", weighted: 100, unweighted: 100, met: 4},
+        {name: "critical control failed", missing: []string{"mfa_enforced"}, weighted: 100 * 10.0 / 18, unweighted: 75, met: 3},
+        {name: "medium control failed", missing: []string{"incident_response_plan"}, weighted: 100 * 16.0 / 18, unweighted: 75, met: 3},
+        {name: "high and medium controls failed", missing: []string{"asset_inventory", "incident_response_plan"}, weighted: 100 * 12.0 / 18, unweighted: 50, met: 2},
+        {name: "no evidence", missing: []string{"asset_inventory", "mfa_enforced", "vulnerability_scan_date", "incident_response_plan"}, weighted: 0, unweighted: 0},
+    }
+    for _, tt := range tests {
+        evidence := ncaEvidence(tt.missing...)
+        score, met, total := weighted.controlScore("NCA", evidence, time.Now())
+        if score != tt.weighted || met != tt.met || total != 4 {
+            t.Errorf("%s: weighted score %v with %d of %d met, want %v with %d of 4", tt.name, score, met, total, tt.weighted, tt.met)
+        }
+        if score, _, _ := unweighted.controlScore("NCA", evidence, time.Now()); score != tt.unweighted {
+            t.Errorf("%s: unweighted score %v, want %v", tt.name, score, tt.unweighted)
+        }
+    }
+}
+
+func TestControlWeight(t *testing.T) {
+    tests := []struct {
+        requirement EvidenceRequirement
+        want        float64
+    }{
+        {requirement: EvidenceRequirement{Severity: severityCritical}, want: 8},
+        {requirement: EvidenceRequirement{Severity: severityHigh}, want: 4},
+        {requirement: EvidenceRequirement{}, want: 2},
+        {requirement: EvidenceRequirement{Severity: severityLow}, want: 1},
+        {requirement: EvidenceRequirement{Severity: severityLow, Weight: 10}, want: 10},
+    }
+    for _, tt := range tests {
+        if got := tt.requirement.weight(); got != tt.want {
+            t.Errorf("weight of %+v = %v, want %v", tt.requirement, got, tt.want)
+        }
+    }
+    if got := newControlFixtureEngine(true).controlWeight("NCA", "undeclared"); got != 0 {
+        t.Errorf("weight of an undeclared control = %v, want 0", got)
+    }
+}
+
+func TestValidateControlWeights(t *testing.T) {
+    tests := []struct {
+        name        string
+        requirement EvidenceRequirement
+        ok          bool
+    }{
+        {name: "default severity", requirement: EvidenceRequirement{Key: "k"}, ok: true},
+        {name: "overridden weight", requirement: EvidenceRequirement{Key: "k", Severity: severityHigh, Weight: 3}, ok: true},
+        {name: "unknown severity", requirement: EvidenceRequirement{Key: "k", Severity: "SEVERE"}},
+        {name: "negative weight", requirement: EvidenceRequirement{Key: "k", Weight: -1}},
+    }
+    for _, tt := range tests {
+        if err := validateControlWeights("NCA", []EvidenceRequirement{tt.requirement}); (err == nil) != tt.ok {
+            t.Errorf("%s: validateControlWeights() = %v, want ok %t", tt.name, err, tt.ok)
+        }
+    }
+}
+
+// NCA reports its controls met, declared and critical ones failed
+func TestCheckNCAScoresControls(t *testing.T) {
+    service, _ := newTestService(t)
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"NCA"}, Evidence: ncaEvidence("mfa_enforced")})
+    if err != nil {
+        t.Fatal(err)
+    }
+    result := response.FrameworkResults[0]
+    details := result.GetNcaDetails()
+    if result.Score != roundScore(100*10.0/18) || details.RequirementsMet != 3 || details.RequirementsTotal != 4 || details.CriticalIssues != 1 {
+        t.Errorf("NCA %v with %d of %d met and %d critical issues, want %v, 3 of 4 and 1", result.Score, details.RequirementsMet, details.RequirementsTotal, details.CriticalIssues, roundScore(100*10.0/18))
+    }
+}
+
+// Remediation ranks failed controls by their weight's share of the headroom
+func TestPlanRemediationWeightsControls(t *testing.T) {
+    results := []*FrameworkResult{{Framework: "NCA", Score: 50}}
+    failed := map[string][]*EvidenceVerdict{"NCA": {
+        {Key: "incident_response_plan", Verdict: controlMissing},
+        {Key: "mfa_enforced", Verdict: controlMissing},
+    }}
+    weights := map[string]float64{"NCA": 1}
+    equal := func(framework, key string) float64 { return 1 }
+
+    tests := []struct {
+        name          string
+        controlWeight func(framework, key string) float64
+        want          []string
+        impacts       []float64
+    }{
+        {name: "weighted", controlWeight: newControlFixtureEngine(true).controlWeight, want: []string{"mfa_enforced", "incident_response_plan"}, impacts: []float64{40, 10}},
+        {name: "unweighted", controlWeight: equal, want: []string{"incident_response_plan", "mfa_enforced"}, impacts: []float64{25, 25}},
+    }
+    for _, tt := range tests {
+        plan, current := planRemediation(results, failed, weights, tt.controlWeight, 100)
+        if current != 50 || len(plan) != len(tt.want) {
+            t.Fatalf("%s: plan %v from %v, want %d controls from 50", tt.name, plan, current, len(tt.want))
+        }
+        for i, control := range plan {
+            if control.key != tt.want[i] || control.impact != tt.impacts[i] {
+                t.Errorf("%s: control %d %s worth %v, want %s worth %v", tt.name, i, control.key, control.impact, tt.want[i], tt.impacts[i])
+            }
+        }
+    }
+}
+
+// Reweighting a control changes the framework's input key, so memoized
+// and cached results computed under the old weights are not reused
+func TestReweightingInvalidatesResults(t *testing.T) {
+    req := &ComplianceRequest{OrganizationId: "org-1", Evidence: ncaEvidence()}
+    engine := newControlFixtureEngine(true)
+    before := engine.InputKey("NCA", req)
+    if again := newControlFixtureEngine(true).InputKey("NCA", req); again != before {
+        t.Errorf("input key %s for the same weights, want %s", again, before)
+    }
+    engine.requirements["NCA"][0].Severity = severityLow
+    if after := engine.InputKey("NCA", req); after == before {
+        t.Error("input key unchanged by reweighting a control")
+    }
+}
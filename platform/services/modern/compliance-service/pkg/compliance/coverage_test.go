@@ -0,0 +1,77 @@
+package compliance
+
+import (
+    "context"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Evidence coverage counts the required evidence present and usable,
+// whatever the score
+func TestEvidenceCoverage(t *testing.T) {
+    var calls atomic.Int64
+    engine := NewRulesEngine(DefaultRulesetVersion, nil)
+    engine.Register("SAMA", countingChecker("SAMA", &calls))
+    engine.RequireEvidence("SAMA",
+        EvidenceRequirement{Key: "mfa_enabled", Type: evidenceBool},
+        EvidenceRequirement{Key: "encryption_at_rest", Type: evidenceBool},
+        EvidenceRequirement{Key: "pentest_report", Type: evidenceString, MaxAge: 24 * time.Hour},
+    )
+    engine.Register("NCA", countingChecker("NCA", &calls))
+
+    fresh := timestamppb.New(time.Now().Add(-time.Hour))
+    expired := timestamppb.New(time.Now().Add(-48 * time.Hour))
+    tests := []struct {
+        name      string
+        framework string
+        evidence  []*EvidenceItem
+        score     float64
+        coverage  float64
+    }{
+        {name: "no evidence", framework: "SAMA", coverage: 0},
+        {
+            name:      "one of three",
+            framework: "SAMA",
+            evidence:  []*EvidenceItem{{Key: "mfa_enabled", Value: "true"}},
+            score:     50,
+            coverage:  33.33,
+        },
+        {
+            name:      "complete but failing",
+            framework: "SAMA",
+            evidence: []*EvidenceItem{
+                {Key: "mfa_enabled", Value: "false"},
+                {Key: "encryption_at_rest", Value: "false"},
+                {Key: "pentest_report", Value: "clean", CollectedAt: fresh},
+            },
+            score:    0,
+            coverage: 100,
+        },
+        {
+            name:      "expired and mistyped evidence not counted",
+            framework: "SAMA",
+            evidence: []*EvidenceItem{
+                {Key: "mfa_enabled", Value: "true"},
+                {Key: "encryption_at_rest", Value: "maybe"},
+                {Key: "pentest_report", Value: "clean", CollectedAt: expired},
+            },
+            score:    50,
+            coverage: 33.33,
+        },
+        {name: "nothing required", framework: "NCA", coverage: 100},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := engine.Evaluate(context.Background(), tt.framework, &ComplianceRequest{OrganizationId: "org-1", Evidence: tt.evidence})
+            if result == nil {
+                t.Fatal("no result")
+            }
+            if result.Score != tt.score || result.EvidenceCoverage != tt.coverage {
+                t.Errorf("score %v coverage %v, want %v and %v", result.Score, result.EvidenceCoverage, tt.score, tt.coverage)
+            }
+        })
+    }
+}
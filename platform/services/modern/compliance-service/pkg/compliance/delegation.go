@@ -0,0 +1,79 @@
+package compliance
+
+import (
+    "context"
+    "crypto/subtle"
+    "fmt"
+    "strings"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+)
+
+// RequestedBy - who a check was performed for: the authenticated principal
+// and, for delegated checks, the end user it acted on behalf of
+type RequestedBy struct {
+    Principal   string `json:"principal"`
+    SubjectID   string `json:"subject_id,omitempty"`
+    DisplayName string `json:"display_name,omitempty"`
+}
+
+// Principals allowed to set on_behalf_of, keyed by name
+type delegatePrincipals map[string]string
+
+// Parse "portal:token1,console:token2" into principal name -> bearer token
+func parseDelegatePrincipals(value string) (delegatePrincipals, error) {
+    principals := make(delegatePrincipals)
+    for i, entry := range strings.Split(value, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        parts := strings.SplitN(entry, ":", 2)
+        if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+            return nil, fmt.Errorf("invalid delegate principal at position %d, expected name:token", i+1)
+        }
+        principals[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+    }
+    return principals, nil
+}
+
+// Name of the principal whose bearer token the caller presented, if any
+func (p delegatePrincipals) authenticate(ctx context.Context) (string, bool) {
+    md, _ := metadata.FromIncomingContext(ctx)
+    for _, value := range md.Get("authorization") {
+        token := strings.TrimPrefix(value, "Bearer ")
+        for name, expected := range p {
+            if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+                return name, true
+            }
+        }
+    }
+    return "", false
+}
+
+// Resolve who a request is performed for. Setting on_behalf_of requires a
+// delegate principal; anyone else is rejected with PermissionDenied.
+func (s *ComplianceService) requestedBy(ctx context.Context, req *ComplianceRequest) (*RequestedBy, error) {
+    principal, delegate := s.delegates.authenticate(ctx)
+    if !delegate {
+        principal = "tenant:" + tenantFromContext(ctx)
+    }
+
+    actor := req.OnBehalfOf
+    if actor == nil {
+        return &RequestedBy{Principal: principal}, nil
+    }
+    if !delegate {
+        return nil, reasonError(codes.PermissionDenied, reasonDelegationDenied, "caller may not act on behalf of other users")
+    }
+    if actor.SubjectId == "" {
+        return nil, status.Error(codes.InvalidArgument, "on_behalf_of.subject_id is required")
+    }
+    return &RequestedBy{
+        Principal:   principal,
+        SubjectID:   actor.SubjectId,
+        DisplayName: actor.DisplayName,
+    }, nil
+}
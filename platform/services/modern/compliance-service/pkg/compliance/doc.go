@@ -0,0 +1,10 @@
+// Package compliance - the compliance service: rules engine, response cache,
+// event publishing, gRPC API and HTTP gateway. New builds a service from a
+// ServiceConfig; RegisterGRPC mounts it on a caller's server and Serve runs
+// it standalone.
+//
+// The gRPC and message types are generated from proto/compliance.proto into
+// this package. Regenerate them after editing the proto with go generate.
+package compliance
+
+//go:generate protoc -I ../../../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative compliance.proto
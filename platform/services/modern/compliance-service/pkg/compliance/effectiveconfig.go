@@ -0,0 +1,238 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "os"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/emptypb"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Sources of an effective config value
+const (
+    configSourceDefault = "default"
+    configSourceEnv     = "env"
+    configSourceFile    = "file"
+    configSourceDynamic = "dynamic"
+)
+
+// Secret handling named by a config field's secret tag: "true" hides the
+// whole value, "pairs" hides the secret half of "name:secret" entries and
+// "false" shows it
+const (
+    secretWhole = "true"
+    secretPairs = "pairs"
+    secretNone  = "false"
+)
+
+// Field names that look like credentials. An untagged field matching one
+// is redacted anyway, so a new secret cannot leak for want of a tag; tag it
+// secret:"false" to show it.
+var secretNameHints = []string{"token", "password", "secret", "salt", "key", "credential"}
+
+// Environment variables seeding runtime config defaults, by YAML key
+var runtimeEnvDefaults = map[string][]string{
+    "cache":              {"CACHE_TTL", "FRAMEWORK_CACHE_TTLS"},
+    "org_allowlist":      {"ORG_ALLOWLIST"},
+    "tracing":            {"TRACE_SAMPLE_RATIO", "TRACE_LATENCY_THRESHOLD"},
+    "obligation_horizon": {"OBLIGATION_HORIZON"},
+}
+
+// Secret handling of a config field
+func secretMode(field reflect.StructField) string {
+    if mode, ok := field.Tag.Lookup("secret"); ok {
+        return mode
+    }
+    name := strings.ToLower(field.Name)
+    for _, hint := range secretNameHints {
+        if strings.Contains(name, hint) {
+            return secretWhole
+        }
+    }
+    return secretNone
+}
+
+// Replace the secret parts of a value with redactedValue
+func redactSecret(value, mode string) string {
+    switch {
+    case value == "" || mode == secretNone:
+        return value
+    case mode == secretPairs:
+        var entries []string
+        for _, entry := range strings.Split(value, ",") {
+            if name := strings.TrimSpace(strings.SplitN(entry, ":", 2)[0]); name != "" {
+                entries = append(entries, name+":"+redactedValue)
+            }
+        }
+        sort.Strings(entries)
+        return strings.Join(entries, ",")
+    }
+    return redactedValue
+}
+
+// Visit every leaf field of a config struct under its dotted path
+func walkConfig(v reflect.Value, prefix string, visit func(path string, field reflect.StructField, value reflect.Value)) {
+    for i := 0; i < v.NumField(); i++ {
+        field := v.Type().Field(i)
+        if !field.IsExported() {
+            continue
+        }
+        if field.Type.Kind() == reflect.Struct {
+            walkConfig(v.Field(i), prefix+field.Name+".", visit)
+            continue
+        }
+        visit(prefix+field.Name, field, v.Field(i))
+    }
+}
+
+func configString(value reflect.Value) string {
+    if d, ok := value.Interface().(time.Duration); ok {
+        return d.String()
+    }
+    return fmt.Sprint(value.Interface())
+}
+
+// Service configuration with every secret-tagged or secret-looking string
+// field redacted
+func redactedServiceConfig(config ServiceConfig) ServiceConfig {
+    walkConfig(reflect.ValueOf(&config).Elem(), "", func(_ string, field reflect.StructField, value reflect.Value) {
+        if value.Kind() == reflect.String {
+            value.SetString(redactSecret(value.String(), secretMode(field)))
+        }
+    })
+    return config
+}
+
+// Every service config value with its source: env when its env-tagged
+// variable is set, default otherwise. Secrets carry a SHA-256 fingerprint
+// so environments can be compared without revealing them.
+func serviceConfigValues(config ServiceConfig) []*ConfigValue {
+    var values []*ConfigValue
+    walkConfig(reflect.ValueOf(config), "", func(path string, field reflect.StructField, value reflect.Value) {
+        raw := configString(value)
+        entry := &ConfigValue{Path: path, Value: raw, Source: configSourceDefault}
+        if env := field.Tag.Get("env"); env != "" && os.Getenv(env) != "" {
+            entry.Source = configSourceEnv
+        }
+        if mode := secretMode(field); mode != secretNone && value.Kind() == reflect.String && raw != "" {
+            entry.Value = redactSecret(raw, mode)
+            entry.Fingerprint = fingerprint(raw)
+        }
+        values = append(values, entry)
+    })
+    return values
+}
+
+// Every reloadable setting, by YAML key, with its source: dynamic when the
+// operational overrides set it, else file, env or default
+func runtimeConfigValues(runtime *RuntimeConfig, state *OperationalState) []*ConfigValue {
+    dynamic := map[string]bool{
+        "weights":              len(state.Weights) > 0 || len(state.DisabledFrameworks) > 0,
+        "thresholds":           state.Thresholds != nil,
+        "sama_subdomain_gates": state.SamaSubdomainGates != nil,
+        "framework_gates":      state.FrameworkGates != nil || len(state.DisabledFrameworks) > 0,
+    }
+
+    var values []*ConfigValue
+    v := reflect.ValueOf(runtime).Elem()
+    for i := 0; i < v.NumField(); i++ {
+        key := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+        if key == "" || key == "-" {
+            continue
+        }
+        data, err := json.Marshal(v.Field(i).Interface())
+        if err != nil {
+            continue
+        }
+
+        source := configSourceDefault
+        switch {
+        case dynamic[key]:
+            source = configSourceDynamic
+        case containsString(runtime.FileKeys, key):
+            source = configSourceFile
+        default:
+            for _, env := range runtimeEnvDefaults[key] {
+                if os.Getenv(env) != "" {
+                    source = configSourceEnv
+                }
+            }
+        }
+        values = append(values, &ConfigValue{Path: "runtime." + key, Value: string(data), Source: source})
+    }
+    return values
+}
+
+// GetEffectiveConfig - admin RPC returning the configuration this instance
+// is running with after any reloads, each value with its source, secrets
+// redacted
+func (s *ComplianceService) GetEffectiveConfig(ctx context.Context, _ *emptypb.Empty) (*EffectiveConfig, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    return s.effectiveConfig()
+}
+
+// Effective configuration, shared by the RPC and the admin endpoint
+func (s *ComplianceService) effectiveConfig() (*EffectiveConfig, error) {
+    runtime := s.runtimeConfig()
+    service, err := json.Marshal(redactedServiceConfig(s.config))
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "failed to encode service config: %v", err)
+    }
+
+    effective := &EffectiveConfig{
+        ConfigVersion:               runtime.Version,
+        LoadedAt:                    timestamppb.New(runtime.LoadedAt),
+        RulesetVersion:              s.engine.rulesetVersion,
+        Frameworks:                  s.engine.Frameworks(),
+        Weights:                     runtime.Weights,
+        CompliantThreshold:          runtime.Thresholds.Compliant,
+        PartiallyCompliantThreshold: runtime.Thresholds.PartiallyCompliant,
+        CacheDefaultTtl:             runtime.Cache.Default.String(),
+        CacheFrameworkTtls:          make(map[string]string, len(runtime.Cache.Frameworks)),
+        SamaSubdomainGates:          runtime.SamaSubdomainGates,
+        FeatureFlags: map[string]string{
+            "result_schema":                 runtime.ResultSchema,
+            "schema_validation_sample_rate": strconv.FormatFloat(runtime.SchemaValidationRate, 'f', -1, 64),
+            "trace_sample_ratio":            strconv.FormatFloat(runtime.Tracing.SampleRatio, 'f', -1, 64),
+            "trace_latency_threshold":       runtime.Tracing.LatencyThreshold.String(),
+            "org_allowlist_enabled":         strconv.FormatBool(len(runtime.OrgAllowlist) > 0),
+            "verbose_errors":                strconv.FormatBool(s.config.VerboseErrors),
+            "cache_backend":                 defaultString(s.config.CacheBackend, cacheBackendRedis),
+            "event_payload_mode":            defaultString(s.config.Events.Mode, "emit-v1"),
+            "event_routing":                 defaultString(s.config.Events.Routing, "header"),
+            "event_publish_on":              defaultString(s.config.Events.PublishOn, publishOnEvery),
+            "obligation_horizon":            runtime.ObligationHorizon.String(),
+            "regulatory_milestones":         strconv.Itoa(len(runtime.Calendar)),
+            "tenant_profiles":               strconv.Itoa(len(runtime.TenantProfiles)),
+            "org_id_normalization":          defaultString(s.config.OrgIDNormalization, orgIDNormalizeTrim),
+            "self_test":                     strconv.FormatBool(s.selfTest != nil),
+            "history_format":                defaultString(s.config.HistoryFormat, evaluationFormatFull),
+        },
+        ServiceJson:             string(service),
+        OperationalStateVersion: runtime.OperationalVersion,
+        DisabledFrameworks:      runtime.DisabledFrameworks,
+    }
+    for framework, ttl := range runtime.Cache.Frameworks {
+        effective.CacheFrameworkTtls[framework] = ttl.String()
+    }
+    effective.Values = append(serviceConfigValues(s.config), runtimeConfigValues(runtime, s.operational.Current())...)
+    return effective, nil
+}
+
+func defaultString(value, def string) string {
+    if value == "" {
+        return def
+    }
+    return value
+}
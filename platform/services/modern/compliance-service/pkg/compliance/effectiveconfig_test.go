@@ -0,0 +1,113 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+)
+
+func adminContext(token string) context.Context {
+    return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestGetEffectiveConfigRequiresAdmin(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+    })
+    tests := []struct {
+        name string
+        ctx  context.Context
+        code codes.Code
+    }{
+        {name: "no credentials", ctx: context.Background(), code: codes.PermissionDenied},
+        {name: "wrong token", ctx: adminContext("guess"), code: codes.PermissionDenied},
+        {name: "admin", ctx: adminContext("admin-secret"), code: codes.OK},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := service.GetEffectiveConfig(tt.ctx, nil); status.Code(err) != tt.code {
+                t.Errorf("GetEffectiveConfig() = %v, want %v", err, tt.code)
+            }
+        })
+    }
+}
+
+// The effective config reports what was loaded, with secrets redacted but
+// fingerprinted
+func TestGetEffectiveConfigMatchesLoaded(t *testing.T) {
+    t.Cleanup(func() { logRedactor = &Redactor{} })
+    t.Setenv("VERBOSE_ERRORS", "true")
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+        config.LogRedactFields = "organization_id=hash"
+        config.LogRedactSalt = "salt-secret"
+        config.DelegatePrincipals = "portal:portal-secret"
+        config.FrameworkCacheTTLs = "SAMA:1m"
+        config.OrgAllowlist = "org-*"
+    })
+    effective, err := service.GetEffectiveConfig(adminContext("admin-secret"), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    runtime := service.runtimeConfig()
+    if effective.ConfigVersion != runtime.Version || effective.RulesetVersion != service.engine.rulesetVersion {
+        t.Errorf("config version %q ruleset %q, want %q and %q", effective.ConfigVersion, effective.RulesetVersion, runtime.Version, service.engine.rulesetVersion)
+    }
+    if len(effective.Frameworks) != len(service.engine.Frameworks()) || len(effective.Weights) != len(runtime.Weights) {
+        t.Errorf("frameworks %v weights %v, want the %d registered", effective.Frameworks, effective.Weights, len(service.engine.Frameworks()))
+    }
+    for framework, weight := range runtime.Weights {
+        if effective.Weights[framework] != weight {
+            t.Errorf("%s weight %v, want %v", framework, effective.Weights[framework], weight)
+        }
+    }
+    if effective.CompliantThreshold != runtime.Thresholds.Compliant || effective.CacheFrameworkTtls["SAMA"] != "1m0s" {
+        t.Errorf("compliant threshold %v, SAMA TTL %q, want %v and 1m0s", effective.CompliantThreshold, effective.CacheFrameworkTtls["SAMA"], runtime.Thresholds.Compliant)
+    }
+    for flag, want := range map[string]string{"verbose_errors": "true", "org_allowlist_enabled": "true"} {
+        if got := effective.FeatureFlags[flag]; got != want {
+            t.Errorf("feature flag %s = %q, want %q", flag, got, want)
+        }
+    }
+
+    for _, secret := range []string{"admin-secret", "salt-secret", "portal-secret"} {
+        if strings.Contains(effective.ServiceJson, secret) {
+            t.Errorf("service config exposes %s", secret)
+        }
+    }
+    if !strings.Contains(effective.ServiceJson, "portal:"+redactedValue) {
+        t.Errorf("service config should keep the delegate principal name: %s", effective.ServiceJson)
+    }
+
+    values := make(map[string]*ConfigValue)
+    for _, value := range effective.Values {
+        values[value.Path] = value
+    }
+    tests := []struct {
+        path        string
+        value       string
+        source      string
+        fingerprint string
+    }{
+        {path: "Admin.Token", value: redactedValue, source: configSourceDefault, fingerprint: fingerprint("admin-secret")},
+        {path: "LogRedactSalt", value: redactedValue, source: configSourceDefault, fingerprint: fingerprint("salt-secret")},
+        {path: "DelegatePrincipals", value: "portal:" + redactedValue, source: configSourceDefault, fingerprint: fingerprint("portal:portal-secret")},
+        {path: "VerboseErrors", value: "true", source: configSourceEnv},
+        {path: "OrgAllowlist", value: "org-*", source: configSourceDefault},
+    }
+    for _, tt := range tests {
+        got := values[tt.path]
+        if got == nil {
+            t.Errorf("%s missing from values", tt.path)
+            continue
+        }
+        if got.Value != tt.value || got.Source != tt.source || got.Fingerprint != tt.fingerprint {
+            t.Errorf("%s = %q from %s fingerprint %q, want %q from %s fingerprint %q", tt.path, got.Value, got.Source, got.Fingerprint, tt.value, tt.source, tt.fingerprint)
+        }
+    }
+}
@@ -0,0 +1,561 @@
+package compliance
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "log"
+    "sort"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/protobuf/proto"
+)
+
+// DefaultRulesetVersion - version of the built-in ruleset, part of every
+// compute cache key
+const DefaultRulesetVersion = "2024.2"
+
+// Returned by EvaluateAll when a run outlives the compute timeout
+var errComputeTimeout = errors.New("compliance computation timed out")
+
+// FrameworkChecker - evaluates a single framework for an organization
+type FrameworkChecker func(ctx context.Context, req *ComplianceRequest) *FrameworkResult
+
+// RulesEngine - runs registered framework checkers with memoization
+type RulesEngine struct {
+    rulesetVersion string
+    checkers       map[string]FrameworkChecker
+    frameworks     []string
+    dependencies   map[string][]string
+    requirements   map[string][]EvidenceRequirement
+    scales         map[string]ScoreScale // Native scales of frameworks reporting native scores
+    memo           *ComputeCache
+    faults         *FaultInjector
+    scheduler      *PriorityScheduler
+    latency        checkerLatency
+    limits         map[string]chan struct{}          // Per-framework concurrency semaphores
+    shadows        map[string]shadowRuleset          // Candidate rulesets, registered at startup
+    promoted       atomic.Pointer[map[string]string] // Frameworks whose shadow is active, by version
+    computeTimeout time.Duration                     // Hard limit on one run, whatever the caller's deadline; 0 for none
+}
+
+// Create a rules engine; a nil memo disables memoization
+func NewRulesEngine(rulesetVersion string, memo *ComputeCache) *RulesEngine {
+    return &RulesEngine{
+        rulesetVersion: rulesetVersion,
+        checkers:       make(map[string]FrameworkChecker),
+        dependencies:   make(map[string][]string),
+        requirements:   make(map[string][]EvidenceRequirement),
+        scales:         make(map[string]ScoreScale),
+        limits:         make(map[string]chan struct{}),
+        shadows:        make(map[string]shadowRuleset),
+        memo:           memo,
+    }
+}
+
+// Register a checker for a framework, in evaluation order. The checker only
+// runs once every framework it depends on has produced a result.
+func (e *RulesEngine) Register(framework string, checker FrameworkChecker, dependsOn ...string) {
+    if _, exists := e.checkers[framework]; !exists {
+        e.frameworks = append(e.frameworks, framework)
+        frameworkLimitWaits.precreate(framework)
+    }
+    e.checkers[framework] = checker
+    e.AddDependencies(framework, dependsOn...)
+}
+
+// Checker registered for a framework
+func (e *RulesEngine) Checker(framework string) (FrameworkChecker, bool) {
+    checker, ok := e.checkers[framework]
+    return checker, ok
+}
+
+// AddDependencies declares additional prerequisites for a framework
+func (e *RulesEngine) AddDependencies(framework string, dependsOn ...string) {
+    for _, dep := range dependsOn {
+        if !containsString(e.dependencies[framework], dep) {
+            e.dependencies[framework] = append(e.dependencies[framework], dep)
+        }
+    }
+}
+
+// RequireEvidence declares the evidence a framework reads
+func (e *RulesEngine) RequireEvidence(framework string, requirements ...EvidenceRequirement) {
+    e.requirements[framework] = append(e.requirements[framework], requirements...)
+}
+
+// SetScoreScale declares how a framework's native scores map onto 0-100
+func (e *RulesEngine) SetScoreScale(framework string, scale ScoreScale) {
+    e.scales[framework] = scale
+}
+
+// SelectFrameworks resolves a requested framework selection; an empty
+// selection means every registered framework
+func (e *RulesEngine) SelectFrameworks(requested []string) ([]string, error) {
+    if len(requested) == 0 {
+        return e.frameworks, nil
+    }
+    selected := make([]string, 0, len(requested))
+    for _, framework := range requested {
+        if _, ok := e.checkers[framework]; !ok {
+            return nil, fmt.Errorf("unknown framework %q", framework)
+        }
+        if !containsString(selected, framework) {
+            selected = append(selected, framework)
+        }
+    }
+    return selected, nil
+}
+
+// Validate the dependency graph: every prerequisite must be registered and
+// the graph must be acyclic. Called once at startup.
+func (e *RulesEngine) Validate() error {
+    const (
+        unvisited = iota
+        visiting
+        visited
+    )
+    state := make(map[string]int, len(e.frameworks))
+
+    var visit func(framework string, path []string) error
+    visit = func(framework string, path []string) error {
+        switch state[framework] {
+        case visiting:
+            return fmt.Errorf("framework dependency cycle: %s", strings.Join(append(path, framework), " -> "))
+        case visited:
+            return nil
+        }
+        state[framework] = visiting
+        for _, dep := range e.dependencies[framework] {
+            if _, ok := e.checkers[dep]; !ok {
+                return fmt.Errorf("framework %s depends on unregistered framework %s", framework, dep)
+            }
+            if err := visit(dep, append(path, framework)); err != nil {
+                return err
+            }
+        }
+        state[framework] = visited
+        return nil
+    }
+
+    for framework := range e.dependencies {
+        if _, ok := e.checkers[framework]; !ok {
+            return fmt.Errorf("dependencies declared for unregistered framework %s", framework)
+        }
+    }
+    for framework, requirements := range e.requirements {
+        if err := validateControlWeights(framework, requirements); err != nil {
+            return err
+        }
+    }
+    for framework, scale := range e.scales {
+        if _, ok := e.checkers[framework]; !ok {
+            return fmt.Errorf("score scale declared for unregistered framework %s", framework)
+        }
+        if err := scale.Validate(framework); err != nil {
+            return err
+        }
+    }
+    for _, framework := range e.frameworks {
+        if err := visit(framework, nil); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// EvaluateAll runs the frameworks the request lists, every registered one
+// when it lists none. Prerequisites outside that scope are evaluated for
+// their dependents but not returned. Independent frameworks run in
+// parallel; a framework with prerequisites starts as soon as they complete and
+// can read their results through DependencyResult. Frameworks present in
+// reuse are not evaluated; the supplied result is used as-is. Returns
+// errLoadShed if any evaluation was shed by the scheduler, errComputeTimeout
+// if the run outlived the compute timeout and ctx's error if the caller
+// cancelled or its deadline passed, since checkers then return nothing.
+func (e *RulesEngine) EvaluateAll(ctx context.Context, req *ComplianceRequest, reuse map[string]*FrameworkResult) ([]*FrameworkResult, error) {
+    results, _, err := e.EvaluateUntil(ctx, req, reuse, nil)
+    return results, err
+}
+
+// EvaluateUntil is EvaluateAll with an early exit: frameworks start fastest
+// first by historical latency, and once decided reports that the results so
+// far settle the outcome, outstanding evaluations are cancelled and returned
+// as skipped. A nil decided evaluates everything.
+func (e *RulesEngine) EvaluateUntil(ctx context.Context, req *ComplianceRequest, reuse map[string]*FrameworkResult, decided func(results []*FrameworkResult, outstanding []string) bool) ([]*FrameworkResult, []string, error) {
+    parent := ctx
+    if e.computeTimeout > 0 {
+        var cancelTimeout context.CancelFunc
+        ctx, cancelTimeout = context.WithTimeoutCause(ctx, e.computeTimeout, errComputeTimeout)
+        defer cancelTimeout()
+    }
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    scope := e.RequestFrameworks(req)
+    frameworks := e.withPrerequisites(scope)
+    if decided != nil {
+        frameworks = e.latency.order(frameworks)
+    }
+
+    done := make(map[string]chan struct{}, len(frameworks))
+    for _, framework := range frameworks {
+        done[framework] = make(chan struct{})
+    }
+
+    type evaluation struct {
+        framework string
+        result    *FrameworkResult
+    }
+    completed := &dependencyResults{results: make(map[string]*FrameworkResult, len(frameworks))}
+    results := make(chan evaluation, len(frameworks))
+    var shed atomic.Bool
+
+    for _, framework := range frameworks {
+        go func(framework string) {
+            defer close(done[framework])
+
+            for _, dep := range e.dependencies[framework] {
+                <-done[dep]
+            }
+
+            result, reused := reuse[framework]
+            if !reused {
+                var err error
+                result, err = e.scheduledEvaluate(ctx, framework, req, completed)
+                if err == errLoadShed {
+                    shed.Store(true)
+                }
+            }
+            completed.set(framework, result)
+            results <- evaluation{framework, result}
+        }(framework)
+    }
+
+    // Collect results within scope, dropping frameworks that failed to
+    // evaluate
+    collected := make([]*FrameworkResult, 0, len(scope))
+    outstanding := append([]string(nil), scope...)
+    var skipped []string
+    stopped := false
+    for range frameworks {
+        evaluation := <-results
+        if !containsString(scope, evaluation.framework) {
+            continue
+        }
+        outstanding = removeString(outstanding, evaluation.framework)
+        switch {
+        case evaluation.result != nil:
+            collected = append(collected, evaluation.result)
+            observeResult(ctx, evaluation.result)
+        case stopped:
+            skipped = append(skipped, evaluation.framework)
+        }
+        if !stopped && decided != nil && len(outstanding) > 0 && decided(collected, outstanding) {
+            stopped = true
+            cancel()
+        }
+    }
+    if shed.Load() {
+        return nil, nil, errLoadShed
+    }
+    if context.Cause(ctx) == errComputeTimeout {
+        computeTimeouts.Inc()
+        return nil, nil, errComputeTimeout
+    }
+    if err := parent.Err(); err != nil {
+        return nil, nil, err
+    }
+    return collected, skipped, nil
+}
+
+// Evaluate once the framework's concurrency limit admits it and a worker
+// slot is granted by priority. The framework limit is taken first so a
+// throttled framework never holds a shared worker slot while it waits.
+func (e *RulesEngine) scheduledEvaluate(ctx context.Context, framework string, req *ComplianceRequest, completed *dependencyResults) (*FrameworkResult, error) {
+    release, err := e.acquireFramework(ctx, framework)
+    if err != nil {
+        return nil, err
+    }
+    defer release()
+
+    if e.scheduler != nil {
+        if err := e.scheduler.Acquire(ctx, req.Priority); err != nil {
+            return nil, err
+        }
+        defer e.scheduler.Release()
+    }
+    depCtx := context.WithValue(ctx, dependencyResultsKey{}, completed)
+    return e.Evaluate(depCtx, framework, req), nil
+}
+
+// Frameworks returns the registered frameworks in registration order
+func (e *RulesEngine) Frameworks() []string {
+    return e.frameworks
+}
+
+// Frameworks a request is scored on, in evaluation order: those it lists,
+// or every registered framework when it lists none. Names are resolved and
+// validated before evaluation, so unknown ones are ignored here.
+func (e *RulesEngine) RequestFrameworks(req *ComplianceRequest) []string {
+    if len(req.Frameworks) == 0 {
+        return e.frameworks
+    }
+    var selected []string
+    for _, framework := range e.frameworks {
+        if containsString(req.Frameworks, framework) {
+            selected = append(selected, framework)
+        }
+    }
+    return selected
+}
+
+// Frameworks evaluated for a scope: the scope and, transitively, the
+// prerequisites its frameworks read, in evaluation order
+func (e *RulesEngine) withPrerequisites(scope []string) []string {
+    needed := make(map[string]bool, len(e.frameworks))
+    var visit func(framework string)
+    visit = func(framework string) {
+        if needed[framework] {
+            return
+        }
+        needed[framework] = true
+        for _, dep := range e.dependencies[framework] {
+            visit(dep)
+        }
+    }
+    for _, framework := range scope {
+        visit(framework)
+    }
+    var frameworks []string
+    for _, framework := range e.frameworks {
+        if needed[framework] {
+            frameworks = append(frameworks, framework)
+        }
+    }
+    return frameworks
+}
+
+// Evaluate a single framework, reusing a memoized result for identical inputs
+func (e *RulesEngine) Evaluate(ctx context.Context, framework string, req *ComplianceRequest) *FrameworkResult {
+    checker, ok := e.activeChecker(framework)
+    if !ok {
+        return nil
+    }
+
+    startTime := time.Now()
+    defer func() {
+        recordFrameworkTiming(ctx, framework, time.Since(startTime))
+        recordSpan(ctx, "evaluate "+framework, startTime, nil)
+    }()
+
+    if e.faults != nil && !e.faults.Apply(ctx, framework) {
+        markTraceDegraded(ctx, "fault injected into "+framework)
+        return nil
+    }
+
+    if e.memo == nil {
+        return e.runChecker(ctx, framework, checker, req)
+    }
+
+    key := e.InputKey(framework, req)
+    if result, ok := e.memo.Get(key); ok {
+        computeCacheHits.Inc()
+        return proto.Clone(result).(*FrameworkResult)
+    }
+    computeCacheMisses.Inc()
+
+    result := e.runChecker(ctx, framework, checker, req)
+    if result != nil {
+        e.memo.Set(key, proto.Clone(result).(*FrameworkResult))
+    }
+    return result
+}
+
+// Run a checker, treating a panic like any other failed evaluation, and
+// record the framework's evidence coverage on its result. A checker still
+// running when ctx ends is abandoned, so one that ignores cancellation
+// cannot hold its worker slot past the compute timeout.
+func (e *RulesEngine) runChecker(ctx context.Context, framework string, checker FrameworkChecker, req *ComplianceRequest) *FrameworkResult {
+    type checked struct {
+        result *FrameworkResult
+        err    error
+    }
+    startTime := time.Now()
+    done := make(chan checked, 1)
+    go func() {
+        result, err := runChecker(ctx, checker, req)
+        done <- checked{result, err}
+    }()
+
+    var result *FrameworkResult
+    var err error
+    select {
+    case c := <-done:
+        result, err = c.result, c.err
+    case <-ctx.Done():
+        if context.Cause(ctx) == errComputeTimeout {
+            log.Printf("Checker %s abandoned for %s after the compute timeout", framework, redact(fieldOrganizationID, req.OrganizationId))
+        }
+        return nil
+    }
+    if ctx.Err() == nil {
+        e.latency.observe(framework, time.Since(startTime))
+    }
+    if err == nil && result != nil {
+        err = e.normalizeScore(framework, result)
+    }
+    if err != nil {
+        log.Printf("Checker %s failed for %s: %v", framework, redact(fieldOrganizationID, req.OrganizationId), err)
+        markTraceDegraded(ctx, "checker "+framework+" failed")
+        return nil
+    }
+    // Rounded here, before the result is memoized or reaches any cache
+    roundFrameworkResult(result)
+
+    // Coverage is independent of the score: a low score with full coverage
+    // is non-compliance, with low coverage it is missing evidence
+    set, _ := e.assembleEvidence([]string{framework}, req.Evidence, time.Now())
+    result.EvidenceCoverage = e.evidenceStatus(framework, set).EstimatedCoverage
+    e.stampRuleset(framework, result)
+    return result
+}
+
+// InputKey hashes (active ruleset version, framework, evidence the framework
+// declares it reads, input keys of its prerequisites). The organization is
+// deliberately excluded: organizations with identical relevant evidence share
+// results, and an organization's results stay reusable until evidence the
+// framework reads changes.
+func (e *RulesEngine) InputKey(framework string, req *ComplianceRequest) string {
+    consumed := make([]*EvidenceItem, 0, len(req.Evidence))
+    for _, item := range req.Evidence {
+        for _, requirement := range e.requirements[framework] {
+            if item.Key == requirement.Key {
+                consumed = append(consumed, item)
+                break
+            }
+        }
+    }
+
+    // Prerequisite results feed the checker, so their inputs are ours too;
+    // Validate guarantees the recursion terminates. The ruleset checksum
+    // covers control severities, so reweighting invalidates results.
+    version := e.frameworkVersion(framework)
+    scope := version + ":" + e.rulesetChecksum(framework, version)
+    for _, dep := range e.dependencies[framework] {
+        scope += "/" + e.InputKey(dep, req)
+    }
+    return evidenceHash(scope, framework, consumed)
+}
+
+// Order-independent hash of evidence items, prefixed by scoping values
+func evidenceHash(scope string, framework string, items []*EvidenceItem) string {
+    h := sha256.New()
+    write := func(s string) {
+        h.Write([]byte(s))
+        h.Write([]byte{0})
+    }
+
+    write(scope)
+    write(framework)
+
+    entries := make([]string, 0, len(items))
+    for _, item := range items {
+        entries = append(entries, item.Key+"\x00"+item.Type+"\x00"+item.Value)
+    }
+    sort.Strings(entries)
+    for _, entry := range entries {
+        write(entry)
+    }
+
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// Results of already-evaluated frameworks within one EvaluateAll run
+type dependencyResults struct {
+    mu      sync.RWMutex
+    results map[string]*FrameworkResult
+}
+
+type dependencyResultsKey struct{}
+
+func (d *dependencyResults) set(framework string, result *FrameworkResult) {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.results[framework] = result
+}
+
+// DependencyResult returns the result of a prerequisite framework from within
+// a checker. Only declared dependencies are guaranteed to be present.
+func DependencyResult(ctx context.Context, framework string) (*FrameworkResult, bool) {
+    deps, ok := ctx.Value(dependencyResultsKey{}).(*dependencyResults)
+    if !ok {
+        return nil, false
+    }
+    deps.mu.RLock()
+    defer deps.mu.RUnlock()
+    result, ok := deps.results[framework]
+    return result, ok && result != nil
+}
+
+// Parse a dependency declaration such as "PDPL:NCA;COMPOSITE:NCA,SAMA"
+func parseFrameworkDependencies(value string) (map[string][]string, error) {
+    deps := make(map[string][]string)
+    for _, entry := range strings.Split(value, ";") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        parts := strings.SplitN(entry, ":", 2)
+        if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+            return nil, fmt.Errorf("invalid framework dependency %q", entry)
+        }
+        framework := strings.TrimSpace(parts[0])
+        for _, dep := range strings.Split(parts[1], ",") {
+            if dep = strings.TrimSpace(dep); dep != "" {
+                deps[framework] = append(deps[framework], dep)
+            }
+        }
+    }
+    return deps, nil
+}
+
+// values without value, filtered in place
+func removeString(values []string, value string) []string {
+    kept := values[:0]
+    for _, v := range values {
+        if v != value {
+            kept = append(kept, v)
+        }
+    }
+    return kept
+}
+
+func containsString(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
+
+// Rules engine metrics
+var (
+    computeTimeouts = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_compute_timeouts_total",
+            Help: "Evaluation runs aborted at the compute timeout",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(computeTimeouts)
+}
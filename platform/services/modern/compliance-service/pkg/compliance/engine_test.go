@@ -0,0 +1,281 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "google.golang.org/protobuf/proto"
+)
+
+// Checker scoring the two controls it declares, counting its runs
+func countingChecker(framework string, calls *atomic.Int64) FrameworkChecker {
+    return func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        calls.Add(1)
+        score := 0.0
+        for _, item := range req.Evidence {
+            if (item.Key == "mfa_enabled" || item.Key == "encryption_at_rest") && item.Value == "true" {
+                score += 50
+            }
+        }
+        return &FrameworkResult{Framework: framework, Score: score}
+    }
+}
+
+func newMemoEngine(memo *ComputeCache, calls *atomic.Int64) *RulesEngine {
+    engine := NewRulesEngine(DefaultRulesetVersion, memo)
+    engine.Register("SAMA", countingChecker("SAMA", calls))
+    engine.RequireEvidence("SAMA", EvidenceRequirement{Key: "mfa_enabled"}, EvidenceRequirement{Key: "encryption_at_rest"})
+    return engine
+}
+
+func evidenceRequest(org string, values ...string) *ComplianceRequest {
+    keys := []string{"mfa_enabled", "encryption_at_rest"}
+    req := &ComplianceRequest{OrganizationId: org}
+    for i, value := range values {
+        req.Evidence = append(req.Evidence, &EvidenceItem{Key: keys[i], Value: value})
+    }
+    return req
+}
+
+// Memoized results equal what the rules compute, and only identical rule
+// inputs share one
+func TestComputeCacheMatchesRules(t *testing.T) {
+    tests := []struct {
+        name  string
+        reqs  []*ComplianceRequest
+        calls int64
+    }{
+        {
+            name:  "identical inputs run once",
+            reqs:  []*ComplianceRequest{evidenceRequest("org-1", "true", "false"), evidenceRequest("org-1", "true", "false")},
+            calls: 1,
+        },
+        {
+            name:  "organizations with the same evidence share a result",
+            reqs:  []*ComplianceRequest{evidenceRequest("org-1", "true", "true"), evidenceRequest("org-2", "true", "true")},
+            calls: 1,
+        },
+        {
+            name:  "changed evidence runs again",
+            reqs:  []*ComplianceRequest{evidenceRequest("org-1", "true", "false"), evidenceRequest("org-1", "true", "true")},
+            calls: 2,
+        },
+        {
+            name: "evidence the framework does not read is ignored",
+            reqs: []*ComplianceRequest{
+                evidenceRequest("org-1", "true"),
+                {OrganizationId: "org-1", Evidence: []*EvidenceItem{{Key: "mfa_enabled", Value: "true"}, {Key: "badge_color", Value: "red"}}},
+            },
+            calls: 1,
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var memoCalls, plainCalls atomic.Int64
+            memoized := newMemoEngine(NewComputeCache(time.Minute), &memoCalls)
+            plain := newMemoEngine(nil, &plainCalls)
+            for i, req := range tt.reqs {
+                got := memoized.Evaluate(context.Background(), "SAMA", req)
+                want := plain.Evaluate(context.Background(), "SAMA", req)
+                if !proto.Equal(got, want) {
+                    t.Errorf("request %d: memoized %v, computed %v", i, got, want)
+                }
+            }
+            if got := memoCalls.Load(); got != tt.calls {
+                t.Errorf("checker ran %d times with memoization, want %d", got, tt.calls)
+            }
+        })
+    }
+}
+
+// A memoized result is a copy: callers changing it do not change the memo
+func TestComputeCacheReturnsCopies(t *testing.T) {
+    var calls atomic.Int64
+    engine := newMemoEngine(NewComputeCache(time.Minute), &calls)
+    req := evidenceRequest("org-1", "true", "true")
+
+    first := engine.Evaluate(context.Background(), "SAMA", req)
+    first.Score = 0
+    if second := engine.Evaluate(context.Background(), "SAMA", req); second.Score != 100 {
+        t.Errorf("memoized score %v after the caller changed its copy, want 100", second.Score)
+    }
+}
+
+func TestComputeCacheExpires(t *testing.T) {
+    var calls atomic.Int64
+    engine := newMemoEngine(NewComputeCache(10*time.Millisecond), &calls)
+    req := evidenceRequest("org-1", "true", "true")
+
+    engine.Evaluate(context.Background(), "SAMA", req)
+    time.Sleep(20 * time.Millisecond)
+    engine.Evaluate(context.Background(), "SAMA", req)
+    if got := calls.Load(); got != 2 {
+        t.Errorf("checker ran %d times across the TTL, want 2", got)
+    }
+}
+
+// Repeated evaluation of identical inputs, with and without memoization
+func BenchmarkRepeatedEvaluation(b *testing.B) {
+    slow := func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        time.Sleep(100 * time.Microsecond)
+        return &FrameworkResult{Framework: "SAMA", Score: 80}
+    }
+    req := evidenceRequest("org-1", "true", "true")
+    for _, bm := range []struct {
+        name string
+        memo *ComputeCache
+    }{
+        {name: "unmemoized"},
+        {name: "memoized", memo: NewComputeCache(time.Minute)},
+    } {
+        b.Run(bm.name, func(b *testing.B) {
+            engine := NewRulesEngine(DefaultRulesetVersion, bm.memo)
+            engine.Register("SAMA", slow)
+            engine.RequireEvidence("SAMA", EvidenceRequirement{Key: "mfa_enabled"}, EvidenceRequirement{Key: "encryption_at_rest"})
+            for i := 0; i < b.N; i++ {
+                engine.Evaluate(context.Background(), "SAMA", req)
+            }
+        })
+    }
+}
+
+// A composite framework starts only once its base has a result, and reads it
+func TestDependentRunsAfterPrerequisite(t *testing.T) {
+    engine := NewRulesEngine(DefaultRulesetVersion, nil)
+    var mu sync.Mutex
+    var order []string
+    record := func(framework string) {
+        mu.Lock()
+        defer mu.Unlock()
+        order = append(order, framework)
+    }
+    engine.Register("COMPOSITE", func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        record("COMPOSITE")
+        base, ok := DependencyResult(ctx, "BASE")
+        if !ok {
+            return nil
+        }
+        return &FrameworkResult{Framework: "COMPOSITE", Score: base.Score + 10}
+    }, "BASE")
+    engine.Register("BASE", func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        time.Sleep(20 * time.Millisecond)
+        record("BASE")
+        return &FrameworkResult{Framework: "BASE", Score: 70}
+    })
+    if err := engine.Validate(); err != nil {
+        t.Fatal(err)
+    }
+
+    // BASE runs for COMPOSITE but is outside the requested scope
+    results, err := engine.EvaluateAll(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"COMPOSITE"}}, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(results) != 1 || results[0].Framework != "COMPOSITE" || results[0].Score != 80 {
+        t.Errorf("got %v, want only COMPOSITE scored 80", results)
+    }
+    if got := strings.Join(order, ","); got != "BASE,COMPOSITE" {
+        t.Errorf("ran %s, want BASE,COMPOSITE", got)
+    }
+}
+
+// Frameworks without dependencies between them are evaluated concurrently
+func TestIndependentFrameworksRunInParallel(t *testing.T) {
+    engine := NewRulesEngine(DefaultRulesetVersion, nil)
+    var started sync.WaitGroup
+    started.Add(2)
+    both := make(chan struct{})
+    go func() {
+        started.Wait()
+        close(both)
+    }()
+    for _, framework := range []string{"NCA", "SAMA"} {
+        framework := framework
+        engine.Register(framework, func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+            started.Done()
+            select {
+            case <-both:
+                return &FrameworkResult{Framework: framework, Score: 90}
+            case <-time.After(time.Second):
+                return nil
+            }
+        })
+    }
+
+    results, err := engine.EvaluateAll(context.Background(), &ComplianceRequest{OrganizationId: "org-1"}, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(results) != 2 {
+        t.Errorf("%d results, want 2: checkers did not overlap", len(results))
+    }
+}
+
+func TestValidateDependencyGraph(t *testing.T) {
+    noop := func(ctx context.Context, req *ComplianceRequest) *FrameworkResult { return nil }
+    tests := []struct {
+        name  string
+        setup func(e *RulesEngine)
+        want  string
+    }{
+        {
+            name: "chain",
+            setup: func(e *RulesEngine) {
+                e.Register("A", noop)
+                e.Register("B", noop, "A")
+                e.Register("C", noop, "A", "B")
+            },
+        },
+        {
+            name: "cycle",
+            setup: func(e *RulesEngine) {
+                e.Register("A", noop, "C")
+                e.Register("B", noop, "A")
+                e.Register("C", noop, "B")
+            },
+            want: "framework dependency cycle: A -> C -> B -> A",
+        },
+        {
+            name: "self dependency",
+            setup: func(e *RulesEngine) {
+                e.Register("A", noop, "A")
+            },
+            want: "framework dependency cycle: A -> A",
+        },
+        {
+            name: "unregistered prerequisite",
+            setup: func(e *RulesEngine) {
+                e.Register("A", noop, "GDPR")
+            },
+            want: "framework A depends on unregistered framework GDPR",
+        },
+        {
+            name: "dependencies of an unregistered framework",
+            setup: func(e *RulesEngine) {
+                e.Register("A", noop)
+                e.AddDependencies("GDPR", "A")
+            },
+            want: "dependencies declared for unregistered framework GDPR",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            engine := NewRulesEngine(DefaultRulesetVersion, nil)
+            tt.setup(engine)
+            err := engine.Validate()
+            if tt.want == "" {
+                if err != nil {
+                    t.Errorf("Validate() = %v, want nil", err)
+                }
+                return
+            }
+            if err == nil || err.Error() != tt.want {
+                t.Errorf("Validate() = %v, want %q", err, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,243 @@
+package compliance
+
+import (
+    "log"
+    "os"
+    "strconv"
+    "time"
+)
+
+// ConfigFromEnv - service configuration read from the environment, with the
+// defaults the service has always run with
+func ConfigFromEnv() ServiceConfig {
+    config := ServiceConfig{
+        Name:        "compliance-service",
+        Version:     "1.0.0",
+        Port:        os.Getenv("SERVICE_PORT"),
+        MetricsPort: os.Getenv("METRICS_PORT"),
+        HTTPPort:    os.Getenv("HTTP_PORT"),
+        RedisAddr:   os.Getenv("REDIS_ADDR"),
+        KafkaAddr:   os.Getenv("KAFKA_ADDR"),
+        ClusterNode: os.Getenv("CLUSTER_NODE"),
+
+        ComputeCacheTTL:       envDuration("COMPUTE_CACHE_TTL", 30*time.Second),
+        FrameworkDependencies: os.Getenv("FRAMEWORK_DEPENDENCIES"),
+        FrameworkConcurrency:  os.Getenv("FRAMEWORK_CONCURRENCY"),
+        TenantQuotas:          os.Getenv("TENANT_MONTHLY_QUOTAS"),
+        RateLimitRPS:          envFloat("RATE_LIMIT_RPS", 0),
+        RateLimitBurst:        envInt("RATE_LIMIT_BURST", 20),
+        SlowRequestThreshold:  envDuration("SLOW_REQUEST_THRESHOLD", 2*time.Second),
+        WorkerPoolSize:        envInt("WORKER_POOL_SIZE", 64),
+        PriorityAging:         envDuration("PRIORITY_AGING", time.Second),
+        MaxQueuedEvaluations:  envInt("MAX_QUEUED_EVALUATIONS", 1024),
+        MaxQueueWait:          envDuration("MAX_QUEUE_WAIT", 5*time.Second),
+        ComputeTimeout:        envDuration("COMPUTE_TIMEOUT", time.Minute),
+        JobWorkers:            envInt("JOB_WORKERS", 4),
+        JobBulkWorkers:        envInt("JOB_BULK_WORKERS", 0),
+        JobMaxPending:         envInt("JOB_MAX_PENDING", 10000),
+        FrameworkFallbackTTL:  envDuration("FRAMEWORK_FALLBACK_TTL", 0),
+        EvaluationLockTTL:     envDuration("EVALUATION_LOCK_TTL", 30*time.Second),
+        RegistryCacheTTL:      envDuration("REGISTRY_CACHE_TTL", 30*time.Second),
+        OrgIDNormalization:    defaultString(os.Getenv("ORG_ID_NORMALIZATION"), orgIDNormalizeTrim),
+        ReplayRetention:       envDuration("REPLAY_RETENTION", 90*24*time.Hour),
+        HistoryFormat:         os.Getenv("HISTORY_FORMAT"),
+        HistoryMaxRuns:        envInt("HISTORY_MAX_RUNS", 0),
+        HistoryPruneInterval:  envDuration("HISTORY_PRUNE_INTERVAL", time.Hour),
+        HistoryPruneBatch:     envInt("HISTORY_PRUNE_BATCH", 500),
+        TimelineRetention:     envDuration("STATUS_TIMELINE_RETENTION", 365*24*time.Hour),
+        EvidenceTTL:           envDuration("EVIDENCE_TTL", 7*24*time.Hour),
+        EvidenceExpiryWarning: envDuration("EVIDENCE_EXPIRY_WARNING", 30*24*time.Hour),
+        OrgAllowlist:          os.Getenv("ORG_ALLOWLIST"),
+        TraceSampleRatio:      envFloat("TRACE_SAMPLE_RATIO", 0.1),
+        TraceLatencyThreshold: envDuration("TRACE_LATENCY_THRESHOLD", time.Second),
+        TraceBufferSpans:      envInt("TRACE_BUFFER_SPANS", 10000),
+        VerboseErrors:         os.Getenv("VERBOSE_ERRORS") == "true",
+        GRPCCompression:       os.Getenv("GRPC_COMPRESSION") != "false",
+        GatewayDocs:           os.Getenv("GATEWAY_DOCS") != "false",
+        LogRedactFields:       os.Getenv("LOG_REDACT_FIELDS"),
+        LogRedactSalt:         os.Getenv("LOG_REDACT_SALT"),
+        DelegatePrincipals:    os.Getenv("DELEGATE_PRINCIPALS"),
+        RollupInterval:        envDuration("ROLLUP_INTERVAL", 30*time.Second),
+        RollupWindow:          envDuration("ROLLUP_WINDOW", 24*time.Hour),
+        SnapshotRetention:     envDuration("SNAPSHOT_RETENTION", 90*24*time.Hour),
+        OrgListMaxPageSize:    envInt("ORG_LIST_MAX_PAGE_SIZE", 500),
+        OperationalStatePoll:  envDuration("OPERATIONAL_STATE_POLL", 10*time.Second),
+
+        Consumer: ConsumerConfig{
+            RequestTopic:   os.Getenv("KAFKA_REQUEST_TOPIC"),
+            ResponseTopic:  os.Getenv("KAFKA_RESPONSE_TOPIC"),
+            PauseHighWater: envInt("CONSUMER_PAUSE_HIGH_WATER", 512),
+            DrainTimeout:   envDuration("CONSUMER_DRAIN_TIMEOUT", 30*time.Second),
+            DedupeTTL:      envDuration("CONSUMER_DEDUPE_TTL", 24*time.Hour),
+        },
+        Client: ClientConfig{
+            LBPolicy:            os.Getenv("CLIENT_LB_POLICY"),
+            Timeout:             envDuration("CLIENT_TIMEOUT", 30*time.Second),
+            RetryMaxAttempts:    envInt("CLIENT_RETRY_MAX_ATTEMPTS", 3),
+            RetryInitialBackoff: envDuration("CLIENT_RETRY_INITIAL_BACKOFF", 100*time.Millisecond),
+            RetryMaxBackoff:     envDuration("CLIENT_RETRY_MAX_BACKOFF", 2*time.Second),
+            RetryableCodes:      os.Getenv("CLIENT_RETRYABLE_CODES"),
+        },
+        Anomaly: AnomalyConfig{
+            Interval:     envDuration("ANOMALY_CHECK_INTERVAL", time.Minute),
+            Window:       envInt("ANOMALY_WINDOW", 500),
+            Recent:       envInt("ANOMALY_RECENT", 50),
+            StdDevs:      envFloat("ANOMALY_STDDEVS", 3),
+            MaxErrorRate: envFloat("ANOMALY_MAX_ERROR_RATE", 0.2),
+        },
+        Warmup: WarmupConfig{
+            Organizations: os.Getenv("WARMUP_ORGS"),
+            MaxDuration:   envDuration("WARMUP_MAX_DURATION", time.Minute),
+        },
+        SelfTest: SelfTestConfig{
+            Fixture: os.Getenv("SELF_TEST_FIXTURE"),
+            Timeout: envDuration("SELF_TEST_TIMEOUT", 30*time.Second),
+        },
+        Outbound: OutboundConfig{
+            MaxIdleConnsPerHost:  envInt("OUTBOUND_MAX_IDLE_PER_HOST", 32),
+            MaxConnsPerHost:      envInt("OUTBOUND_MAX_CONNS_PER_HOST", 64),
+            MaxConcurrentPerHost: envInt("OUTBOUND_MAX_CONCURRENT_PER_HOST", 32),
+            HostLimits:           os.Getenv("OUTBOUND_HOST_LIMITS"),
+            DialTimeout:          envDuration("OUTBOUND_DIAL_TIMEOUT", 5*time.Second),
+            TLSHandshakeTimeout:  envDuration("OUTBOUND_TLS_TIMEOUT", 5*time.Second),
+            DNSCacheTTL:          envDuration("OUTBOUND_DNS_CACHE_TTL", 30*time.Second),
+        },
+        Connectors: ConnectorConfig{
+            File:    os.Getenv("EVIDENCE_CONNECTORS_FILE"),
+            Retries: envInt("EVIDENCE_CONNECTOR_RETRIES", 2),
+            Timeout: envDuration("EVIDENCE_CONNECTOR_TIMEOUT", 5*time.Second),
+        },
+        Mirror: MirrorConfig{
+            Target:  os.Getenv("MIRROR_TARGET"),
+            Percent: envFloat("MIRROR_PERCENT", 0),
+            Timeout: envDuration("MIRROR_TIMEOUT", 10*time.Second),
+            Workers: envInt("MIRROR_WORKERS", 4),
+            Accept:  os.Getenv("MIRROR_ACCEPT") == "true",
+        },
+        MetricsExport: MetricsExportConfig{
+            Prometheus:   os.Getenv("METRICS_PROMETHEUS") != "false",
+            OTLPEndpoint: os.Getenv("METRICS_OTLP_ENDPOINT"),
+            OTLPInsecure: os.Getenv("METRICS_OTLP_INSECURE") == "true",
+            OTLPInterval: envDuration("METRICS_OTLP_INTERVAL", 30*time.Second),
+        },
+        Schedule: ScheduleConfig{
+            RunAt:             os.Getenv("SCHEDULE_RUN_AT"),
+            JitterWindow:      envDuration("SCHEDULE_JITTER_WINDOW", 2*time.Hour),
+            MaxConcurrentRuns: envInt("SCHEDULE_MAX_CONCURRENT_RUNS", 4),
+            RunRetention:      envDuration("SCHEDULE_RUN_RETENTION", 30*24*time.Hour),
+        },
+        ConfigFile:            os.Getenv("CONFIG_FILE"),
+        RegulatoryCalendar:    os.Getenv("REGULATORY_CALENDAR"),
+        ObligationHorizon:     envDuration("OBLIGATION_HORIZON", 90*24*time.Hour),
+        DefaultRegion:         os.Getenv("DEFAULT_REGION"),
+        DefaultLocale:         os.Getenv("DEFAULT_LOCALE"),
+        KnowledgeBase:         os.Getenv("KNOWLEDGE_BASE"),
+        CacheTTL:              envDuration("CACHE_TTL", 5*time.Minute),
+        CacheMaxValueSize:     envInt("CACHE_MAX_VALUE_BYTES", 1<<20),
+        CacheBackend:          os.Getenv("CACHE_BACKEND"),
+        MemcachedServers:      os.Getenv("MEMCACHED_SERVERS"),
+        FrameworkCacheTTLs:    os.Getenv("FRAMEWORK_CACHE_TTLS"),
+
+        Events: EventConfig{
+            Mode:         os.Getenv("EVENT_PAYLOAD_MODE"),
+            Routing:      os.Getenv("EVENT_VERSION_ROUTING"),
+            DedupeWindow: envDuration("EVENT_DEDUPE_WINDOW", 0),
+
+            PublishOn:          os.Getenv("EVENT_PUBLISH_ON"),
+            CrossingBoundaries: os.Getenv("EVENT_CROSSING_BOUNDARIES"),
+            HeartbeatInterval:  envDuration("EVENT_HEARTBEAT_INTERVAL", 24*time.Hour),
+
+            SpoolMemoryEvents:  envInt("EVENT_SPOOL_MEMORY_EVENTS", 1000),
+            SpoolDir:           os.Getenv("EVENT_SPOOL_DIR"),
+            SpoolSegmentBytes:  envInt("EVENT_SPOOL_SEGMENT_BYTES", 16<<20),
+            SpoolMaxDiskBytes:  envInt("EVENT_SPOOL_MAX_DISK_BYTES", 1<<30),
+            SpoolRetryInterval: envDuration("EVENT_SPOOL_RETRY_INTERVAL", 5*time.Second),
+        },
+
+        Admin: AdminConfig{
+            Port:         os.Getenv("ADMIN_PORT"),
+            Token:        os.Getenv("ADMIN_TOKEN"),
+            TLSCert:      os.Getenv("ADMIN_TLS_CERT"),
+            TLSKey:       os.Getenv("ADMIN_TLS_KEY"),
+            ClientCAFile: os.Getenv("ADMIN_CLIENT_CA"),
+            Open:         os.Getenv("ADMIN_OPEN") == "true",
+        },
+
+        TenantAuth: TenantAuthConfig{
+            JWKSURL:  os.Getenv("TENANT_JWKS_URL"),
+            Issuer:   os.Getenv("TENANT_JWT_ISSUER"),
+            Audience: os.Getenv("TENANT_JWT_AUDIENCE"),
+            Claim:    os.Getenv("TENANT_JWT_CLAIM"),
+            Refresh:  envDuration("TENANT_JWKS_REFRESH", 5*time.Minute),
+            FailOpen: os.Getenv("TENANT_AUTH_FAIL_OPEN") == "true",
+        },
+    }
+
+    if config.Port == "" {
+        config.Port = "50051"
+    }
+    if config.MetricsPort == "" {
+        config.MetricsPort = "9090"
+    }
+    if config.HTTPPort == "" {
+        config.HTTPPort = "8080"
+    }
+    if config.Consumer.ResponseTopic == "" {
+        config.Consumer.ResponseTopic = "compliance-responses"
+    }
+    if config.TenantAuth.Claim == "" {
+        config.TenantAuth.Claim = "tenant"
+    }
+    if config.Client.LBPolicy == "" {
+        config.Client.LBPolicy = "round_robin"
+    }
+    if config.Client.RetryableCodes == "" {
+        config.Client.RetryableCodes = "UNAVAILABLE"
+    }
+
+    return config
+}
+
+// Read a duration from the environment, accepting Go durations or seconds
+func envDuration(name string, def time.Duration) time.Duration {
+    value := os.Getenv(name)
+    if value == "" {
+        return def
+    }
+    if d, err := time.ParseDuration(value); err == nil {
+        return d
+    }
+    if secs, err := strconv.Atoi(value); err == nil {
+        return time.Duration(secs) * time.Second
+    }
+    log.Printf("Invalid %s=%q, using default %s", name, value, def)
+    return def
+}
+
+// Read a float from the environment
+func envFloat(name string, def float64) float64 {
+    value := os.Getenv(name)
+    if value == "" {
+        return def
+    }
+    f, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        log.Printf("Invalid %s=%q, using default %v", name, value, def)
+        return def
+    }
+    return f
+}
+
+// Read an integer from the environment
+func envInt(name string, def int) int {
+    value := os.Getenv(name)
+    if value == "" {
+        return def
+    }
+    n, err := strconv.Atoi(value)
+    if err != nil {
+        log.Printf("Invalid %s=%q, using default %d", name, value, def)
+        return def
+    }
+    return n
+}
@@ -0,0 +1,176 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "log"
+    "strings"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/genproto/googleapis/rpc/errdetails"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Domain of the stable error codes attached to every error
+const errorDomain = "compliance.doganailap"
+
+// Stable error codes for client branching, carried as ErrorInfo.reason
+const (
+    reasonInvalidArgument        = "INVALID_ARGUMENT"
+    reasonNotFound               = "NOT_FOUND"
+    reasonAlreadyExists          = "ALREADY_EXISTS"
+    reasonPermissionDenied       = "PERMISSION_DENIED"
+    reasonRateLimited            = "RATE_LIMITED"
+    reasonQuotaExceeded          = "QUOTA_EXCEEDED"
+    reasonOverloaded             = "OVERLOADED"
+    reasonOrgNotAllowed          = "ORGANIZATION_NOT_ALLOWED"
+    reasonDelegationDenied       = "DELEGATION_DENIED"
+    reasonEvaluationInProgress   = "EVALUATION_IN_PROGRESS"
+    reasonVersionConflict        = "VERSION_CONFLICT"
+    reasonFailedPrecondition     = "FAILED_PRECONDITION"
+    reasonConnectorMisconfigured = "EVIDENCE_CONNECTOR_MISCONFIGURED"
+    reasonUnavailable            = "UNAVAILABLE"
+    reasonInternal               = "INTERNAL"
+)
+
+// Error with a stable reason code attached
+func reasonError(code codes.Code, reason, message string) error {
+    return reasonErrorWithMetadata(code, reason, message, nil)
+}
+
+// Error with a stable reason code and details for the client, such as the
+// component at fault
+func reasonErrorWithMetadata(code codes.Code, reason, message string, metadata map[string]string) error {
+    st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata})
+    if err != nil {
+        return status.Error(code, message)
+    }
+    return st.Err()
+}
+
+// Codes whose messages come from internals (dependency failures, bugs) and
+// must not reach clients verbatim
+func internalCode(code codes.Code) bool {
+    switch code {
+    case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss:
+        return true
+    }
+    return false
+}
+
+// Default reason for errors raised without one
+func defaultReason(code codes.Code) string {
+    switch code {
+    case codes.InvalidArgument, codes.OutOfRange:
+        return reasonInvalidArgument
+    case codes.NotFound:
+        return reasonNotFound
+    case codes.AlreadyExists:
+        return reasonAlreadyExists
+    case codes.PermissionDenied, codes.Unauthenticated:
+        return reasonPermissionDenied
+    case codes.ResourceExhausted:
+        return reasonRateLimited
+    case codes.FailedPrecondition, codes.Aborted:
+        return reasonFailedPrecondition
+    case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+        return reasonUnavailable
+    }
+    return reasonInternal
+}
+
+// Redis error replies that mean the server is briefly unable to serve
+var transientRedisReplies = []string{"LOADING", "BUSY", "READONLY", "MASTERDOWN", "TRYAGAIN", "CLUSTERDOWN"}
+
+// Status for a failed state store call. Only failures a retry can fix are
+// Unavailable, as clients retry that code on idempotent methods: connection
+// errors and transient server replies. Other command errors are Internal
+// and deadlines keep their own codes.
+func storeError(err error, message string) error {
+    code := codes.Unavailable
+    var reply redis.Error
+    switch {
+    case errors.Is(err, context.DeadlineExceeded):
+        code = codes.DeadlineExceeded
+    case errors.Is(err, context.Canceled):
+        code = codes.Canceled
+    case errors.As(err, &reply):
+        code = codes.Internal
+        for _, prefix := range transientRedisReplies {
+            if strings.HasPrefix(reply.Error(), prefix) {
+                code = codes.Unavailable
+            }
+        }
+    }
+    return status.Errorf(code, "%s: %v", message, err)
+}
+
+// Map an error to what the client sees. Full detail is always logged; the
+// client gets a generic message for internal failures unless verbose errors
+// are enabled. Every error carries a stable reason code.
+func (s *ComplianceService) publicError(ctx context.Context, rpc string, err error) error {
+    if err == nil {
+        return nil
+    }
+    st := status.Convert(err)
+    reqID := requestID(ctx)
+
+    reason := errorReason(st)
+    if reason == "" {
+        reason = defaultReason(st.Code())
+    }
+
+    message := st.Message()
+    if internalCode(st.Code()) {
+        log.Printf("Error: rpc=%s request_id=%s code=%s reason=%s: %s", rpc, reqID, st.Code(), reason, message)
+        if !s.config.VerboseErrors {
+            message = "internal error, reference request_id " + reqID
+        }
+    }
+
+    return reasonErrorWithMetadata(st.Code(), reason, message, errorMetadata(st))
+}
+
+// Stable reason code attached to a status, empty if none
+func errorReason(st *status.Status) string {
+    for _, detail := range st.Details() {
+        if info, ok := detail.(*errdetails.ErrorInfo); ok {
+            return info.Reason
+        }
+    }
+    return ""
+}
+
+// Metadata attached alongside the reason code, nil if none
+func errorMetadata(st *status.Status) map[string]string {
+    for _, detail := range st.Details() {
+        if info, ok := detail.(*errdetails.ErrorInfo); ok {
+            return info.Metadata
+        }
+    }
+    return nil
+}
+
+// Unary interceptor applying publicError to every RPC
+func (s *ComplianceService) errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    response, err := handler(ctx, req)
+    if err != nil {
+        return nil, s.publicError(ctx, rpcName(info.FullMethod), err)
+    }
+    return response, nil
+}
+
+// Stream interceptor applying publicError to every streaming RPC
+func (s *ComplianceService) streamErrorInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+    if err := handler(srv, stream); err != nil {
+        return s.publicError(stream.Context(), rpcName(info.FullMethod), err)
+    }
+    return nil
+}
+
+// "/compliance.Compliance/CheckCompliance" -> "CheckCompliance"
+func rpcName(fullMethod string) string {
+    return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
@@ -0,0 +1,85 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+)
+
+// Clients get sanitized messages with a stable reason; the full detail is
+// logged against the request ID
+func TestPublicError(t *testing.T) {
+    const leak = "dial tcp 10.0.4.12:6379: connection refused"
+    tests := []struct {
+        name     string
+        err      error
+        verbose  bool
+        code     codes.Code
+        reason   string
+        message  string
+        metadata map[string]string
+        logged   bool
+    }{
+        {name: "raw error", err: errors.New(leak), code: codes.Unknown, reason: reasonInternal, message: "internal error, reference request_id req-1", logged: true},
+        {name: "store failure", err: storeError(errors.New(leak), "failed to read cache"), code: codes.Unavailable, reason: reasonUnavailable, message: "internal error, reference request_id req-1", logged: true},
+        {name: "verbose", err: errors.New(leak), verbose: true, code: codes.Unknown, reason: reasonInternal, message: leak, logged: true},
+        {name: "client error", err: status.Error(codes.InvalidArgument, "unknown framework GDPR"), code: codes.InvalidArgument, reason: reasonInvalidArgument, message: "unknown framework GDPR"},
+        {
+            name:     "reason and metadata kept",
+            err:      reasonErrorWithMetadata(codes.FailedPrecondition, reasonConnectorMisconfigured, "connector misconfigured", map[string]string{"connector": "okta"}),
+            code:     codes.FailedPrecondition,
+            reason:   reasonConnectorMisconfigured,
+            message:  "connector misconfigured",
+            metadata: map[string]string{"connector": "okta"},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service := &ComplianceService{config: ServiceConfig{VerboseErrors: tt.verbose}}
+            logs := captureLog(t)
+            ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+
+            st := status.Convert(service.publicError(ctx, "CheckCompliance", tt.err))
+            if st.Code() != tt.code || st.Message() != tt.message {
+                t.Errorf("client sees %v %q, want %v %q", st.Code(), st.Message(), tt.code, tt.message)
+            }
+            if reason := errorReason(st); reason != tt.reason {
+                t.Errorf("reason %q, want %s", reason, tt.reason)
+            }
+            if tt.metadata != nil && errorMetadata(st)["connector"] != tt.metadata["connector"] {
+                t.Errorf("metadata %v, want %v", errorMetadata(st), tt.metadata)
+            }
+
+            lines := logs.lines("rpc=CheckCompliance")
+            if !tt.logged {
+                if len(lines) != 0 {
+                    t.Errorf("client error logged: %v", lines)
+                }
+                return
+            }
+            if len(lines) != 1 || !strings.Contains(lines[0], "request_id=req-1") || !strings.Contains(lines[0], leak) {
+                t.Errorf("logged %v, want the full error against req-1", lines)
+            }
+        })
+    }
+}
+
+func TestErrorInterceptorSanitizes(t *testing.T) {
+    service := &ComplianceService{config: ServiceConfig{}}
+    captureLog(t)
+    info := &grpc.UnaryServerInfo{FullMethod: "/compliance.ComplianceService/CheckCompliance"}
+    failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+        return nil, errors.New("pq: relation \"evaluations\" does not exist")
+    }
+
+    _, err := service.errorInterceptor(context.Background(), nil, info, failing)
+    if msg := status.Convert(err).Message(); strings.Contains(msg, "evaluations") || !strings.HasPrefix(msg, "internal error") {
+        t.Errorf("client sees %q, want the generic message", msg)
+    }
+}
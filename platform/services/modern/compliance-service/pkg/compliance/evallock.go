@@ -0,0 +1,155 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "log"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+)
+
+// How often an interactive caller polls a held evaluation lock
+const evaluationLockPoll = 50 * time.Millisecond
+
+// Returned to background callers when the organization is already being evaluated
+var errEvaluationInProgress = reasonError(codes.Aborted, reasonEvaluationInProgress, "evaluation already in progress for this organization")
+
+// Returned by fenced writes from a holder whose lease was superseded
+var errStaleFence = errors.New("stale fencing token")
+
+// Deletes the lock only if it still holds our token
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+    return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// EvaluationLocks - per-organization evaluation locks in Redis, so the job
+// queue, the Kafka consumer and interactive RPCs never evaluate the same
+// organization and scope concurrently. Each lease carries a fencing token,
+// increasing per organization and scope, that guards later writes.
+type EvaluationLocks struct {
+    redis *redis.Client
+    ttl   time.Duration
+}
+
+// EvaluationLease - a held evaluation lock
+type EvaluationLease struct {
+    Token  int64
+    Scope  string
+    Waited bool // Another evaluation held the lock first and has completed
+    locks  *EvaluationLocks
+    key    string
+    owner  string // Lock value identifying this holder
+}
+
+// Create evaluation locks; a lease expires after ttl if its holder dies
+func NewEvaluationLocks(client *redis.Client, ttl time.Duration) *EvaluationLocks {
+    return &EvaluationLocks{redis: client, ttl: ttl}
+}
+
+// Acquire the lock for an organization and scope. Background callers get
+// errEvaluationInProgress when it is held; interactive callers wait for the
+// holder to finish, and the returned lease reports Waited so they can reuse
+// its result. The fencing token is drawn once the lock is held, so it is
+// newer than that of every earlier holder of the scope.
+func (l *EvaluationLocks) Acquire(ctx context.Context, organizationID, scope string) (*EvaluationLease, error) {
+    lease := &EvaluationLease{Scope: scope, locks: l, key: evaluationLockKey(organizationID, scope), owner: newRequestID()}
+
+    for {
+        acquired, err := l.redis.SetNX(ctx, lease.key, lease.owner, l.ttl).Result()
+        if err != nil {
+            return nil, err
+        }
+        if acquired {
+            break
+        }
+        if isBackgroundEvaluation(ctx) {
+            evaluationLockAcquisitions.WithLabelValues("skipped").Inc()
+            log.Printf("Skipping evaluation of %s (%s): another evaluation is in progress", redact(fieldOrganizationID, organizationID), scope)
+            return nil, errEvaluationInProgress
+        }
+
+        lease.Waited = true
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case <-time.After(evaluationLockPoll):
+        }
+    }
+
+    token, err := l.redis.Incr(ctx, evaluationFenceKey(organizationID, scope)).Result()
+    if err != nil {
+        lease.Release(context.WithoutCancel(ctx))
+        return nil, err
+    }
+    lease.Token = token
+    evaluationLockAcquisitions.WithLabelValues(lockOutcome(lease.Waited)).Inc()
+    return lease, nil
+}
+
+// Release the lock if this lease still holds it
+func (l *EvaluationLease) Release(ctx context.Context) {
+    if err := releaseLockScript.Run(ctx, l.locks.redis, []string{l.key}, l.owner).Err(); err != nil {
+        log.Printf("Failed to release evaluation lock %s: %v", l.key, err)
+    }
+}
+
+func lockOutcome(waited bool) string {
+    if waited {
+        return "waited"
+    }
+    return "acquired"
+}
+
+// Scope of an evaluation: its framework selection, "all" when unrestricted
+func evaluationScope(req *ComplianceRequest) string {
+    if len(req.Frameworks) == 0 {
+        return "all"
+    }
+    frameworks := append([]string(nil), req.Frameworks...)
+    sort.Strings(frameworks)
+    return strings.Join(frameworks, ",")
+}
+
+func evaluationLockKey(organizationID, scope string) string {
+    return "eval-lock:" + organizationID + ":" + scope
+}
+
+func evaluationFenceKey(organizationID, scope string) string {
+    return "eval-fence:" + organizationID + ":" + scope
+}
+
+// Marks evaluations started by the job queue or the Kafka consumer, which
+// skip rather than wait when an organization is already being evaluated
+type backgroundEvaluationKey struct{}
+
+func withBackgroundEvaluation(ctx context.Context) context.Context {
+    return context.WithValue(ctx, backgroundEvaluationKey{}, true)
+}
+
+func isBackgroundEvaluation(ctx context.Context) bool {
+    background, _ := ctx.Value(backgroundEvaluationKey{}).(bool)
+    return background
+}
+
+// Evaluation lock metrics
+var (
+    evaluationLockAcquisitions = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_evaluation_lock_total",
+            Help: "Evaluation lock attempts by outcome (acquired, waited, skipped)",
+        },
+        []string{"outcome"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(evaluationLockAcquisitions)
+}
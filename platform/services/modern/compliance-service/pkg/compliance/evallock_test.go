@@ -0,0 +1,189 @@
+package compliance
+
+import (
+    "context"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+func newTestEvaluationLocks(t *testing.T, ttl time.Duration) (*EvaluationLocks, *miniredis.Miniredis) {
+    server := miniredis.RunT(t)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    return NewEvaluationLocks(client, ttl), server
+}
+
+// Wait until a lock key is held, failing the test after a second
+func waitForLock(t *testing.T, server *miniredis.Miniredis, key string) {
+    t.Helper()
+    for deadline := time.Now().Add(time.Second); !server.Exists(key); time.Sleep(time.Millisecond) {
+        if time.Now().After(deadline) {
+            t.Fatalf("%s never locked", key)
+        }
+    }
+}
+
+func TestEvaluationLockInterleavings(t *testing.T) {
+    ctx := context.Background()
+    locks, _ := newTestEvaluationLocks(t, time.Minute)
+    first, err := locks.Acquire(ctx, "org-1", "all")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Background entrants skip while the scope is held
+    before := testutil.ToFloat64(evaluationLockAcquisitions.WithLabelValues("skipped"))
+    if _, err := locks.Acquire(withBackgroundEvaluation(ctx), "org-1", "all"); err != errEvaluationInProgress {
+        t.Errorf("background Acquire() = %v, want errEvaluationInProgress", err)
+    }
+    if got := testutil.ToFloat64(evaluationLockAcquisitions.WithLabelValues("skipped")) - before; got != 1 {
+        t.Errorf("%v skips counted, want 1", got)
+    }
+
+    // Other scopes are not blocked and are fenced separately
+    other, err := locks.Acquire(withBackgroundEvaluation(ctx), "org-1", "NCA")
+    if err != nil {
+        t.Fatalf("Acquire() of another scope = %v", err)
+    }
+    other.Release(ctx)
+    if other.Token != 1 {
+        t.Errorf("NCA scope token %d, want its own counter at 1", other.Token)
+    }
+
+    // Interactive entrants wait for the holder and then take a newer token
+    acquired := make(chan *EvaluationLease)
+    go func() {
+        lease, err := locks.Acquire(ctx, "org-1", "all")
+        if err != nil {
+            t.Error(err)
+        }
+        acquired <- lease
+    }()
+    select {
+    case <-acquired:
+        t.Fatal("interactive Acquire() returned while the lock was held")
+    case <-time.After(3 * evaluationLockPoll):
+    }
+    first.Release(ctx)
+    second := <-acquired
+    if second == nil {
+        return
+    }
+    defer second.Release(ctx)
+    if !second.Waited || second.Token <= first.Token {
+        t.Errorf("second lease waited=%t token %d, want waited with a token above %d", second.Waited, second.Token, first.Token)
+    }
+
+    // A cancelled waiter gives up
+    waitCtx, cancel := context.WithTimeout(ctx, 2*evaluationLockPoll)
+    defer cancel()
+    if _, err := locks.Acquire(waitCtx, "org-1", "all"); err != context.DeadlineExceeded {
+        t.Errorf("Acquire() with a deadline = %v, want context.DeadlineExceeded", err)
+    }
+}
+
+// A holder whose lease expired cannot release the lock a newer holder took
+func TestExpiredLeaseDoesNotReleaseSuccessor(t *testing.T) {
+    ctx := context.Background()
+    locks, server := newTestEvaluationLocks(t, time.Second)
+    stale, err := locks.Acquire(ctx, "org-1", "all")
+    if err != nil {
+        t.Fatal(err)
+    }
+    server.FastForward(2 * time.Second)
+    current, err := locks.Acquire(ctx, "org-1", "all")
+    if err != nil || current.Waited {
+        t.Fatalf("Acquire() after expiry = %v waited=%t, want the lock at once", err, current != nil && current.Waited)
+    }
+
+    stale.Release(ctx)
+    if !server.Exists(evaluationLockKey("org-1", "all")) {
+        t.Error("expired lease released its successor's lock")
+    }
+    current.Release(ctx)
+    if server.Exists(evaluationLockKey("org-1", "all")) {
+        t.Error("lock still held after its holder released it")
+    }
+}
+
+// History writes from a superseded lease are rejected; other scopes and
+// newer tokens write normally
+func TestScoreHistoryRejectsStaleFence(t *testing.T) {
+    ctx := context.Background()
+    history := newTestScoreHistory(t)
+    scored := func(score float64) *ComplianceResponse {
+        return &ComplianceResponse{OrganizationId: "org-1", FrameworkResults: []*FrameworkResult{{Framework: "NCA", Score: score}}}
+    }
+
+    tests := []struct {
+        name  string
+        score float64
+        lease EvaluationLease
+        want  error
+    }{
+        {name: "newer writer", score: 70, lease: EvaluationLease{Token: 2, Scope: "all"}, want: nil},
+        {name: "superseded writer", score: 10, lease: EvaluationLease{Token: 1, Scope: "all"}, want: errStaleFence},
+        {name: "other scope", score: 80, lease: EvaluationLease{Token: 1, Scope: "NCA"}, want: nil},
+        {name: "same token again", score: 90, lease: EvaluationLease{Token: 2, Scope: "all"}, want: nil},
+    }
+    for _, tt := range tests {
+        if err := history.Record(ctx, scored(tt.score), &tt.lease); err != tt.want {
+            t.Errorf("%s: Record() = %v, want %v", tt.name, err, tt.want)
+        }
+    }
+
+    response := scored(0)
+    if err := history.AttachTrends(ctx, response, 10); err != nil {
+        t.Fatal(err)
+    }
+    if got, want := response.FrameworkResults[0].Trend, []float64{70, 80, 90}; !reflect.DeepEqual(got, want) {
+        t.Errorf("history %v, want %v without the superseded score", got, want)
+    }
+}
+
+// Concurrent checks of one organization: an interactive caller waits for
+// the running evaluation and serves its result, a background one skips
+func TestConcurrentChecksShareOneEvaluation(t *testing.T) {
+    service, server := newTestService(t)
+    service.faults.Set("SAMA", Fault{Delay: 200 * time.Millisecond})
+    ctx := context.Background()
+
+    type outcome struct {
+        response *ComplianceResponse
+        err      error
+    }
+    first := make(chan outcome)
+    go func() {
+        response, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+        first <- outcome{response, err}
+    }()
+    waitForLock(t, server, evaluationLockKey("org-1", "all"))
+
+    _, err := service.CheckCompliance(withBackgroundEvaluation(ctx), &ComplianceRequest{OrganizationId: "org-1"})
+    if status.Code(err) != codes.Aborted || errorReason(status.Convert(err)) != reasonEvaluationInProgress {
+        t.Errorf("background check = %v, want Aborted %s", err, reasonEvaluationInProgress)
+    }
+
+    waited := testutil.ToFloat64(evaluationLockAcquisitions.WithLabelValues("waited"))
+    second, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    done := <-first
+    if done.err != nil {
+        t.Fatal(done.err)
+    }
+    if got := testutil.ToFloat64(evaluationLockAcquisitions.WithLabelValues("waited")) - waited; got != 1 {
+        t.Errorf("%v waits counted, want 1", got)
+    }
+    if second.ContentHash != done.response.ContentHash || second.Timestamp != done.response.Timestamp {
+        t.Errorf("waiting check answered %s at %d, want the running evaluation's %s at %d", second.ContentHash, second.Timestamp, done.response.ContentHash, done.response.Timestamp)
+    }
+}
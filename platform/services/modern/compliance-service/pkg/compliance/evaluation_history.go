@@ -0,0 +1,257 @@
+package compliance
+
+import (
+    "context"
+    "encoding/base64"
+    "fmt"
+    "log"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// What is persisted per evaluation
+const (
+    evaluationFormatFull    = "FULL"    // Request, response and provenance
+    evaluationFormatSummary = "SUMMARY" // Organization, timestamp, status and scores
+)
+
+// History page sizes
+const (
+    historyDefaultLimit = 50
+    historyMaxLimit     = 500
+)
+
+func validEvaluationFormat(format string) error {
+    switch format {
+    case "", evaluationFormatFull, evaluationFormatSummary:
+        return nil
+    }
+    return fmt.Errorf("unknown history format %q, want %s or %s", format, evaluationFormatFull, evaluationFormatSummary)
+}
+
+// Reduce a record to what the summary format keeps
+func summarizeEvaluation(record *EvaluationRecord) *EvaluationRecord {
+    response := record.Response
+    summary := &ComplianceResponse{
+        OrganizationId: response.OrganizationId,
+        Timestamp:      response.Timestamp,
+        OverallScore:   response.OverallScore,
+        Status:         response.Status,
+        ContentHash:    response.ContentHash,
+        SchemaVersion:  response.SchemaVersion,
+    }
+    for _, result := range response.FrameworkResults {
+        summary.FrameworkResults = append(summary.FrameworkResults, &FrameworkResult{
+            Framework: result.Framework,
+            Score:     result.Score,
+            Outcome:   result.Outcome,
+        })
+    }
+    return &EvaluationRecord{
+        RequestId:      record.RequestId,
+        Request:        &ComplianceRequest{OrganizationId: record.Request.OrganizationId, OnBehalfOf: record.Request.OnBehalfOf},
+        Response:       summary,
+        RulesetVersion: record.RulesetVersion,
+        Format:         evaluationFormatSummary,
+        Principal:      record.Principal,
+    }
+}
+
+// FailedPrecondition for a record stored as a summary, which cannot be put
+// to a use that needs its evidence or details
+func requireFullRecord(record *EvaluationRecord, use string) error {
+    if record.Format == evaluationFormatSummary {
+        return status.Errorf(codes.FailedPrecondition, "evaluation %s was stored as a summary and cannot be %s", record.RequestId, use)
+    }
+    return nil
+}
+
+// historyCursor - position after the last run of a history page: its
+// evaluation time and run ID, ties on the time being ordered by ID as the
+// index orders them, descending
+type historyCursor struct {
+    timestamp int64
+    runID     string
+}
+
+func (c historyCursor) token() string {
+    return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.timestamp, 10) + "|" + c.runID))
+}
+
+func parseHistoryCursor(token string) (historyCursor, error) {
+    raw, err := base64.RawURLEncoding.DecodeString(token)
+    if err != nil {
+        return historyCursor{}, err
+    }
+    timestamp, runID, ok := strings.Cut(string(raw), "|")
+    if !ok || runID == "" {
+        return historyCursor{}, fmt.Errorf("malformed cursor")
+    }
+    cursor := historyCursor{runID: runID}
+    if cursor.timestamp, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+        return historyCursor{}, err
+    }
+    return cursor, nil
+}
+
+// Stored evaluations of an organization made within [from, to), newest
+// first, after the cursor when given; records that expired ahead of the
+// index are left out. With a positive limit, the cursor past the last run
+// returned is nil unless more runs follow.
+func (e *EvaluationStore) History(ctx context.Context, organizationID string, from, to time.Time, after *historyCursor, limit int64) ([]*EvaluationRecord, *historyCursor, error) {
+    index := evaluationIndexKey(organizationID)
+    lower := strconv.FormatInt(from.Unix(), 10)
+    upper := "(" + strconv.FormatInt(to.Unix(), 10)
+    var entries []redis.Z
+    if after != nil {
+        // Runs sharing the cursor's time that sort after it, then older ones
+        at := strconv.FormatInt(after.timestamp, 10)
+        ties, err := e.redis.ZRevRangeByScoreWithScores(ctx, index, &redis.ZRangeBy{Min: at, Max: at}).Result()
+        if err != nil {
+            return nil, nil, storeError(err, "failed to look up evaluations")
+        }
+        for _, entry := range ties {
+            if entry.Member.(string) < after.runID {
+                entries = append(entries, entry)
+            }
+        }
+        upper = "(" + at
+    }
+    // One more than the page holds tells whether another follows
+    count := limit + 1 - int64(len(entries))
+    if limit <= 0 {
+        count = 0
+    }
+    if limit <= 0 || count > 0 {
+        older, err := e.redis.ZRevRangeByScoreWithScores(ctx, index, &redis.ZRangeBy{Min: lower, Max: upper, Count: count}).Result()
+        if err != nil {
+            return nil, nil, storeError(err, "failed to look up evaluations")
+        }
+        entries = append(entries, older...)
+    }
+
+    var next *historyCursor
+    if limit > 0 && int64(len(entries)) > limit {
+        entries = entries[:limit]
+        last := entries[len(entries)-1]
+        next = &historyCursor{timestamp: int64(last.Score), runID: last.Member.(string)}
+    }
+    if len(entries) == 0 {
+        return nil, nil, nil
+    }
+    ids := make([]string, len(entries))
+    for i, entry := range entries {
+        ids[i] = entry.Member.(string)
+    }
+    keys := make([]string, len(ids))
+    for i, id := range ids {
+        keys[i] = evaluationRecordKey(organizationID, id)
+    }
+    values, err := e.redis.MGet(ctx, keys...).Result()
+    if err != nil {
+        return nil, nil, storeError(err, "failed to load evaluations")
+    }
+
+    records := make([]*EvaluationRecord, 0, len(values))
+    for i, value := range values {
+        data, ok := value.(string)
+        if !ok {
+            continue
+        }
+        record := &EvaluationRecord{}
+        if err := proto.Unmarshal([]byte(data), record); err != nil {
+            log.Printf("Ignoring undecodable evaluation %s: %v", ids[i], err)
+            continue
+        }
+        records = append(records, record)
+    }
+    return records, next, nil
+}
+
+// GetComplianceHistory - an organization's stored evaluations, newest
+// first, summarized the same way whether stored in full or as a summary
+func (s *ComplianceService) GetComplianceHistory(ctx context.Context, req *ComplianceHistoryRequest) (*ComplianceHistoryResponse, error) {
+    if req.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+    }
+    if req.From < 0 || req.To < 0 || (req.To > 0 && req.From >= req.To) {
+        return nil, status.Error(codes.InvalidArgument, "range must satisfy 0 <= from < to")
+    }
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+
+    now := time.Now()
+    from, to := now.Add(-s.config.ReplayRetention), now.Add(time.Second)
+    if req.From > 0 {
+        from = time.Unix(req.From, 0)
+    }
+    if req.To > 0 {
+        to = time.Unix(req.To, 0)
+    }
+    limit := int64(req.Limit)
+    if limit <= 0 {
+        limit = historyDefaultLimit
+    }
+    if limit > historyMaxLimit {
+        limit = historyMaxLimit
+    }
+
+    var after *historyCursor
+    if req.PageToken != "" {
+        cursor, err := parseHistoryCursor(req.PageToken)
+        if err != nil {
+            return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+        }
+        // The run the cursor points past has aged out of retention
+        if cursor.timestamp < now.Add(-s.config.ReplayRetention).Unix() {
+            return nil, status.Error(codes.InvalidArgument, "page_token expired; list the history again from the first page")
+        }
+        // A cursor of another range would skip or repeat runs of this one
+        if cursor.timestamp < from.Unix() || cursor.timestamp >= to.Unix() {
+            return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+        }
+        after = &cursor
+    }
+
+    records, next, err := s.replays.History(ctx, organizationID, from, to, after, limit)
+    if err != nil {
+        return nil, err
+    }
+    response := &ComplianceHistoryResponse{Runs: make([]*ComplianceRunSummary, 0, len(records))}
+    if next != nil {
+        response.NextPageToken = next.token()
+    }
+    for _, record := range records {
+        run := &ComplianceRunSummary{
+            RequestId:       record.RequestId,
+            Timestamp:       record.Response.GetTimestamp(),
+            OverallScore:    record.Response.GetOverallScore(),
+            Status:          record.Response.GetStatus(),
+            FrameworkScores: make(map[string]float64),
+            RulesetVersion:  record.RulesetVersion,
+            Format:          defaultString(record.Format, evaluationFormatFull),
+            ContentHash:     record.Response.GetContentHash(),
+            Principal:       record.Principal,
+            OnBehalfOf:      record.Request.GetOnBehalfOf(),
+        }
+        for _, result := range record.Response.GetFrameworkResults() {
+            if scoredResult(result) {
+                run.FrameworkScores[result.Framework] = result.Score
+            }
+        }
+        response.Runs = append(response.Runs, run)
+    }
+    log.Printf("Audit: rpc=GetComplianceHistory org=%s runs=%d", redact(fieldOrganizationID, organizationID), len(response.Runs))
+    return response, nil
+}
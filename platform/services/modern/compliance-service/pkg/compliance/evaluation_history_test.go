@@ -0,0 +1,188 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Evaluations stored in either format come back from the history with the
+// scores and content hash they were evaluated at; only a full record keeps
+// the request's evidence and can be replayed
+func TestEvaluationHistoryFormatsRoundTrip(t *testing.T) {
+    tests := []struct {
+        name         string
+        format       string
+        wantFormat   string
+        wantEvidence int
+        wantReplay   codes.Code
+    }{
+        {name: "unset", format: "", wantFormat: evaluationFormatFull, wantEvidence: 1, wantReplay: codes.OK},
+        {name: "full", format: evaluationFormatFull, wantFormat: evaluationFormatFull, wantEvidence: 1, wantReplay: codes.OK},
+        {name: "summary", format: evaluationFormatSummary, wantFormat: evaluationFormatSummary, wantReplay: codes.FailedPrecondition},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service, _ := newTestService(t, func(config *ServiceConfig) {
+                config.ComputeCacheTTL = 0
+                config.HistoryFormat = tt.format
+            })
+            spy := spyOnCheckers(service)
+            spy.setScore("SAMA", 62.5)
+            ctx := context.Background()
+            evaluated, err := service.CheckCompliance(ctx, &ComplianceRequest{
+                OrganizationId: "org-1",
+                Evidence:       []*EvidenceItem{{Key: "aml_program", Value: "true"}},
+            })
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            history, err := service.GetComplianceHistory(ctx, &ComplianceHistoryRequest{OrganizationId: "org-1"})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(history.Runs) != 1 {
+                t.Fatalf("history has %d runs, want 1", len(history.Runs))
+            }
+            run := history.Runs[0]
+            if run.RequestId != evaluated.RunId || run.Format != tt.wantFormat {
+                t.Errorf("run %s in format %s, want %s in %s", run.RequestId, run.Format, evaluated.RunId, tt.wantFormat)
+            }
+            if run.Timestamp != evaluated.Timestamp || run.OverallScore != evaluated.OverallScore || run.Status != evaluated.Status {
+                t.Errorf("run at %d scored %v %s, want %d scored %v %s", run.Timestamp, run.OverallScore, run.Status,
+                    evaluated.Timestamp, evaluated.OverallScore, evaluated.Status)
+            }
+            if evaluated.ContentHash == "" || run.ContentHash != evaluated.ContentHash {
+                t.Errorf("run content hash %q, want %q", run.ContentHash, evaluated.ContentHash)
+            }
+            for _, result := range evaluated.FrameworkResults {
+                if score, ok := run.FrameworkScores[result.Framework]; ok != scoredResult(result) || score != result.Score {
+                    t.Errorf("%s history score %v, want %v", result.Framework, score, result.Score)
+                }
+            }
+
+            record, err := service.replays.Load(ctx, "org-1", evaluated.RunId)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if record.Response.ContentHash != evaluated.ContentHash {
+                t.Errorf("record content hash %q, want %q", record.Response.ContentHash, evaluated.ContentHash)
+            }
+            if len(record.Request.Evidence) != tt.wantEvidence {
+                t.Errorf("record keeps %d evidence items, want %d", len(record.Request.Evidence), tt.wantEvidence)
+            }
+            _, err = service.ReplayCompliance(ctx, &ReplayRequest{RequestId: evaluated.RunId, OrganizationId: "org-1"})
+            if status.Code(err) != tt.wantReplay {
+                t.Errorf("ReplayCompliance() = %v, want %s", err, tt.wantReplay)
+            }
+        })
+    }
+}
+
+// Paging the history visits every run once, newest first, including runs
+// evaluated in the same second; tokens that do not parse, point outside
+// the range or past retention are refused
+func TestComplianceHistoryPages(t *testing.T) {
+    service, _ := newTestService(t)
+    ctx := context.Background()
+    now := time.Now().Unix()
+    runs := []struct {
+        id        string
+        timestamp int64
+    }{
+        {"run-a", now - 10}, {"run-b", now - 10}, {"run-c", now - 10},
+        {"run-d", now - 70}, {"run-e", now - 130},
+    }
+    for _, run := range runs {
+        req := &ComplianceRequest{OrganizationId: "org-1"}
+        response := &ComplianceResponse{OrganizationId: "org-1", Timestamp: run.timestamp, Status: "COMPLIANT"}
+        if err := service.replays.Store(ctx, run.id, DefaultRulesetVersion, "tenant:"+defaultTenant, req, response); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    client := dialTestService(t, service, &payloadSizes{})
+    it := NewHistoryIterator(ctx, client, &ComplianceHistoryRequest{OrganizationId: "org-1", Limit: 2})
+    var ids []string
+    pages := make(map[*ComplianceHistoryResponse]bool)
+    for it.Next() {
+        ids = append(ids, it.Item().RequestId)
+        pages[it.Page()] = true
+    }
+    if it.Err() != nil {
+        t.Fatal(it.Err())
+    }
+    if got := strings.Join(ids, ","); got != "run-c,run-b,run-a,run-d,run-e" || len(pages) != 3 {
+        t.Errorf("read %s over %d pages, want every run newest first over 3", got, len(pages))
+    }
+
+    tests := []struct {
+        name      string
+        token     string
+        from      int64
+        wantError string
+    }{
+        {name: "not a cursor", token: "not-a-cursor", wantError: "invalid page_token"},
+        {name: "past retention", token: historyCursor{timestamp: now - int64(service.config.ReplayRetention/time.Second) - 60, runID: "run-z"}.token(), wantError: "expired"},
+        {name: "outside the range", token: historyCursor{timestamp: now - 130, runID: "run-e"}.token(), from: now - 100, wantError: "invalid page_token"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := service.GetComplianceHistory(ctx, &ComplianceHistoryRequest{OrganizationId: "org-1", From: tt.from, PageToken: tt.token})
+            if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), tt.wantError) {
+                t.Errorf("GetComplianceHistory() = %v, want InvalidArgument %q", err, tt.wantError)
+            }
+        })
+    }
+}
+
+// A delegated check's history row names the end user it was performed
+// for, with the delegate principal that asked, in either format; a
+// tenant's own check names only the tenant
+func TestComplianceHistoryRequestedBy(t *testing.T) {
+    endUser := &Actor{SubjectId: "user-42", DisplayName: "Amal Saleh"}
+    tests := []struct {
+        name          string
+        format        string
+        ctx           context.Context
+        onBehalfOf    *Actor
+        wantPrincipal string
+    }{
+        {name: "delegated, full", format: evaluationFormatFull, ctx: adminContext("portal-secret"), onBehalfOf: endUser, wantPrincipal: "portal"},
+        {name: "delegated, summary", format: evaluationFormatSummary, ctx: adminContext("portal-secret"), onBehalfOf: endUser, wantPrincipal: "portal"},
+        {name: "tenant's own", format: evaluationFormatSummary, ctx: context.Background(), wantPrincipal: "tenant:" + defaultTenant},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service, _ := newTestService(t, func(config *ServiceConfig) {
+                config.ComputeCacheTTL = 0
+                config.HistoryFormat = tt.format
+                config.DelegatePrincipals = "portal:portal-secret"
+            })
+            if _, err := service.CheckCompliance(tt.ctx, &ComplianceRequest{OrganizationId: "org-1", OnBehalfOf: tt.onBehalfOf}); err != nil {
+                t.Fatal(err)
+            }
+
+            history, err := service.GetComplianceHistory(context.Background(), &ComplianceHistoryRequest{OrganizationId: "org-1"})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(history.Runs) != 1 {
+                t.Fatalf("history has %d runs, want 1", len(history.Runs))
+            }
+            run := history.Runs[0]
+            if run.Principal != tt.wantPrincipal {
+                t.Errorf("run requested by %q, want %q", run.Principal, tt.wantPrincipal)
+            }
+            if !proto.Equal(run.OnBehalfOf, tt.onBehalfOf) {
+                t.Errorf("run performed for %v, want %v", run.OnBehalfOf, tt.onBehalfOf)
+            }
+        })
+    }
+}
@@ -0,0 +1,452 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/events"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+)
+
+// Event payload schema versions
+const (
+    eventSchemaV1 = 1
+    eventSchemaV2 = 2
+)
+
+// Header carrying the payload schema version on every event
+const eventVersionHeader = "schema-version"
+
+// Topic for compliance result events
+const resultsTopic = "compliance-results"
+
+// EventConfig - which results are published, in which payload versions and
+// how they are routed
+type EventConfig struct {
+    Mode    string `env:"EVENT_PAYLOAD_MODE"`    // emit-v1, emit-v2 or emit-both
+    Routing string `env:"EVENT_VERSION_ROUTING"` // header: one topic with a version header; topic: one topic per version
+
+    // Identical results (same organization and content hash) published
+    // again within this window are suppressed; 0 disables deduplication
+    DedupeWindow time.Duration `env:"EVENT_DEDUPE_WINDOW"`
+
+    // every publishes each result; crossing only when an organization's
+    // overall score moves into another band. Bands are split at the
+    // boundaries, e.g. "90,70", or are the status bands when none are set.
+    // In crossing mode an organization whose band holds is still published
+    // once per heartbeat interval; 0 disables heartbeats.
+    PublishOn          string        `env:"EVENT_PUBLISH_ON"`
+    CrossingBoundaries string        `env:"EVENT_CROSSING_BOUNDARIES"`
+    HeartbeatInterval  time.Duration `env:"EVENT_HEARTBEAT_INTERVAL"`
+
+    // Events whose publish fails are spooled and replayed in order: up to
+    // SpoolMemoryEvents in memory, older ones in segment files under
+    // SpoolDir, capped at SpoolMaxDiskBytes. Without a dir events beyond the
+    // memory limit are dropped.
+    SpoolMemoryEvents  int           `env:"EVENT_SPOOL_MEMORY_EVENTS"`
+    SpoolDir           string        `env:"EVENT_SPOOL_DIR"`
+    SpoolSegmentBytes  int           `env:"EVENT_SPOOL_SEGMENT_BYTES"`
+    SpoolMaxDiskBytes  int           `env:"EVENT_SPOOL_MAX_DISK_BYTES"`
+    SpoolRetryInterval time.Duration `env:"EVENT_SPOOL_RETRY_INTERVAL"`
+}
+
+// Event publishing modes
+const (
+    publishOnEvery    = "every"
+    publishOnCrossing = "crossing"
+)
+
+// How long an organization's last published band is remembered; a band
+// forgotten by then publishes as if seen for the first time
+const bandStateTTL = 30 * 24 * time.Hour
+
+// Outcomes of the band check, as returned by bandCheckScript
+const (
+    bandWithin    = 0
+    bandInitial   = 1
+    bandCrossed   = 2
+    bandHeartbeat = 3
+)
+
+// Record an organization's band and decide whether its result is published:
+// on its first result, when the band changed, or once the heartbeat
+// interval passed since the last publish.
+//
+// KEYS[1] - band state hash of the organization (fields band, published_at)
+// ARGV[1] - current band
+// ARGV[2] - now, Unix milliseconds
+// ARGV[3] - heartbeat interval in milliseconds, 0 for none
+// ARGV[4] - state TTL in milliseconds
+//
+// Returns {outcome, previous band}; the band is -1 when none was recorded.
+var bandCheckScript = redis.NewScript(`
+local state = redis.call('HMGET', KEYS[1], 'band', 'published_at')
+local previous = tonumber(state[1])
+local publishedAt = tonumber(state[2]) or 0
+local band = tonumber(ARGV[1])
+local now = tonumber(ARGV[2])
+local heartbeat = tonumber(ARGV[3])
+
+local outcome = 0
+if previous == nil then
+    outcome = 1
+elseif previous ~= band then
+    outcome = 2
+elseif heartbeat > 0 and now - publishedAt >= heartbeat then
+    outcome = 3
+end
+
+if outcome == 0 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[4])
+else
+    redis.call('HSET', KEYS[1], 'band', band, 'published_at', now)
+    redis.call('PEXPIRE', KEYS[1], ARGV[4])
+end
+return {outcome, previous or -1}
+`)
+
+// ComplianceEvent - everything an event payload may be rendered from
+type ComplianceEvent struct {
+    Response    *ComplianceResponse
+    Evidence    map[string]*FrameworkEvidenceStatus
+    Degradation []DegradationEntry
+    RequestedBy *RequestedBy
+    Transition  *StatusTransition // Set when the run changed the status
+    Region      string
+    Locale      string
+}
+
+// DegradationEntry - a framework that could not be evaluated normally
+type DegradationEntry struct {
+    Framework string `json:"framework"`
+    Reason    string `json:"reason"`
+}
+
+// ComplianceEventV2 - v2 payload: v1 plus control findings, coverage and degradation
+type ComplianceEventV2 struct {
+    SchemaVersion  int                 `json:"schema_version"`
+    OrganizationID string              `json:"organization_id"`
+    Timestamp      int64               `json:"timestamp"`
+    OverallScore   float64             `json:"overall_score"`
+    Status         string              `json:"status"`
+    Frameworks     []FrameworkEventV2  `json:"frameworks"`
+    Degradation    []DegradationEntry  `json:"degradation"`
+    RequestedBy    *RequestedBy        `json:"requested_by,omitempty"`
+    Transition     *StatusTransitionV2 `json:"status_transition,omitempty"`
+    Region         string              `json:"region,omitempty"`
+    Locale         string              `json:"locale,omitempty"` // Consumers render notifications in it
+}
+
+// StatusTransitionV2 - the status change a run made, with its primary cause
+type StatusTransitionV2 struct {
+    OldStatus      string `json:"old_status"`
+    NewStatus      string `json:"new_status"`
+    RunID          string `json:"run_id"`
+    CauseKind      string `json:"cause_kind"`
+    CauseFramework string `json:"cause_framework,omitempty"`
+    CauseSubject   string `json:"cause_subject,omitempty"`
+    CauseDetail    string `json:"cause_detail,omitempty"`
+}
+
+// FrameworkEventV2 - per-framework section of the v2 payload
+type FrameworkEventV2 struct {
+    Framework string             `json:"framework"`
+    Score     float64            `json:"score"`
+    Coverage  float64            `json:"coverage"`
+    Controls  []ControlFindingV2 `json:"controls"`
+}
+
+// ControlFindingV2 - outcome of a single control requirement
+type ControlFindingV2 struct {
+    ID     string `json:"id"`
+    Status string `json:"status"` // MET or MISSING
+}
+
+// EncodeEvent renders an event in the requested payload schema version.
+// v1 is the historical shape: the response encoded as-is.
+func EncodeEvent(version int, event *ComplianceEvent) ([]byte, error) {
+    switch version {
+    case eventSchemaV1:
+        return json.Marshal(event.Response)
+    case eventSchemaV2:
+        return json.Marshal(newComplianceEventV2(event))
+    }
+    return nil, fmt.Errorf("unsupported event schema version %d", version)
+}
+
+func newComplianceEventV2(event *ComplianceEvent) *ComplianceEventV2 {
+    response := event.Response
+    payload := &ComplianceEventV2{
+        SchemaVersion:  eventSchemaV2,
+        OrganizationID: response.OrganizationId,
+        Timestamp:      response.Timestamp,
+        OverallScore:   response.OverallScore,
+        Status:         response.Status,
+        Frameworks:     []FrameworkEventV2{},
+        Degradation:    event.Degradation,
+        RequestedBy:    event.RequestedBy,
+        Region:         event.Region,
+        Locale:         event.Locale,
+    }
+    if payload.Degradation == nil {
+        payload.Degradation = []DegradationEntry{}
+    }
+    if transition := event.Transition; transition != nil {
+        payload.Transition = &StatusTransitionV2{
+            OldStatus:      transition.OldStatus,
+            NewStatus:      transition.NewStatus,
+            RunID:          transition.RunId,
+            CauseKind:      transition.Cause.GetKind(),
+            CauseFramework: transition.Cause.GetFramework(),
+            CauseSubject:   transition.Cause.GetSubject(),
+            CauseDetail:    transition.Cause.GetDetail(),
+        }
+    }
+
+    for _, result := range response.FrameworkResults {
+        framework := FrameworkEventV2{
+            Framework: result.Framework,
+            Score:     result.Score,
+            Controls:  []ControlFindingV2{},
+        }
+        if evidence, ok := event.Evidence[result.Framework]; ok {
+            framework.Coverage = evidence.EstimatedCoverage
+            missing := make(map[string]bool, len(evidence.MissingKeys))
+            for _, key := range evidence.MissingKeys {
+                missing[key] = true
+            }
+            for _, requirement := range builtinEvidenceRequirements[result.Framework] {
+                finding := ControlFindingV2{ID: requirement.Key, Status: "MET"}
+                if missing[requirement.Key] {
+                    finding.Status = "MISSING"
+                }
+                framework.Controls = append(framework.Controls, finding)
+            }
+        }
+        payload.Frameworks = append(payload.Frameworks, framework)
+    }
+    return payload
+}
+
+// Where events and consumer responses are published; an *events.Producer
+// outside tests
+type messagePublisher interface {
+    PublishMessage(ctx context.Context, topic string, key, value []byte, headers map[string]string) error
+}
+
+// EventPublisher - encodes and publishes events per the configured versions
+type EventPublisher struct {
+    producer   messagePublisher
+    redis      *redis.Client
+    config     EventConfig
+    versions   []int
+    boundaries []float64 // Ascending; nil uses the status bands
+    spool      *events.Spool
+}
+
+// Create an event publisher; an unknown mode is a configuration error
+func NewEventPublisher(producer *events.Producer, client *redis.Client, config EventConfig) (*EventPublisher, error) {
+    var versions []int
+    switch config.Mode {
+    case "", "emit-v1":
+        versions = []int{eventSchemaV1}
+    case "emit-v2":
+        versions = []int{eventSchemaV2}
+    case "emit-both":
+        versions = []int{eventSchemaV1, eventSchemaV2}
+    default:
+        return nil, fmt.Errorf("invalid event payload mode %q", config.Mode)
+    }
+    if config.Routing != "" && config.Routing != "header" && config.Routing != "topic" {
+        return nil, fmt.Errorf("invalid event routing %q", config.Routing)
+    }
+    if config.DedupeWindow < 0 {
+        return nil, fmt.Errorf("event dedupe window must not be negative")
+    }
+    if config.PublishOn != "" && config.PublishOn != publishOnEvery && config.PublishOn != publishOnCrossing {
+        return nil, fmt.Errorf("invalid event publish mode %q", config.PublishOn)
+    }
+    if config.HeartbeatInterval < 0 {
+        return nil, fmt.Errorf("event heartbeat interval must not be negative")
+    }
+    boundaries, err := parseBandBoundaries(config.CrossingBoundaries)
+    if err != nil {
+        return nil, err
+    }
+    if config.SpoolMemoryEvents < 1 || config.SpoolSegmentBytes < 1 || config.SpoolMaxDiskBytes < 0 || config.SpoolRetryInterval <= 0 {
+        return nil, fmt.Errorf("event spool memory events, segment bytes and retry interval must be positive")
+    }
+    spool, err := events.NewSpool(config.SpoolMemoryEvents, config.SpoolDir, int64(config.SpoolSegmentBytes), int64(config.SpoolMaxDiskBytes))
+    if err != nil {
+        return nil, err
+    }
+    return &EventPublisher{producer: producer, redis: client, config: config, versions: versions, boundaries: boundaries, spool: spool}, nil
+}
+
+// Parse "90,70" into ascending score boundaries within [0, 100]
+func parseBandBoundaries(value string) ([]float64, error) {
+    var boundaries []float64
+    for _, entry := range strings.Split(value, ",") {
+        if entry = strings.TrimSpace(entry); entry == "" {
+            continue
+        }
+        boundary, err := strconv.ParseFloat(entry, 64)
+        if err != nil || boundary < 0 || boundary > 100 {
+            return nil, fmt.Errorf("invalid event crossing boundary %q, expected a score within [0, 100]", entry)
+        }
+        boundaries = append(boundaries, boundary)
+    }
+    sort.Float64s(boundaries)
+    return boundaries, nil
+}
+
+// Band of a response: boundaries at or below its overall score, or its
+// status rank when no boundaries are configured
+func (p *EventPublisher) band(response *ComplianceResponse) int {
+    if len(p.boundaries) == 0 {
+        return statusRank(response.Status)
+    }
+    return sort.Search(len(p.boundaries), func(i int) bool { return p.boundaries[i] > response.OverallScore })
+}
+
+// Publish the event in every configured version, unless an identical
+// result was published within the dedupe window or, when publishing on
+// crossings, the organization stayed in its band
+func (p *EventPublisher) Publish(ctx context.Context, topic string, event *ComplianceEvent) {
+    if p.duplicate(ctx, topic, event.Response) {
+        eventsSuppressed.WithLabelValues(topic).Inc()
+        return
+    }
+    if !p.bandChanged(ctx, topic, event.Response) {
+        return
+    }
+    key := []byte(event.Response.OrganizationId)
+    for _, version := range p.versions {
+        value, err := EncodeEvent(version, event)
+        if err != nil {
+            log.Printf("Failed to encode v%d event: %v", version, err)
+            continue
+        }
+
+        target := topic
+        if p.config.Routing == "topic" && version != eventSchemaV1 {
+            target = versionedTopic(topic, version)
+        }
+        headers := map[string]string{eventVersionHeader: strconv.Itoa(version)}
+        p.publish(ctx, &events.Message{Topic: target, Key: key, Value: value, Headers: headers, EnqueuedAt: time.Now()})
+    }
+}
+
+// Publish a message, spooling it when the publish fails or earlier events
+// are still spooled, so an organization's events are not reordered
+func (p *EventPublisher) publish(ctx context.Context, message *events.Message) {
+    if p.spool.Pending() {
+        p.spool.Enqueue(message)
+        return
+    }
+    if err := p.producer.PublishMessage(ctx, message.Topic, message.Key, message.Value, message.Headers); err != nil {
+        log.Printf("Failed to publish event to %s, spooling for replay: %v", message.Topic, err)
+        p.spool.Enqueue(message)
+    }
+}
+
+// Run - replay spooled events until ctx is done
+func (p *EventPublisher) Run(ctx context.Context) {
+    p.spool.Run(ctx, p.config.SpoolRetryInterval, func(ctx context.Context, message *events.Message) error {
+        return p.producer.PublishMessage(ctx, message.Topic, message.Key, message.Value, message.Headers)
+    })
+}
+
+// Claim the (organization, content hash) pair for the dedupe window, shared
+// across replicas; false means publish. Redis errors fail open, as a
+// duplicate is preferable to a lost event.
+func (p *EventPublisher) duplicate(ctx context.Context, topic string, response *ComplianceResponse) bool {
+    if p.config.DedupeWindow <= 0 || response.ContentHash == "" {
+        return false
+    }
+    key := "event-dedupe:" + topic + ":" + response.OrganizationId + ":" + response.ContentHash
+    claimed, err := p.redis.SetNX(ctx, key, 1, p.config.DedupeWindow).Result()
+    if err != nil {
+        log.Printf("Event dedupe unavailable, publishing: %v", err)
+        return false
+    }
+    return !claimed
+}
+
+// In crossing mode, record the organization's band shared across replicas;
+// true means publish. Redis errors fail open, like deduplication.
+func (p *EventPublisher) bandChanged(ctx context.Context, topic string, response *ComplianceResponse) bool {
+    if p.config.PublishOn != publishOnCrossing {
+        return true
+    }
+    band := p.band(response)
+    key := "event-band:" + topic + ":" + response.OrganizationId
+    result, err := bandCheckScript.Run(ctx, p.redis, []string{key}, band, time.Now().UnixMilli(), p.config.HeartbeatInterval.Milliseconds(), bandStateTTL.Milliseconds()).Int64Slice()
+    if err != nil {
+        log.Printf("Event band state unavailable, publishing: %v", err)
+        return true
+    }
+
+    switch result[0] {
+    case bandWithin:
+        eventBandDecisions.WithLabelValues(topic, "within_band").Inc()
+        return false
+    case bandInitial:
+        eventBandDecisions.WithLabelValues(topic, "initial").Inc()
+    case bandCrossed:
+        eventBandDecisions.WithLabelValues(topic, "crossed").Inc()
+        direction := "up"
+        if int64(band) < result[1] {
+            direction = "down"
+        }
+        eventBandCrossings.WithLabelValues(topic, direction).Inc()
+    case bandHeartbeat:
+        eventBandDecisions.WithLabelValues(topic, "heartbeat").Inc()
+    }
+    return true
+}
+
+// Versioned topic name; v1 keeps the original topic for existing consumers
+func versionedTopic(topic string, version int) string {
+    return strings.Join([]string{topic, "v" + strconv.Itoa(version)}, ".")
+}
+
+// Event metrics
+var (
+    eventsSuppressed = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_events_suppressed_total",
+            Help: "Events not published because an identical result was published within the dedupe window",
+        },
+        []string{"topic"},
+    )
+
+    eventBandDecisions = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_event_band_decisions_total",
+            Help: "Results checked in crossing mode, by decision: initial, crossed and heartbeat publish, within_band does not",
+        },
+        []string{"topic", "decision"},
+    )
+
+    eventBandCrossings = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_event_band_crossings_total",
+            Help: "Organizations whose overall score moved into another band, by direction",
+        },
+        []string{"topic", "direction"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(eventsSuppressed)
+    prometheus.MustRegister(eventBandDecisions)
+    prometheus.MustRegister(eventBandCrossings)
+}
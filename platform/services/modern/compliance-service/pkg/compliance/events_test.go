@@ -0,0 +1,267 @@
+package compliance
+
+import (
+    "bytes"
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/redis/go-redis/v9"
+)
+
+// Event publisher on miniredis publishing to a fake, configured from the
+// environment defaults
+func newTestEventPublisher(t *testing.T, configure func(*EventConfig)) (*EventPublisher, *fakePublisher, *miniredis.Miniredis) {
+    server := miniredis.RunT(t)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    config := ConfigFromEnv().Events
+    configure(&config)
+    publisher, err := NewEventPublisher(nil, client, config)
+    if err != nil {
+        t.Fatal(err)
+    }
+    fake := &fakePublisher{}
+    publisher.producer = fake
+    return publisher, fake, server
+}
+
+func resultEvent(org, contentHash string) *ComplianceEvent {
+    return &ComplianceEvent{Response: &ComplianceResponse{OrganizationId: org, ContentHash: contentHash, Status: "COMPLIANT", OverallScore: 90}}
+}
+
+func TestEventDedupeWindow(t *testing.T) {
+    publisher, fake, server := newTestEventPublisher(t, func(config *EventConfig) {
+        config.DedupeWindow = time.Minute
+    })
+    ctx := context.Background()
+    published := func() int {
+        fake.mu.Lock()
+        defer fake.mu.Unlock()
+        return len(fake.messages)
+    }
+
+    before := testutil.ToFloat64(eventsSuppressed.WithLabelValues(resultsTopic))
+    for i := 0; i < 5; i++ {
+        publisher.Publish(ctx, resultsTopic, resultEvent("org-1", "hash-a"))
+    }
+    if got := published(); got != 1 {
+        t.Errorf("%d events published for 5 identical results, want 1", got)
+    }
+    if got := testutil.ToFloat64(eventsSuppressed.WithLabelValues(resultsTopic)) - before; got != 4 {
+        t.Errorf("%v suppressed, want 4", got)
+    }
+
+    // A changed result, or the same result for another organization, is new
+    publisher.Publish(ctx, resultsTopic, resultEvent("org-1", "hash-b"))
+    publisher.Publish(ctx, resultsTopic, resultEvent("org-2", "hash-a"))
+    if got := published(); got != 3 {
+        t.Errorf("%d events published, want 3 with the changed and other results", got)
+    }
+
+    // Once the window passes the result is published again
+    server.FastForward(time.Minute)
+    publisher.Publish(ctx, resultsTopic, resultEvent("org-1", "hash-a"))
+    if got := published(); got != 4 {
+        t.Errorf("%d events published, want the repeat after the window", got)
+    }
+}
+
+func TestEventDedupeDisabledOrUnavailable(t *testing.T) {
+    tests := []struct {
+        name   string
+        window time.Duration
+        down   bool
+    }{
+        {name: "disabled", window: 0},
+        {name: "redis down publishes", window: time.Minute, down: true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            publisher, fake, server := newTestEventPublisher(t, func(config *EventConfig) {
+                config.DedupeWindow = tt.window
+            })
+            if tt.down {
+                server.Close()
+            }
+            for i := 0; i < 3; i++ {
+                publisher.Publish(context.Background(), resultsTopic, resultEvent("org-1", "hash-a"))
+            }
+            if got := len(fake.messages); got != 3 {
+                t.Errorf("%d events published, want all 3", got)
+            }
+        })
+    }
+}
+
+func scoredEvent(org string, score float64) *ComplianceEvent {
+    return &ComplianceEvent{Response: &ComplianceResponse{OrganizationId: org, ContentHash: fmt.Sprint(score), OverallScore: score}}
+}
+
+// In crossing mode only the first result, band crossings and heartbeats
+// are published
+func TestEventPublishOnBandCrossing(t *testing.T) {
+    publisher, fake, server := newTestEventPublisher(t, func(config *EventConfig) {
+        config.DedupeWindow = 0
+        config.PublishOn = publishOnCrossing
+        config.CrossingBoundaries = "90,70"
+        config.HeartbeatInterval = time.Hour
+    })
+    ctx := context.Background()
+    crossings := func(direction string) float64 {
+        return testutil.ToFloat64(eventBandCrossings.WithLabelValues(resultsTopic, direction))
+    }
+    down, up := crossings("down"), crossings("up")
+    within := testutil.ToFloat64(eventBandDecisions.WithLabelValues(resultsTopic, "within_band"))
+
+    tests := []struct {
+        score   float64
+        publish bool
+    }{
+        {score: 95, publish: true},  // first result
+        {score: 92, publish: false}, // within the top band
+        {score: 90, publish: false}, // a boundary belongs to the band above it
+        {score: 89, publish: true},  // crossed down
+        {score: 75, publish: false},
+        {score: 60, publish: true}, // crossed down again
+        {score: 69.9, publish: false},
+        {score: 91, publish: true}, // crossed up two bands
+    }
+    for _, tt := range tests {
+        before := len(fake.messages)
+        publisher.Publish(ctx, resultsTopic, scoredEvent("org-1", tt.score))
+        if published := len(fake.messages) > before; published != tt.publish {
+            t.Errorf("score %v published %t, want %t", tt.score, published, tt.publish)
+        }
+    }
+    if got := crossings("down") - down; got != 2 {
+        t.Errorf("%v downward crossings counted, want 2", got)
+    }
+    if got := crossings("up") - up; got != 1 {
+        t.Errorf("%v upward crossings counted, want 1", got)
+    }
+    if got := testutil.ToFloat64(eventBandDecisions.WithLabelValues(resultsTopic, "within_band")) - within; got != 4 {
+        t.Errorf("%v within-band decisions counted, want 4", got)
+    }
+
+    // Another organization's band is its own
+    publisher.Publish(ctx, resultsTopic, scoredEvent("org-2", 91))
+    if len(fake.messages) != 5 {
+        t.Errorf("%d events published, want the first result of org-2", len(fake.messages))
+    }
+
+    // Once the heartbeat interval has passed the band is published again
+    server.HSet("event-band:"+resultsTopic+":org-1", "published_at", fmt.Sprint(time.Now().Add(-time.Hour).UnixMilli()))
+    publisher.Publish(ctx, resultsTopic, scoredEvent("org-1", 93))
+    if len(fake.messages) != 6 {
+        t.Errorf("%d events published, want a heartbeat for org-1", len(fake.messages))
+    }
+}
+
+// Without boundaries the bands are the statuses
+func TestEventPublishOnStatusCrossing(t *testing.T) {
+    publisher, fake, _ := newTestEventPublisher(t, func(config *EventConfig) {
+        config.DedupeWindow = 0
+        config.PublishOn = publishOnCrossing
+    })
+    for _, status := range []string{"COMPLIANT", "COMPLIANT", "PARTIALLY_COMPLIANT", "PARTIALLY_COMPLIANT", "COMPLIANT"} {
+        event := scoredEvent("org-1", 80)
+        event.Response.Status = status
+        publisher.Publish(context.Background(), resultsTopic, event)
+    }
+    if got := len(fake.messages); got != 3 {
+        t.Errorf("%d events published for two status changes, want 3", got)
+    }
+}
+
+func TestNewEventPublisherRejectsInvalidCrossingConfig(t *testing.T) {
+    tests := []struct {
+        name      string
+        configure func(*EventConfig)
+    }{
+        {name: "unknown mode", configure: func(c *EventConfig) { c.PublishOn = "sometimes" }},
+        {name: "negative heartbeat", configure: func(c *EventConfig) { c.HeartbeatInterval = -time.Second }},
+        {name: "boundary above 100", configure: func(c *EventConfig) { c.CrossingBoundaries = "90,110" }},
+        {name: "boundary not a number", configure: func(c *EventConfig) { c.CrossingBoundaries = "high" }},
+    }
+    for _, tt := range tests {
+        config := ConfigFromEnv().Events
+        tt.configure(&config)
+        if _, err := NewEventPublisher(nil, nil, config); err == nil {
+            t.Errorf("%s: NewEventPublisher() accepted the config", tt.name)
+        }
+    }
+}
+
+// Rewrite golden files under testdata from the current output
+var updateGolden = flag.Bool("update", false, "rewrite golden files under testdata")
+
+// Compare output byte for byte with the golden file testdata/name
+func compareGolden(t *testing.T, name string, got []byte) {
+    t.Helper()
+    path := filepath.Join("testdata", name)
+    if *updateGolden {
+        if err := os.WriteFile(path, got, 0o644); err != nil {
+            t.Fatal(err)
+        }
+    }
+    want, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !bytes.Equal(got, want) {
+        t.Errorf("output differs from %s\ngot:  %s\nwant: %s", path, got, want)
+    }
+}
+
+// Payloads consumers depend on keep their exact encoding in each schema
+// version; a change to either shows up as a golden file diff
+func TestEncodeEventGolden(t *testing.T) {
+    event := &ComplianceEvent{
+        Response: &ComplianceResponse{
+            OrganizationId: "org-1",
+            Timestamp:      1767225600,
+            OverallScore:   82.5,
+            Status:         "PARTIALLY_COMPLIANT",
+            FrameworkResults: []*FrameworkResult{
+                {Framework: "NCA", Score: 90, RulesetVersion: "2025.1", EvaluatedAt: 1767225600},
+                {Framework: "SAMA", Score: 75, RulesetVersion: "2025.1", Reused: true},
+            },
+        },
+        Evidence: map[string]*FrameworkEvidenceStatus{
+            "NCA": {Framework: "NCA", MissingKeys: []string{"mfa_enforced"}, RequiredTotal: 4, RequiredSatisfied: 3, EstimatedCoverage: 75},
+        },
+        Degradation: []DegradationEntry{{Framework: "PDPL", Reason: "timeout"}},
+        RequestedBy: &RequestedBy{Principal: "portal", SubjectID: "user-7"},
+        Transition: &StatusTransition{
+            OrganizationId: "org-1",
+            OldStatus:      "COMPLIANT",
+            NewStatus:      "PARTIALLY_COMPLIANT",
+            RunId:          "run-1",
+            Cause:          &StatusCause{Kind: "FRAMEWORK_SCORE", Framework: "SAMA", Detail: "SAMA 91 -> 75"},
+        },
+        Region: "KSA",
+        Locale: "ar-SA",
+    }
+    for _, tt := range []struct {
+        version int
+        golden  string
+    }{
+        {eventSchemaV1, "event_v1.golden.json"},
+        {eventSchemaV2, "event_v2.golden.json"},
+    } {
+        t.Run(tt.golden, func(t *testing.T) {
+            payload, err := EncodeEvent(tt.version, event)
+            if err != nil {
+                t.Fatal(err)
+            }
+            compareGolden(t, tt.golden, payload)
+        })
+    }
+}
@@ -0,0 +1,217 @@
+package compliance
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Evidence value types
+const (
+    evidenceString = "STRING"
+    evidenceBool   = "BOOL"
+    evidenceNumber = "NUMBER"
+    evidenceDate   = "DATE"
+)
+
+// Evidence verdicts
+const (
+    verdictValid       = "VALID"
+    verdictInvalidType = "INVALID_TYPE"
+    verdictExpired     = "EXPIRED"
+    verdictUnknownKey  = "UNKNOWN_KEY"
+)
+
+// EvidenceRequirement - a piece of evidence a framework reads, and the
+// control it evidences
+type EvidenceRequirement struct {
+    Key      string
+    Type     string
+    MaxAge   time.Duration // 0 means evidence never expires
+    Severity string        // Of the control: CRITICAL, HIGH, MEDIUM (default) or LOW
+    Weight   float64       // Overrides the severity's weight when set
+}
+
+// EvidenceValue - an evidence item after type coercion
+type EvidenceValue struct {
+    Key         string
+    Type        string
+    Raw         string
+    Bool        bool
+    Number      float64
+    Date        time.Time
+    CollectedAt time.Time
+}
+
+// EvidenceSet - usable evidence keyed by evidence key
+type EvidenceSet map[string]*EvidenceValue
+
+// Evidence read by the built-in checkers
+var builtinEvidenceRequirements = map[string][]EvidenceRequirement{
+    "NCA": {
+        {Key: "asset_inventory", Type: evidenceBool, Severity: severityHigh},
+        {Key: "mfa_enforced", Type: evidenceBool, Severity: severityCritical},
+        {Key: "vulnerability_scan_date", Type: evidenceDate, MaxAge: 90 * 24 * time.Hour, Severity: severityHigh},
+        {Key: "incident_response_plan", Type: evidenceBool, MaxAge: 365 * 24 * time.Hour, Severity: severityMedium},
+    },
+    "SAMA": {
+        {Key: "capital_adequacy_ratio", Type: evidenceNumber, MaxAge: 90 * 24 * time.Hour},
+        {Key: "aml_program", Type: evidenceBool},
+        {Key: "bcp_test_date", Type: evidenceDate, MaxAge: 365 * 24 * time.Hour},
+        {Key: "cyber_resilience_assessment_date", Type: evidenceDate, MaxAge: 365 * 24 * time.Hour},
+        {Key: "incident_notification_hours", Type: evidenceNumber},
+        {Key: "third_party_register", Type: evidenceBool},
+        {Key: samaMaturityEvidence, Type: evidenceNumber, MaxAge: 365 * 24 * time.Hour},
+    },
+    "PDPL": {
+        {Key: "dpo_appointed", Type: evidenceBool},
+        {Key: "consent_management", Type: evidenceString},
+        {Key: "breach_notification_process", Type: evidenceBool},
+        {Key: "data_inventory_date", Type: evidenceDate, MaxAge: 365 * 24 * time.Hour},
+    },
+    "ISO27001": {
+        {Key: "isms_scope", Type: evidenceString},
+        {Key: "risk_assessment_date", Type: evidenceDate, MaxAge: 365 * 24 * time.Hour},
+        {Key: "internal_audit_date", Type: evidenceDate, MaxAge: 365 * 24 * time.Hour},
+    },
+    "NIST": {
+        {Key: "asset_inventory", Type: evidenceBool},
+        {Key: "mfa_enforced", Type: evidenceBool},
+        {Key: "incident_response_plan", Type: evidenceBool, MaxAge: 365 * 24 * time.Hour},
+        {Key: "backup_restore_test_date", Type: evidenceDate, MaxAge: 180 * 24 * time.Hour},
+        {Key: nistTierEvidence, Type: evidenceNumber, MaxAge: 365 * 24 * time.Hour},
+    },
+}
+
+// Coerce an evidence item to the type its requirement expects
+func coerceEvidence(item *EvidenceItem, requirement EvidenceRequirement) (*EvidenceValue, error) {
+    valueType := strings.ToUpper(item.Type)
+    if valueType == "" {
+        valueType = requirement.Type
+    }
+    if requirement.Type != "" && valueType != requirement.Type {
+        return nil, fmt.Errorf("expected %s, got %s", requirement.Type, valueType)
+    }
+
+    value := &EvidenceValue{Key: item.Key, Type: valueType, Raw: item.Value}
+    if item.CollectedAt != nil {
+        value.CollectedAt = item.CollectedAt.AsTime()
+    }
+
+    raw := strings.TrimSpace(item.Value)
+    switch valueType {
+    case evidenceBool:
+        b, err := strconv.ParseBool(raw)
+        if err != nil {
+            return nil, fmt.Errorf("%q is not a boolean", item.Value)
+        }
+        value.Bool = b
+    case evidenceNumber:
+        f, err := strconv.ParseFloat(raw, 64)
+        if err != nil {
+            return nil, fmt.Errorf("%q is not a number", item.Value)
+        }
+        value.Number = f
+    case evidenceDate:
+        d, err := time.Parse("2006-01-02", raw)
+        if err != nil {
+            if d, err = time.Parse(time.RFC3339, raw); err != nil {
+                return nil, fmt.Errorf("%q is not a date", item.Value)
+            }
+        }
+        value.Date = d
+    case evidenceString:
+        if raw == "" {
+            return nil, fmt.Errorf("empty value")
+        }
+    default:
+        return nil, fmt.Errorf("unsupported evidence type %s", valueType)
+    }
+    return value, nil
+}
+
+// When a piece of evidence expires under its requirement. Evidence aged
+// exactly MaxAge is still valid; it expires the instant after.
+func evidenceExpiry(value *EvidenceValue, requirement EvidenceRequirement) (time.Time, bool) {
+    if requirement.MaxAge <= 0 {
+        return time.Time{}, false
+    }
+    switch {
+    case !value.Date.IsZero():
+        return value.Date.Add(requirement.MaxAge), true
+    case !value.CollectedAt.IsZero():
+        return value.CollectedAt.Add(requirement.MaxAge), true
+    }
+    return time.Time{}, false
+}
+
+// Assemble evidence for the given frameworks: coerce types, flag expired items
+// and unknown keys. Only VALID items end up in the returned set.
+func (e *RulesEngine) assembleEvidence(frameworks []string, items []*EvidenceItem, now time.Time) (EvidenceSet, []*EvidenceVerdict) {
+    requirements := make(map[string]EvidenceRequirement)
+    for _, framework := range frameworks {
+        for _, requirement := range e.requirements[framework] {
+            requirements[requirement.Key] = requirement
+        }
+    }
+
+    set := make(EvidenceSet)
+    verdicts := make([]*EvidenceVerdict, 0, len(items))
+    for _, item := range items {
+        if item == nil {
+            continue
+        }
+        requirement, ok := requirements[item.Key]
+        if !ok {
+            verdicts = append(verdicts, &EvidenceVerdict{
+                Key:     item.Key,
+                Verdict: verdictUnknownKey,
+                Message: "not read by any selected framework",
+            })
+            continue
+        }
+
+        value, err := coerceEvidence(item, requirement)
+        if err != nil {
+            verdicts = append(verdicts, &EvidenceVerdict{Key: item.Key, Verdict: verdictInvalidType, Message: err.Error()})
+            continue
+        }
+
+        if expiresAt, ok := evidenceExpiry(value, requirement); ok && now.After(expiresAt) {
+            verdicts = append(verdicts, &EvidenceVerdict{
+                Key:     item.Key,
+                Verdict: verdictExpired,
+                Type:    value.Type,
+                Message: fmt.Sprintf("expired %s; must be no older than %s", expiresAt.Format(time.RFC3339), requirement.MaxAge),
+            })
+            continue
+        }
+
+        set[item.Key] = value
+        verdicts = append(verdicts, &EvidenceVerdict{Key: item.Key, Verdict: verdictValid, Type: value.Type})
+    }
+    return set, verdicts
+}
+
+// Required-key analysis of an evidence set for one framework
+func (e *RulesEngine) evidenceStatus(framework string, set EvidenceSet) *FrameworkEvidenceStatus {
+    requirements := e.requirements[framework]
+    status := &FrameworkEvidenceStatus{
+        Framework:     framework,
+        RequiredTotal: int32(len(requirements)),
+    }
+    for _, requirement := range requirements {
+        if _, ok := set[requirement.Key]; ok {
+            status.RequiredSatisfied++
+        } else {
+            status.MissingKeys = append(status.MissingKeys, requirement.Key)
+        }
+    }
+
+    status.EstimatedCoverage = 100
+    if status.RequiredTotal > 0 {
+        status.EstimatedCoverage = roundScore(100 * float64(status.RequiredSatisfied) / float64(status.RequiredTotal))
+    }
+    return status
+}
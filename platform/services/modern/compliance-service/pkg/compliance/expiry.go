@@ -0,0 +1,147 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+    "sort"
+    "time"
+)
+
+// Topic for upcoming evidence expiry notices
+const evidenceExpiryTopic = "evidence-expiry"
+
+// EvidenceExpiryEvent - published when an evaluation finds evidence that
+// expires within the warning window, so it can be refreshed in time.
+// Every evaluation republishes its notices; consumers dedupe on key and
+// expires_at.
+type EvidenceExpiryEvent struct {
+    OrganizationID string                 `json:"organization_id"`
+    EvaluatedAt    time.Time              `json:"evaluated_at"`
+    Expiring       []EvidenceExpiryNotice `json:"expiring"`
+}
+
+// EvidenceExpiryNotice - one piece of evidence about to expire
+type EvidenceExpiryNotice struct {
+    Key           string    `json:"key"`
+    Frameworks    []string  `json:"frameworks"`
+    ExpiresAt     time.Time `json:"expires_at"`
+    DaysRemaining int32     `json:"days_remaining"`
+}
+
+// Expiry of every valid piece of evidence the frameworks read, soonest
+// first. A key read by several frameworks expires at its earliest max age.
+func (e *RulesEngine) evidenceExpiries(frameworks []string, set EvidenceSet, now time.Time) []*EvidenceExpiry {
+    byKey := make(map[string]*EvidenceExpiry)
+    for _, framework := range frameworks {
+        for _, requirement := range e.requirements[framework] {
+            value, ok := set[requirement.Key]
+            if !ok {
+                continue
+            }
+            expiresAt, ok := evidenceExpiry(value, requirement)
+            if !ok {
+                continue
+            }
+            expiry, seen := byKey[requirement.Key]
+            if !seen {
+                expiry = &EvidenceExpiry{Key: requirement.Key, ExpiresAt: expiresAt.Unix()}
+                byKey[requirement.Key] = expiry
+            } else if expiresAt.Unix() < expiry.ExpiresAt {
+                expiry.ExpiresAt = expiresAt.Unix()
+            }
+            expiry.Frameworks = append(expiry.Frameworks, framework)
+        }
+    }
+
+    expiries := make([]*EvidenceExpiry, 0, len(byKey))
+    for _, expiry := range byKey {
+        expiry.DaysRemaining = int32(time.Unix(expiry.ExpiresAt, 0).Sub(now) / (24 * time.Hour))
+        expiries = append(expiries, expiry)
+    }
+    sort.Slice(expiries, func(i, j int) bool {
+        if expiries[i].ExpiresAt != expiries[j].ExpiresAt {
+            return expiries[i].ExpiresAt < expiries[j].ExpiresAt
+        }
+        return expiries[i].Key < expiries[j].Key
+    })
+    return expiries
+}
+
+// Expiries falling within window of now, inclusive of its end
+func expiringWithin(expiries []*EvidenceExpiry, now time.Time, window time.Duration) []*EvidenceExpiry {
+    cutoff := now.Add(window).Unix()
+    var soon []*EvidenceExpiry
+    for _, expiry := range expiries {
+        if expiry.ExpiresAt > cutoff {
+            break
+        }
+        soon = append(soon, expiry)
+    }
+    return soon
+}
+
+// Evidence expiring within the warning window for the evaluated frameworks
+func (s *ComplianceService) expiringSoon(results []*FrameworkResult, evidence []*EvidenceItem, now time.Time) []*EvidenceExpiry {
+    if s.config.EvidenceExpiryWarning <= 0 {
+        return nil
+    }
+    frameworks := evaluatedFrameworks(results)
+    set, _ := s.engine.assembleEvidence(frameworks, evidence, now)
+    return expiringWithin(s.engine.evidenceExpiries(frameworks, set, now), now, s.config.EvidenceExpiryWarning)
+}
+
+// Cache TTL of a response and how its base was chosen, cut short so it
+// never outlives its evidence or attestations; 0
+// when evidence expires now and the response must not be cached
+func (s *ComplianceService) responseTTL(ctx context.Context, runtime *RuntimeConfig, response *ComplianceResponse, evidence []*EvidenceItem) (time.Duration, string) {
+    now := time.Now()
+    base, mode := s.baseResponseTTL(ctx, runtime, response)
+    ttl := attestationTTL(base, response, now)
+    frameworks := evaluatedFrameworks(response.FrameworkResults)
+    set, _ := s.engine.assembleEvidence(frameworks, evidence, now)
+    if expiries := s.engine.evidenceExpiries(frameworks, set, now); len(expiries) > 0 {
+        if remaining := time.Unix(expiries[0].ExpiresAt, 0).Sub(now); remaining < ttl {
+            ttl = remaining
+        }
+        if ttl < 0 {
+            ttl = 0
+        }
+    }
+    return ttl, mode
+}
+
+// Publish an expiry notice for a response with evidence expiring soon
+func (s *ComplianceService) publishEvidenceExpiry(ctx context.Context, response *ComplianceResponse) {
+    if len(response.ExpiringSoon) == 0 {
+        return
+    }
+    event := EvidenceExpiryEvent{
+        OrganizationID: response.OrganizationId,
+        EvaluatedAt:    time.Unix(response.Timestamp, 0).UTC(),
+        Expiring:       make([]EvidenceExpiryNotice, 0, len(response.ExpiringSoon)),
+    }
+    for _, expiry := range response.ExpiringSoon {
+        event.Expiring = append(event.Expiring, EvidenceExpiryNotice{
+            Key:           expiry.Key,
+            Frameworks:    expiry.Frameworks,
+            ExpiresAt:     time.Unix(expiry.ExpiresAt, 0).UTC(),
+            DaysRemaining: expiry.DaysRemaining,
+        })
+    }
+    value, _ := json.Marshal(event)
+    if err := s.kafkaProducer.PublishMessage(ctx, evidenceExpiryTopic, []byte(response.OrganizationId), value, nil); err != nil {
+        log.Printf("Failed to publish evidence expiry for %s: %v", redact(fieldOrganizationID, response.OrganizationId), err)
+    }
+}
+
+// Frameworks that produced a real result
+func evaluatedFrameworks(results []*FrameworkResult) []string {
+    frameworks := make([]string, 0, len(results))
+    for _, result := range results {
+        if scoredResult(result) {
+            frameworks = append(frameworks, result.Framework)
+        }
+    }
+    return frameworks
+}
@@ -0,0 +1,75 @@
+package compliance
+
+import (
+    "testing"
+    "time"
+
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Evidence aged exactly its control's max age is still valid, and expired
+// one tick later, whether its age comes from a dated value or from when
+// it was collected
+func TestEvidenceMaxAgeBoundary(t *testing.T) {
+    service, _ := newTestService(t)
+    scanned := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    collected := time.Date(2026, 3, 15, 9, 30, 0, 0, time.UTC)
+    tests := []struct {
+        name        string
+        item        *EvidenceItem
+        now         time.Time
+        wantVerdict string
+    }{
+        {
+            name:        "dated, exactly max age",
+            item:        &EvidenceItem{Key: "vulnerability_scan_date", Value: "2026-01-01"},
+            now:         scanned.Add(90 * 24 * time.Hour),
+            wantVerdict: verdictValid,
+        },
+        {
+            name:        "dated, one tick past max age",
+            item:        &EvidenceItem{Key: "vulnerability_scan_date", Value: "2026-01-01"},
+            now:         scanned.Add(90*24*time.Hour + time.Nanosecond),
+            wantVerdict: verdictExpired,
+        },
+        {
+            name:        "collected, exactly max age",
+            item:        &EvidenceItem{Key: "incident_response_plan", Value: "true", CollectedAt: timestamppb.New(collected)},
+            now:         collected.Add(365 * 24 * time.Hour),
+            wantVerdict: verdictValid,
+        },
+        {
+            name:        "collected, one tick past max age",
+            item:        &EvidenceItem{Key: "incident_response_plan", Value: "true", CollectedAt: timestamppb.New(collected)},
+            now:         collected.Add(365*24*time.Hour + time.Nanosecond),
+            wantVerdict: verdictExpired,
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            set, verdicts := service.engine.assembleEvidence([]string{"NCA"}, []*EvidenceItem{tt.item}, tt.now)
+            if len(verdicts) != 1 || verdicts[0].Verdict != tt.wantVerdict {
+                t.Fatalf("assembleEvidence() verdicts = %v, want one %s", verdicts, tt.wantVerdict)
+            }
+            if _, ok := set[tt.item.Key]; ok != (tt.wantVerdict == verdictValid) {
+                t.Errorf("evidence in the set = %v, want it only while valid", ok)
+            }
+        })
+    }
+}
+
+// The warning window includes evidence expiring exactly at its end and
+// leaves out evidence expiring a second later
+func TestExpiringWithinWindowBoundary(t *testing.T) {
+    now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+    window := 30 * 24 * time.Hour
+    expiries := []*EvidenceExpiry{
+        {Key: "bcp_test_date", ExpiresAt: now.Add(24 * time.Hour).Unix()},
+        {Key: "aml_program", ExpiresAt: now.Add(window).Unix()},
+        {Key: "third_party_register", ExpiresAt: now.Add(window + time.Second).Unix()},
+    }
+    soon := expiringWithin(expiries, now, window)
+    if len(soon) != 2 || soon[0].Key != "bcp_test_date" || soon[1].Key != "aml_program" {
+        t.Errorf("expiringWithin() = %v, want bcp_test_date and aml_program", soon)
+    }
+}
@@ -0,0 +1,137 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/cache"
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/protobuf/proto"
+)
+
+// Outcome of a framework whose check failed and whose last good result for
+// the organization was reused instead
+const frameworkStale = "STALE"
+
+// Key prefix for each organization's last good result per framework
+const lastResultKeyPrefix = "framework-last:"
+
+func lastResultKey(organizationID, framework string) string {
+    return lastResultKeyPrefix + organizationID + ":" + framework
+}
+
+// SetLastResults keeps freshly evaluated results as the organization's last
+// good result per framework, stamped with when they were evaluated
+func (c *ResponseCache) SetLastResults(ctx context.Context, organizationID string, results []*FrameworkResult, maxAge time.Duration) error {
+    now := time.Now().Unix()
+    entries := make([]cache.Entry, 0, len(results))
+    for _, result := range results {
+        if result.Outcome == frameworkNotApplicable || result.Outcome == frameworkInsufficientData {
+            continue
+        }
+        last := proto.Clone(result).(*FrameworkResult)
+        if !last.Reused {
+            last.EvaluatedAt = now
+        }
+        last.Reused = false
+        data, err := proto.Marshal(last)
+        if err != nil {
+            return fmt.Errorf("failed to encode framework result: %v", err)
+        }
+        if c.oversized("framework", result.Framework, data) {
+            continue
+        }
+        entries = append(entries, cache.Entry{Key: lastResultKey(organizationID, result.Framework), Value: data, TTL: maxAge})
+    }
+    if len(entries) == 0 {
+        return nil
+    }
+    return c.backend.BatchSet(ctx, entries)
+}
+
+// GetLastResults fetches the organization's last good results for the given
+// frameworks; frameworks without one are absent
+func (c *ResponseCache) GetLastResults(ctx context.Context, organizationID string, frameworks []string) (map[string]*FrameworkResult, error) {
+    keys := make([]string, len(frameworks))
+    for i, framework := range frameworks {
+        keys[i] = lastResultKey(organizationID, framework)
+    }
+    values, err := c.backend.BatchGet(ctx, keys)
+    if err != nil {
+        return nil, err
+    }
+
+    results := make(map[string]*FrameworkResult, len(frameworks))
+    for i, data := range values {
+        if data == nil {
+            continue
+        }
+        result := &FrameworkResult{}
+        if err := proto.Unmarshal(data, result); err != nil {
+            log.Printf("Ignoring undecodable last %s result: %v", frameworks[i], err)
+            continue
+        }
+        results[frameworks[i]] = result
+    }
+    return results, nil
+}
+
+// Frameworks that produced no result and were not deliberately skipped
+func failedFrameworks(frameworks []string, results []*FrameworkResult, skipped []string) []string {
+    produced := make(map[string]bool, len(results))
+    for _, result := range results {
+        produced[result.Framework] = true
+    }
+    var failed []string
+    for _, framework := range frameworks {
+        if !produced[framework] && !containsString(skipped, framework) {
+            failed = append(failed, framework)
+        }
+    }
+    return failed
+}
+
+// Last good results standing in for failed checks, marked STALE, so a
+// partial outage does not drop frameworks out of the overall score.
+// Nothing is reused when fallback is disabled or the request was cancelled.
+func (s *ComplianceService) staleFallbacks(ctx context.Context, organizationID string, failed []string) []*FrameworkResult {
+    if s.config.FrameworkFallbackTTL <= 0 || len(failed) == 0 || ctx.Err() != nil {
+        return nil
+    }
+    last, err := s.cache.GetLastResults(ctx, organizationID, failed)
+    if err != nil {
+        log.Printf("Failed to load last framework results for %s: %v", redact(fieldOrganizationID, organizationID), err)
+        return nil
+    }
+
+    var stale []*FrameworkResult
+    for _, framework := range failed {
+        result, ok := last[framework]
+        if !ok {
+            continue
+        }
+        result.Outcome = frameworkStale
+        result.Trend = nil
+        stale = append(stale, result)
+        frameworkFallbacks.WithLabelValues(framework).Inc()
+        markTraceDegraded(ctx, "stale "+framework+" result reused")
+    }
+    return stale
+}
+
+// Fallback metrics
+var (
+    frameworkFallbacks = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_framework_fallback_total",
+            Help: "Failed framework checks answered with the organization's last good result",
+        },
+        []string{"framework"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(frameworkFallbacks)
+}
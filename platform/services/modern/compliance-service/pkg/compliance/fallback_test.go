@@ -0,0 +1,114 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// Drop cached responses and framework results, so the next check runs
+// every framework's checker again
+func dropCachedResults(server *miniredis.Miniredis) {
+    for _, key := range server.Keys() {
+        if strings.HasPrefix(key, responseKeyPrefix) || strings.HasPrefix(key, frameworkKeyPrefix) {
+            server.Del(key)
+        }
+    }
+}
+
+func resultFor(response *ComplianceResponse, framework string) *FrameworkResult {
+    for _, result := range response.FrameworkResults {
+        if result.Framework == framework {
+            return result
+        }
+    }
+    return nil
+}
+
+// A failing check is answered with the organization's last good result,
+// marked STALE, and the partly stale response is not cached
+func TestFailedCheckFallsBackToLastResult(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.FrameworkFallbackTTL = time.Hour
+        config.ComputeCacheTTL = 0
+    })
+    ctx := context.Background()
+    req := &ComplianceRequest{OrganizationId: "org-1"}
+
+    good, err := service.CheckCompliance(ctx, req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    last := resultFor(good, "SAMA")
+    if last == nil {
+        t.Fatal("no SAMA result to fall back to")
+    }
+
+    dropCachedResults(server)
+    service.faults.Set("SAMA", Fault{Fail: true})
+    before := testutil.ToFloat64(frameworkFallbacks.WithLabelValues("SAMA"))
+    response, err := service.CheckCompliance(ctx, req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    stale := resultFor(response, "SAMA")
+    if stale == nil || stale.Outcome != frameworkStale {
+        t.Fatalf("SAMA result %v, want the last good result marked STALE", stale)
+    }
+    if stale.Score != last.Score || stale.EvaluatedAt == 0 || stale.EvaluatedAt > time.Now().Unix() {
+        t.Errorf("stale SAMA scored %v evaluated at %d, want the last good %v with its evaluation time", stale.Score, stale.EvaluatedAt, last.Score)
+    }
+    if got := testutil.ToFloat64(frameworkFallbacks.WithLabelValues("SAMA")) - before; got != 1 {
+        t.Errorf("%v fallbacks counted, want 1", got)
+    }
+    if len(response.FrameworkResults) != len(good.FrameworkResults) {
+        t.Errorf("%d results, want all %d with SAMA standing in", len(response.FrameworkResults), len(good.FrameworkResults))
+    }
+    for _, key := range server.Keys() {
+        if strings.HasPrefix(key, responseKeyPrefix) {
+            t.Errorf("partly stale response cached under %s", key)
+        }
+    }
+}
+
+// Without a last good result, or with the fallback disabled, a failed
+// check drops out of the response
+func TestFailedCheckWithoutFallback(t *testing.T) {
+    tests := []struct {
+        name string
+        ttl  time.Duration
+        seed bool
+    }{
+        {name: "no last result", ttl: time.Hour},
+        {name: "fallback disabled", ttl: 0, seed: true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service, server := newTestService(t, func(config *ServiceConfig) {
+                config.FrameworkFallbackTTL = tt.ttl
+                config.ComputeCacheTTL = 0
+            })
+            ctx := context.Background()
+            if tt.seed {
+                if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"}); err != nil {
+                    t.Fatal(err)
+                }
+                dropCachedResults(server)
+            }
+
+            service.faults.Set("SAMA", Fault{Fail: true})
+            response, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if result := resultFor(response, "SAMA"); result != nil {
+                t.Errorf("SAMA result %v for a failed check, want none", result)
+            }
+        })
+    }
+}
@@ -0,0 +1,66 @@
+package compliance
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+// Fault - an injected failure mode for a framework checker
+type Fault struct {
+    Delay time.Duration `json:"delay"`
+    Fail  bool          `json:"fail"`
+}
+
+// FaultInjector - operator-controlled faults for resilience testing,
+// toggled through the admin listener
+type FaultInjector struct {
+    mu     sync.RWMutex
+    faults map[string]Fault
+}
+
+// Create an empty fault injector
+func NewFaultInjector() *FaultInjector {
+    return &FaultInjector{faults: make(map[string]Fault)}
+}
+
+// Set the fault for a framework; a zero Fault clears it
+func (f *FaultInjector) Set(framework string, fault Fault) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if fault == (Fault{}) {
+        delete(f.faults, framework)
+        return
+    }
+    f.faults[framework] = fault
+}
+
+// Snapshot of active faults
+func (f *FaultInjector) Active() map[string]Fault {
+    f.mu.RLock()
+    defer f.mu.RUnlock()
+    active := make(map[string]Fault, len(f.faults))
+    for framework, fault := range f.faults {
+        active[framework] = fault
+    }
+    return active
+}
+
+// Apply the fault for a framework; returns false if the check must fail
+func (f *FaultInjector) Apply(ctx context.Context, framework string) bool {
+    f.mu.RLock()
+    fault, ok := f.faults[framework]
+    f.mu.RUnlock()
+    if !ok {
+        return true
+    }
+
+    if fault.Delay > 0 {
+        select {
+        case <-time.After(fault.Delay):
+        case <-ctx.Done():
+            return false
+        }
+    }
+    return !fault.Fail
+}
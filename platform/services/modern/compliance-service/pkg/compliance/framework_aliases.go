@@ -0,0 +1,69 @@
+package compliance
+
+import (
+    "fmt"
+    "strings"
+)
+
+// Validate framework aliases, e.g. {"ISO 27001": ISO27001}: each names a
+// registered framework and none can be mistaken for another framework or
+// alias, as names match case-insensitively
+func validateFrameworkAliases(aliases map[string]string, frameworks []string) error {
+    for alias, framework := range aliases {
+        if strings.TrimSpace(alias) == "" {
+            return fmt.Errorf("framework alias must not be empty")
+        }
+        if !containsString(frameworks, framework) {
+            return fmt.Errorf("framework alias %q names unknown framework %s", alias, framework)
+        }
+        for _, other := range frameworks {
+            if other != framework && strings.EqualFold(alias, other) {
+                return fmt.Errorf("framework alias %q for %s clashes with framework %s", alias, framework, other)
+            }
+        }
+        for otherAlias, otherFramework := range aliases {
+            if otherAlias != alias && otherFramework != framework && strings.EqualFold(normalizeFrameworkName(alias), normalizeFrameworkName(otherAlias)) {
+                return fmt.Errorf("framework aliases %q and %q name different frameworks", alias, otherAlias)
+            }
+        }
+    }
+    return nil
+}
+
+func normalizeFrameworkName(name string) string {
+    return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Canonical ID of a framework name: a registered framework or configured
+// alias, matched case-insensitively. Other names are returned unchanged for
+// the caller to reject as unknown.
+func (s *ComplianceService) resolveFramework(name string) string {
+    frameworks := s.engine.Frameworks()
+    if containsString(frameworks, name) {
+        return name
+    }
+    normalized := normalizeFrameworkName(name)
+    for _, framework := range frameworks {
+        if strings.ToLower(framework) == normalized {
+            return framework
+        }
+    }
+    for alias, framework := range s.runtimeConfig().FrameworkAliases {
+        if normalizeFrameworkName(alias) == normalized {
+            return framework
+        }
+    }
+    return name
+}
+
+// Canonical IDs of framework names, in order; nil for none
+func (s *ComplianceService) resolveFrameworks(names []string) []string {
+    if len(names) == 0 {
+        return names
+    }
+    resolved := make([]string, len(names))
+    for i, name := range names {
+        resolved[i] = s.resolveFramework(name)
+    }
+    return resolved
+}
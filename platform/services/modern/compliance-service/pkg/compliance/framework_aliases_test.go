@@ -0,0 +1,86 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+const aliasesConfig = `
+framework_aliases:
+  ISO 27001: ISO27001
+  iso-27001: ISO27001
+  Saudi Central Bank: SAMA
+`
+
+func TestResolveFramework(t *testing.T) {
+    service, _ := newConfigFileService(t, aliasesConfig)
+    tests := []struct {
+        name string
+        want string
+    }{
+        {name: "ISO27001", want: "ISO27001"},
+        {name: "iso27001", want: "ISO27001"},
+        {name: "ISO 27001", want: "ISO27001"},
+        {name: "Iso 27001", want: "ISO27001"},
+        {name: " iso-27001 ", want: "ISO27001"},
+        {name: "saudi central bank", want: "SAMA"},
+        {name: "ISO 9001", want: "ISO 9001"},
+    }
+    for _, tt := range tests {
+        if got := service.resolveFramework(tt.name); got != tt.want {
+            t.Errorf("resolveFramework(%q) = %q, want %q", tt.name, got, tt.want)
+        }
+    }
+}
+
+// Aliases select the canonical framework, and share its cached response
+func TestCheckComplianceResolvesAliases(t *testing.T) {
+    service, _ := newConfigFileService(t, aliasesConfig)
+    ctx := context.Background()
+
+    var first *ComplianceResponse
+    for _, name := range []string{"ISO 27001", "iso-27001", "iso27001"} {
+        info := &requestInfo{id: "req-" + name}
+        response, err := service.CheckCompliance(context.WithValue(ctx, requestInfoKey{}, info), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{name}})
+        if err != nil {
+            t.Fatalf("%s: %v", name, err)
+        }
+        if len(response.FrameworkResults) != 1 || response.FrameworkResults[0].Framework != "ISO27001" {
+            t.Errorf("%s evaluated %v, want ISO27001", name, response.FrameworkResults)
+        }
+        if first == nil {
+            first = response
+        } else if info.cacheStatus != cacheStatusHit || response.ContentHash != first.ContentHash {
+            t.Errorf("%s cache %s, want the ISO27001 response cached by the first alias", name, info.cacheStatus)
+        }
+    }
+
+    _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"ISO 9001"}})
+    if status.Code(err) != codes.InvalidArgument {
+        t.Errorf("unknown framework = %v, want InvalidArgument", err)
+    }
+}
+
+func TestValidateFrameworkAliases(t *testing.T) {
+    frameworks := []string{"SAMA", "NCA", "ISO27001"}
+    tests := []struct {
+        name    string
+        aliases map[string]string
+        ok      bool
+    }{
+        {name: "valid", aliases: map[string]string{"ISO 27001": "ISO27001", "iso-27001": "ISO27001"}, ok: true},
+        {name: "same alias twice for one framework", aliases: map[string]string{"ISO 27001": "ISO27001", "iso 27001": "ISO27001"}, ok: true},
+        {name: "empty alias", aliases: map[string]string{" ": "SAMA"}},
+        {name: "unknown framework", aliases: map[string]string{"GDPR": "EU-GDPR"}},
+        {name: "clashes with a framework", aliases: map[string]string{"nca": "SAMA"}},
+        {name: "names two frameworks", aliases: map[string]string{"central": "SAMA", "Central": "NCA"}},
+    }
+    for _, tt := range tests {
+        if err := validateFrameworkAliases(tt.aliases, frameworks); (err == nil) != tt.ok {
+            t.Errorf("%s: validateFrameworkAliases() = %v, want ok %t", tt.name, err, tt.ok)
+        }
+    }
+}
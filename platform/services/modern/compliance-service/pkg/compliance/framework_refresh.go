@@ -0,0 +1,190 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// GetFrameworkResult - one framework's result for an organization. A cached
+// result is served unless refresh is set. A refreshed result replaces only
+// that framework's entry in the cached aggregate response, whose overall
+// score, status and content hash are recomputed in place; the other
+// frameworks' cached results are kept. The read-modify-write runs under the
+// organization's evaluation lock, so it cannot interleave with a full
+// evaluation or another refresh; without the lock the aggregate is dropped
+// instead of patched.
+func (s *ComplianceService) GetFrameworkResult(ctx context.Context, req *FrameworkResultRequest) (*FrameworkResultResponse, error) {
+    if req.Request == nil || req.Request.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "request.organization_id is required")
+    }
+    if framework := s.resolveFramework(req.Framework); framework != req.Framework {
+        req = proto.Clone(req).(*FrameworkResultRequest)
+        req.Framework = framework
+    }
+    if !containsString(s.engine.Frameworks(), req.Framework) {
+        return nil, status.Errorf(codes.NotFound, "framework %s not registered", req.Framework)
+    }
+    creq := req.Request
+    organizationID, err := s.registry.ResolveID(ctx, creq.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+    if organizationID != creq.OrganizationId {
+        creq = proto.Clone(creq).(*ComplianceRequest)
+        creq.OrganizationId = organizationID
+    }
+    runtime := s.tenantRuntimeConfig(ctx)
+    // Scoped as CheckCompliance scopes it, so the aggregate read and patched
+    // is the one a check of the same request caches
+    if creq, err = s.applyRequestDefaults(creq, runtime); err != nil {
+        return nil, err
+    }
+    if !containsString(s.engine.RequestFrameworks(creq), req.Framework) {
+        return nil, status.Errorf(codes.InvalidArgument, "framework %s is outside the request's framework scope", req.Framework)
+    }
+    key := runtime.responseKey(creq)
+    log.Printf("Audit: rpc=GetFrameworkResult org=%s framework=%s refresh=%t", redact(fieldOrganizationID, organizationID), req.Framework, req.Refresh)
+
+    if !req.Refresh {
+        if cached, err := s.cache.Get(ctx, key); err == nil && cached != nil {
+            if result := findFrameworkResult(cached.FrameworkResults, req.Framework); result != nil {
+                return s.frameworkResultResponse(result, cached, false, runtime), nil
+            }
+        }
+    }
+
+    // Hold the lock a check of the same scope takes, so the aggregate read
+    // below is still current when it is written back
+    lease, err := s.locks.Acquire(ctx, organizationID, evaluationScope(creq))
+    locked := err == nil
+    if err != nil && (err == errEvaluationInProgress || ctx.Err() != nil) {
+        return nil, err
+    } else if err != nil {
+        log.Printf("Evaluation lock unavailable for %s, refreshing %s without patching the cache: %v", redact(fieldOrganizationID, organizationID), req.Framework, err)
+    } else {
+        defer lease.Release(context.WithoutCancel(ctx))
+    }
+
+    inputKeys := make(map[string]string, len(s.engine.Frameworks()))
+    for _, framework := range s.engine.Frameworks() {
+        inputKeys[framework] = s.engine.InputKey(framework, creq)
+    }
+
+    // Every other framework comes from the aggregate, else from the
+    // framework cache; prerequisites missing from both are evaluated too
+    reuse := make(map[string]*FrameworkResult)
+    aggregate, err := s.cache.Get(ctx, key)
+    if err != nil {
+        aggregate = nil
+    }
+    if aggregate != nil {
+        for _, result := range aggregate.FrameworkResults {
+            reuse[result.Framework] = result
+        }
+    } else if cached, err := s.cache.GetFrameworks(ctx, inputKeys); err == nil {
+        reuse = cached
+    }
+    delete(reuse, req.Framework)
+    for framework, result := range s.engine.insufficientDataResults(runtime.MinimumInputs, creq.Evidence, time.Now()) {
+        reuse[framework] = result
+    }
+    attested, err := s.attestations.Active(ctx, organizationID, time.Now())
+    if err != nil {
+        log.Printf("Attestations unavailable for %s, scoring %s: %v", redact(fieldOrganizationID, organizationID), req.Framework, err)
+    }
+    for framework, result := range notApplicableResults(attested) {
+        reuse[framework] = result
+    }
+
+    results, err := s.engine.EvaluateAll(ctx, creq, reuse)
+    if err == errLoadShed {
+        retryAfter := s.engine.scheduler.RetryAfter()
+        setRetryAfter(ctx, retryAfter)
+        return nil, reasonError(codes.ResourceExhausted, reasonOverloaded, fmt.Sprintf("service overloaded, retry after %s", retryAfter))
+    }
+    if err == errComputeTimeout {
+        return nil, status.Errorf(codes.DeadlineExceeded, "compliance computation exceeded %s", s.engine.computeTimeout)
+    }
+    if err != nil {
+        // Cancelled by the caller; a partial result must not be cached
+        return nil, status.FromContextError(err).Err()
+    }
+    var fresh []*FrameworkResult
+    for _, result := range results {
+        if _, reused := reuse[result.Framework]; !reused {
+            fresh = append(fresh, proto.Clone(result).(*FrameworkResult))
+        }
+    }
+    if err := s.cache.SetFrameworks(ctx, inputKeys, fresh, runtime.Cache); err != nil {
+        log.Printf("Failed to cache framework results for %s: %v", redact(fieldOrganizationID, organizationID), err)
+    }
+    refreshed := findFrameworkResult(results, req.Framework)
+    if refreshed == nil {
+        return nil, status.Errorf(codes.Unavailable, "framework %s failed to evaluate", req.Framework)
+    }
+
+    if aggregate == nil || !locked {
+        if aggregate != nil {
+            if err := s.cache.Invalidate(ctx, organizationID); err != nil {
+                log.Printf("Failed to drop cached responses for %s: %v", redact(fieldOrganizationID, organizationID), err)
+            }
+        }
+        applyPrecisionPolicy(&ComplianceResponse{FrameworkResults: []*FrameworkResult{refreshed}})
+        return s.frameworkResultResponse(refreshed, nil, false, runtime), nil
+    }
+
+    merged := make([]*FrameworkResult, len(aggregate.FrameworkResults))
+    for i, result := range aggregate.FrameworkResults {
+        merged[i] = result
+        if result.Framework == req.Framework {
+            merged[i] = refreshed
+        }
+    }
+    if findFrameworkResult(aggregate.FrameworkResults, req.Framework) == nil {
+        merged = append(merged, refreshed)
+    }
+    updated := s.buildResponse(organizationID, merged, creq.Evidence, runtime)
+    patched := false
+    if ttl, mode := s.responseTTL(ctx, runtime, updated, creq.Evidence); ttl > 0 {
+        recordCacheTTL(updated, ttl, mode)
+        if err := s.cache.Set(ctx, key, updated, ttl); err != nil {
+            log.Printf("Failed to patch cached response for %s: %v", redact(fieldOrganizationID, organizationID), err)
+        } else {
+            patched = true
+        }
+    } else if err := s.cache.Invalidate(ctx, organizationID); err != nil {
+        // Evidence has expired since: the aggregate must not be served on
+        log.Printf("Failed to drop cached responses for %s: %v", redact(fieldOrganizationID, organizationID), err)
+    }
+    return s.frameworkResultResponse(findFrameworkResult(updated.FrameworkResults, req.Framework), updated, patched, runtime), nil
+}
+
+func findFrameworkResult(results []*FrameworkResult, framework string) *FrameworkResult {
+    for _, result := range results {
+        if result.Framework == framework {
+            return result
+        }
+    }
+    return nil
+}
+
+// Response carrying a result shaped for egress, with the aggregate's
+// overall score and status when there is one
+func (s *ComplianceService) frameworkResultResponse(result *FrameworkResult, aggregate *ComplianceResponse, updated bool, runtime *RuntimeConfig) *FrameworkResultResponse {
+    shaped := shapeResponse(&ComplianceResponse{FrameworkResults: []*FrameworkResult{result}}, runtime.ResultSchema)
+    response := &FrameworkResultResponse{Result: shaped.FrameworkResults[0], AggregateUpdated: updated}
+    if aggregate != nil {
+        response.OverallScore = aggregate.OverallScore
+        response.Status = aggregate.Status
+    }
+    return response
+}
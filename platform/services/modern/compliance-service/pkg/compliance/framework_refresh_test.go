@@ -0,0 +1,166 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "testing"
+
+    "github.com/alicebob/miniredis/v2"
+)
+
+// Wrap every registered checker to count its runs, and let the test
+// override one framework's score
+type checkerSpy struct {
+    mu        sync.Mutex
+    calls     map[string]*atomic.Int64
+    overrides map[string]float64
+}
+
+func spyOnCheckers(service *ComplianceService) *checkerSpy {
+    spy := &checkerSpy{calls: make(map[string]*atomic.Int64), overrides: make(map[string]float64)}
+    for _, framework := range service.engine.Frameworks() {
+        checker, _ := service.engine.Checker(framework)
+        calls := &atomic.Int64{}
+        spy.calls[framework] = calls
+        service.engine.Register(framework, func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+            calls.Add(1)
+            result := checker(ctx, req)
+            spy.mu.Lock()
+            defer spy.mu.Unlock()
+            if score, ok := spy.overrides[framework]; ok && result != nil {
+                result.Score = score
+            }
+            return result
+        })
+    }
+    return spy
+}
+
+func (s *checkerSpy) setScore(framework string, score float64) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.overrides[framework] = score
+}
+
+func (s *checkerSpy) runs() map[string]int64 {
+    runs := make(map[string]int64, len(s.calls))
+    for framework, calls := range s.calls {
+        runs[framework] = calls.Load()
+    }
+    return runs
+}
+
+// The organization's cached aggregate response
+func cachedAggregate(t *testing.T, service *ComplianceService, server *miniredis.Miniredis, org string) *ComplianceResponse {
+    t.Helper()
+    for _, key := range server.Keys() {
+        if rest, ok := strings.CutPrefix(key, responseKeyPrefix); ok && strings.HasPrefix(rest, org+":") {
+            response, err := service.cache.Get(context.Background(), rest)
+            if err != nil {
+                t.Fatal(err)
+            }
+            return response
+        }
+    }
+    return nil
+}
+
+// A single-framework refresh patches only that framework into the cached
+// aggregate, with the overall a full evaluation would give
+func TestFrameworkRefreshPatchesAggregate(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    ctx := context.Background()
+    req := &ComplianceRequest{OrganizationId: "org-1"}
+
+    spy.setScore("SAMA", 90)
+    if _, err := service.CheckCompliance(ctx, req); err != nil {
+        t.Fatal(err)
+    }
+    before := cachedAggregate(t, service, server, "org-1")
+    if before == nil {
+        t.Fatal("no aggregate cached")
+    }
+
+    spy.setScore("SAMA", 20)
+    runs := spy.runs()
+    response, err := service.GetFrameworkResult(ctx, &FrameworkResultRequest{Request: req, Framework: "SAMA", Refresh: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !response.AggregateUpdated || response.Result.Score != 20 {
+        t.Fatalf("refresh scored %v, aggregate updated %t, want 20 and true", response.Result.Score, response.AggregateUpdated)
+    }
+    for framework, n := range spy.runs() {
+        want := runs[framework]
+        if framework == "SAMA" {
+            want++
+        }
+        if n != want {
+            t.Errorf("%s ran %d times, want %d", framework, n, want)
+        }
+    }
+
+    patched := cachedAggregate(t, service, server, "org-1")
+    if patched == nil || len(patched.FrameworkResults) != len(before.FrameworkResults) {
+        t.Fatalf("patched aggregate %v, want all %d frameworks kept", patched, len(before.FrameworkResults))
+    }
+    for _, result := range before.FrameworkResults {
+        got := resultFor(patched, result.Framework)
+        if result.Framework == "SAMA" {
+            if got.Score != 20 {
+                t.Errorf("patched SAMA score %v, want 20", got.Score)
+            }
+        } else if got.Score != result.Score || got.EvaluatedAt != result.EvaluatedAt {
+            t.Errorf("%s changed from %v to %v, want it kept", result.Framework, result.Score, got.Score)
+        }
+    }
+    if response.OverallScore != patched.OverallScore || response.Status != patched.Status || patched.OverallScore == before.OverallScore {
+        t.Errorf("refresh overall %v %s, aggregate %v %s, previously %v", response.OverallScore, response.Status, patched.OverallScore, patched.Status, before.OverallScore)
+    }
+
+    // A full evaluation of the same evidence agrees with the patch
+    dropCachedResults(server)
+    full, err := service.CheckCompliance(ctx, req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if full.OverallScore != patched.OverallScore || full.Status != patched.Status {
+        t.Errorf("full evaluation %v %s, patched aggregate %v %s", full.OverallScore, full.Status, patched.OverallScore, patched.Status)
+    }
+}
+
+// Without refresh the cached result is served; without an aggregate a
+// refresh caches none
+func TestFrameworkResultWithoutPatch(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    ctx := context.Background()
+    req := &ComplianceRequest{OrganizationId: "org-1"}
+
+    response, err := service.GetFrameworkResult(ctx, &FrameworkResultRequest{Request: req, Framework: "SAMA", Refresh: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.AggregateUpdated || cachedAggregate(t, service, server, "org-1") != nil {
+        t.Error("refresh without a cached aggregate created one")
+    }
+
+    if _, err := service.CheckCompliance(ctx, req); err != nil {
+        t.Fatal(err)
+    }
+    runs := spy.runs()
+    response, err = service.GetFrameworkResult(ctx, &FrameworkResultRequest{Request: req, Framework: "SAMA"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.AggregateUpdated || spy.runs()["SAMA"] != runs["SAMA"] {
+        t.Error("cached result evaluated again without refresh")
+    }
+}
@@ -0,0 +1,230 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/encoding/protojson"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Report type producing a gap analysis
+const reportTypeGapAnalysis = "GAP_ANALYSIS"
+
+// Issue of a control with no usable evidence at all
+const controlMissing = "MISSING"
+
+// A failed control and what remediating it is worth
+type gapControl struct {
+    key        string
+    issue      string
+    frameworks []string
+    impact     float64 // Overall score points recovered
+}
+
+// Plan remediation towards a target overall score.
+//
+// Controls are evidence requirement keys without usable evidence. A
+// framework's headroom (100 - score) is split across its failed controls by
+// severity weight, and a control read by several frameworks recovers its
+// share in each, weighted like calculateOverallScore. Every control costs one unit of
+// effort, so the smallest set reaching the target is found greedily by
+// impact; ties break on key so the plan is deterministic.
+func planRemediation(results []*FrameworkResult, failed map[string][]*EvidenceVerdict, weights map[string]float64, controlWeight func(framework, key string) float64, target float64) ([]*gapControl, float64) {
+    totalWeight := 0.0
+    current := 0.0
+    for _, result := range results {
+        if !scoredResult(result) {
+            continue
+        }
+        if weight, ok := weights[result.Framework]; ok {
+            totalWeight += weight
+            current += result.Score * weight
+        }
+    }
+    if totalWeight == 0 {
+        return nil, 0
+    }
+    current /= totalWeight
+
+    controls := make(map[string]*gapControl)
+    for _, result := range results {
+        weight, ok := weights[result.Framework]
+        verdicts := failed[result.Framework]
+        if !ok || len(verdicts) == 0 || !scoredResult(result) {
+            continue
+        }
+        failedWeight := 0.0
+        for _, verdict := range verdicts {
+            failedWeight += controlWeight(result.Framework, verdict.Key)
+        }
+        if failedWeight == 0 {
+            continue
+        }
+        for _, verdict := range verdicts {
+            share := (100 - result.Score) * controlWeight(result.Framework, verdict.Key) / failedWeight * weight / totalWeight
+            control, exists := controls[verdict.Key]
+            if !exists {
+                control = &gapControl{key: verdict.Key, issue: verdict.Verdict}
+                controls[verdict.Key] = control
+            }
+            control.frameworks = append(control.frameworks, result.Framework)
+            control.impact += share
+        }
+    }
+
+    ordered := make([]*gapControl, 0, len(controls))
+    for _, control := range controls {
+        sort.Strings(control.frameworks)
+        ordered = append(ordered, control)
+    }
+    sort.Slice(ordered, func(i, j int) bool {
+        if ordered[i].impact != ordered[j].impact {
+            return ordered[i].impact > ordered[j].impact
+        }
+        return ordered[i].key < ordered[j].key
+    })
+
+    var plan []*gapControl
+    projected := current
+    for _, control := range ordered {
+        if projected >= target {
+            break
+        }
+        plan = append(plan, control)
+        projected += control.impact
+    }
+    return plan, current
+}
+
+// Failed controls per framework: required keys whose evidence is missing,
+// expired or of the wrong type
+func (e *RulesEngine) failedControls(frameworks []string, items []*EvidenceItem, now time.Time) map[string][]*EvidenceVerdict {
+    set, verdicts := e.assembleEvidence(frameworks, items, now)
+    byKey := make(map[string]*EvidenceVerdict, len(verdicts))
+    for _, verdict := range verdicts {
+        byKey[verdict.Key] = verdict
+    }
+
+    failed := make(map[string][]*EvidenceVerdict, len(frameworks))
+    for _, framework := range frameworks {
+        for _, requirement := range e.requirements[framework] {
+            if _, ok := set[requirement.Key]; ok {
+                continue
+            }
+            verdict := &EvidenceVerdict{Key: requirement.Key, Verdict: controlMissing}
+            if existing, ok := byKey[requirement.Key]; ok {
+                verdict.Verdict = existing.Verdict
+            }
+            failed[framework] = append(failed[framework], verdict)
+        }
+    }
+    return failed
+}
+
+// GetGapAnalysis - the smallest set of failed controls whose remediation
+// reaches the target, ordered as a roadmap with cumulative projected scores
+func (s *ComplianceService) GetGapAnalysis(ctx context.Context, req *GapAnalysisRequest) (*GapAnalysis, error) {
+    if req.Request == nil || req.Request.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "request.organization_id is required")
+    }
+    runtime := s.tenantRuntimeConfig(ctx)
+
+    target := req.TargetScore
+    if target == 0 {
+        switch req.TargetStatus {
+        case "", "COMPLIANT":
+            target = runtime.Thresholds.Compliant
+        case "PARTIALLY_COMPLIANT":
+            target = runtime.Thresholds.PartiallyCompliant
+        default:
+            return nil, status.Errorf(codes.InvalidArgument, "unsupported target status %q", req.TargetStatus)
+        }
+    }
+    if target < 0 || target > 100 {
+        return nil, status.Error(codes.InvalidArgument, "target_score must be within [0, 100]")
+    }
+
+    // The roadmap needs every framework's result
+    request := req.Request
+    if request.ShortCircuit {
+        request = proto.Clone(request).(*ComplianceRequest)
+        request.ShortCircuit = false
+    }
+    response, err := s.checkCompliance(ctx, request)
+    if err != nil {
+        return nil, err
+    }
+
+    failed := s.engine.failedControls(s.engine.Frameworks(), req.Request.Evidence, time.Now())
+    plan, current := planRemediation(response.FrameworkResults, failed, runtime.Weights, s.engine.controlWeight, target)
+
+    analysis := &GapAnalysis{
+        OrganizationId: response.OrganizationId,
+        CurrentScore:   roundScore(current),
+        CurrentStatus:  response.Status,
+        TargetScore:    target,
+    }
+
+    projected := current
+    for i, control := range plan {
+        projected += control.impact
+        analysis.Roadmap = append(analysis.Roadmap, &RemediationStep{
+            Order:           int32(i + 1),
+            Control:         control.key,
+            Frameworks:      control.frameworks,
+            Issue:           control.issue,
+            ScoreImpact:     roundScore(control.impact),
+            ProjectedScore:  roundScore(projected),
+            ProjectedStatus: s.determineStatus(projected, runtime.Thresholds),
+            Hint:            remediationHint(control.key, control.issue),
+            Guidance:        s.knowledge.Guidance(control.key, control.frameworks),
+        })
+    }
+
+    // Sub-domain and framework gates cap the status regardless of evidence;
+    // framework gates are only reported when they cap below the target band
+    analysis.Blocking = applySamaSubdomainGates(response.FrameworkResults, runtime.SamaSubdomainGates)
+    samaBlocked := len(analysis.Blocking) > 0 && target >= runtime.Thresholds.Compliant
+    _, gated := applyFrameworkGates(s.determineStatus(target, runtime.Thresholds), response.FrameworkResults, runtime.FrameworkGates)
+    analysis.Blocking = append(analysis.Blocking, gated...)
+    analysis.Achievable = projected >= target && !samaBlocked && len(gated) == 0
+    analysis.NotApplicable = responseAttestations(response)
+    return analysis, nil
+}
+
+// GenerateReport - only the gap-analysis report type is produced here, as JSON
+func (s *ComplianceService) GenerateReport(ctx context.Context, req *ReportRequest) (*ReportResponse, error) {
+    if req.ReportType != reportTypeGapAnalysis {
+        return nil, status.Errorf(codes.Unimplemented, "report type %q is not supported", req.ReportType)
+    }
+    if req.Format != "" && req.Format != "JSON" {
+        return nil, status.Errorf(codes.InvalidArgument, "gap analysis is only available as JSON")
+    }
+
+    analysis, err := s.GetGapAnalysis(ctx, &GapAnalysisRequest{
+        Request:      &ComplianceRequest{OrganizationId: req.OrganizationId, Frameworks: req.Frameworks},
+        TargetStatus: req.TargetStatus,
+        TargetScore:  req.TargetScore,
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    content, err := protojson.Marshal(analysis)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "failed to encode report: %v", err)
+    }
+    return &ReportResponse{
+        ReportId:    fmt.Sprintf("gap-%s-%s", req.OrganizationId, newRequestID()),
+        Content:     content,
+        Format:      "JSON",
+        GeneratedAt: timestamppb.Now(),
+        Metadata:    map[string]string{"report_type": reportTypeGapAnalysis},
+    }, nil
+}
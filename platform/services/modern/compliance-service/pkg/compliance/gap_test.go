@@ -0,0 +1,161 @@
+package compliance
+
+import (
+    "math"
+    "reflect"
+    "testing"
+)
+
+// Expected step of a remediation plan, impact in overall score points
+type wantGap struct {
+    key        string
+    frameworks []string
+    impact     float64
+}
+
+func missing(keys ...string) []*EvidenceVerdict {
+    verdicts := make([]*EvidenceVerdict, len(keys))
+    for i, key := range keys {
+        verdicts[i] = &EvidenceVerdict{Key: key, Verdict: controlMissing}
+    }
+    return verdicts
+}
+
+// Plans worked out by hand from the fixture scores, weights and control
+// severities; the arithmetic is in each case's comment
+func TestPlanRemediation(t *testing.T) {
+    severity := map[string]float64{
+        "NCA/mfa_enforced":    2,
+        "NCA/asset_inventory": 1,
+        "NCA/incident_plan":   1,
+        "SAMA/aml_program":    1,
+        "SAMA/incident_plan":  1,
+        "PDPL/dpo_appointed":  1,
+        "NIST/a_control":      1,
+        "NIST/b_control":      1,
+    }
+    controlWeight := func(framework, key string) float64 { return severity[framework+"/"+key] }
+
+    tests := []struct {
+        name        string
+        results     []*FrameworkResult
+        failed      map[string][]*EvidenceVerdict
+        weights     map[string]float64
+        target      float64
+        wantCurrent float64
+        want        []wantGap
+    }{
+        {
+            // current 60×.5 + 80×.5 = 70. NCA's 40 points split 2:1 are
+            // 26.67 and 13.33, worth half each overall; SAMA's 20 are worth 10.
+            // 70 + 13.33 = 83.33 falls short of 90, + 10 reaches 93.33.
+            name:    "severity split, greedy stop",
+            results: []*FrameworkResult{{Framework: "NCA", Score: 60}, {Framework: "SAMA", Score: 80}},
+            failed: map[string][]*EvidenceVerdict{
+                "NCA":  missing("mfa_enforced", "asset_inventory"),
+                "SAMA": missing("aml_program"),
+            },
+            weights:     map[string]float64{"NCA": 0.5, "SAMA": 0.5},
+            target:      90,
+            wantCurrent: 70,
+            want: []wantGap{
+                {"mfa_enforced", []string{"NCA"}, 40.0 * 2 / 3 * 0.5},
+                {"aml_program", []string{"SAMA"}, 10},
+            },
+        },
+        {
+            // current 50×.6 + 70×.4 = 58. incident_plan recovers 25×.6 = 15
+            // from NCA and 30×.4 = 12 from SAMA, 27 in all: 58 + 27 = 85.
+            name:    "control shared across frameworks",
+            results: []*FrameworkResult{{Framework: "NCA", Score: 50}, {Framework: "SAMA", Score: 70}},
+            failed: map[string][]*EvidenceVerdict{
+                "NCA":  missing("incident_plan", "asset_inventory"),
+                "SAMA": missing("incident_plan"),
+            },
+            weights:     map[string]float64{"NCA": 0.6, "SAMA": 0.4},
+            target:      80,
+            wantCurrent: 58,
+            want: []wantGap{
+                {"incident_plan", []string{"NCA", "SAMA"}, 27},
+            },
+        },
+        {
+            // current 80; both controls recover 10, so key order decides
+            name:        "ties break on key",
+            results:     []*FrameworkResult{{Framework: "NIST", Score: 80}},
+            failed:      map[string][]*EvidenceVerdict{"NIST": missing("b_control", "a_control")},
+            weights:     map[string]float64{"NIST": 1},
+            target:      95,
+            wantCurrent: 80,
+            want: []wantGap{
+                {"a_control", []string{"NIST"}, 10},
+                {"b_control", []string{"NIST"}, 10},
+            },
+        },
+        {
+            // current 70 already meets 70
+            name:        "target already met",
+            results:     []*FrameworkResult{{Framework: "NCA", Score: 60}, {Framework: "SAMA", Score: 80}},
+            failed:      map[string][]*EvidenceVerdict{"NCA": missing("mfa_enforced")},
+            weights:     map[string]float64{"NCA": 0.5, "SAMA": 0.5},
+            target:      70,
+            wantCurrent: 70,
+        },
+        {
+            // PDPL is unweighted and SAMA not applicable: neither counts
+            // towards the score nor contributes controls. current is NCA's 60,
+            // mfa_enforced recovers 26.67 and asset_inventory 13.33; 100 is
+            // only reached with both.
+            name: "unweighted and unscored frameworks ignored",
+            results: []*FrameworkResult{
+                {Framework: "NCA", Score: 60},
+                {Framework: "SAMA", Score: 0, Outcome: frameworkNotApplicable},
+                {Framework: "PDPL", Score: 0},
+            },
+            failed: map[string][]*EvidenceVerdict{
+                "NCA":  missing("mfa_enforced", "asset_inventory"),
+                "SAMA": missing("aml_program"),
+                "PDPL": missing("dpo_appointed"),
+            },
+            weights:     map[string]float64{"NCA": 0.25, "SAMA": 0.25},
+            target:      100,
+            wantCurrent: 60,
+            want: []wantGap{
+                {"mfa_enforced", []string{"NCA"}, 40.0 * 2 / 3},
+                {"asset_inventory", []string{"NCA"}, 40.0 / 3},
+            },
+        },
+        {
+            name:    "nothing weighted",
+            results: []*FrameworkResult{{Framework: "PDPL", Score: 40}},
+            failed:  map[string][]*EvidenceVerdict{"PDPL": missing("dpo_appointed")},
+            weights: map[string]float64{"NCA": 1},
+            target:  90,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            plan, current := planRemediation(tt.results, tt.failed, tt.weights, controlWeight, tt.target)
+            if !approxEqual(current, tt.wantCurrent) {
+                t.Errorf("planRemediation() current = %v, want %v", current, tt.wantCurrent)
+            }
+            if len(plan) != len(tt.want) {
+                t.Fatalf("planRemediation() planned %d controls, want %d", len(plan), len(tt.want))
+            }
+            for i, want := range tt.want {
+                got := plan[i]
+                if got.key != want.key || !reflect.DeepEqual(got.frameworks, want.frameworks) || !approxEqual(got.impact, want.impact) {
+                    t.Errorf("step %d = %s %v %.4f, want %s %v %.4f", i+1, got.key, got.frameworks, got.impact, want.key, want.frameworks, want.impact)
+                }
+                if got.issue != controlMissing {
+                    t.Errorf("step %d issue = %q, want %q", i+1, got.issue, controlMissing)
+                }
+            }
+        })
+    }
+}
+
+func approxEqual(a, b float64) bool {
+    return math.Abs(a-b) < 1e-9
+}
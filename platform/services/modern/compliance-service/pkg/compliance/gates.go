@@ -0,0 +1,66 @@
+package compliance
+
+import (
+    "fmt"
+    "sort"
+)
+
+// Status bands from lowest to highest
+var statusBands = []string{"NON_COMPLIANT", "PARTIALLY_COMPLIANT", "COMPLIANT"}
+
+// Rank of a status band, higher is better
+func statusRank(status string) int {
+    for i, band := range statusBands {
+        if band == status {
+            return i
+        }
+    }
+    return 0
+}
+
+// Validate gating framework minimums against the registered frameworks
+func validateFrameworkGates(gates map[string]StatusThresholds, frameworks []string) error {
+    for framework, gate := range gates {
+        if !containsString(frameworks, framework) {
+            return fmt.Errorf("gate configured for unknown framework %s", framework)
+        }
+        if gate.PartiallyCompliant < 0 || gate.Compliant > 100 || gate.PartiallyCompliant > gate.Compliant {
+            return fmt.Errorf("gate for framework %s must satisfy 0 <= partially_compliant <= compliant <= 100", framework)
+        }
+    }
+    return nil
+}
+
+// The highest status a framework score permits under its gate
+func gateCeiling(score float64, gate StatusThresholds) string {
+    if score < gate.PartiallyCompliant {
+        return "NON_COMPLIANT"
+    } else if score < gate.Compliant {
+        return "PARTIALLY_COMPLIANT"
+    }
+    return "COMPLIANT"
+}
+
+// Apply weakest-link gates to an overall status: a gating framework below
+// its minimum for a band caps the status below that band, however high the
+// weighted average. Returns the capped status and the frameworks that
+// capped it, sorted.
+func applyFrameworkGates(status string, results []*FrameworkResult, gates map[string]StatusThresholds) (string, []string) {
+    capped := status
+    var failed []string
+    for _, result := range results {
+        gate, ok := gates[result.Framework]
+        if !ok || !scoredResult(result) {
+            continue
+        }
+        ceiling := gateCeiling(roundScore(result.Score), gate)
+        if statusRank(ceiling) < statusRank(status) {
+            failed = append(failed, result.Framework)
+        }
+        if statusRank(ceiling) < statusRank(capped) {
+            capped = ceiling
+        }
+    }
+    sort.Strings(failed)
+    return capped, failed
+}
@@ -0,0 +1,110 @@
+package compliance
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestApplyFrameworkGates(t *testing.T) {
+    gates := map[string]StatusThresholds{
+        "NCA":  {Compliant: 80, PartiallyCompliant: 50},
+        "SAMA": {Compliant: 70},
+    }
+    tests := []struct {
+        name    string
+        status  string
+        results []*FrameworkResult
+        want    string
+        failed  []string
+    }{
+        {
+            name:    "gates met",
+            status:  "COMPLIANT",
+            results: []*FrameworkResult{{Framework: "NCA", Score: 80}, {Framework: "SAMA", Score: 70}},
+            want:    "COMPLIANT",
+        },
+        {
+            name:    "below compliant minimum",
+            status:  "COMPLIANT",
+            results: []*FrameworkResult{{Framework: "NCA", Score: 79.9}, {Framework: "SAMA", Score: 95}},
+            want:    "PARTIALLY_COMPLIANT",
+            failed:  []string{"NCA"},
+        },
+        {
+            name:    "weakest link decides",
+            status:  "COMPLIANT",
+            results: []*FrameworkResult{{Framework: "SAMA", Score: 60}, {Framework: "NCA", Score: 40}},
+            want:    "NON_COMPLIANT",
+            failed:  []string{"NCA", "SAMA"},
+        },
+        {
+            name:    "never raises the status",
+            status:  "NON_COMPLIANT",
+            results: []*FrameworkResult{{Framework: "NCA", Score: 100}},
+            want:    "NON_COMPLIANT",
+        },
+        {
+            name:    "unscored gating framework ignored",
+            status:  "COMPLIANT",
+            results: []*FrameworkResult{{Framework: "NCA", Score: 0, Outcome: frameworkNotApplicable}},
+            want:    "COMPLIANT",
+        },
+        {
+            name:    "ungated framework ignored",
+            status:  "COMPLIANT",
+            results: []*FrameworkResult{{Framework: "PDPL", Score: 0}},
+            want:    "COMPLIANT",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, failed := applyFrameworkGates(tt.status, tt.results, gates)
+            if got != tt.want || !reflect.DeepEqual(failed, tt.failed) {
+                t.Errorf("applyFrameworkGates() = %s %v, want %s %v", got, failed, tt.want, tt.failed)
+            }
+        })
+    }
+}
+
+// A low gating framework caps the response status despite a weighted
+// average well inside the COMPLIANT band
+func TestGatingFrameworkCapsResponseStatus(t *testing.T) {
+    service := newPrecisionService()
+    results := func() []*FrameworkResult {
+        return []*FrameworkResult{{Framework: "SAMA", Score: 100}, {Framework: "NCA", Score: 55}, {Framework: "PDPL", Score: 100}}
+    }
+
+    ungated := service.buildResponse("org-1", results(), nil, precisionRuntime())
+    if ungated.OverallScore != 85 || ungated.Status != "COMPLIANT" {
+        t.Fatalf("ungated: %v %s, want 85 COMPLIANT", ungated.OverallScore, ungated.Status)
+    }
+
+    runtime := precisionRuntime()
+    runtime.FrameworkGates = map[string]StatusThresholds{"NCA": {Compliant: 80, PartiallyCompliant: 50}}
+    gated := service.buildResponse("org-1", results(), nil, runtime)
+    if gated.OverallScore != 85 || gated.Status != "PARTIALLY_COMPLIANT" {
+        t.Errorf("gated: %v %s, want 85 capped to PARTIALLY_COMPLIANT", gated.OverallScore, gated.Status)
+    }
+    if gated.ContentHash == ungated.ContentHash {
+        t.Error("content hash unchanged although the status was capped")
+    }
+}
+
+func TestValidateFrameworkGates(t *testing.T) {
+    frameworks := []string{"NCA", "SAMA"}
+    tests := []struct {
+        name  string
+        gates map[string]StatusThresholds
+        ok    bool
+    }{
+        {name: "valid", gates: map[string]StatusThresholds{"NCA": {Compliant: 80, PartiallyCompliant: 50}}, ok: true},
+        {name: "unknown framework", gates: map[string]StatusThresholds{"GDPR": {Compliant: 80}}},
+        {name: "bands inverted", gates: map[string]StatusThresholds{"NCA": {Compliant: 50, PartiallyCompliant: 80}}},
+        {name: "above 100", gates: map[string]StatusThresholds{"NCA": {Compliant: 120}}},
+    }
+    for _, tt := range tests {
+        if err := validateFrameworkGates(tt.gates, frameworks); (err == nil) != tt.ok {
+            t.Errorf("%s: validateFrameworkGates() = %v, want ok %t", tt.name, err, tt.ok)
+        }
+    }
+}
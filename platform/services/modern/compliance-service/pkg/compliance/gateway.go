@@ -0,0 +1,486 @@
+package compliance
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "encoding/json"
+    "io"
+    "math"
+    "net/http"
+    "strconv"
+    "time"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/encoding/protojson"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Cache status reported in the envelope meta
+const (
+    cacheStatusHit    = "HIT"
+    cacheStatusMiss   = "MISS"
+    cacheStatusBypass = "BYPASS"
+)
+
+// Envelope - consistent wrapper for every HTTP/JSON gateway response.
+// gRPC callers keep receiving bare messages.
+type Envelope struct {
+    Data   json.RawMessage `json:"data"`
+    Meta   EnvelopeMeta    `json:"meta"`
+    Errors []EnvelopeError `json:"errors"`
+}
+
+// EnvelopeMeta - request metadata returned with every response
+type EnvelopeMeta struct {
+    RequestID   string          `json:"request_id"`
+    DurationMs  float64         `json:"duration_ms"`
+    CacheStatus string          `json:"cache_status"`
+    Pagination  *PaginationMeta `json:"pagination,omitempty"`
+    Debug       *DebugMeta      `json:"debug,omitempty"`
+}
+
+// DebugMeta - diagnostics for the request, such as the trace sampling decision
+type DebugMeta struct {
+    Trace *TraceDecision `json:"trace,omitempty"`
+}
+
+// PaginationMeta - populated for responses that carry page tokens or counts
+type PaginationMeta struct {
+    NextPageToken string `json:"next_page_token,omitempty"`
+    TotalCount    int64  `json:"total_count,omitempty"`
+}
+
+// EnvelopeError - a client-facing error entry
+type EnvelopeError struct {
+    Code    string `json:"code"`
+    Reason  string `json:"reason,omitempty"` // Stable error code, see errors.go
+    Message string `json:"message"`
+}
+
+// Per-request information shared between the gateway and the service
+type requestInfo struct {
+    id          string
+    cacheStatus string
+    retryAfter  time.Duration
+}
+
+type requestInfoKey struct{}
+
+// Record the cache outcome for the current request, if a gateway is listening
+func setCacheStatus(ctx context.Context, cacheStatus string) {
+    if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+        info.cacheStatus = cacheStatus
+    }
+}
+
+// Advise the caller when to retry: a Retry-After header on the gateway, a
+// retry-after response header for gRPC callers
+func setRetryAfter(ctx context.Context, retryAfter time.Duration) {
+    if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+        info.retryAfter = retryAfter
+        return
+    }
+    grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+}
+
+// Request ID from the gateway or the x-request-id gRPC header, generated
+// when the caller supplied none
+func requestID(ctx context.Context) string {
+    if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok && info.id != "" {
+        return info.id
+    }
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        if values := md.Get("x-request-id"); len(values) > 0 && values[0] != "" {
+            return values[0]
+        }
+    }
+    return newRequestID()
+}
+
+// A gateway route: decodes the body into a fresh request and calls the service
+type gatewayRoute struct {
+    Method  string
+    Path    string
+    RPC     string
+    Request func() proto.Message
+    Call    func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+// Gateway - HTTP/JSON front end for the compliance service
+type Gateway struct {
+    service *ComplianceService
+    mux     *http.ServeMux
+    routes  []gatewayRoute
+}
+
+// Create the HTTP/JSON gateway for a service
+func NewGateway(service *ComplianceService) *Gateway {
+    g := &Gateway{service: service, mux: http.NewServeMux()}
+
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/check",
+        RPC:     "CheckCompliance",
+        Request: func() proto.Message { return &ComplianceRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.CheckCompliance(ctx, req.(*ComplianceRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/gap-analysis",
+        RPC:     "GetGapAnalysis",
+        Request: func() proto.Message { return &GapAnalysisRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetGapAnalysis(ctx, req.(*GapAnalysisRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/replay",
+        RPC:     "ReplayCompliance",
+        Request: func() proto.Message { return &ReplayRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ReplayCompliance(ctx, req.(*ReplayRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/latest",
+        RPC:     "ListLatestResults",
+        Request: func() proto.Message { return &ListLatestResultsRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListLatestResults(ctx, req.(*ListLatestResultsRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/organizations",
+        RPC:     "ListOrganizations",
+        Request: func() proto.Message { return &ListOrganizationsRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListOrganizations(ctx, req.(*ListOrganizationsRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/framework",
+        RPC:     "GetFrameworkResult",
+        Request: func() proto.Message { return &FrameworkResultRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetFrameworkResult(ctx, req.(*FrameworkResultRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/history",
+        RPC:     "GetComplianceHistory",
+        Request: func() proto.Message { return &ComplianceHistoryRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetComplianceHistory(ctx, req.(*ComplianceHistoryRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/status-timeline",
+        RPC:     "GetStatusTimeline",
+        Request: func() proto.Message { return &StatusTimelineRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetStatusTimeline(ctx, req.(*StatusTimelineRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/schedule-runs",
+        RPC:     "ListScheduleRuns",
+        Request: func() proto.Message { return &ListScheduleRunsRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListScheduleRuns(ctx, req.(*ListScheduleRunsRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/pinned-result",
+        RPC:     "GetPinnedResult",
+        Request: func() proto.Message { return &GetPinnedResultRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetPinnedResult(ctx, req.(*GetPinnedResultRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/compliance/run-timings",
+        RPC:     "GetRunTimings",
+        Request: func() proto.Message { return &RunTimingsRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetRunTimings(ctx, req.(*RunTimingsRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/evidence/validate",
+        RPC:     "ValidateEvidence",
+        Request: func() proto.Message { return &ValidateEvidenceRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ValidateEvidence(ctx, req.(*ValidateEvidenceRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/evidence/verify",
+        RPC:     "VerifyEvidence",
+        Request: func() proto.Message { return &VerifyEvidenceRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.VerifyEvidence(ctx, req.(*VerifyEvidenceRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/organizations/aliases",
+        RPC:     "RegisterAlias",
+        Request: func() proto.Message { return &RegisterAliasRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.RegisterAlias(ctx, req.(*RegisterAliasRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/organizations/resolve",
+        RPC:     "ResolveOrganization",
+        Request: func() proto.Message { return &ResolveOrganizationRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ResolveOrganization(ctx, req.(*ResolveOrganizationRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/organizations/attestations",
+        RPC:     "ListAttestations",
+        Request: func() proto.Message { return &ListAttestationsRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListAttestations(ctx, req.(*ListAttestationsRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/regulatory-milestones",
+        RPC:     "ListRegulatoryMilestones",
+        Request: func() proto.Message { return &ListRegulatoryMilestonesRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListRegulatoryMilestones(ctx, req.(*ListRegulatoryMilestonesRequest))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodPost,
+        Path:    "/v1/usage/report",
+        RPC:     "GetUsageReport",
+        Request: func() proto.Message { return &UsageReportRequest{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetUsageReport(ctx, req.(*UsageReportRequest))
+        },
+    })
+
+    g.handle(gatewayRoute{
+        Method:  http.MethodGet,
+        Path:    "/v1/frameworks",
+        RPC:     "ListFrameworks",
+        Request: func() proto.Message { return &emptypb.Empty{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListFrameworks(ctx, req.(*emptypb.Empty))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodGet,
+        Path:    "/v1/regions",
+        RPC:     "ListRegions",
+        Request: func() proto.Message { return &emptypb.Empty{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.ListRegions(ctx, req.(*emptypb.Empty))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodGet,
+        Path:    "/v1/capacity",
+        RPC:     "GetCapacity",
+        Request: func() proto.Message { return &emptypb.Empty{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetCapacity(ctx, req.(*emptypb.Empty))
+        },
+    })
+    g.handle(gatewayRoute{
+        Method:  http.MethodGet,
+        Path:    "/v1/service-config",
+        RPC:     "GetServiceConfig",
+        Request: func() proto.Message { return &emptypb.Empty{} },
+        Call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+            return service.GetServiceConfig(ctx, req.(*emptypb.Empty))
+        },
+    })
+
+    if service.config.GatewayDocs {
+        g.serveDocs()
+    }
+    return g
+}
+
+// ServeHTTP implements http.Handler
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    g.mux.ServeHTTP(w, r)
+}
+
+func (g *Gateway) handle(route gatewayRoute) {
+    g.routes = append(g.routes, route)
+    g.mux.HandleFunc(route.Path, func(w http.ResponseWriter, r *http.Request) {
+        g.serve(route, w, r)
+    })
+}
+
+func (g *Gateway) serve(route gatewayRoute, w http.ResponseWriter, r *http.Request) {
+    startTime := time.Now()
+    info := &requestInfo{id: r.Header.Get("X-Request-ID"), cacheStatus: cacheStatusBypass}
+    if info.id == "" {
+        info.id = newRequestID()
+    }
+
+    ctx := context.WithValue(r.Context(), requestInfoKey{}, info)
+    traceID := traceIDFromTraceparent(r.Header.Get(traceparentHeader))
+    if traceID == "" {
+        traceID = info.id
+    }
+    decision := g.service.sampler.Decide(traceID)
+    md := metadata.MD{}
+    if tenant := r.Header.Get("X-Tenant-ID"); tenant != "" {
+        md.Set(tenantMetadataKey, tenant)
+    }
+    if token := r.Header.Get("X-Tenant-Token"); token != "" {
+        md.Set(tenantTokenMetadataKey, token)
+    }
+    if authorization := r.Header.Get("Authorization"); authorization != "" {
+        md.Set("authorization", authorization)
+    }
+    ctx = metadata.NewIncomingContext(ctx, md)
+
+    ctx, trace := g.service.sampler.Begin(ctx, traceID)
+    var response proto.Message
+    err := status.Error(codes.Unimplemented, "method not allowed")
+    if r.Method == route.Method {
+        // Routes call the service directly, past the gRPC interceptors
+        var authenticated context.Context
+        if authenticated, err = g.service.authenticateTenant(ctx); err == nil {
+            response, err = g.invoke(authenticated, route, r)
+        }
+    }
+    g.service.sampler.Finish(trace, route.RPC, startTime, err)
+
+    envelope := Envelope{
+        Data:   json.RawMessage("null"),
+        Errors: []EnvelopeError{},
+        Meta: EnvelopeMeta{
+            RequestID:   info.id,
+            CacheStatus: info.cacheStatus,
+            Debug:       &DebugMeta{Trace: &decision},
+        },
+    }
+
+    httpStatus := http.StatusOK
+    if err != nil {
+        st := status.Convert(g.service.publicError(ctx, route.RPC, err))
+        httpStatus = httpStatusFromCode(st.Code())
+        if r.Method != route.Method {
+            httpStatus = http.StatusMethodNotAllowed
+        }
+        envelope.Errors = append(envelope.Errors, EnvelopeError{Code: st.Code().String(), Reason: errorReason(st), Message: st.Message()})
+    } else if data, marshalErr := protojson.Marshal(response); marshalErr != nil {
+        httpStatus = http.StatusInternalServerError
+        envelope.Errors = append(envelope.Errors, EnvelopeError{Code: codes.Internal.String(), Reason: reasonInternal, Message: "failed to encode response"})
+    } else {
+        envelope.Data = data
+        envelope.Meta.Pagination = paginationMeta(response)
+    }
+
+    envelope.Meta.DurationMs = float64(time.Since(startTime).Microseconds()) / 1000
+
+    w.Header().Set("Content-Type", "application/json")
+    w.Header().Set("X-Request-ID", info.id)
+    w.Header().Set("X-Trace-Sampled", strconv.FormatBool(decision.Sampled))
+    if info.retryAfter > 0 {
+        w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(info.retryAfter.Seconds()))))
+    }
+    w.WriteHeader(httpStatus)
+    json.NewEncoder(w).Encode(envelope)
+}
+
+func (g *Gateway) invoke(ctx context.Context, route gatewayRoute, r *http.Request) (proto.Message, error) {
+    req := route.Request()
+    body, err := io.ReadAll(io.LimitReader(r.Body, 4<<20))
+    if err != nil {
+        return nil, status.Error(codes.InvalidArgument, "failed to read request body")
+    }
+    if len(body) > 0 {
+        if err := protojson.Unmarshal(body, req); err != nil {
+            return nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+        }
+    }
+    return route.Call(ctx, req)
+}
+
+// Pick up page token and total count fields from list responses
+func paginationMeta(response proto.Message) *PaginationMeta {
+    fields := response.ProtoReflect().Descriptor().Fields()
+    msg := response.ProtoReflect()
+
+    meta := &PaginationMeta{}
+    found := false
+    if fd := fields.ByName("next_page_token"); fd != nil {
+        meta.NextPageToken = msg.Get(fd).String()
+        found = true
+    }
+    if fd := fields.ByName("total_count"); fd != nil {
+        meta.TotalCount = msg.Get(fd).Int()
+        found = true
+    }
+    if !found {
+        return nil
+    }
+    return meta
+}
+
+func newRequestID() string {
+    b := make([]byte, 8)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
+
+// Map gRPC status codes to HTTP status codes
+func httpStatusFromCode(code codes.Code) int {
+    switch code {
+    case codes.OK:
+        return http.StatusOK
+    case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+        return http.StatusBadRequest
+    case codes.Unauthenticated:
+        return http.StatusUnauthorized
+    case codes.PermissionDenied:
+        return http.StatusForbidden
+    case codes.NotFound:
+        return http.StatusNotFound
+    case codes.AlreadyExists, codes.Aborted:
+        return http.StatusConflict
+    case codes.ResourceExhausted:
+        return http.StatusTooManyRequests
+    case codes.Canceled:
+        return 499
+    case codes.Unimplemented:
+        return http.StatusNotImplemented
+    case codes.Unavailable:
+        return http.StatusServiceUnavailable
+    case codes.DeadlineExceeded:
+        return http.StatusGatewayTimeout
+    }
+    return http.StatusInternalServerError
+}
@@ -0,0 +1,113 @@
+package compliance
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "google.golang.org/protobuf/encoding/protojson"
+)
+
+// Envelope of a gateway call, with the HTTP status
+func callGateway(t *testing.T, gateway *Gateway, method, path, body string, header http.Header) (int, http.Header, Envelope) {
+    t.Helper()
+    r := httptest.NewRequest(method, path, strings.NewReader(body))
+    for key, values := range header {
+        r.Header[key] = values
+    }
+    w := httptest.NewRecorder()
+    gateway.ServeHTTP(w, r)
+
+    var envelope Envelope
+    if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+        t.Fatalf("%s %s: response is not an envelope: %v\n%s", method, path, err, w.Body)
+    }
+    return w.Code, w.Header(), envelope
+}
+
+func TestGatewayEnvelope(t *testing.T) {
+    service, _ := newTestService(t)
+    gateway := NewGateway(service)
+
+    tests := []struct {
+        name        string
+        method      string
+        body        string
+        requestID   string
+        status      int
+        cacheStatus string
+        errorCode   string
+    }{
+        {name: "first check", method: http.MethodPost, body: `{"organization_id":"org-1"}`, requestID: "req-1", status: http.StatusOK, cacheStatus: cacheStatusMiss},
+        {name: "repeated check", method: http.MethodPost, body: `{"organization_id":"org-1"}`, requestID: "req-2", status: http.StatusOK, cacheStatus: cacheStatusHit},
+        {name: "generated request ID", method: http.MethodPost, body: `{"organization_id":"org-1"}`, status: http.StatusOK, cacheStatus: cacheStatusHit},
+        {name: "malformed body", method: http.MethodPost, body: `{"organization_id":`, requestID: "req-3", status: http.StatusBadRequest, cacheStatus: cacheStatusBypass, errorCode: "InvalidArgument"},
+        {name: "unknown framework", method: http.MethodPost, body: `{"organization_id":"org-1","frameworks":["GDPR"]}`, requestID: "req-4", status: http.StatusBadRequest, cacheStatus: cacheStatusBypass, errorCode: "InvalidArgument"},
+        {name: "wrong method", method: http.MethodGet, requestID: "req-5", status: http.StatusMethodNotAllowed, cacheStatus: cacheStatusBypass, errorCode: "Unimplemented"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            header := http.Header{}
+            if tt.requestID != "" {
+                header.Set("X-Request-ID", tt.requestID)
+            }
+            code, responseHeader, envelope := callGateway(t, gateway, tt.method, "/v1/compliance/check", tt.body, header)
+
+            if code != tt.status {
+                t.Errorf("HTTP %d, want %d", code, tt.status)
+            }
+            if tt.requestID != "" && envelope.Meta.RequestID != tt.requestID {
+                t.Errorf("meta.request_id %q, want %q", envelope.Meta.RequestID, tt.requestID)
+            }
+            if envelope.Meta.RequestID == "" || responseHeader.Get("X-Request-ID") != envelope.Meta.RequestID {
+                t.Errorf("meta.request_id %q, X-Request-ID header %q", envelope.Meta.RequestID, responseHeader.Get("X-Request-ID"))
+            }
+            if envelope.Meta.CacheStatus != tt.cacheStatus {
+                t.Errorf("meta.cache_status %q, want %q", envelope.Meta.CacheStatus, tt.cacheStatus)
+            }
+            if envelope.Meta.DurationMs <= 0 {
+                t.Errorf("meta.duration_ms %v, want a positive duration", envelope.Meta.DurationMs)
+            }
+            if envelope.Errors == nil {
+                t.Error("errors is null, want a list")
+            }
+
+            if tt.errorCode == "" {
+                var data ComplianceResponse
+                if len(envelope.Errors) != 0 {
+                    t.Errorf("errors %v, want none", envelope.Errors)
+                }
+                if err := protojson.Unmarshal(envelope.Data, &data); err != nil || data.OrganizationId != "org-1" {
+                    t.Errorf("data %s, want the compliance response for org-1", envelope.Data)
+                }
+                return
+            }
+            if string(envelope.Data) != "null" {
+                t.Errorf("data %s, want null", envelope.Data)
+            }
+            if len(envelope.Errors) != 1 || envelope.Errors[0].Code != tt.errorCode || envelope.Errors[0].Message == "" {
+                t.Errorf("errors %+v, want one %s with a message", envelope.Errors, tt.errorCode)
+            }
+        })
+    }
+}
+
+func TestGatewayEnvelopePagination(t *testing.T) {
+    service, _ := newTestService(t)
+    gateway := NewGateway(service)
+    for _, org := range []string{"org-1", "org-2", "org-3"} {
+        if code, _, envelope := callGateway(t, gateway, http.MethodPost, "/v1/compliance/check", `{"organization_id":"`+org+`"}`, nil); code != http.StatusOK {
+            t.Fatalf("check %s: HTTP %d %v", org, code, envelope.Errors)
+        }
+    }
+
+    code, _, envelope := callGateway(t, gateway, http.MethodPost, "/v1/organizations", `{"page_size":2}`, nil)
+    if code != http.StatusOK {
+        t.Fatalf("HTTP %d %v", code, envelope.Errors)
+    }
+    if envelope.Meta.Pagination == nil || envelope.Meta.Pagination.NextPageToken == "" {
+        t.Errorf("meta.pagination %+v, want a next page token", envelope.Meta.Pagination)
+    }
+}
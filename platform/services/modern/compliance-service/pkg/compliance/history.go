@@ -0,0 +1,154 @@
+package compliance
+
+import (
+    "context"
+    "strconv"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+const (
+    // Scores kept per organization and framework; also the largest trend
+    scoreHistoryMaxPoints     = 30
+    scoreHistoryDefaultPoints = 10
+
+    // History of organizations no longer checked expires after this long
+    scoreHistoryRetention = 90 * 24 * time.Hour
+)
+
+// ScoreHistory - the most recent framework scores of each organization,
+// newest first in a capped Redis list per organization and framework
+type ScoreHistory struct {
+    redis *redis.Client
+}
+
+// Create a score history store
+func NewScoreHistory(client *redis.Client) *ScoreHistory {
+    return &ScoreHistory{redis: client}
+}
+
+// Appends scores only if the writer's fencing token is not older than the
+// last one recorded for the organization and scope. KEYS[1] is the fence,
+// KEYS[2:] the score lists; ARGV is the token, retention in ms, max points,
+// then scores.
+var recordHistoryScript = redis.NewScript(`
+local fence = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) < fence then
+    return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+for i = 2, #KEYS do
+    redis.call('LPUSH', KEYS[i], ARGV[i + 2])
+    redis.call('LTRIM', KEYS[i], 0, tonumber(ARGV[3]) - 1)
+    redis.call('PEXPIRE', KEYS[i], ARGV[2])
+end
+return 1
+`)
+
+// Record the framework scores of a freshly evaluated response, fenced by the
+// evaluation lease. A writer whose lease was superseded by a newer
+// evaluation of the same scope gets errStaleFence and its scores are
+// dropped, so history rows stay in evaluation order. Evaluations of other
+// scopes run concurrently by design and never fence each other.
+func (h *ScoreHistory) Record(ctx context.Context, response *ComplianceResponse, lease *EvaluationLease) error {
+    keys := []string{scoreHistoryFenceKey(response.OrganizationId, lease.Scope)}
+    args := []interface{}{lease.Token, scoreHistoryRetention.Milliseconds(), scoreHistoryMaxPoints}
+    for _, result := range response.FrameworkResults {
+        if !scoredResult(result) || result.Outcome == frameworkStale {
+            continue
+        }
+        keys = append(keys, scoreHistoryKey(response.OrganizationId, result.Framework))
+        args = append(args, strconv.FormatFloat(result.Score, 'f', -1, 64))
+    }
+
+    recorded, err := recordHistoryScript.Run(ctx, h.redis, keys, args...).Int()
+    if err != nil {
+        return err
+    }
+    if recorded == 0 {
+        return errStaleFence
+    }
+    return nil
+}
+
+// Attach the last points scores of each framework to its result, oldest
+// first. points is clamped to the stored history.
+func (h *ScoreHistory) AttachTrends(ctx context.Context, response *ComplianceResponse, points int) error {
+    if points <= 0 {
+        points = scoreHistoryDefaultPoints
+    }
+    if points > scoreHistoryMaxPoints {
+        points = scoreHistoryMaxPoints
+    }
+
+    pipe := h.redis.Pipeline()
+    cmds := make([]*redis.StringSliceCmd, len(response.FrameworkResults))
+    for i, result := range response.FrameworkResults {
+        cmds[i] = pipe.LRange(ctx, scoreHistoryKey(response.OrganizationId, result.Framework), 0, int64(points-1))
+    }
+    if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+        return err
+    }
+
+    for i, result := range response.FrameworkResults {
+        values := cmds[i].Val()
+        trend := make([]float64, 0, len(values))
+        for j := len(values) - 1; j >= 0; j-- {
+            if score, err := strconv.ParseFloat(values[j], 64); err == nil {
+                trend = append(trend, score)
+            }
+        }
+        result.Trend = trend
+    }
+    return nil
+}
+
+// Folds one score list into another, then drops it. KEYS[1] is the source
+// list, KEYS[2] the target; ARGV is whether the source leads, retention in
+// ms and max points.
+var mergeHistoryScript = redis.NewScript(`
+local from = redis.call('LRANGE', KEYS[1], 0, -1)
+if #from == 0 then
+    return 0
+end
+if ARGV[1] == '1' then
+    local to = redis.call('LRANGE', KEYS[2], 0, tonumber(ARGV[3]) - 1)
+    redis.call('DEL', KEYS[2])
+    redis.call('RPUSH', KEYS[2], unpack(from))
+    if #to > 0 then
+        redis.call('RPUSH', KEYS[2], unpack(to))
+    end
+else
+    redis.call('RPUSH', KEYS[2], unpack(from))
+end
+redis.call('LTRIM', KEYS[2], 0, tonumber(ARGV[3]) - 1)
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// Merge folds the source's scores of each framework into the target's.
+// Lists hold no times, so one organization's scores lead as the newer
+// whole: the source's if sourceFirst, else the target's.
+func (h *ScoreHistory) Merge(ctx context.Context, source, target string, frameworks []string, sourceFirst bool) error {
+    lead := "0"
+    if sourceFirst {
+        lead = "1"
+    }
+    for _, framework := range frameworks {
+        keys := []string{scoreHistoryKey(source, framework), scoreHistoryKey(target, framework)}
+        if err := mergeHistoryScript.Run(ctx, h.redis, keys, lead, scoreHistoryRetention.Milliseconds(), scoreHistoryMaxPoints).Err(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func scoreHistoryFenceKey(organizationID, scope string) string {
+    return "score-history-fence:" + organizationID + ":" + scope
+}
+
+func scoreHistoryKey(organizationID, framework string) string {
+    return "score-history:" + organizationID + ":" + framework
+}
@@ -0,0 +1,166 @@
+package compliance
+
+import (
+    "context"
+    "log"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/protobuf/proto"
+)
+
+// Claimed by the replica pruning evaluation history, so one prunes per interval
+const historyPruneClaimKey = "evaluations:prune"
+
+// Prune the evaluation history of every organization in batches: index
+// entries past retention, whose records have expired, and beyond maxRuns
+// (0 for no limit) the oldest runs and their records. Records of pinned
+// runs are never deleted. Each batch is a few small commands, so queries
+// interleave with pruning. Returns the runs pruned.
+func (e *EvaluationStore) Prune(ctx context.Context, maxRuns, batch int) (int, error) {
+    cutoff := strconv.FormatInt(time.Now().Add(-e.retention).Unix(), 10)
+    pruned := 0
+    iter := e.redis.Scan(ctx, 0, evaluationIndexKey("*"), int64(batch)).Iterator()
+    for iter.Next(ctx) {
+        organizationID := strings.TrimPrefix(iter.Val(), evaluationIndexKey(""))
+        expired, err := e.redis.ZRemRangeByScore(ctx, iter.Val(), "-inf", cutoff).Result()
+        if err != nil {
+            return pruned, err
+        }
+        pruned += int(expired)
+        if maxRuns <= 0 {
+            continue
+        }
+        n, err := e.pruneExcess(ctx, organizationID, maxRuns, batch)
+        pruned += n
+        if err != nil {
+            return pruned, err
+        }
+    }
+    return pruned, iter.Err()
+}
+
+// Delete an organization's oldest unpinned runs until at most maxRuns
+// runs remain or only pinned ones are left over, batch at a time
+func (e *EvaluationStore) pruneExcess(ctx context.Context, organizationID string, maxRuns, batch int) (int, error) {
+    index := evaluationIndexKey(organizationID)
+    count, err := e.redis.ZCard(ctx, index).Result()
+    if err != nil {
+        return 0, err
+    }
+    excess := int(count) - maxRuns
+    if excess <= 0 {
+        return 0, nil
+    }
+    pinned, err := e.pinnedRuns(ctx, organizationID)
+    if err != nil {
+        return 0, err
+    }
+
+    pruned, kept := 0, 0
+    for pruned < excess {
+        size := batch
+        if remaining := excess - pruned; remaining < size {
+            size = remaining
+        }
+        // Pinned runs are left in place; read past them
+        ids, err := e.redis.ZRange(ctx, index, int64(kept), int64(kept+size-1)).Result()
+        if err != nil || len(ids) == 0 {
+            return pruned, err
+        }
+        var doomed []interface{}
+        pipe := e.redis.Pipeline()
+        for _, id := range ids {
+            if pinned[id] {
+                kept++
+                continue
+            }
+            doomed = append(doomed, id)
+            pipe.Del(ctx, evaluationRecordKey(organizationID, id))
+        }
+        if len(doomed) > 0 {
+            pipe.ZRem(ctx, index, doomed...)
+            if _, err := pipe.Exec(ctx); err != nil {
+                return pruned, err
+            }
+        }
+        pruned += len(doomed)
+        if kept >= int(count) {
+            break
+        }
+    }
+    return pruned, nil
+}
+
+// Run IDs of an organization's pinned runs
+func (e *EvaluationStore) pinnedRuns(ctx context.Context, organizationID string) (map[string]bool, error) {
+    values, err := e.redis.HVals(ctx, pinnedResultsKey(organizationID)).Result()
+    if err != nil {
+        return nil, err
+    }
+    pinned := make(map[string]bool, len(values))
+    for _, data := range values {
+        pin := &PinnedResult{}
+        if err := proto.Unmarshal([]byte(data), pin); err == nil {
+            pinned[pin.RunId] = true
+        }
+    }
+    return pinned, nil
+}
+
+// Prune evaluation history every interval on whichever replica claims it
+func (s *ComplianceService) runHistoryPruning(ctx context.Context) {
+    interval := s.config.HistoryPruneInterval
+    if interval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+        claimed, err := s.redis.SetNX(ctx, historyPruneClaimKey, "running", interval).Result()
+        if err != nil || !claimed {
+            continue
+        }
+        start := time.Now()
+        pruned, err := s.replays.Prune(ctx, s.config.HistoryMaxRuns, s.config.HistoryPruneBatch)
+        historyPrunedRuns.Add(float64(pruned))
+        if err != nil {
+            log.Printf("Evaluation history pruning stopped after %d runs: %v", pruned, err)
+            continue
+        }
+        historyPruneDuration.Observe(time.Since(start).Seconds())
+        if pruned > 0 {
+            log.Printf("Pruned %d runs from evaluation history in %s", pruned, time.Since(start).Round(time.Millisecond))
+        }
+    }
+}
+
+// History retention metrics
+var (
+    historyPrunedRuns = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_history_pruned_runs_total",
+            Help: "Stored evaluations dropped from history for age or the per-organization limit",
+        },
+    )
+
+    historyPruneDuration = prometheus.NewHistogram(
+        prometheus.HistogramOpts{
+            Name:    "compliance_history_prune_duration_seconds",
+            Help:    "Duration of completed evaluation history pruning passes",
+            Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(historyPrunedRuns)
+    prometheus.MustRegister(historyPruneDuration)
+}
@@ -0,0 +1,162 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/redis/go-redis/v9"
+)
+
+func newTestEvaluationStore(t *testing.T, retention time.Duration) (*EvaluationStore, *redis.Client) {
+    server := miniredis.RunT(t)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    return NewEvaluationStore(client, retention, evaluationFormatFull), client
+}
+
+// Store runs of an organization evaluated at each time, named org/run-N
+func storeRuns(t *testing.T, store *EvaluationStore, organizationID string, times ...time.Time) []string {
+    t.Helper()
+    var ids []string
+    for i, at := range times {
+        id := fmt.Sprintf("%s/run-%d", organizationID, i)
+        req := &ComplianceRequest{OrganizationId: organizationID}
+        if err := store.Store(context.Background(), id, DefaultRulesetVersion, "tenant:"+defaultTenant, req, &ComplianceResponse{OrganizationId: organizationID, Timestamp: at.Unix()}); err != nil {
+            t.Fatal(err)
+        }
+        ids = append(ids, id)
+    }
+    return ids
+}
+
+func indexedRuns(t *testing.T, client *redis.Client, organizationID string) []string {
+    t.Helper()
+    ids, err := client.ZRange(context.Background(), evaluationIndexKey(organizationID), 0, -1).Result()
+    if err != nil {
+        t.Fatal(err)
+    }
+    return ids
+}
+
+// Index entries past retention are dropped; runs within it survive
+func TestPruneDropsRunsPastRetention(t *testing.T) {
+    ctx := context.Background()
+    store, client := newTestEvaluationStore(t, 24*time.Hour)
+    now := time.Now()
+    recent := storeRuns(t, store, "org-1", now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+    // Entries left behind by records that have already expired
+    for i, age := range []time.Duration{48 * time.Hour, 30 * time.Hour, 25 * time.Hour} {
+        client.ZAdd(ctx, evaluationIndexKey("org-1"), redis.Z{Score: float64(now.Add(-age).Unix()), Member: fmt.Sprintf("org-1/old-%d", i)})
+    }
+    other := storeRuns(t, store, "org-2", now)
+
+    pruned, err := store.Prune(ctx, 0, 1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if pruned != 3 {
+        t.Errorf("Prune() = %d, want the 3 runs past retention", pruned)
+    }
+    if got := indexedRuns(t, client, "org-1"); !reflect.DeepEqual(got, recent) {
+        t.Errorf("org-1 runs %v, want %v", got, recent)
+    }
+    if got := indexedRuns(t, client, "org-2"); !reflect.DeepEqual(got, other) {
+        t.Errorf("org-2 runs %v, want %v", got, other)
+    }
+    for _, id := range recent {
+        if _, err := store.Load(ctx, "org-1", id); err != nil {
+            t.Errorf("Load(%s) = %v, want the run kept", id, err)
+        }
+    }
+}
+
+// Beyond the per-organization limit the oldest runs and their records go,
+// in batches; pinned runs are kept and count towards the limit
+func TestPruneCapsRunsPerOrganization(t *testing.T) {
+    for _, batch := range []int{1, 2, 500} {
+        t.Run(fmt.Sprintf("batch %d", batch), func(t *testing.T) {
+            ctx := context.Background()
+            store, client := newTestEvaluationStore(t, 24*time.Hour)
+            now := time.Now()
+            var times []time.Time
+            for i := 6; i > 0; i-- {
+                times = append(times, now.Add(-time.Duration(i)*time.Minute))
+            }
+            ids := storeRuns(t, store, "org-1", times...)
+            if err := store.Pin(ctx, &PinnedResult{OrganizationId: "org-1", Period: "2026-Q1", RunId: ids[1]}); err != nil {
+                t.Fatal(err)
+            }
+            small := storeRuns(t, store, "org-2", now)
+
+            pruned, err := store.Prune(ctx, 2, batch)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if pruned != 4 {
+                t.Errorf("Prune() = %d, want the 4 unpinned runs beyond the limit", pruned)
+            }
+            want := []string{ids[1], ids[5]}
+            if got := indexedRuns(t, client, "org-1"); !reflect.DeepEqual(got, want) {
+                t.Errorf("org-1 runs %v, want the pinned run and the newest %v", got, want)
+            }
+            for i, id := range ids {
+                _, err := store.Load(ctx, "org-1", id)
+                if kept := containsString(want, id); (err == nil) != kept {
+                    t.Errorf("Load(run %d) = %v, want kept %t", i, err, kept)
+                }
+            }
+            if got := indexedRuns(t, client, "org-2"); !reflect.DeepEqual(got, small) {
+                t.Errorf("org-2 runs %v, want %v under the limit", got, small)
+            }
+        })
+    }
+}
+
+// Only the replica holding the claim prunes
+func TestHistoryPruningClaimed(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.HistoryMaxRuns = 1
+        config.HistoryPruneInterval = 20 * time.Millisecond
+    })
+    now := time.Now()
+    storeRuns(t, service.replays, "org-1", now.Add(-2*time.Minute), now.Add(-time.Minute), now)
+    before := testutil.ToFloat64(historyPrunedRuns)
+
+    server.Set(historyPruneClaimKey, "another replica")
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan struct{})
+    go func() {
+        service.runHistoryPruning(ctx)
+        close(done)
+    }()
+    defer func() {
+        cancel()
+        <-done
+    }()
+
+    time.Sleep(100 * time.Millisecond)
+    if got := indexedRuns(t, service.redis, "org-1"); len(got) != 3 {
+        t.Fatalf("%d runs while another replica holds the claim, want all 3", len(got))
+    }
+
+    server.Del(historyPruneClaimKey)
+    deadline := time.Now().Add(2 * time.Second)
+    for testutil.ToFloat64(historyPrunedRuns)-before < 2 {
+        if time.Now().After(deadline) {
+            t.Fatal("history not pruned after the claim was released")
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    if got := indexedRuns(t, service.redis, "org-1"); len(got) != 1 {
+        t.Errorf("%d runs after pruning, want 1", len(got))
+    }
+    if got := testutil.ToFloat64(historyPrunedRuns) - before; got != 2 {
+        t.Errorf("%v runs counted as pruned, want 2", got)
+    }
+}
@@ -0,0 +1,106 @@
+package compliance
+
+import (
+    "context"
+    "reflect"
+    "testing"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/redis/go-redis/v9"
+)
+
+func newTestScoreHistory(t *testing.T) *ScoreHistory {
+    server := miniredis.RunT(t)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    return NewScoreHistory(client)
+}
+
+func TestAttachTrends(t *testing.T) {
+    ctx := context.Background()
+    history := newTestScoreHistory(t)
+    for i := 1; i <= 35; i++ {
+        scored := &ComplianceResponse{OrganizationId: "org-1", FrameworkResults: []*FrameworkResult{{Framework: "NCA", Score: float64(i)}}}
+        if err := history.Record(ctx, scored, &EvaluationLease{Token: int64(i), Scope: "all"}); err != nil {
+            t.Fatal(err)
+        }
+    }
+    scores := func(from, to int) []float64 {
+        var trend []float64
+        for i := from; i <= to; i++ {
+            trend = append(trend, float64(i))
+        }
+        return trend
+    }
+
+    tests := []struct {
+        name   string
+        points int
+        want   []float64
+    }{
+        {name: "requested points, oldest first", points: 3, want: []float64{33, 34, 35}},
+        {name: "default", points: 0, want: scores(26, 35)},
+        {name: "capped at the stored history", points: 100, want: scores(6, 35)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            response := &ComplianceResponse{OrganizationId: "org-1", FrameworkResults: []*FrameworkResult{{Framework: "NCA"}, {Framework: "SAMA"}}}
+            if err := history.AttachTrends(ctx, response, tt.points); err != nil {
+                t.Fatal(err)
+            }
+            if got := response.FrameworkResults[0].Trend; !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("NCA trend %v, want %v", got, tt.want)
+            }
+            if got := response.FrameworkResults[1].Trend; len(got) != 0 {
+                t.Errorf("SAMA trend %v without history, want empty", got)
+            }
+        })
+    }
+}
+
+// A check asking for trends gets each framework's recent scores, its own
+// last; one that does not gets none
+func TestCheckComplianceIncludesTrend(t *testing.T) {
+    service, _ := newTestService(t)
+    ctx := context.Background()
+
+    var responses []*ComplianceResponse
+    for _, values := range [][]string{{"false", "false"}, {"true", "false"}, {"true", "true"}} {
+        req := evidenceRequest("org-1", values...)
+        req.IncludeTrend = len(responses) == 2
+        req.TrendPoints = 3
+        response, err := service.CheckCompliance(ctx, req)
+        if err != nil {
+            t.Fatal(err)
+        }
+        responses = append(responses, response)
+    }
+
+    for _, result := range responses[1].FrameworkResults {
+        if len(result.Trend) != 0 {
+            t.Errorf("%s trend %v without include_trend", result.Framework, result.Trend)
+        }
+    }
+    checked := 0
+    for i, result := range responses[2].FrameworkResults {
+        var want []float64
+        for _, response := range responses {
+            earlier := response.FrameworkResults[i]
+            if earlier.Framework != result.Framework || earlier.Outcome != "" {
+                want = nil
+                break
+            }
+            want = append(want, earlier.Score)
+        }
+        if want == nil {
+            continue
+        }
+        checked++
+        if !reflect.DeepEqual(result.Trend, want) {
+            t.Errorf("%s trend %v, want %v", result.Framework, result.Trend, want)
+        }
+    }
+    if checked == 0 {
+        t.Fatal("no framework evaluated in every check")
+    }
+}
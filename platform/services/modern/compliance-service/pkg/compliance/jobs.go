@@ -0,0 +1,403 @@
+package compliance
+
+import (
+    "container/heap"
+    "context"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Job states
+const (
+    jobPending   = "PENDING"
+    jobRunning   = "RUNNING"
+    jobSucceeded = "SUCCEEDED"
+    jobFailed    = "FAILED"
+)
+
+// Budget tiers used for execution-time estimates
+const (
+    tierInteractive = "interactive"
+    tierBulk        = "bulk"
+)
+
+const (
+    // Finished jobs stay pollable this long
+    jobRetention = time.Hour
+    // Smoothing factor of the per-tier execution time EWMA
+    jobEWMAAlpha = 0.2
+    // Estimate used before a tier has completed any job
+    jobDefaultEstimate = 500 * time.Millisecond
+    // A job shed by the evaluation scheduler is queued again after this
+    // long, at most jobMaxSheds times before it fails
+    jobShedBackoff = time.Second
+    jobMaxSheds    = 10
+)
+
+// A queued compliance check
+type checkJob struct {
+    id          string
+    seq         uint64
+    priority    int32
+    request     *ComplianceRequest
+    md          metadata.MD
+    state       string
+    submittedAt time.Time
+    finishedAt  time.Time
+    result      *ComplianceResponse
+    err         string
+    sheds       int
+    index       int
+    // Set for jobs of Run: evaluated under the caller's context, closing
+    // done once finished with the evaluation's own error
+    ctx     context.Context
+    done    chan struct{}
+    failure error
+}
+
+func (j *checkJob) tier() string {
+    if j.priority > 0 {
+        return tierInteractive
+    }
+    return tierBulk
+}
+
+// Pending jobs ordered by priority, then submission order
+type jobHeap []*checkJob
+
+func (h jobHeap) Len() int { return len(h) }
+func (h jobHeap) Less(i, j int) bool {
+    if h[i].priority != h[j].priority {
+        return h[i].priority > h[j].priority
+    }
+    return h[i].seq < h[j].seq
+}
+func (h jobHeap) Swap(i, j int) {
+    h[i], h[j] = h[j], h[i]
+    h[i].index = i
+    h[j].index = j
+}
+func (h *jobHeap) Push(x interface{}) {
+    job := x.(*checkJob)
+    job.index = len(*h)
+    *h = append(*h, job)
+}
+func (h *jobHeap) Pop() interface{} {
+    old := *h
+    job := old[len(old)-1]
+    *h = old[:len(old)-1]
+    job.index = -1
+    return job
+}
+
+// JobQueue - in-process queue of asynchronous compliance checks served by a
+// fixed set of workers. Jobs live in the replica that accepted them.
+//
+// Workers evaluate through the shared evaluation scheduler like any other
+// check, so jobs never add concurrency of their own. Bulk jobs may occupy
+// at most bulkLimit workers, keeping the rest free for interactive jobs, and
+// a bulk job shed by the scheduler goes back in the queue instead of failing.
+type JobQueue struct {
+    mu         sync.Mutex
+    cond       *sync.Cond
+    pending    jobHeap
+    jobs       map[string]*checkJob
+    seq        uint64
+    workers    int
+    bulkLimit  int
+    bulkActive int
+    maxPending int // Submissions beyond this many pending jobs are rejected, 0 is unbounded
+    ewma       map[string]time.Duration
+    evaluate   func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error)
+}
+
+// Create a job queue; call Start to launch the workers. A bulk limit of 0,
+// or one that would leave no worker for interactive jobs, reserves one.
+func NewJobQueue(workers, bulkLimit, maxPending int, evaluate func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error)) *JobQueue {
+    if workers < 1 {
+        workers = 1
+    }
+    if bulkLimit <= 0 || bulkLimit >= workers {
+        bulkLimit = workers - 1
+    }
+    if bulkLimit < 1 {
+        bulkLimit = 1
+    }
+    q := &JobQueue{
+        jobs:       make(map[string]*checkJob),
+        workers:    workers,
+        bulkLimit:  bulkLimit,
+        maxPending: maxPending,
+        ewma:       make(map[string]time.Duration),
+        evaluate:   evaluate,
+    }
+    q.cond = sync.NewCond(&q.mu)
+    return q
+}
+
+// Start the workers and the retention sweeper
+func (q *JobQueue) Start(ctx context.Context) {
+    for i := 0; i < q.workers; i++ {
+        go q.work(ctx)
+    }
+    go func() {
+        <-ctx.Done()
+        q.mu.Lock()
+        q.cond.Broadcast()
+        q.mu.Unlock()
+    }()
+    go q.sweep(ctx)
+}
+
+// Submit queues a check, capturing the caller's metadata for the worker.
+// A full queue rejects it with ResourceExhausted.
+func (q *JobQueue) Submit(ctx context.Context, req *ComplianceRequest, priority int32) (*CheckJob, error) {
+    md, _ := metadata.FromIncomingContext(ctx)
+
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    if q.maxPending > 0 && len(q.pending) >= q.maxPending {
+        jobsRejected.Inc()
+        return nil, reasonError(codes.ResourceExhausted, reasonOverloaded, "job queue full, retry later")
+    }
+
+    q.seq++
+    job := &checkJob{
+        id:          newRequestID(),
+        seq:         q.seq,
+        priority:    priority,
+        request:     proto.Clone(req).(*ComplianceRequest),
+        md:          md.Copy(),
+        state:       jobPending,
+        submittedAt: time.Now(),
+    }
+    job.request.Priority = priority
+    q.jobs[job.id] = job
+    heap.Push(&q.pending, job)
+    q.cond.Signal()
+
+    return q.snapshot(job), nil
+}
+
+// Run queues a check in the tier of its priority and waits for it. The
+// caller's context carries through to the evaluation, and the evaluation's
+// error is returned as is. Callers bound their own runs, so the pending
+// limit does not apply, and the jobs are not pollable.
+func (q *JobQueue) Run(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+    q.mu.Lock()
+    q.seq++
+    job := &checkJob{
+        id:          newRequestID(),
+        seq:         q.seq,
+        priority:    req.Priority,
+        request:     req,
+        state:       jobPending,
+        submittedAt: time.Now(),
+        ctx:         ctx,
+        done:        make(chan struct{}),
+    }
+    heap.Push(&q.pending, job)
+    q.cond.Signal()
+    q.mu.Unlock()
+
+    select {
+    case <-job.done:
+        return job.result, job.failure
+    case <-ctx.Done():
+    }
+    q.mu.Lock()
+    if job.state == jobPending && job.index >= 0 {
+        heap.Remove(&q.pending, job.index)
+        q.mu.Unlock()
+        return nil, ctx.Err()
+    }
+    q.mu.Unlock()
+    // Already running, or waiting out a shed; it fails with ctx
+    <-job.done
+    return job.result, job.failure
+}
+
+// Get the current state of a job
+func (q *JobQueue) Get(id string) (*CheckJob, bool) {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    job, ok := q.jobs[id]
+    if !ok {
+        return nil, false
+    }
+    return q.snapshot(job), true
+}
+
+// Snapshot a job; PENDING jobs get their effective queue position and an
+// estimated start based on the EWMA of the jobs ahead of them. Caller holds mu.
+func (q *JobQueue) snapshot(job *checkJob) *CheckJob {
+    out := &CheckJob{
+        JobId:       job.id,
+        State:       job.state,
+        Priority:    job.priority,
+        SubmittedAt: timestamppb.New(job.submittedAt),
+        Result:      job.result,
+        Error:       job.err,
+    }
+    if job.state != jobPending {
+        return out
+    }
+
+    ordered := make(jobHeap, len(q.pending))
+    copy(ordered, q.pending)
+    sort.Slice(ordered, ordered.Less)
+
+    var ahead time.Duration
+    for i, pending := range ordered {
+        if pending == job {
+            out.QueuePosition = int32(i + 1)
+            break
+        }
+        ahead += q.estimate(pending.tier())
+    }
+    out.EstimatedStartSeconds = roundScore((ahead / time.Duration(q.workers)).Seconds())
+    return out
+}
+
+// Rolling execution time estimate for a tier. Caller holds mu.
+func (q *JobQueue) estimate(tier string) time.Duration {
+    if d, ok := q.ewma[tier]; ok {
+        return d
+    }
+    return jobDefaultEstimate
+}
+
+// Depth of the pending queue
+func (q *JobQueue) Depth() int {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    return len(q.pending)
+}
+
+// Whether a worker may take the next pending job: interactive jobs sort
+// first, so the head is bulk only when no interactive job waits. Caller
+// holds mu.
+func (q *JobQueue) runnable() bool {
+    return len(q.pending) > 0 && (q.pending[0].tier() != tierBulk || q.bulkActive < q.bulkLimit)
+}
+
+func (q *JobQueue) work(ctx context.Context) {
+    for {
+        q.mu.Lock()
+        for !q.runnable() && ctx.Err() == nil {
+            q.cond.Wait()
+        }
+        if ctx.Err() != nil {
+            q.mu.Unlock()
+            return
+        }
+        job := heap.Pop(&q.pending).(*checkJob)
+        job.state = jobRunning
+        tier := job.tier()
+        if tier == tierBulk {
+            q.bulkActive++
+        }
+        q.mu.Unlock()
+
+        startTime := time.Now()
+        jobCtx := job.ctx
+        if jobCtx == nil {
+            jobCtx = withBackgroundEvaluation(metadata.NewIncomingContext(ctx, job.md))
+        }
+        result, err := q.evaluate(jobCtx, job.request)
+        elapsed := time.Since(startTime)
+
+        q.mu.Lock()
+        if tier == tierBulk {
+            q.bulkActive--
+            q.cond.Signal()
+        }
+        if err != nil && errorReason(status.Convert(err)) == reasonOverloaded && job.sheds < jobMaxSheds {
+            job.sheds++
+            job.state = jobPending
+            q.mu.Unlock()
+            jobsRequeued.Inc()
+            time.AfterFunc(jobShedBackoff, func() { q.requeue(job) })
+            continue
+        }
+        job.finishedAt = time.Now()
+        if err != nil {
+            job.state = jobFailed
+            job.err = err.Error()
+        } else {
+            job.state = jobSucceeded
+            job.result = result
+        }
+        if job.done != nil {
+            job.failure = err
+            close(job.done)
+        }
+        if previous, ok := q.ewma[tier]; ok {
+            q.ewma[tier] = time.Duration(jobEWMAAlpha*float64(elapsed) + (1-jobEWMAAlpha)*float64(previous))
+        } else {
+            q.ewma[tier] = elapsed
+        }
+        q.mu.Unlock()
+    }
+}
+
+// Put a shed job back in the pending queue
+func (q *JobQueue) requeue(job *checkJob) {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    heap.Push(&q.pending, job)
+    q.cond.Signal()
+}
+
+// Drop finished jobs past retention
+func (q *JobQueue) sweep(ctx context.Context) {
+    ticker := time.NewTicker(time.Minute)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            cutoff := time.Now().Add(-jobRetention)
+            q.mu.Lock()
+            for id, job := range q.jobs {
+                if !job.finishedAt.IsZero() && job.finishedAt.Before(cutoff) {
+                    delete(q.jobs, id)
+                }
+            }
+            q.mu.Unlock()
+        }
+    }
+}
+
+// Job queue metrics
+var (
+    jobsRejected = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_jobs_rejected_total",
+            Help: "Job submissions rejected because the job queue was full",
+        },
+    )
+
+    jobsRequeued = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_jobs_requeued_total",
+            Help: "Jobs shed by the evaluation scheduler and queued again",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(jobsRejected)
+    prometheus.MustRegister(jobsRequeued)
+}
@@ -0,0 +1,268 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Start a job queue whose workers stop with the test
+func startJobQueue(t *testing.T, workers, bulkLimit, maxPending int, evaluate func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error)) *JobQueue {
+    ctx, cancel := context.WithCancel(context.Background())
+    t.Cleanup(cancel)
+    q := NewJobQueue(workers, bulkLimit, maxPending, evaluate)
+    q.Start(ctx)
+    return q
+}
+
+func submitJob(t *testing.T, q *JobQueue, org string, priority int32) string {
+    t.Helper()
+    job, err := q.Submit(context.Background(), &ComplianceRequest{OrganizationId: org}, priority)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return job.JobId
+}
+
+// Poll a job until it finishes
+func waitForJob(t *testing.T, q *JobQueue, id string, timeout time.Duration) *CheckJob {
+    t.Helper()
+    for deadline := time.Now().Add(timeout); ; {
+        job, _ := q.Get(id)
+        if job.State == jobSucceeded || job.State == jobFailed {
+            return job
+        }
+        if time.Now().After(deadline) {
+            t.Fatalf("job %s still %s after %v", id, job.State, timeout)
+        }
+        time.Sleep(time.Millisecond)
+    }
+}
+
+func TestNewJobQueueBulkLimit(t *testing.T) {
+    tests := []struct {
+        workers   int
+        bulkLimit int
+        want      int
+    }{
+        {workers: 4, bulkLimit: 0, want: 3},
+        {workers: 4, bulkLimit: 2, want: 2},
+        {workers: 4, bulkLimit: 4, want: 3},
+        {workers: 4, bulkLimit: -1, want: 3},
+        {workers: 1, bulkLimit: 0, want: 1},
+        {workers: 0, bulkLimit: 0, want: 1},
+    }
+    for _, tt := range tests {
+        if got := NewJobQueue(tt.workers, tt.bulkLimit, 0, nil).bulkLimit; got != tt.want {
+            t.Errorf("NewJobQueue(%d, %d) bulk limit %d, want %d", tt.workers, tt.bulkLimit, got, tt.want)
+        }
+    }
+}
+
+// A backlog of bulk jobs never takes the worker kept for interactive jobs
+func TestJobQueueReservesWorkerForInteractive(t *testing.T) {
+    release := make(chan struct{})
+    var mu sync.Mutex
+    var bulkRunning, maxBulk int
+    q := startJobQueue(t, 3, 0, 0, func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+        if req.Priority > 0 {
+            return &ComplianceResponse{OrganizationId: req.OrganizationId}, nil
+        }
+        mu.Lock()
+        bulkRunning++
+        maxBulk = max(maxBulk, bulkRunning)
+        mu.Unlock()
+        <-release
+        mu.Lock()
+        bulkRunning--
+        mu.Unlock()
+        return &ComplianceResponse{OrganizationId: req.OrganizationId}, nil
+    })
+
+    var bulk []string
+    for i := 0; i < 10; i++ {
+        bulk = append(bulk, submitJob(t, q, fmt.Sprintf("bulk-%d", i), 0))
+    }
+    interactive := submitJob(t, q, "interactive", 5)
+    if job := waitForJob(t, q, interactive, time.Second); job.State != jobSucceeded {
+        t.Errorf("interactive job %s behind a bulk backlog: %s", job.State, job.Error)
+    }
+
+    close(release)
+    for _, id := range bulk {
+        waitForJob(t, q, id, time.Second)
+    }
+    mu.Lock()
+    defer mu.Unlock()
+    if maxBulk != 2 {
+        t.Errorf("%d bulk jobs ran at once on 3 workers, want 2", maxBulk)
+    }
+}
+
+// Interactive jobs submitted alongside a large bulk batch start within a
+// few evaluations of being submitted
+func TestJobQueueBoundsInteractiveLatency(t *testing.T) {
+    const work = 5 * time.Millisecond
+    var mu sync.Mutex
+    submitted := make(map[string]time.Time)
+    var worst time.Duration
+    q := startJobQueue(t, 4, 0, 0, func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+        if req.Priority > 0 {
+            mu.Lock()
+            worst = max(worst, time.Since(submitted[req.OrganizationId]))
+            mu.Unlock()
+        }
+        time.Sleep(work)
+        return &ComplianceResponse{OrganizationId: req.OrganizationId}, nil
+    })
+
+    var ids []string
+    for i := 0; i < 200; i++ {
+        ids = append(ids, submitJob(t, q, fmt.Sprintf("bulk-%d", i), 0))
+    }
+    for i := 0; i < 10; i++ {
+        org := fmt.Sprintf("interactive-%d", i)
+        mu.Lock()
+        submitted[org] = time.Now()
+        mu.Unlock()
+        ids = append(ids, submitJob(t, q, org, 5))
+        time.Sleep(2 * work)
+    }
+    for _, id := range ids {
+        waitForJob(t, q, id, 5*time.Second)
+    }
+
+    mu.Lock()
+    defer mu.Unlock()
+    if worst > 4*work {
+        t.Errorf("interactive job waited %v behind the batch, want under %v", worst, 4*work)
+    }
+}
+
+func TestJobQueueRejectsWhenFull(t *testing.T) {
+    // Not started, so submissions stay pending
+    q := NewJobQueue(1, 0, 2, nil)
+    before := testutil.ToFloat64(jobsRejected)
+    for i := 0; i < 2; i++ {
+        if _, err := q.Submit(context.Background(), &ComplianceRequest{OrganizationId: "org-1"}, 0); err != nil {
+            t.Fatalf("submission %d: %v", i+1, err)
+        }
+    }
+
+    _, err := q.Submit(context.Background(), &ComplianceRequest{OrganizationId: "org-1"}, 5)
+    st := status.Convert(err)
+    if st.Code() != codes.ResourceExhausted || errorReason(st) != reasonOverloaded {
+        t.Errorf("Submit() on a full queue = %v, want ResourceExhausted %s", err, reasonOverloaded)
+    }
+    if got := testutil.ToFloat64(jobsRejected) - before; got != 1 {
+        t.Errorf("%v rejections counted, want 1", got)
+    }
+    if q.Depth() != 2 {
+        t.Errorf("depth %d after a rejection, want 2", q.Depth())
+    }
+}
+
+// A job shed by the evaluation scheduler is queued again rather than failed
+func TestJobQueueRequeuesShedJob(t *testing.T) {
+    var mu sync.Mutex
+    calls := 0
+    q := startJobQueue(t, 2, 0, 0, func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+        mu.Lock()
+        defer mu.Unlock()
+        calls++
+        if calls == 1 {
+            return nil, reasonError(codes.ResourceExhausted, reasonOverloaded, "evaluation queue full")
+        }
+        return &ComplianceResponse{OrganizationId: req.OrganizationId}, nil
+    })
+
+    before := testutil.ToFloat64(jobsRequeued)
+    id := submitJob(t, q, "org-1", 0)
+    job := waitForJob(t, q, id, jobShedBackoff+time.Second)
+    if job.State != jobSucceeded || job.Result.GetOrganizationId() != "org-1" {
+        t.Errorf("shed job %s (%s), want it to succeed on the retry", job.State, job.Error)
+    }
+    if got := testutil.ToFloat64(jobsRequeued) - before; got != 1 {
+        t.Errorf("%v requeues counted, want 1", got)
+    }
+}
+
+// Run evaluates under the caller's context and returns the evaluation's own
+// error; a run cancelled while pending leaves the queue
+func TestJobQueueRun(t *testing.T) {
+    type marker struct{}
+    q := startJobQueue(t, 2, 0, 0, func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+        if ctx.Value(marker{}) == nil {
+            return nil, errors.New("caller's context lost")
+        }
+        if req.OrganizationId == "busy" {
+            return nil, errEvaluationInProgress
+        }
+        return &ComplianceResponse{OrganizationId: req.OrganizationId}, nil
+    })
+    ctx := context.WithValue(context.Background(), marker{}, true)
+    if response, err := q.Run(ctx, &ComplianceRequest{OrganizationId: "org-1"}); err != nil || response.OrganizationId != "org-1" {
+        t.Errorf("Run() = %v, %v; want org-1's response", response, err)
+    }
+    if _, err := q.Run(ctx, &ComplianceRequest{OrganizationId: "busy"}); err != errEvaluationInProgress {
+        t.Errorf("Run() = %v, want the evaluation's error", err)
+    }
+
+    // Not started, so the run stays pending
+    idle := NewJobQueue(1, 0, 0, nil)
+    cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+    defer cancel()
+    if _, err := idle.Run(cancelled, &ComplianceRequest{OrganizationId: "org-1"}); err != context.DeadlineExceeded {
+        t.Errorf("Run() = %v, want the context's error", err)
+    }
+    if idle.Depth() != 0 {
+        t.Errorf("depth %d after a cancelled run, want 0", idle.Depth())
+    }
+}
+
+// As the queue drains, a pending job's position only moves forward
+func TestJobQueuePositionsMonotonic(t *testing.T) {
+    release := make(chan struct{})
+    q := startJobQueue(t, 1, 0, 0, func(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+        <-release
+        return &ComplianceResponse{OrganizationId: req.OrganizationId}, nil
+    })
+
+    var ids []string
+    for i := 0; i < 6; i++ {
+        ids = append(ids, submitJob(t, q, fmt.Sprintf("org-%d", i), 0))
+    }
+    // Interactive jobs join ahead of the bulk ones still waiting
+    ids = append(ids, submitJob(t, q, "interactive", 5))
+
+    previous := make(map[string]*CheckJob)
+    for drained := 0; drained <= len(ids); drained++ {
+        for _, id := range ids {
+            job, _ := q.Get(id)
+            if job.State != jobPending {
+                continue
+            }
+            if before, ok := previous[id]; ok {
+                if job.QueuePosition > before.QueuePosition {
+                    t.Errorf("job %s moved back from position %d to %d", id, before.QueuePosition, job.QueuePosition)
+                }
+            }
+            previous[id] = job
+        }
+        if drained < len(ids) {
+            release <- struct{}{}
+        }
+    }
+    for _, id := range ids {
+        if job := waitForJob(t, q, id, time.Second); job.State != jobSucceeded {
+            t.Errorf("job %s %s: %s", id, job.State, job.Error)
+        }
+    }
+}
@@ -0,0 +1,137 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+    "net/url"
+    "os"
+    "strings"
+    "time"
+
+    "gopkg.in/yaml.v3"
+)
+
+// Longest wait for a knowledge base served over HTTP
+const knowledgeBaseFetchTimeout = 10 * time.Second
+
+// KnowledgeEntry - curated remediation guidance for one control
+type KnowledgeEntry struct {
+    Summary string   `yaml:"summary" json:"summary"`
+    Steps   []string `yaml:"steps" json:"steps"`
+    Links   []string `yaml:"links" json:"links"`
+}
+
+// KnowledgeBase - guidance keyed by framework, then control (evidence key),
+// e.g. {NCA: {vulnerability_scan_date: {...}}}. Controls without an entry
+// get only the generic hint.
+type KnowledgeBase map[string]map[string]*KnowledgeEntry
+
+// Load the knowledge base from a YAML/JSON file or an http(s) URL serving
+// the same document; empty means no knowledge base. A file that cannot be
+// read or parsed is a configuration error, while an unreachable service
+// only degrades guidance to the generic hints.
+func loadKnowledgeBase(source string, engine *RulesEngine, client *http.Client) (KnowledgeBase, error) {
+    if source == "" {
+        return KnowledgeBase{}, nil
+    }
+
+    var data []byte
+    if u, err := url.Parse(source); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+        data, err = fetchKnowledgeBase(client, source)
+        if err != nil {
+            log.Printf("Knowledge base unavailable, using generic remediation hints: %v", err)
+            return KnowledgeBase{}, nil
+        }
+    } else if data, err = os.ReadFile(source); err != nil {
+        return nil, fmt.Errorf("failed to read knowledge base: %v", err)
+    }
+
+    kb := KnowledgeBase{}
+    if err := yaml.Unmarshal(data, &kb); err != nil {
+        return nil, fmt.Errorf("failed to parse knowledge base: %v", err)
+    }
+    if err := kb.validate(engine); err != nil {
+        return nil, fmt.Errorf("invalid knowledge base: %v", err)
+    }
+    return kb, nil
+}
+
+func fetchKnowledgeBase(client *http.Client, source string) ([]byte, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), knowledgeBaseFetchTimeout)
+    defer cancel()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+    if err != nil {
+        return nil, err
+    }
+    resp, err := client.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("GET %s returned %s", source, resp.Status)
+    }
+    return io.ReadAll(resp.Body)
+}
+
+// Entries must name registered frameworks and controls they declare, and
+// links must be absolute URLs
+func (kb KnowledgeBase) validate(engine *RulesEngine) error {
+    for framework, entries := range kb {
+        if _, ok := engine.checkers[framework]; !ok {
+            return fmt.Errorf("unknown framework %s", framework)
+        }
+        for control, entry := range entries {
+            declared := false
+            for _, requirement := range engine.requirements[framework] {
+                declared = declared || requirement.Key == control
+            }
+            if !declared {
+                return fmt.Errorf("%s does not declare control %s", framework, control)
+            }
+            if entry == nil || (entry.Summary == "" && len(entry.Steps) == 0) {
+                return fmt.Errorf("%s/%s has neither summary nor steps", framework, control)
+            }
+            for _, link := range entry.Links {
+                if u, err := url.Parse(link); err != nil || u.Scheme == "" || u.Host == "" {
+                    return fmt.Errorf("%s/%s has invalid link %q", framework, control, link)
+                }
+            }
+        }
+    }
+    return nil
+}
+
+// Curated guidance for a control from each framework that reads it, in
+// framework order; frameworks without an entry are left out
+func (kb KnowledgeBase) Guidance(control string, frameworks []string) []*ControlGuidance {
+    var guidance []*ControlGuidance
+    for _, framework := range frameworks {
+        entry, ok := kb[framework][control]
+        if !ok {
+            continue
+        }
+        guidance = append(guidance, &ControlGuidance{
+            Framework: framework,
+            Summary:   entry.Summary,
+            Steps:     entry.Steps,
+            Links:     entry.Links,
+        })
+    }
+    return guidance
+}
+
+// Generic remediation hint for a failed control
+func remediationHint(control, issue string) string {
+    name := strings.ReplaceAll(control, "_", " ")
+    switch issue {
+    case verdictExpired:
+        return fmt.Sprintf("Refresh the %s evidence; the supplied one has expired", name)
+    case verdictInvalidType:
+        return fmt.Sprintf("Resubmit the %s evidence with the expected type", name)
+    }
+    return fmt.Sprintf("Supply %s evidence", name)
+}
@@ -0,0 +1,110 @@
+package compliance
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "path/filepath"
+    "testing"
+)
+
+const knowledgeBaseFixture = `
+NCA:
+  vulnerability_scan_date:
+    summary: Run an authenticated scan of every internet-facing asset
+    steps: [Scope the external attack surface, Scan with an approved tool]
+    links: [https://nca.gov.sa/ecc/2-3]
+  incident_response_plan:
+    summary: Approve and publish an incident response plan
+`
+
+// Roadmap steps indexed by control
+func roadmapSteps(t *testing.T, service *ComplianceService) map[string]*RemediationStep {
+    t.Helper()
+    analysis, err := service.GetGapAnalysis(context.Background(), &GapAnalysisRequest{
+        Request:     &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"NCA", "NIST"}},
+        TargetScore: 100,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    steps := make(map[string]*RemediationStep, len(analysis.Roadmap))
+    for _, step := range analysis.Roadmap {
+        steps[step.Control] = step
+    }
+    return steps
+}
+
+// Controls with a knowledge base entry carry its guidance for the
+// frameworks that have one; others get only the generic hint
+func TestGapAnalysisAttachesKnowledgeBaseGuidance(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "knowledge.yaml")
+    writeConfigFile(t, path, knowledgeBaseFixture)
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.KnowledgeBase = path
+    })
+    spy := spyOnCheckers(service)
+    spy.setScore("NCA", 50)
+    spy.setScore("NIST", 50)
+    steps := roadmapSteps(t, service)
+
+    tests := []struct {
+        control      string
+        wantGuidance []string // Frameworks guidance comes from
+        wantSummary  string
+    }{
+        {control: "vulnerability_scan_date", wantGuidance: []string{"NCA"}, wantSummary: "Run an authenticated scan of every internet-facing asset"},
+        {control: "incident_response_plan", wantGuidance: []string{"NCA"}, wantSummary: "Approve and publish an incident response plan"},
+        {control: "mfa_enforced"},
+        {control: "backup_restore_test_date"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.control, func(t *testing.T) {
+            step, ok := steps[tt.control]
+            if !ok {
+                t.Fatalf("%s missing from the roadmap", tt.control)
+            }
+            if step.Hint != remediationHint(tt.control, step.Issue) {
+                t.Errorf("hint %q, want the generic hint", step.Hint)
+            }
+            if len(step.Guidance) != len(tt.wantGuidance) {
+                t.Fatalf("guidance from %d frameworks, want %v", len(step.Guidance), tt.wantGuidance)
+            }
+            for i, guidance := range step.Guidance {
+                if guidance.Framework != tt.wantGuidance[i] || guidance.Summary != tt.wantSummary {
+                    t.Errorf("guidance %s %q, want %s %q", guidance.Framework, guidance.Summary, tt.wantGuidance[i], tt.wantSummary)
+                }
+            }
+        })
+    }
+
+    scan := steps["vulnerability_scan_date"].Guidance[0]
+    if len(scan.Steps) != 2 || len(scan.Links) != 1 || scan.Links[0] != "https://nca.gov.sa/ecc/2-3" {
+        t.Errorf("vulnerability_scan_date guidance steps %v links %v, want the entry's", scan.Steps, scan.Links)
+    }
+}
+
+// A knowledge base service that cannot be reached leaves every step with
+// the generic hint and no guidance
+func TestGapAnalysisWithoutKnowledgeBase(t *testing.T) {
+    unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+    }))
+    t.Cleanup(unavailable.Close)
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.KnowledgeBase = unavailable.URL
+    })
+    spy := spyOnCheckers(service)
+    spy.setScore("NCA", 50)
+    spy.setScore("NIST", 50)
+
+    steps := roadmapSteps(t, service)
+    if len(steps) == 0 {
+        t.Fatal("empty roadmap")
+    }
+    for control, step := range steps {
+        if len(step.Guidance) != 0 || step.Hint == "" {
+            t.Errorf("%s guidance %v hint %q, want only the generic hint", control, step.Guidance, step.Hint)
+        }
+    }
+}
@@ -0,0 +1,355 @@
+package compliance
+
+import (
+    "context"
+    "log"
+    "strconv"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Statuses in ascending order, as sorted by ListLatestResults
+var latestStatuses = []string{"NON_COMPLIANT", "PARTIALLY_COMPLIANT", "COMPLIANT"}
+
+// Sort orders of ListLatestResults
+const (
+    latestSortLastChecked = "LAST_CHECKED"
+    latestSortScore       = "SCORE"
+    latestSortStatus      = "STATUS"
+)
+
+// Page size bounds of ListLatestResults
+const (
+    latestDefaultPageSize = 50
+    latestMaxPageSize     = 500
+)
+
+// Set of tenants with a latest-results table, for the snapshot job
+const latestTenantsKey = "latest:tenants"
+
+// LatestResults - each tenant's latest result per organization, kept in a
+// hash alongside sorted-set indexes by score and by check time, overall and
+// per status. A page is one index range plus one hash lookup, so listing
+// never scans history however many organizations a tenant has.
+type LatestResults struct {
+    redis             *redis.Client
+    snapshotRetention time.Duration
+}
+
+// Create the latest-results store; daily snapshots expire after retention
+func NewLatestResults(client *redis.Client, snapshotRetention time.Duration) *LatestResults {
+    return &LatestResults{redis: client, snapshotRetention: snapshotRetention}
+}
+
+// Writes an organization's latest result and moves it between status
+// indexes atomically. KEYS[1] is the result hash, KEYS[2] the tenant set,
+// KEYS[3:4] the overall score and check-time indexes, then one such pair per
+// status. ARGV is the organization, encoded result, score, check time,
+// tenant and the index of the current status pair.
+var recordLatestScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('SADD', KEYS[2], ARGV[5])
+redis.call('ZADD', KEYS[3], ARGV[3], ARGV[1])
+redis.call('ZADD', KEYS[4], ARGV[4], ARGV[1])
+for i = 5, #KEYS, 2 do
+    if i == tonumber(ARGV[6]) then
+        redis.call('ZADD', KEYS[i], ARGV[3], ARGV[1])
+        redis.call('ZADD', KEYS[i + 1], ARGV[4], ARGV[1])
+    else
+        redis.call('ZREM', KEYS[i], ARGV[1])
+        redis.call('ZREM', KEYS[i + 1], ARGV[1])
+    end
+end
+return 1
+`)
+
+// Record a persisted response as the organization's latest result, with
+// the sector and tags its request described the organization by
+func (l *LatestResults) Record(ctx context.Context, tenant string, req *ComplianceRequest, response *ComplianceResponse) error {
+    result := &LatestResult{
+        OrganizationId:  response.OrganizationId,
+        OverallScore:    response.OverallScore,
+        Status:          response.Status,
+        CheckedAt:       response.Timestamp,
+        FrameworkScores: make(map[string]float64, len(response.FrameworkResults)),
+        Sector:          req.Sector,
+        Tags:            req.Tags,
+    }
+    for _, framework := range response.FrameworkResults {
+        if framework.Outcome != frameworkNotApplicable && framework.Outcome != frameworkInsufficientData {
+            result.FrameworkScores[framework.Framework] = framework.Score
+        }
+    }
+    return l.write(ctx, tenant, result)
+}
+
+// Write a latest result and move it into its status indexes
+func (l *LatestResults) write(ctx context.Context, tenant string, result *LatestResult) error {
+    data, err := proto.Marshal(result)
+    if err != nil {
+        return err
+    }
+    keys := append([]string{latestResultsKey(tenant), latestTenantsKey}, latestIndexKeys(tenant)...)
+    current := 0
+    for i, s := range latestStatuses {
+        if s == result.Status {
+            // Lua index of the status's pair, after the overall one
+            current = 5 + 2*i
+        }
+    }
+    return recordLatestScript.Run(ctx, l.redis, keys,
+        result.OrganizationId, data, result.OverallScore, result.CheckedAt, tenant, current).Err()
+}
+
+// Removes an organization from a tenant's latest results. KEYS[1] is the
+// result hash, then every index; ARGV[1] is the organization.
+var removeLatestScript = redis.NewScript(`
+redis.call('HDEL', KEYS[1], ARGV[1])
+for i = 2, #KEYS do
+    redis.call('ZREM', KEYS[i], ARGV[1])
+end
+return 1
+`)
+
+// Merge folds the source's latest result into the target's in every
+// tenant, the one checked last becoming the target's
+func (l *LatestResults) Merge(ctx context.Context, source, target string) error {
+    tenants, err := l.redis.SMembers(ctx, latestTenantsKey).Result()
+    if err != nil {
+        return err
+    }
+    for _, tenant := range tenants {
+        values, err := l.redis.HMGet(ctx, latestResultsKey(tenant), source, target).Result()
+        if err != nil {
+            return err
+        }
+        data, ok := values[0].(string)
+        if !ok {
+            continue
+        }
+        result := &LatestResult{}
+        if err := proto.Unmarshal([]byte(data), result); err != nil {
+            return err
+        }
+        current := &LatestResult{}
+        if existing, ok := values[1].(string); !ok || proto.Unmarshal([]byte(existing), current) != nil || result.CheckedAt > current.CheckedAt {
+            result.OrganizationId = target
+            if err := l.write(ctx, tenant, result); err != nil {
+                return err
+            }
+        }
+        keys := append([]string{latestResultsKey(tenant)}, latestIndexKeys(tenant)...)
+        if err := removeLatestScript.Run(ctx, l.redis, keys, source).Err(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// Page of a tenant's latest results: organization IDs from the index, then
+// their results in one lookup. Returns the results and the total matching.
+func (l *LatestResults) List(ctx context.Context, tenant, statusFilter, sortBy string, ascending bool, offset, limit int64) ([]*LatestResult, int64, error) {
+    var ids []string
+    var total int64
+    var err error
+    if sortBy == latestSortStatus && statusFilter == "" {
+        ids, total, err = l.rangeByStatus(ctx, tenant, ascending, offset, limit)
+    } else {
+        index := latestIndexKey(tenant, statusFilter, sortBy)
+        ids, total, err = l.rangeIndex(ctx, index, ascending, offset, limit)
+    }
+    if err != nil || len(ids) == 0 {
+        return nil, total, err
+    }
+
+    values, err := l.redis.HMGet(ctx, latestResultsKey(tenant), ids...).Result()
+    if err != nil {
+        return nil, 0, err
+    }
+    results := make([]*LatestResult, 0, len(values))
+    for _, value := range values {
+        data, ok := value.(string)
+        if !ok {
+            continue
+        }
+        result := &LatestResult{}
+        if err := proto.Unmarshal([]byte(data), result); err != nil {
+            return nil, 0, err
+        }
+        results = append(results, result)
+    }
+    return results, total, nil
+}
+
+func (l *LatestResults) rangeIndex(ctx context.Context, index string, ascending bool, offset, limit int64) ([]string, int64, error) {
+    pipe := l.redis.Pipeline()
+    count := pipe.ZCard(ctx, index)
+    var page *redis.StringSliceCmd
+    if ascending {
+        page = pipe.ZRange(ctx, index, offset, offset+limit-1)
+    } else {
+        page = pipe.ZRevRange(ctx, index, offset, offset+limit-1)
+    }
+    if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+        return nil, 0, err
+    }
+    return page.Val(), count.Val(), nil
+}
+
+// Status order, then score within each status, paging across the per-status
+// score indexes
+func (l *LatestResults) rangeByStatus(ctx context.Context, tenant string, ascending bool, offset, limit int64) ([]string, int64, error) {
+    statuses := make([]string, len(latestStatuses))
+    copy(statuses, latestStatuses)
+    if !ascending {
+        for i, j := 0, len(statuses)-1; i < j; i, j = i+1, j-1 {
+            statuses[i], statuses[j] = statuses[j], statuses[i]
+        }
+    }
+
+    pipe := l.redis.Pipeline()
+    counts := make([]*redis.IntCmd, len(statuses))
+    for i, s := range statuses {
+        counts[i] = pipe.ZCard(ctx, latestIndexKey(tenant, s, latestSortScore))
+    }
+    if _, err := pipe.Exec(ctx); err != nil {
+        return nil, 0, err
+    }
+
+    var ids []string
+    var total int64
+    for i, s := range statuses {
+        n := counts[i].Val()
+        total += n
+        if int64(len(ids)) >= limit {
+            continue
+        }
+        if offset >= n {
+            offset -= n
+            continue
+        }
+        page, _, err := l.rangeIndex(ctx, latestIndexKey(tenant, s, latestSortScore), ascending, offset, limit-int64(len(ids)))
+        if err != nil {
+            return nil, 0, err
+        }
+        ids = append(ids, page...)
+        offset = 0
+    }
+    return ids, total, nil
+}
+
+// Run takes a daily snapshot of every tenant's latest results. Replicas
+// race for each day's marker, so exactly one takes it.
+func (l *LatestResults) Run(ctx context.Context) {
+    if l.snapshotRetention <= 0 {
+        return
+    }
+    ticker := time.NewTicker(time.Hour)
+    defer ticker.Stop()
+
+    for {
+        l.snapshot(ctx, time.Now().UTC())
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+func (l *LatestResults) snapshot(ctx context.Context, now time.Time) {
+    day := now.Format("2006-01-02")
+    claimed, err := l.redis.SetNX(ctx, "latest-snapshot:taken:"+day, 1, 48*time.Hour).Result()
+    if err != nil || !claimed {
+        return
+    }
+
+    tenants, err := l.redis.SMembers(ctx, latestTenantsKey).Result()
+    if err != nil {
+        log.Printf("Daily snapshot failed to list tenants: %v", err)
+        return
+    }
+    for _, tenant := range tenants {
+        target := latestSnapshotKey(tenant, day)
+        if err := l.redis.Copy(ctx, latestResultsKey(tenant), target, 0, true).Err(); err != nil {
+            log.Printf("Daily snapshot of tenant %s failed: %v", tenant, err)
+            continue
+        }
+        l.redis.Expire(ctx, target, l.snapshotRetention)
+    }
+    log.Printf("Daily snapshot %s taken for %d tenants", day, len(tenants))
+}
+
+func latestResultsKey(tenant string) string {
+    return "latest:" + tenant
+}
+
+// Index of a tenant's results in one sort order; an empty status indexes all
+func latestIndexKey(tenant, statusFilter, sortBy string) string {
+    scope := "all"
+    if statusFilter != "" {
+        scope = statusFilter
+    }
+    return "latest:" + tenant + ":" + scope + ":" + sortBy
+}
+
+// Every index of a tenant: the overall score and check-time pair, then one
+// pair per status
+func latestIndexKeys(tenant string) []string {
+    keys := []string{latestIndexKey(tenant, "", latestSortScore), latestIndexKey(tenant, "", latestSortLastChecked)}
+    for _, s := range latestStatuses {
+        keys = append(keys, latestIndexKey(tenant, s, latestSortScore), latestIndexKey(tenant, s, latestSortLastChecked))
+    }
+    return keys
+}
+
+func latestSnapshotKey(tenant, day string) string {
+    return "latest-snapshot:" + tenant + ":" + day
+}
+
+// ListLatestResults - the calling tenant's organizations at their latest
+// result, filtered by status and sorted by score, status or last check
+func (s *ComplianceService) ListLatestResults(ctx context.Context, req *ListLatestResultsRequest) (*ListLatestResultsResponse, error) {
+    sortBy := req.SortBy
+    switch sortBy {
+    case "":
+        sortBy = latestSortLastChecked
+    case latestSortLastChecked, latestSortScore, latestSortStatus:
+    default:
+        return nil, status.Errorf(codes.InvalidArgument, "unsupported sort_by %q", req.SortBy)
+    }
+    if req.Status != "" && !containsString(latestStatuses, req.Status) {
+        return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", req.Status)
+    }
+    if sortBy == latestSortStatus && req.Status != "" {
+        sortBy = latestSortScore
+    }
+
+    pageSize := int64(req.PageSize)
+    if pageSize <= 0 {
+        pageSize = latestDefaultPageSize
+    } else if pageSize > latestMaxPageSize {
+        pageSize = latestMaxPageSize
+    }
+    var offset int64
+    if req.PageToken != "" {
+        var err error
+        if offset, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil || offset < 0 {
+            return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+        }
+    }
+
+    results, total, err := s.latest.List(ctx, tenantFromContext(ctx), req.Status, sortBy, req.Ascending, offset, pageSize)
+    if err != nil {
+        return nil, storeError(err, "failed to list latest results")
+    }
+    response := &ListLatestResultsResponse{Results: results, TotalCount: int32(total)}
+    if next := offset + pageSize; next < total {
+        response.NextPageToken = strconv.FormatInt(next, 10)
+    }
+    return response, nil
+}
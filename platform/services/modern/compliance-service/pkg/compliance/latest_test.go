@@ -0,0 +1,83 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/redis/go-redis/v9"
+)
+
+// Pages of a tenant with 50k organizations, first and deep, in every sort
+// order. Each page must come back within 100ms.
+func BenchmarkListLatestResults(b *testing.B) {
+    const (
+        organizations = 50000
+        pageSize      = 50
+        budget        = 100 * time.Millisecond
+    )
+    ctx := context.Background()
+    server := miniredis.RunT(b)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    b.Cleanup(func() { client.Close() })
+    latest := NewLatestResults(client, 0)
+
+    checked := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+    for i := 0; i < organizations; i++ {
+        score := float64(i%10000) / 100
+        status := "NON_COMPLIANT"
+        if score >= 90 {
+            status = "COMPLIANT"
+        } else if score >= 70 {
+            status = "PARTIALLY_COMPLIANT"
+        }
+        result := &LatestResult{
+            OrganizationId:  fmt.Sprintf("org-%05d", i),
+            OverallScore:    score,
+            Status:          status,
+            CheckedAt:       checked + int64(i*7%organizations),
+            FrameworkScores: map[string]float64{"NCA": score, "SAMA": score, "PDPL": score},
+        }
+        if err := latest.write(ctx, defaultTenant, result); err != nil {
+            b.Fatal(err)
+        }
+    }
+
+    tests := []struct {
+        name   string
+        status string
+        sortBy string
+    }{
+        {name: "last checked", sortBy: latestSortLastChecked},
+        {name: "score", sortBy: latestSortScore},
+        {name: "status", sortBy: latestSortStatus},
+        {name: "partially compliant by score", status: "PARTIALLY_COMPLIANT", sortBy: latestSortScore},
+    }
+    for _, tt := range tests {
+        // The deeper offset is the last page of the 10k partially compliant
+        // organizations
+        for _, offset := range []int64{0, 10000 - pageSize} {
+            b.Run(fmt.Sprintf("%s/offset %d", tt.name, offset), func(b *testing.B) {
+                var total int64
+                for i := 0; i < b.N; i++ {
+                    results, n, err := latest.List(ctx, defaultTenant, tt.status, tt.sortBy, false, offset, pageSize)
+                    if err != nil {
+                        b.Fatal(err)
+                    }
+                    if len(results) != pageSize {
+                        b.Fatalf("%d results at offset %d of %d, want %d", len(results), offset, n, pageSize)
+                    }
+                    total = n
+                }
+                if tt.status == "" && total != organizations {
+                    b.Errorf("listed %d organizations, want %d", total, organizations)
+                }
+                if perPage := b.Elapsed() / time.Duration(b.N); perPage > budget {
+                    b.Errorf("%v per page, want within %v", perPage, budget)
+                }
+            })
+        }
+    }
+}
@@ -0,0 +1,95 @@
+package compliance
+
+import (
+    "sync/atomic"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// observerCache - children of a single-label histogram created up front for
+// the label's known values, so the hot path reads a map instead of hashing
+// labels into the vector on every observation. Values outside the set fall
+// back to the vector. The map is replaced, never mutated, so lookups take
+// no lock.
+type observerCache struct {
+    vec      *prometheus.HistogramVec
+    children atomic.Pointer[map[string]prometheus.Observer]
+}
+
+func newObserverCache(vec *prometheus.HistogramVec, values ...string) *observerCache {
+    c := &observerCache{vec: vec}
+    c.precreate(values...)
+    return c
+}
+
+// Create children for further known values, such as frameworks registered
+// after init
+func (c *observerCache) precreate(values ...string) {
+    for {
+        current := c.children.Load()
+        next := make(map[string]prometheus.Observer)
+        if current != nil {
+            for value, child := range *current {
+                next[value] = child
+            }
+        }
+        for _, value := range values {
+            if _, ok := next[value]; !ok {
+                next[value] = c.vec.WithLabelValues(value)
+            }
+        }
+        if c.children.CompareAndSwap(current, &next) {
+            return
+        }
+    }
+}
+
+func (c *observerCache) get(value string) prometheus.Observer {
+    if children := c.children.Load(); children != nil {
+        if child, ok := (*children)[value]; ok {
+            return child
+        }
+    }
+    return c.vec.WithLabelValues(value)
+}
+
+// counterCache - observerCache for a single-label counter
+type counterCache struct {
+    vec      *prometheus.CounterVec
+    children atomic.Pointer[map[string]prometheus.Counter]
+}
+
+func newCounterCache(vec *prometheus.CounterVec, values ...string) *counterCache {
+    c := &counterCache{vec: vec}
+    c.precreate(values...)
+    return c
+}
+
+func (c *counterCache) precreate(values ...string) {
+    for {
+        current := c.children.Load()
+        next := make(map[string]prometheus.Counter)
+        if current != nil {
+            for value, child := range *current {
+                next[value] = child
+            }
+        }
+        for _, value := range values {
+            if _, ok := next[value]; !ok {
+                next[value] = c.vec.WithLabelValues(value)
+            }
+        }
+        if c.children.CompareAndSwap(current, &next) {
+            return
+        }
+    }
+}
+
+func (c *counterCache) get(value string) prometheus.Counter {
+    if children := c.children.Load(); children != nil {
+        if child, ok := (*children)[value]; ok {
+            return child
+        }
+    }
+    return c.vec.WithLabelValues(value)
+}
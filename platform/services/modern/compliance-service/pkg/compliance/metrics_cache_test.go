@@ -0,0 +1,114 @@
+package compliance
+
+import (
+    "fmt"
+    "sync"
+    "testing"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestCounterVec() *prometheus.CounterVec {
+    return prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total", Help: "Test counter"}, []string{"op"})
+}
+
+func newTestHistogramVec() *prometheus.HistogramVec {
+    return prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_seconds", Help: "Test histogram"}, []string{"op"})
+}
+
+// Cached children are the vector's own, and values outside the known set
+// still land in the vector
+func TestMetricCachesRecordIntoVector(t *testing.T) {
+    counters := newTestCounterVec()
+    counterChildren := newCounterCache(counters, "check", "history")
+    histograms := newTestHistogramVec()
+    observerChildren := newObserverCache(histograms, "check")
+
+    if got := testutil.CollectAndCount(counters); got != 2 {
+        t.Errorf("%d series before any request, want the 2 precreated", got)
+    }
+    counterChildren.get("check").Inc()
+    counterChildren.get("check").Inc()
+    counterChildren.get("unknown").Inc()
+    observerChildren.get("check").Observe(0.5)
+    observerChildren.get("unknown").Observe(1)
+
+    tests := []struct {
+        value string
+        want  float64
+    }{
+        {value: "check", want: 2},
+        {value: "history", want: 0},
+        {value: "unknown", want: 1},
+    }
+    for _, tt := range tests {
+        if got := testutil.ToFloat64(counters.WithLabelValues(tt.value)); got != tt.want {
+            t.Errorf("counter %s = %v, want %v", tt.value, got, tt.want)
+        }
+    }
+    if got := testutil.CollectAndCount(histograms); got != 2 {
+        t.Errorf("%d histogram series, want check and unknown", got)
+    }
+}
+
+// Values precreated while other goroutines read keep every earlier child
+func TestMetricCachePrecreateConcurrent(t *testing.T) {
+    counters := newTestCounterVec()
+    cache := newCounterCache(counters, "check")
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(2)
+        go func(i int) {
+            defer wg.Done()
+            cache.precreate(fmt.Sprintf("framework-%d", i))
+        }(i)
+        go func() {
+            defer wg.Done()
+            for j := 0; j < 100; j++ {
+                cache.get("check").Inc()
+            }
+        }()
+    }
+    wg.Wait()
+
+    if got := len(*cache.children.Load()); got != 9 {
+        t.Errorf("%d cached children, want check and 8 frameworks", got)
+    }
+    if got := testutil.ToFloat64(counters.WithLabelValues("check")); got != 800 {
+        t.Errorf("check counted %v, want 800", got)
+    }
+}
+
+func TestMetricCacheLookupDoesNotAllocate(t *testing.T) {
+    counters := newCounterCache(newTestCounterVec(), "check")
+    observers := newObserverCache(newTestHistogramVec(), "check")
+    allocs := testing.AllocsPerRun(1000, func() {
+        counters.get("check").Inc()
+        observers.get("check").Observe(0.1)
+    })
+    if allocs != 0 {
+        t.Errorf("%v allocations per cached lookup, want 0", allocs)
+    }
+}
+
+// Observing through the cache against hashing labels into the vector on
+// every call. Neither allocates for one label; the cache saves the hash
+// and the vector lookup.
+func BenchmarkMetricLabels(b *testing.B) {
+    histograms := newTestHistogramVec()
+    cache := newObserverCache(histograms, "check")
+
+    b.Run("WithLabelValues", func(b *testing.B) {
+        b.ReportAllocs()
+        for i := 0; i < b.N; i++ {
+            histograms.WithLabelValues("check").Observe(0.1)
+        }
+    })
+    b.Run("cached", func(b *testing.B) {
+        b.ReportAllocs()
+        for i := 0; i < b.N; i++ {
+            cache.get("check").Observe(0.1)
+        }
+    })
+}
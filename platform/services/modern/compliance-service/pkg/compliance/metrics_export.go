@@ -0,0 +1,133 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    otelprometheus "go.opentelemetry.io/contrib/bridges/prometheus"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+    sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+    "go.opentelemetry.io/otel/sdk/metric/metricdata"
+    "go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Longest the final push on shutdown may take
+const metricsPushShutdownTimeout = 10 * time.Second
+
+// MetricsExportConfig - how metrics leave the service: scraped from
+// /metrics, pushed over OTLP to a collector, or both
+type MetricsExportConfig struct {
+    // Serve /metrics for Prometheus scraping; probes are served either way
+    Prometheus bool `env:"METRICS_PROMETHEUS"`
+
+    // OTLP/gRPC endpoint metrics are pushed to, as host:port; empty disables
+    OTLPEndpoint string `env:"METRICS_OTLP_ENDPOINT"`
+
+    // Push without TLS, for a collector sidecar
+    OTLPInsecure bool `env:"METRICS_OTLP_INSECURE"`
+
+    // Interval between pushes
+    OTLPInterval time.Duration `env:"METRICS_OTLP_INTERVAL"`
+}
+
+// MetricsPusher - periodically pushes every metric registered with the
+// Prometheus default registry over OTLP. The metrics are read through the
+// OpenTelemetry Prometheus bridge, so no metric is defined twice and the
+// pushed series match the scraped ones.
+type MetricsPusher struct {
+    endpoint string
+    provider *sdkmetric.MeterProvider
+}
+
+// Create a pusher for the service name and version; nil when the OTLP
+// endpoint is unset
+func newMetricsPusher(name, version string, config MetricsExportConfig) (*MetricsPusher, error) {
+    if config.OTLPEndpoint == "" {
+        return nil, nil
+    }
+    if config.OTLPInterval <= 0 {
+        return nil, fmt.Errorf("metrics OTLP interval must be positive")
+    }
+    options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(config.OTLPEndpoint)}
+    if config.OTLPInsecure {
+        options = append(options, otlpmetricgrpc.WithInsecure())
+    }
+    // The exporter dials lazily, so an unreachable collector fails pushes
+    // rather than startup
+    exporter, err := otlpmetricgrpc.New(context.Background(), options...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create OTLP metrics exporter for %s: %v", config.OTLPEndpoint, err)
+    }
+    reader := sdkmetric.NewPeriodicReader(
+        &countingExporter{Exporter: exporter, endpoint: config.OTLPEndpoint},
+        sdkmetric.WithInterval(config.OTLPInterval),
+        sdkmetric.WithProducer(otelprometheus.NewMetricProducer(otelprometheus.WithGatherer(prometheus.DefaultGatherer))),
+    )
+    provider := sdkmetric.NewMeterProvider(
+        sdkmetric.WithReader(reader),
+        sdkmetric.WithResource(resource.NewSchemaless(
+            attribute.String("service.name", name),
+            attribute.String("service.version", version),
+        )),
+    )
+    return &MetricsPusher{endpoint: config.OTLPEndpoint, provider: provider}, nil
+}
+
+// Run - push until ctx is done, then push a final time so the last
+// interval's increments are not lost
+func (p *MetricsPusher) Run(ctx context.Context) {
+    if p == nil {
+        return
+    }
+    log.Printf("Pushing metrics over OTLP to %s", p.endpoint)
+    <-ctx.Done()
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), metricsPushShutdownTimeout)
+    defer cancel()
+    if err := p.provider.Shutdown(shutdownCtx); err != nil {
+        log.Printf("Final OTLP metrics push to %s failed: %v", p.endpoint, err)
+    }
+}
+
+// Counts and logs failed pushes, which the periodic reader would otherwise
+// only hand to the global OpenTelemetry error handler
+type countingExporter struct {
+    sdkmetric.Exporter
+    endpoint string
+}
+
+func (e *countingExporter) Export(ctx context.Context, metrics *metricdata.ResourceMetrics) error {
+    err := e.Exporter.Export(ctx, metrics)
+    if err != nil {
+        metricsPushFailures.Inc()
+        log.Printf("OTLP metrics push to %s failed: %v", e.endpoint, err)
+        return err
+    }
+    metricsPushes.Inc()
+    return nil
+}
+
+// Metrics export metrics
+var (
+    metricsPushes = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_metrics_otlp_pushes_total",
+            Help: "Metric pushes accepted by the OTLP endpoint",
+        },
+    )
+
+    metricsPushFailures = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_metrics_otlp_push_failures_total",
+            Help: "Metric pushes the OTLP endpoint failed or refused",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(metricsPushes)
+    prometheus.MustRegister(metricsPushFailures)
+}
@@ -0,0 +1,191 @@
+package compliance
+
+import (
+    "context"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+    metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// In-memory OTLP receiver keeping every push, or refusing them all
+type testMetricsReceiver struct {
+    collectormetrics.UnimplementedMetricsServiceServer
+    refuse bool
+
+    mu     sync.Mutex
+    pushes []*collectormetrics.ExportMetricsServiceRequest
+}
+
+func (r *testMetricsReceiver) Export(ctx context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+    if r.refuse {
+        return nil, status.Error(codes.InvalidArgument, "refused")
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.pushes = append(r.pushes, req)
+    return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (r *testMetricsReceiver) received() []*collectormetrics.ExportMetricsServiceRequest {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return append([]*collectormetrics.ExportMetricsServiceRequest(nil), r.pushes...)
+}
+
+// Start a receiver and a pusher sending to it every interval; the pusher
+// runs until the returned stop makes its final push
+func startMetricsPush(t *testing.T, receiver *testMetricsReceiver, interval time.Duration) (stop func()) {
+    t.Helper()
+    lis, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    server := grpc.NewServer()
+    collectormetrics.RegisterMetricsServiceServer(server, receiver)
+    go server.Serve(lis)
+    t.Cleanup(server.Stop)
+
+    pusher, err := newMetricsPusher("compliance-service", "1.2.3", MetricsExportConfig{OTLPEndpoint: lis.Addr().String(), OTLPInsecure: true, OTLPInterval: interval})
+    if err != nil {
+        t.Fatal(err)
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan struct{})
+    go func() {
+        pusher.Run(ctx)
+        close(done)
+    }()
+    return func() {
+        cancel()
+        <-done
+    }
+}
+
+// Metrics of a push by name
+func pushedMetrics(push *collectormetrics.ExportMetricsServiceRequest) map[string]*metricspb.Metric {
+    metrics := make(map[string]*metricspb.Metric)
+    for _, resourceMetrics := range push.ResourceMetrics {
+        for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+            for _, metric := range scopeMetrics.Metrics {
+                metrics[metric.Name] = metric
+            }
+        }
+    }
+    return metrics
+}
+
+// Every scraped metric family is pushed, from the same definitions and
+// values, and pushes repeat every interval
+func TestMetricsPushedOverOTLP(t *testing.T) {
+    receiver := &testMetricsReceiver{}
+    historyPrunedRuns.Add(3)
+    want := testutil.ToFloat64(historyPrunedRuns)
+    stop := startMetricsPush(t, receiver, 20*time.Millisecond)
+
+    deadline := time.Now().Add(5 * time.Second)
+    for len(receiver.received()) < 2 {
+        if time.Now().After(deadline) {
+            t.Fatalf("%d pushes received, want them every interval", len(receiver.received()))
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    stop()
+
+    push := receiver.received()[0]
+    attributes := make(map[string]string)
+    for _, attribute := range push.ResourceMetrics[0].Resource.Attributes {
+        attributes[attribute.Key] = attribute.Value.GetStringValue()
+    }
+    if attributes["service.name"] != "compliance-service" || attributes["service.version"] != "1.2.3" {
+        t.Errorf("resource %v, want the service name and version", attributes)
+    }
+
+    metrics := pushedMetrics(push)
+    families, err := prometheus.DefaultGatherer.Gather()
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, family := range families {
+        if metrics[family.GetName()] == nil {
+            t.Errorf("%s scraped but not pushed", family.GetName())
+        }
+    }
+    pruned := metrics["compliance_history_pruned_runs_total"].GetSum()
+    if pruned == nil || len(pruned.DataPoints) != 1 || pruned.DataPoints[0].GetAsDouble() != want {
+        t.Errorf("pushed compliance_history_pruned_runs_total %v, want %v", pruned, want)
+    }
+}
+
+// Stopping pushes a final time, so increments after the last interval are
+// not lost
+func TestMetricsPushedOnShutdown(t *testing.T) {
+    receiver := &testMetricsReceiver{}
+    before := testutil.ToFloat64(metricsPushes)
+    stop := startMetricsPush(t, receiver, time.Hour)
+    historyPrunedRuns.Inc()
+    want := testutil.ToFloat64(historyPrunedRuns)
+    stop()
+
+    pushes := receiver.received()
+    if len(pushes) != 1 {
+        t.Fatalf("%d pushes on shutdown, want 1", len(pushes))
+    }
+    if got := pushedMetrics(pushes[0])["compliance_history_pruned_runs_total"].GetSum().DataPoints[0].GetAsDouble(); got != want {
+        t.Errorf("final push compliance_history_pruned_runs_total = %v, want %v", got, want)
+    }
+    if got := testutil.ToFloat64(metricsPushes) - before; got != 1 {
+        t.Errorf("%v pushes counted, want 1", got)
+    }
+}
+
+func TestMetricsPushFailuresCounted(t *testing.T) {
+    before := testutil.ToFloat64(metricsPushFailures)
+    stop := startMetricsPush(t, &testMetricsReceiver{refuse: true}, time.Hour)
+    stop()
+    if got := testutil.ToFloat64(metricsPushFailures) - before; got != 1 {
+        t.Errorf("%v failed pushes counted, want 1", got)
+    }
+}
+
+func TestNewMetricsPusher(t *testing.T) {
+    if pusher, err := newMetricsPusher("compliance-service", "1.2.3", MetricsExportConfig{OTLPInterval: time.Second}); pusher != nil || err != nil {
+        t.Errorf("newMetricsPusher() without an endpoint = %v, %v, want neither", pusher, err)
+    }
+    if _, err := newMetricsPusher("compliance-service", "1.2.3", MetricsExportConfig{OTLPEndpoint: "collector:4317"}); err == nil {
+        t.Error("newMetricsPusher() without an interval succeeded, want an error")
+    }
+}
+
+// Push-only deployments drop /metrics but keep the probes
+func TestMetricsMuxWithoutScraping(t *testing.T) {
+    service, _ := newTestService(t)
+    tests := []struct {
+        name    string
+        metrics http.Handler
+        want    int
+    }{
+        {name: "scraped", metrics: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), want: http.StatusOK},
+        {name: "push only", want: http.StatusNotFound},
+    }
+    for _, tt := range tests {
+        mux := newMetricsMux(service, tt.metrics)
+        for path, want := range map[string]int{"/metrics": tt.want, "/healthz": http.StatusOK} {
+            recorder := httptest.NewRecorder()
+            mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+            if recorder.Code != want {
+                t.Errorf("%s: GET %s = %d, want %d", tt.name, path, recorder.Code, want)
+            }
+        }
+    }
+}
@@ -0,0 +1,55 @@
+package compliance
+
+import (
+    "fmt"
+    "time"
+)
+
+// Outcome of a framework missing evidence it needs before it can be scored
+const frameworkInsufficientData = "INSUFFICIENT_DATA"
+
+// Validate minimum inputs against the registered frameworks and the
+// evidence each declares it reads
+func (e *RulesEngine) validateMinimumInputs(minimums map[string][]string) error {
+    for framework, keys := range minimums {
+        if !containsString(e.frameworks, framework) {
+            return fmt.Errorf("minimum inputs configured for unknown framework %s", framework)
+        }
+        for _, key := range keys {
+            declared := false
+            for _, requirement := range e.requirements[framework] {
+                declared = declared || requirement.Key == key
+            }
+            if !declared {
+                return fmt.Errorf("minimum input %s is not evidence framework %s reads", key, framework)
+            }
+        }
+    }
+    return nil
+}
+
+// Results standing in for frameworks whose minimum inputs are not all
+// present and usable: such a framework is not scored, so an empty request
+// cannot produce a misleading score, and it is left out of the aggregate
+func (e *RulesEngine) insufficientDataResults(minimums map[string][]string, items []*EvidenceItem, now time.Time) map[string]*FrameworkResult {
+    results := make(map[string]*FrameworkResult)
+    for framework, keys := range minimums {
+        set, _ := e.assembleEvidence([]string{framework}, items, now)
+        var missing []string
+        for _, key := range keys {
+            if _, ok := set[key]; !ok {
+                missing = append(missing, key)
+            }
+        }
+        if len(missing) == 0 {
+            continue
+        }
+        results[framework] = &FrameworkResult{
+            Framework:        framework,
+            Outcome:          frameworkInsufficientData,
+            EvidenceCoverage: e.evidenceStatus(framework, set).EstimatedCoverage,
+            MissingInputs:    missing,
+        }
+    }
+    return results
+}
@@ -0,0 +1,115 @@
+package compliance
+
+import (
+    "context"
+    "path/filepath"
+    "reflect"
+    "testing"
+    "time"
+
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A framework missing any of its minimum inputs, whether absent, unusable
+// or expired, is INSUFFICIENT_DATA: it is not evaluated and the overall
+// score is renormalized over the frameworks that were
+func TestInsufficientDataBelowMinimumInputs(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "config.yaml")
+    writeConfigFile(t, path, "minimum_inputs:\n  SAMA: [aml_program, capital_adequacy_ratio]\n")
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+        config.ConfigFile = path
+    })
+    spy := spyOnCheckers(service)
+    for _, framework := range service.engine.Frameworks() {
+        spy.setScore(framework, 80)
+    }
+    spy.setScore("SAMA", 0)
+
+    aml := &EvidenceItem{Key: "aml_program", Value: "true"}
+    capital := &EvidenceItem{Key: "capital_adequacy_ratio", Value: "14.5"}
+    tests := []struct {
+        name        string
+        evidence    []*EvidenceItem
+        wantMissing []string // Nil when SAMA is scored
+    }{
+        {name: "no evidence", wantMissing: []string{"aml_program", "capital_adequacy_ratio"}},
+        {name: "one of two", evidence: []*EvidenceItem{aml}, wantMissing: []string{"capital_adequacy_ratio"}},
+        {
+            name:        "unusable value",
+            evidence:    []*EvidenceItem{{Key: "aml_program", Value: "maybe"}, capital},
+            wantMissing: []string{"aml_program"},
+        },
+        {
+            name: "expired value",
+            evidence: []*EvidenceItem{aml, {
+                Key:         "capital_adequacy_ratio",
+                Value:       "14.5",
+                CollectedAt: timestamppb.New(time.Now().Add(-91 * 24 * time.Hour)),
+            }},
+            wantMissing: []string{"capital_adequacy_ratio"},
+        },
+        {name: "all present", evidence: []*EvidenceItem{aml, capital}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            // One organization per case: the response cache does not key on
+            // when evidence was collected
+            before := spy.runs()["SAMA"]
+            response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-" + tt.name, Evidence: tt.evidence})
+            if err != nil {
+                t.Fatal(err)
+            }
+            var sama *FrameworkResult
+            for _, result := range response.FrameworkResults {
+                if result.Framework == "SAMA" {
+                    sama = result
+                }
+            }
+            if sama == nil {
+                t.Fatal("no SAMA result")
+            }
+            ran := spy.runs()["SAMA"] > before
+
+            if tt.wantMissing == nil {
+                if sama.Outcome != "" || !ran {
+                    t.Errorf("SAMA outcome %q, evaluated %v, want it scored", sama.Outcome, ran)
+                }
+                if response.OverallScore >= 80 {
+                    t.Errorf("overall %v, want SAMA's 0 counted", response.OverallScore)
+                }
+                return
+            }
+            if sama.Outcome != frameworkInsufficientData || ran {
+                t.Errorf("SAMA outcome %q, evaluated %v, want %s unevaluated", sama.Outcome, ran, frameworkInsufficientData)
+            }
+            if !reflect.DeepEqual(sama.MissingInputs, tt.wantMissing) {
+                t.Errorf("missing inputs %v, want %v", sama.MissingInputs, tt.wantMissing)
+            }
+            if response.OverallScore != 80 {
+                t.Errorf("overall %v, want 80 from the other frameworks alone", response.OverallScore)
+            }
+        })
+    }
+}
+
+// Minimum inputs must name a registered framework and evidence it reads
+func TestValidateMinimumInputs(t *testing.T) {
+    service, _ := newTestService(t)
+    tests := []struct {
+        name     string
+        minimums map[string][]string
+        wantErr  bool
+    }{
+        {name: "declared evidence", minimums: map[string][]string{"NCA": {"asset_inventory", "mfa_enforced"}}},
+        {name: "unknown framework", minimums: map[string][]string{"HIPAA": {"asset_inventory"}}, wantErr: true},
+        {name: "evidence of another framework", minimums: map[string][]string{"NCA": {"aml_program"}}, wantErr: true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if err := service.engine.validateMinimumInputs(tt.minimums); (err != nil) != tt.wantErr {
+                t.Errorf("validateMinimumInputs() = %v, want error %v", err, tt.wantErr)
+            }
+        })
+    }
+}
@@ -0,0 +1,184 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "math/rand"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Metadata key marking a mirrored request; its evaluation leaves no trace
+const mirrorMetadataKey = "x-compliance-mirror"
+
+// Mirrored requests queued before new ones are dropped
+const mirrorQueueSize = 256
+
+// MirrorConfig - copies of a sample of CheckCompliance requests forwarded
+// to another instance, typically staging running a release candidate.
+// Responses are discarded and the primary path never waits on the mirror.
+type MirrorConfig struct {
+    // gRPC address of the mirror target; empty disables mirroring
+    Target string `env:"MIRROR_TARGET"`
+
+    // Percentage of requests mirrored, within [0, 100]
+    Percent float64 `env:"MIRROR_PERCENT"`
+
+    // Longest a mirrored call may take
+    Timeout time.Duration `env:"MIRROR_TIMEOUT"`
+
+    // Concurrent mirrored calls
+    Workers int `env:"MIRROR_WORKERS"`
+
+    // Serve mirrored requests, evaluating them without caching, recording
+    // or publishing anything; an instance that does not rejects them
+    Accept bool `env:"MIRROR_ACCEPT"`
+}
+
+// RequestMirror - forwards sampled requests to the mirror target
+type RequestMirror struct {
+    config MirrorConfig
+    conn   *grpc.ClientConn
+    client ComplianceClient
+    queue  chan mirroredRequest
+}
+
+type mirroredRequest struct {
+    req    *ComplianceRequest
+    tenant string
+    token  string // Tenant token, for a target verifying tenants
+}
+
+// Connect to the mirror target; nil when mirroring is disabled
+func newRequestMirror(config MirrorConfig) (*RequestMirror, error) {
+    if config.Target == "" || config.Percent == 0 {
+        return nil, nil
+    }
+    if config.Percent < 0 || config.Percent > 100 {
+        return nil, fmt.Errorf("mirror percent must be within [0, 100]")
+    }
+    if config.Timeout <= 0 || config.Workers < 1 {
+        return nil, fmt.Errorf("mirror timeout and workers must be positive")
+    }
+    conn, err := grpc.NewClient(config.Target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return nil, fmt.Errorf("failed to connect to mirror target %s: %v", config.Target, err)
+    }
+    return &RequestMirror{
+        config: config,
+        conn:   conn,
+        client: NewComplianceClient(conn),
+        queue:  make(chan mirroredRequest, mirrorQueueSize),
+    }, nil
+}
+
+// Queue a sampled copy of a request for the mirror, its organization ID in
+// the form configured for logs and without on_behalf_of. Never blocks: a
+// full queue drops it.
+func (m *RequestMirror) Mirror(ctx context.Context, req *ComplianceRequest) {
+    if m == nil || rand.Float64()*100 >= m.config.Percent {
+        return
+    }
+    if mirroredRequestContext(ctx) {
+        // Never mirror a mirror back
+        return
+    }
+    mirrored := proto.Clone(req).(*ComplianceRequest)
+    mirrored.OrganizationId = redact(fieldOrganizationID, mirrored.OrganizationId)
+    // Mirrors carry no principal, so they cannot act for a subject
+    mirrored.OnBehalfOf = nil
+    select {
+    case m.queue <- mirroredRequest{req: mirrored, tenant: tenantFromContext(ctx), token: tenantTokenFromContext(ctx)}:
+    default:
+        mirrorFailures.WithLabelValues("queue_full").Inc()
+    }
+}
+
+// Run - forward queued requests until ctx is done
+func (m *RequestMirror) Run(ctx context.Context) {
+    if m == nil {
+        return
+    }
+    for i := 0; i < m.config.Workers; i++ {
+        go func() {
+            for {
+                select {
+                case <-ctx.Done():
+                    return
+                case queued := <-m.queue:
+                    m.forward(ctx, queued)
+                }
+            }
+        }()
+    }
+    <-ctx.Done()
+    m.conn.Close()
+}
+
+func (m *RequestMirror) forward(ctx context.Context, queued mirroredRequest) {
+    ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+    defer cancel()
+    ctx = metadata.AppendToOutgoingContext(ctx, mirrorMetadataKey, "true", tenantMetadataKey, queued.tenant)
+    if queued.token != "" {
+        ctx = metadata.AppendToOutgoingContext(ctx, tenantTokenMetadataKey, queued.token)
+    }
+    if _, err := m.client.CheckCompliance(ctx, queued.req); err != nil {
+        mirrorFailures.WithLabelValues(status.Code(err).String()).Inc()
+        log.Printf("Mirrored request to %s failed: %v", m.config.Target, err)
+        return
+    }
+    mirroredRequests.Inc()
+}
+
+// Whether a request arrived as a mirror
+func mirroredRequestContext(ctx context.Context) bool {
+    md, ok := metadata.FromIncomingContext(ctx)
+    if !ok {
+        return false
+    }
+    values := md.Get(mirrorMetadataKey)
+    return len(values) > 0 && values[0] == "true"
+}
+
+// Check a mirrored request may be served here: only instances accepting
+// mirrors serve them, so a misdirected mirror cannot leave records
+func (s *ComplianceService) checkMirrorAccepted(ctx context.Context) (bool, error) {
+    if !mirroredRequestContext(ctx) {
+        return false, nil
+    }
+    if !s.config.Mirror.Accept {
+        return true, status.Errorf(codes.FailedPrecondition, "this instance does not accept mirrored requests")
+    }
+    return true, nil
+}
+
+// Request mirroring metrics
+var (
+    mirroredRequests = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_mirrored_requests_total",
+            Help: "Requests forwarded to the mirror target and answered",
+        },
+    )
+
+    mirrorFailures = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_mirror_failures_total",
+            Help: "Requests not mirrored, by reason: queue_full or the gRPC code of the failed call",
+        },
+        []string{"reason"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(mirroredRequests)
+    prometheus.MustRegister(mirrorFailures)
+}
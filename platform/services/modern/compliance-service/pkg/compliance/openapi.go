@@ -0,0 +1,221 @@
+package compliance
+
+import (
+    "encoding/json"
+    "fmt"
+    "log"
+    "net/http"
+    "strings"
+
+    "google.golang.org/protobuf/reflect/protoreflect"
+    "google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Swagger UI page for /docs, loading the UI from a CDN
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Compliance API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+// Serve the OpenAPI document at /openapi.json and Swagger UI at /docs. The
+// document is built once from the route table and the compiled-in proto
+// descriptors, so it lists exactly the routes the gateway serves.
+func (g *Gateway) serveDocs() {
+    document, err := json.MarshalIndent(g.openAPIDocument(), "", "  ")
+    if err != nil {
+        log.Printf("Not serving gateway docs: failed to encode OpenAPI document: %v", err)
+        return
+    }
+    g.mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        w.Write(document)
+    })
+    g.mux.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "text/html; charset=utf-8")
+        w.Write([]byte(swaggerUIPage))
+    })
+}
+
+// OpenAPI v3 document of the gateway routes. Bodies and data are the proto
+// JSON mapping of each RPC's request and response messages; every response
+// is wrapped in the envelope.
+func (g *Gateway) openAPIDocument() map[string]interface{} {
+    var methods protoreflect.MethodDescriptors
+    if descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(complianceServiceName); err == nil {
+        if service, ok := descriptor.(protoreflect.ServiceDescriptor); ok {
+            methods = service.Methods()
+        }
+    }
+
+    schemas := map[string]interface{}{
+        "Envelope": map[string]interface{}{
+            "type": "object",
+            "properties": map[string]interface{}{
+                "data":   map[string]interface{}{"description": "The RPC response, null on error"},
+                "meta":   map[string]interface{}{"type": "object", "description": "request_id, duration_ms, cache_status and, for lists, pagination"},
+                "errors": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"code": map[string]interface{}{"type": "string"}, "reason": map[string]interface{}{"type": "string"}, "message": map[string]interface{}{"type": "string"}}}},
+            },
+        },
+    }
+    paths := make(map[string]interface{}, len(g.routes))
+    for _, route := range g.routes {
+        request := route.Request().ProtoReflect().Descriptor()
+        operation := map[string]interface{}{
+            "operationId": route.RPC,
+            "summary":     route.RPC,
+        }
+        if route.Method != http.MethodGet {
+            operation["requestBody"] = map[string]interface{}{
+                "content": map[string]interface{}{
+                    "application/json": map[string]interface{}{"schema": messageSchemaRef(request, schemas)},
+                },
+            }
+        }
+
+        data := map[string]interface{}{"description": "See the RPC's response message"}
+        if methods != nil {
+            if method := methods.ByName(protoreflect.Name(route.RPC)); method != nil {
+                data = messageSchemaRef(method.Output(), schemas)
+            }
+        }
+        envelope := map[string]interface{}{
+            "allOf": []interface{}{
+                map[string]interface{}{"$ref": "#/components/schemas/Envelope"},
+                map[string]interface{}{"type": "object", "properties": map[string]interface{}{"data": data}},
+            },
+        }
+        operation["responses"] = map[string]interface{}{
+            "default": map[string]interface{}{
+                "description": "Envelope carrying the response or errors; the HTTP status follows the gRPC code",
+                "content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": envelope}},
+            },
+        }
+        paths[route.Path] = map[string]interface{}{strings.ToLower(route.Method): operation}
+    }
+
+    return map[string]interface{}{
+        "openapi": "3.0.3",
+        "info": map[string]interface{}{
+            "title":   "Compliance API",
+            "version": g.service.config.Version,
+        },
+        "paths": paths,
+        "components": map[string]interface{}{
+            "schemas": schemas,
+            "securitySchemes": map[string]interface{}{
+                "bearerAuth": map[string]interface{}{
+                    "type":        "http",
+                    "scheme":      "bearer",
+                    "description": "Admin token for admin RPCs, or a delegate principal's token",
+                },
+                "tenantHeader": map[string]interface{}{
+                    "type":        "apiKey",
+                    "in":          "header",
+                    "name":        "X-Tenant-ID",
+                    "description": "Calling tenant; the default tenant when absent",
+                },
+            },
+        },
+        "security": []interface{}{
+            map[string]interface{}{},
+            map[string]interface{}{"bearerAuth": []string{}},
+            map[string]interface{}{"tenantHeader": []string{}},
+            map[string]interface{}{"bearerAuth": []string{}, "tenantHeader": []string{}},
+        },
+    }
+}
+
+// Reference to a message's schema, adding it and the messages it refers to
+// on first use
+func messageSchemaRef(message protoreflect.MessageDescriptor, schemas map[string]interface{}) map[string]interface{} {
+    if schema, ok := wellKnownSchema(message); ok {
+        return schema
+    }
+    name := string(message.Name())
+    if message.Parent() != message.ParentFile() {
+        name = strings.ReplaceAll(strings.TrimPrefix(string(message.FullName()), string(message.ParentFile().Package())+"."), ".", "_")
+    }
+    ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+    if _, ok := schemas[name]; ok {
+        return ref
+    }
+
+    properties := make(map[string]interface{})
+    schemas[name] = map[string]interface{}{"type": "object", "properties": properties}
+    fields := message.Fields()
+    for i := 0; i < fields.Len(); i++ {
+        field := fields.Get(i)
+        properties[field.JSONName()] = fieldSchema(field, schemas)
+    }
+    return ref
+}
+
+// Schema of a field in the proto JSON mapping
+func fieldSchema(field protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+    if field.IsMap() {
+        return map[string]interface{}{"type": "object", "additionalProperties": singularSchema(field.MapValue(), schemas)}
+    }
+    if field.IsList() {
+        return map[string]interface{}{"type": "array", "items": singularSchema(field, schemas)}
+    }
+    return singularSchema(field, schemas)
+}
+
+func singularSchema(field protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+    switch field.Kind() {
+    case protoreflect.BoolKind:
+        return map[string]interface{}{"type": "boolean"}
+    case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+        return map[string]interface{}{"type": "integer", "format": "int32"}
+    case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+        return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+    case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+        // 64-bit integers are JSON strings in the proto mapping
+        return map[string]interface{}{"type": "string", "format": "int64"}
+    case protoreflect.FloatKind:
+        return map[string]interface{}{"type": "number", "format": "float"}
+    case protoreflect.DoubleKind:
+        return map[string]interface{}{"type": "number", "format": "double"}
+    case protoreflect.StringKind:
+        return map[string]interface{}{"type": "string"}
+    case protoreflect.BytesKind:
+        return map[string]interface{}{"type": "string", "format": "byte"}
+    case protoreflect.EnumKind:
+        values := field.Enum().Values()
+        names := make([]string, values.Len())
+        for i := range names {
+            names[i] = string(values.Get(i).Name())
+        }
+        return map[string]interface{}{"type": "string", "enum": names}
+    case protoreflect.MessageKind, protoreflect.GroupKind:
+        return messageSchemaRef(field.Message(), schemas)
+    }
+    panic(fmt.Sprintf("unhandled field kind %v", field.Kind()))
+}
+
+// Well-known types with a special JSON mapping
+func wellKnownSchema(message protoreflect.MessageDescriptor) (map[string]interface{}, bool) {
+    switch message.FullName() {
+    case "google.protobuf.Timestamp":
+        return map[string]interface{}{"type": "string", "format": "date-time"}, true
+    case "google.protobuf.Duration":
+        return map[string]interface{}{"type": "string", "example": "1.5s"}, true
+    case "google.protobuf.Empty":
+        return map[string]interface{}{"type": "object"}, true
+    case "google.protobuf.Struct":
+        return map[string]interface{}{"type": "object", "additionalProperties": true}, true
+    case "google.protobuf.Value":
+        return map[string]interface{}{}, true
+    }
+    return nil, false
+}
@@ -0,0 +1,87 @@
+package compliance
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+// The parts of the served OpenAPI document the tests check
+type openAPIDocument struct {
+    Paths map[string]map[string]struct {
+        OperationID string `json:"operationId"`
+    } `json:"paths"`
+    Components struct {
+        SecuritySchemes map[string]struct {
+            Type   string `json:"type"`
+            Scheme string `json:"scheme"`
+            In     string `json:"in"`
+            Name   string `json:"name"`
+        } `json:"securitySchemes"`
+    } `json:"components"`
+}
+
+// Every route the gateway mux serves is in the served document under its
+// method and RPC, and the document lists nothing the mux does not serve
+func TestOpenAPIDocumentCoversEveryRoute(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.GatewayDocs = true
+    })
+    gateway := NewGateway(service)
+    w := httptest.NewRecorder()
+    gateway.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+    if w.Code != http.StatusOK {
+        t.Fatalf("GET /openapi.json = %d, want 200", w.Code)
+    }
+    var document openAPIDocument
+    if err := json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+        t.Fatalf("undecodable document: %v", err)
+    }
+
+    for _, route := range gateway.routes {
+        if _, pattern := gateway.mux.Handler(httptest.NewRequest(route.Method, route.Path, nil)); pattern != route.Path {
+            t.Errorf("%s %s served by %q, want its own route", route.Method, route.Path, pattern)
+        }
+        operation, ok := document.Paths[route.Path][strings.ToLower(route.Method)]
+        if !ok {
+            t.Errorf("%s %s missing from the document", route.Method, route.Path)
+        } else if operation.OperationID != route.RPC {
+            t.Errorf("%s %s documented as %s, want %s", route.Method, route.Path, operation.OperationID, route.RPC)
+        }
+    }
+    operations := 0
+    for path, methods := range document.Paths {
+        operations += len(methods)
+        if _, pattern := gateway.mux.Handler(httptest.NewRequest(http.MethodGet, path, nil)); pattern != path {
+            t.Errorf("documented %s not served by the gateway", path)
+        }
+    }
+    if operations != len(gateway.routes) {
+        t.Errorf("%d documented operations, want one per each of the %d routes", operations, len(gateway.routes))
+    }
+
+    schemes := document.Components.SecuritySchemes
+    if bearer := schemes["bearerAuth"]; bearer.Type != "http" || bearer.Scheme != "bearer" {
+        t.Errorf("bearerAuth %+v, want http bearer", bearer)
+    }
+    if tenant := schemes["tenantHeader"]; tenant.Type != "apiKey" || tenant.In != "header" || tenant.Name != "X-Tenant-ID" {
+        t.Errorf("tenantHeader %+v, want an X-Tenant-ID header API key", tenant)
+    }
+}
+
+// With docs turned off neither the document nor Swagger UI is served
+func TestGatewayDocsDisabled(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.GatewayDocs = false
+    })
+    gateway := NewGateway(service)
+    for _, path := range []string{"/openapi.json", "/docs"} {
+        w := httptest.NewRecorder()
+        gateway.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+        if w.Code != http.StatusNotFound {
+            t.Errorf("GET %s = %d, want 404", path, w.Code)
+        }
+    }
+}
@@ -0,0 +1,265 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "sync/atomic"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/emptypb"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Redis hash holding the shared operational state (fields version and
+// state), and the channel announcing new versions
+const (
+    operationalStateKey     = "operational-state"
+    operationalStateChannel = "operational-state"
+)
+
+// Replace the operational state if it is still at the expected version and
+// announce the new version.
+//
+// KEYS[1] - operational state hash
+// ARGV[1] - expected version
+// ARGV[2] - encoded state of the next version
+// ARGV[3] - channel to publish the next version on
+//
+// Returns {1, next version} on success and {0, current version} on conflict.
+var compareAndSetStateScript = redis.NewScript(`
+local current = tonumber(redis.call('HGET', KEYS[1], 'version') or '0')
+if current ~= tonumber(ARGV[1]) then
+    return {0, current}
+end
+local next = current + 1
+redis.call('HSET', KEYS[1], 'version', next, 'state', ARGV[2])
+redis.call('PUBLISH', ARGV[3], next)
+return {1, next}
+`)
+
+// Returned by CompareAndSet when another update landed first
+type stateConflictError struct {
+    expected, current int64
+}
+
+func (e *stateConflictError) Error() string {
+    return fmt.Sprintf("operational state is at version %d, expected %d", e.current, e.expected)
+}
+
+// OperationalStateStore - operational overrides in Redis, watched by every
+// replica. Changes are picked up from the announcement channel, with
+// polling as the backstop for missed messages.
+type OperationalStateStore struct {
+    redis    *redis.Client
+    interval time.Duration
+    current  atomic.Pointer[OperationalState]
+}
+
+// Create a store polling every interval; it starts with no overrides
+func NewOperationalStateStore(client *redis.Client, interval time.Duration) *OperationalStateStore {
+    store := &OperationalStateStore{redis: client, interval: interval}
+    store.current.Store(&OperationalState{})
+    return store
+}
+
+// Current - the latest version this replica has seen
+func (o *OperationalStateStore) Current() *OperationalState {
+    return o.current.Load()
+}
+
+// Load the stored state; version 0 when none was ever written
+func (o *OperationalStateStore) Load(ctx context.Context) (*OperationalState, error) {
+    values, err := o.redis.HMGet(ctx, operationalStateKey, "state").Result()
+    if err != nil {
+        return nil, err
+    }
+    state := &OperationalState{}
+    if data, ok := values[0].(string); ok {
+        if err := proto.Unmarshal([]byte(data), state); err != nil {
+            return nil, fmt.Errorf("failed to decode operational state: %v", err)
+        }
+    }
+    return state, nil
+}
+
+// CompareAndSet stores state as the next version if the stored version is
+// still expected, returning it with its version set
+func (o *OperationalStateStore) CompareAndSet(ctx context.Context, expected int64, state *OperationalState) (*OperationalState, error) {
+    next := proto.Clone(state).(*OperationalState)
+    next.Version = expected + 1
+    data, err := proto.Marshal(next)
+    if err != nil {
+        return nil, err
+    }
+
+    result, err := compareAndSetStateScript.Run(ctx, o.redis, []string{operationalStateKey}, expected, data, operationalStateChannel).Int64Slice()
+    if err != nil {
+        return nil, err
+    }
+    if result[0] == 0 {
+        return nil, &stateConflictError{expected: expected, current: result[1]}
+    }
+    return next, nil
+}
+
+// Run watches for new versions until ctx is done, calling apply once each
+// is current. A version apply rejects is not retried until a newer one
+// appears.
+func (o *OperationalStateStore) Run(ctx context.Context, apply func() error) {
+    subscription := o.redis.Subscribe(ctx, operationalStateChannel)
+    defer subscription.Close()
+    announcements := subscription.Channel()
+
+    ticker := time.NewTicker(o.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-announcements:
+        case <-ticker.C:
+        }
+        if err := o.refresh(ctx, apply); err != nil {
+            log.Printf("Operational state refresh failed: %v", err)
+        }
+    }
+}
+
+func (o *OperationalStateStore) refresh(ctx context.Context, apply func() error) error {
+    state, err := o.Load(ctx)
+    if err != nil {
+        return err
+    }
+    if state.Version <= o.Current().Version {
+        return nil
+    }
+    previous := o.current.Swap(state)
+    if err := apply(); err != nil {
+        operationalStateApplied.WithLabelValues("rejected").Inc()
+        return fmt.Errorf("version %d rejected, keeping version %d: %v", state.Version, previous.Version, err)
+    }
+    operationalStateApplied.WithLabelValues("applied").Inc()
+    log.Printf("Operational state version %d applied", state.Version)
+    return nil
+}
+
+// Layer operational overrides over a runtime config built from the config file
+func applyOperationalState(runtime *RuntimeConfig, state *OperationalState) {
+    runtime.OperationalVersion = state.Version
+    for framework, weight := range state.Weights {
+        runtime.Weights[framework] = weight
+    }
+    if t := state.Thresholds; t != nil {
+        runtime.Thresholds = StatusThresholds{Compliant: t.Compliant, PartiallyCompliant: t.PartiallyCompliant}
+    }
+    if state.SamaSubdomainGates != nil {
+        runtime.SamaSubdomainGates = state.SamaSubdomainGates
+    }
+    if state.FrameworkGates != nil {
+        runtime.FrameworkGates = make(map[string]StatusThresholds, len(state.FrameworkGates))
+        for framework, gate := range state.FrameworkGates {
+            runtime.FrameworkGates[framework] = StatusThresholds{Compliant: gate.Compliant, PartiallyCompliant: gate.PartiallyCompliant}
+        }
+    }
+    // A disabled framework is still evaluated and reported but counts for nothing
+    for _, framework := range state.DisabledFrameworks {
+        delete(runtime.Weights, framework)
+        delete(runtime.FrameworkGates, framework)
+    }
+    runtime.DisabledFrameworks = state.DisabledFrameworks
+    runtime.PromotedRulesets = state.PromotedRulesets
+}
+
+// GetOperationalState - the operational overrides this replica applies
+func (s *ComplianceService) GetOperationalState(ctx context.Context, _ *emptypb.Empty) (*OperationalState, error) {
+    return s.operational.Current(), nil
+}
+
+// UpdateOperationalState - admin RPC replacing the operational overrides with
+// compare-and-set. The update is validated against this replica's config
+// before it is stored; every replica then applies it on its own.
+func (s *ComplianceService) UpdateOperationalState(ctx context.Context, req *UpdateOperationalStateRequest) (*OperationalState, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if req.State == nil || req.UpdatedBy == "" {
+        return nil, status.Error(codes.InvalidArgument, "state and updated_by are required")
+    }
+    for _, framework := range req.State.DisabledFrameworks {
+        if !containsString(s.engine.Frameworks(), framework) {
+            return nil, status.Errorf(codes.InvalidArgument, "cannot disable unknown framework %s", framework)
+        }
+    }
+
+    for framework, version := range req.State.PromotedRulesets {
+        if shadow, ok := s.engine.shadows[framework]; !ok || shadow.version != version {
+            return nil, status.Errorf(codes.InvalidArgument, "cannot promote unregistered ruleset %s %s", framework, version)
+        }
+    }
+
+    state := proto.Clone(req.State).(*OperationalState)
+    state.UpdatedBy = req.UpdatedBy
+    state.UpdatedAt = timestamppb.Now()
+    if _, err := loadRuntimeConfig(s.config, s.engine.Frameworks(), state); err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "invalid operational state: %v", err)
+    }
+
+    stored, err := s.operational.CompareAndSet(ctx, req.ExpectedVersion, state)
+    if conflict, ok := err.(*stateConflictError); ok {
+        operationalStateConflicts.Inc()
+        return nil, reasonError(codes.Aborted, reasonVersionConflict, conflict.Error()+"; re-read and retry")
+    } else if err != nil {
+        return nil, storeError(err, "failed to store operational state")
+    }
+    log.Printf("Audit: rpc=UpdateOperationalState version=%d updated_by=%s", stored.Version, redact(fieldPrincipal, req.UpdatedBy))
+
+    // Apply here right away; other replicas follow on the announcement
+    if err := s.operational.refresh(ctx, s.rebuildRuntimeConfig); err != nil {
+        log.Printf("Operational state refresh failed: %v", err)
+    }
+    return stored, nil
+}
+
+// Rebuild the runtime config with the current operational overrides
+func (s *ComplianceService) rebuildRuntimeConfig() error {
+    _, err := s.reloadRuntimeConfig()
+    return err
+}
+
+// Operational state metrics
+var (
+    operationalStateApplied = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_operational_state_versions_total",
+            Help: "Operational state versions seen by this replica, by whether they were applied or rejected",
+        },
+        []string{"outcome"},
+    )
+
+    operationalStateConflicts = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_operational_state_conflicts_total",
+            Help: "Operational state updates rejected because another update landed first",
+        },
+    )
+
+    operationalStateVersion = prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "compliance_operational_state_version",
+            Help: "Operational state version applied by this replica; differing values across replicas mean skew",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(operationalStateApplied)
+    prometheus.MustRegister(operationalStateConflicts)
+    prometheus.MustRegister(operationalStateVersion)
+}
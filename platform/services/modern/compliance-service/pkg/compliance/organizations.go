@@ -0,0 +1,281 @@
+package compliance
+
+import (
+    "context"
+    "encoding/base64"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Organizations scanned per requested one before a filtered page is cut
+// short; the cursor resumes where the scan stopped
+const orgListScanFactor = 10
+
+// Index entries fetched per round trip while filling a page
+const orgListBatch = 200
+
+// orgQuery - a ListOrganizations query against one latest-results index.
+// The status filter picks the index and the bounds on its sort key narrow
+// the range; sector, tags and bounds on the other key are checked per row.
+type orgQuery struct {
+    tenant    string
+    index     string
+    ascending bool
+    min       string // Index range, in ZRANGEBYSCORE syntax
+    max       string
+    match     func(*LatestResult) bool // Nil when the index range is exact
+}
+
+// orgCursor - position after the last organization returned: its sort key
+// and ID, ties on the key being ordered by ID as the index orders them
+type orgCursor struct {
+    sortBy    string
+    ascending bool
+    score     float64
+    id        string
+}
+
+func (c orgCursor) token() string {
+    raw := fmt.Sprintf("%s|%t|%s|%s", c.sortBy, c.ascending, strconv.FormatFloat(c.score, 'f', -1, 64), c.id)
+    return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func parseOrgCursor(token string) (orgCursor, error) {
+    raw, err := base64.RawURLEncoding.DecodeString(token)
+    if err != nil {
+        return orgCursor{}, err
+    }
+    parts := strings.SplitN(string(raw), "|", 4)
+    if len(parts) != 4 {
+        return orgCursor{}, fmt.Errorf("malformed cursor")
+    }
+    ascending, err := strconv.ParseBool(parts[1])
+    if err != nil {
+        return orgCursor{}, err
+    }
+    score, err := strconv.ParseFloat(parts[2], 64)
+    if err != nil {
+        return orgCursor{}, err
+    }
+    return orgCursor{sortBy: parts[0], ascending: ascending, score: score, id: parts[3]}, nil
+}
+
+// Whether an index entry is at or before the cursor, so already served
+func (c *orgCursor) covers(entry redis.Z) bool {
+    if c == nil || entry.Score != c.score {
+        return false
+    }
+    id, _ := entry.Member.(string)
+    if c.ascending {
+        return id <= c.id
+    }
+    return id >= c.id
+}
+
+// Page of organizations after the cursor. Walks the index in batches from
+// the cursor's sort key, checking the per-row filters, until the page is
+// full, the index range ends or scanBudget entries were read. Returns the
+// page, the cursor to continue from (nil at the end) and how many entries
+// were scanned.
+func (l *LatestResults) Organizations(ctx context.Context, query orgQuery, sortBy string, after *orgCursor, limit, scanBudget int64) ([]*LatestResult, *orgCursor, int64, error) {
+    low, high := query.min, query.max
+    if after != nil {
+        bound := strconv.FormatFloat(after.score, 'f', -1, 64)
+        if query.ascending {
+            low = bound
+        } else {
+            high = bound
+        }
+    }
+
+    var page []*LatestResult
+    var last *orgCursor
+    var scanned, offset int64
+    for {
+        batch := &redis.ZRangeBy{Min: low, Max: high, Offset: offset, Count: orgListBatch}
+        var entries []redis.Z
+        var err error
+        if query.ascending {
+            entries, err = l.redis.ZRangeByScoreWithScores(ctx, query.index, batch).Result()
+        } else {
+            entries, err = l.redis.ZRevRangeByScoreWithScores(ctx, query.index, batch).Result()
+        }
+        if err != nil {
+            return nil, nil, scanned, err
+        }
+        offset += int64(len(entries))
+
+        var ids []string
+        var scores []float64
+        for _, entry := range entries {
+            if after.covers(entry) {
+                continue
+            }
+            ids = append(ids, entry.Member.(string))
+            scores = append(scores, entry.Score)
+        }
+        if len(ids) > 0 {
+            values, err := l.redis.HMGet(ctx, latestResultsKey(query.tenant), ids...).Result()
+            if err != nil {
+                return nil, nil, scanned, err
+            }
+            for i, value := range values {
+                scanned++
+                last = &orgCursor{sortBy: sortBy, ascending: query.ascending, score: scores[i], id: ids[i]}
+                if data, ok := value.(string); ok {
+                    result := &LatestResult{}
+                    if err := proto.Unmarshal([]byte(data), result); err != nil {
+                        return nil, nil, scanned, err
+                    }
+                    if query.match == nil || query.match(result) {
+                        page = append(page, result)
+                    }
+                }
+                if int64(len(page)) >= limit || scanned >= scanBudget {
+                    return page, last, scanned, nil
+                }
+            }
+        }
+        if len(entries) < orgListBatch {
+            return page, nil, scanned, nil
+        }
+    }
+}
+
+// Matching index entries; O(log n) whatever the range
+func (l *LatestResults) countRange(ctx context.Context, query orgQuery) (int64, error) {
+    return l.redis.ZCount(ctx, query.index, query.min, query.max).Result()
+}
+
+// ListOrganizations - a tenant's organizations at their latest result,
+// filtered by sector, tags, status, score and last check. Sorting by score
+// or by last check walks the matching sorted-set index from a cursor, so
+// pages stay stable as results are recorded. The total is exact when every
+// filter is served by the index and estimated otherwise.
+func (s *ComplianceService) ListOrganizations(ctx context.Context, req *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+    tenant := tenantFromContext(ctx)
+    if req.Tenant != "" && req.Tenant != tenant {
+        if err := s.requireAdmin(ctx); err != nil {
+            return nil, err
+        }
+        tenant = req.Tenant
+    }
+
+    sortBy := req.SortBy
+    switch sortBy {
+    case "":
+        sortBy = latestSortLastChecked
+    case latestSortLastChecked, latestSortScore:
+    default:
+        return nil, status.Errorf(codes.InvalidArgument, "unsupported sort_by %q", req.SortBy)
+    }
+    if req.Status != "" && !containsString(latestStatuses, req.Status) {
+        return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", req.Status)
+    }
+    if req.MinScore < 0 || req.MaxScore < 0 || (req.MaxScore > 0 && req.MinScore > req.MaxScore) {
+        return nil, status.Error(codes.InvalidArgument, "score range must satisfy 0 <= min_score <= max_score")
+    }
+    if req.LastCheckedBefore < 0 {
+        return nil, status.Error(codes.InvalidArgument, "last_checked_before must not be negative")
+    }
+
+    maxPageSize := int64(s.config.OrgListMaxPageSize)
+    if maxPageSize <= 0 {
+        maxPageSize = latestMaxPageSize
+    }
+    pageSize := int64(req.PageSize)
+    if pageSize <= 0 {
+        pageSize = latestDefaultPageSize
+    }
+    if pageSize > maxPageSize {
+        pageSize = maxPageSize
+    }
+
+    var after *orgCursor
+    if req.PageToken != "" {
+        cursor, err := parseOrgCursor(req.PageToken)
+        if err != nil || cursor.sortBy != sortBy || cursor.ascending != req.Ascending {
+            return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+        }
+        after = &cursor
+    }
+
+    query := orgQuery{
+        tenant:    tenant,
+        index:     latestIndexKey(tenant, req.Status, sortBy),
+        ascending: req.Ascending,
+        min:       "-inf",
+        max:       "+inf",
+    }
+    scoreInRange := func(score float64) bool {
+        return score >= req.MinScore && (req.MaxScore == 0 || score <= req.MaxScore)
+    }
+    checkedInRange := func(checkedAt int64) bool {
+        return req.LastCheckedBefore == 0 || checkedAt < req.LastCheckedBefore
+    }
+    var checks []func(*LatestResult) bool
+    if sortBy == latestSortScore {
+        query.min = strconv.FormatFloat(req.MinScore, 'f', -1, 64)
+        if req.MaxScore > 0 {
+            query.max = strconv.FormatFloat(req.MaxScore, 'f', -1, 64)
+        }
+        if req.LastCheckedBefore > 0 {
+            checks = append(checks, func(r *LatestResult) bool { return checkedInRange(r.CheckedAt) })
+        }
+    } else {
+        if req.LastCheckedBefore > 0 {
+            query.max = "(" + strconv.FormatInt(req.LastCheckedBefore, 10)
+        }
+        if req.MinScore > 0 || req.MaxScore > 0 {
+            checks = append(checks, func(r *LatestResult) bool { return scoreInRange(r.OverallScore) })
+        }
+    }
+    if req.Sector != "" {
+        checks = append(checks, func(r *LatestResult) bool { return strings.EqualFold(r.Sector, req.Sector) })
+    }
+    for _, tag := range req.Tags {
+        tag := tag
+        checks = append(checks, func(r *LatestResult) bool { return containsString(r.Tags, tag) })
+    }
+    if len(checks) > 0 {
+        query.match = func(r *LatestResult) bool {
+            for _, check := range checks {
+                if !check(r) {
+                    return false
+                }
+            }
+            return true
+        }
+    }
+
+    organizations, next, scanned, err := s.latest.Organizations(ctx, query, sortBy, after, pageSize, pageSize*orgListScanFactor)
+    if err != nil {
+        return nil, storeError(err, "failed to list organizations")
+    }
+    response := &ListOrganizationsResponse{Organizations: organizations}
+    if next != nil {
+        response.NextPageToken = next.token()
+    }
+
+    // The index count is exact for index-served filters; row filters scale
+    // it by this page's match rate, which needs a page that matched at all
+    if query.match == nil || (scanned > 0 && len(organizations) > 0) {
+        count, err := s.latest.countRange(ctx, query)
+        if err != nil {
+            return nil, storeError(err, "failed to count organizations")
+        }
+        response.HasTotalCount = true
+        response.TotalCount = count
+        if query.match != nil {
+            response.TotalCountApproximate = true
+            response.TotalCount = count * int64(len(organizations)) / scanned
+        }
+    }
+    return response, nil
+}
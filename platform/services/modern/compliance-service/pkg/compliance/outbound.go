@@ -0,0 +1,241 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "net/http"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// OutboundConfig - the shared HTTP client for calls to other services, such
+// as evidence sources and the knowledge base
+type OutboundConfig struct {
+    // Idle connections kept per host, so bursts reuse connections instead
+    // of exhausting ephemeral ports
+    MaxIdleConnsPerHost int `env:"OUTBOUND_MAX_IDLE_PER_HOST"`
+
+    // Connections per host, idle or in use; 0 is unlimited
+    MaxConnsPerHost int `env:"OUTBOUND_MAX_CONNS_PER_HOST"`
+
+    // Requests awaiting response headers per host; further requests wait
+    // their turn
+    MaxConcurrentPerHost int `env:"OUTBOUND_MAX_CONCURRENT_PER_HOST"`
+
+    // Per-source overrides of MaxConcurrentPerHost, "host:limit,..."
+    HostLimits string `env:"OUTBOUND_HOST_LIMITS"`
+
+    DialTimeout         time.Duration `env:"OUTBOUND_DIAL_TIMEOUT"`
+    TLSHandshakeTimeout time.Duration `env:"OUTBOUND_TLS_TIMEOUT"`
+
+    // How long resolved addresses are reused; 0 resolves every dial
+    DNSCacheTTL time.Duration `env:"OUTBOUND_DNS_CACHE_TTL"`
+}
+
+// Parse per-host concurrency overrides such as "evidence.internal:8"
+func parseHostLimits(value string) (map[string]int, error) {
+    limits := make(map[string]int)
+    for _, entry := range strings.Split(value, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        i := strings.LastIndex(entry, ":")
+        if i <= 0 {
+            return nil, fmt.Errorf("invalid outbound host limit %q", entry)
+        }
+        limit, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+        if err != nil || limit < 1 {
+            return nil, fmt.Errorf("invalid outbound host limit %q", entry)
+        }
+        limits[strings.ToLower(strings.TrimSpace(entry[:i]))] = limit
+    }
+    return limits, nil
+}
+
+// Shared outbound client: pooled keep-alive connections over HTTP/2 where
+// offered, cached DNS, a cap on requests in flight per host and per-host
+// latency and error metrics
+func newOutboundClient(config OutboundConfig) (*http.Client, error) {
+    overrides, err := parseHostLimits(config.HostLimits)
+    if err != nil {
+        return nil, err
+    }
+    dialer := &net.Dialer{Timeout: config.DialTimeout, KeepAlive: 30 * time.Second}
+    resolver := &dnsCache{ttl: config.DNSCacheTTL, entries: make(map[string]dnsEntry)}
+
+    transport := &http.Transport{
+        Proxy: http.ProxyFromEnvironment,
+        DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+            host, port, err := net.SplitHostPort(addr)
+            if err != nil {
+                return nil, err
+            }
+            addrs, err := resolver.lookup(ctx, host)
+            if err != nil {
+                return nil, err
+            }
+            // Try each address in turn, as the default dialer does
+            for _, ip := range addrs {
+                var conn net.Conn
+                if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip, port)); err == nil {
+                    return conn, nil
+                }
+            }
+            resolver.forget(host)
+            return nil, err
+        },
+        ForceAttemptHTTP2:     true,
+        MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+        MaxConnsPerHost:       config.MaxConnsPerHost,
+        IdleConnTimeout:       90 * time.Second,
+        TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+        ExpectContinueTimeout: time.Second,
+    }
+    return &http.Client{Transport: &hostLimitedTransport{
+        next:     transport,
+        limit:    config.MaxConcurrentPerHost,
+        limits:   overrides,
+        inFlight: make(map[string]chan struct{}),
+    }}, nil
+}
+
+// Resolved addresses per host, reused for the cache TTL. The system
+// resolver does not report record TTLs, so the configured TTL bounds how
+// long a record is trusted; a host whose addresses all fail to dial is
+// resolved afresh on the next attempt.
+type dnsCache struct {
+    ttl     time.Duration
+    mu      sync.Mutex
+    entries map[string]dnsEntry
+}
+
+type dnsEntry struct {
+    addrs   []string
+    expires time.Time
+}
+
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+    if net.ParseIP(host) != nil {
+        return []string{host}, nil
+    }
+    if c.ttl > 0 {
+        c.mu.Lock()
+        entry, ok := c.entries[host]
+        c.mu.Unlock()
+        if ok && time.Now().Before(entry.expires) {
+            outboundDNSLookups.WithLabelValues("hit").Inc()
+            return entry.addrs, nil
+        }
+    }
+    addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+    if err != nil {
+        outboundDNSLookups.WithLabelValues("error").Inc()
+        return nil, err
+    }
+    outboundDNSLookups.WithLabelValues("miss").Inc()
+    if c.ttl > 0 {
+        c.mu.Lock()
+        c.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(c.ttl)}
+        c.mu.Unlock()
+    }
+    return addrs, nil
+}
+
+func (c *dnsCache) forget(host string) {
+    c.mu.Lock()
+    delete(c.entries, host)
+    c.mu.Unlock()
+}
+
+// Caps requests in flight per host and records per-host outcomes
+type hostLimitedTransport struct {
+    next     http.RoundTripper
+    limit    int
+    limits   map[string]int // Per-host overrides
+    mu       sync.Mutex
+    inFlight map[string]chan struct{}
+}
+
+func (t *hostLimitedTransport) slots(host string) chan struct{} {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    slots, ok := t.inFlight[host]
+    if !ok {
+        limit := t.limit
+        if override, ok := t.limits[host]; ok {
+            limit = override
+        }
+        if limit > 0 {
+            slots = make(chan struct{}, limit)
+        }
+        t.inFlight[host] = slots
+    }
+    return slots
+}
+
+func (t *hostLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+    host := strings.ToLower(req.URL.Hostname())
+    if slots := t.slots(host); slots != nil {
+        select {
+        case slots <- struct{}{}:
+            defer func() { <-slots }()
+        case <-req.Context().Done():
+            outboundRequests.WithLabelValues(host, "throttled").Inc()
+            return nil, req.Context().Err()
+        }
+    }
+
+    start := time.Now()
+    resp, err := t.next.RoundTrip(req)
+    outcome := "ok"
+    switch {
+    case err != nil:
+        outcome = "error"
+    case resp.StatusCode >= 500:
+        outcome = "server_error"
+    case resp.StatusCode >= 400:
+        outcome = "client_error"
+    }
+    outboundRequests.WithLabelValues(host, outcome).Inc()
+    outboundLatency.WithLabelValues(host).Observe(time.Since(start).Seconds())
+    return resp, err
+}
+
+// Outbound HTTP metrics
+var (
+    outboundRequests = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_outbound_requests_total",
+            Help: "Outbound HTTP requests by host and outcome",
+        },
+        []string{"host", "outcome"},
+    )
+
+    outboundLatency = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name: "compliance_outbound_request_duration_seconds",
+            Help: "Outbound HTTP request latency by host, to response headers",
+        },
+        []string{"host"},
+    )
+
+    outboundDNSLookups = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_outbound_dns_lookups_total",
+            Help: "Outbound DNS lookups by cache result",
+        },
+        []string{"result"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(outboundRequests)
+    prometheus.MustRegister(outboundLatency)
+    prometheus.MustRegister(outboundDNSLookups)
+}
@@ -0,0 +1,88 @@
+package compliance
+
+import (
+    "io"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+// A minute of outbound calls at 1k evaluations/min, sent as fast as the
+// client allows: every call succeeds, requests in flight to the host never
+// pass the per-host cap, and the calls share a bounded pool of connections
+func TestOutboundClientUnderLoad(t *testing.T) {
+    const (
+        calls       = 1000
+        callers     = 50
+        concurrency = 4
+        connections = 8
+    )
+    var inFlight, peak, opened atomic.Int64
+    server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        n := inFlight.Add(1)
+        defer inFlight.Add(-1)
+        for {
+            seen := peak.Load()
+            if n <= seen || peak.CompareAndSwap(seen, n) {
+                break
+            }
+        }
+        time.Sleep(time.Millisecond)
+        io.WriteString(w, "ok")
+    }))
+    server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+        if state == http.StateNew {
+            opened.Add(1)
+        }
+    }
+    server.Start()
+    t.Cleanup(server.Close)
+
+    client, err := newOutboundClient(OutboundConfig{
+        MaxIdleConnsPerHost:  connections,
+        MaxConnsPerHost:      connections,
+        MaxConcurrentPerHost: concurrency,
+        DialTimeout:          5 * time.Second,
+        TLSHandshakeTimeout:  5 * time.Second,
+        DNSCacheTTL:          30 * time.Second,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var failed atomic.Int64
+    var wg sync.WaitGroup
+    for i := 0; i < callers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for j := 0; j < calls/callers; j++ {
+                resp, err := client.Get(server.URL)
+                if err != nil {
+                    failed.Add(1)
+                    continue
+                }
+                io.Copy(io.Discard, resp.Body)
+                resp.Body.Close()
+                if resp.StatusCode != http.StatusOK {
+                    failed.Add(1)
+                }
+            }
+        }()
+    }
+    wg.Wait()
+
+    if n := failed.Load(); n > 0 {
+        t.Errorf("%d of %d calls failed", n, calls)
+    }
+    if n := peak.Load(); n > concurrency {
+        t.Errorf("%d requests in flight to one host, want at most %d", n, concurrency)
+    }
+    if n := opened.Load(); n > connections {
+        t.Errorf("%d connections opened for %d calls, want at most %d", n, calls, connections)
+    }
+}
@@ -0,0 +1,222 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Defaults for iterating a paginated list RPC
+const (
+    defaultPageRetries = 2
+    pageRetryBackoff   = 200 * time.Millisecond
+)
+
+// PageIterator - walks the items of a paginated list RPC through a
+// ComplianceClient, fetching each page as the previous one runs out:
+//
+//    it := NewOrganizationsIterator(ctx, client, req)
+//    for it.Next() {
+//        org := it.Item()
+//    }
+//    if err := it.Err(); err != nil { ... }
+//
+// A failed fetch is retried with the same page token, so no item is skipped
+// or repeated. Not safe for concurrent use.
+type PageIterator[P proto.Message, T any] struct {
+    ctx   context.Context
+    fetch func(ctx context.Context, token string) (P, []T, string, error)
+    opts  pageOptions
+
+    page    P
+    items   []T
+    pos     int
+    token   string
+    last    bool
+    yielded int
+    err     error
+}
+
+// PageOption - customizes a PageIterator
+type PageOption func(*pageOptions)
+
+type pageOptions struct {
+    maxItems int
+    retries  int
+}
+
+// WithMaxItems stops the iterator after n items, however many the pages hold
+func WithMaxItems(n int) PageOption {
+    return func(o *pageOptions) {
+        o.maxItems = n
+    }
+}
+
+// WithPageRetries sets how often a page fetch failing with a transient code
+// is retried; the default is 2
+func WithPageRetries(n int) PageOption {
+    return func(o *pageOptions) {
+        o.retries = n
+    }
+}
+
+func newPageIterator[P proto.Message, T any](ctx context.Context, token string, fetch func(context.Context, string) (P, []T, string, error), opts []PageOption) *PageIterator[P, T] {
+    options := pageOptions{retries: defaultPageRetries}
+    for _, opt := range opts {
+        opt(&options)
+    }
+    return &PageIterator[P, T]{ctx: ctx, fetch: fetch, opts: options, token: token}
+}
+
+// Next advances to the next item, fetching a page when the current one is
+// exhausted. It returns false once every item has been read, the item bound
+// is reached, or a fetch fails or ctx is cancelled; Err tells them apart.
+func (it *PageIterator[P, T]) Next() bool {
+    if it.err != nil {
+        return false
+    }
+    if it.opts.maxItems > 0 && it.yielded >= it.opts.maxItems {
+        return false
+    }
+    // A page may come back empty with a next token, when filters reject
+    // every item scanned for it, so keep fetching until one has items
+    for it.pos >= len(it.items) {
+        if it.last {
+            return false
+        }
+        if err := it.ctx.Err(); err != nil {
+            it.err = err
+            return false
+        }
+        if err := it.fetchPage(); err != nil {
+            it.err = err
+            return false
+        }
+    }
+    it.pos++
+    it.yielded++
+    return true
+}
+
+// Item - the current item; valid after Next returned true
+func (it *PageIterator[P, T]) Item() T {
+    return it.items[it.pos-1]
+}
+
+// Page - the raw response holding the current item, for totals and other
+// page-level fields; the zero value before the first fetch
+func (it *PageIterator[P, T]) Page() P {
+    return it.page
+}
+
+// Err - the error that ended iteration, nil when it ran to completion or
+// reached the item bound
+func (it *PageIterator[P, T]) Err() error {
+    return it.err
+}
+
+// Fetch the page at the current token, retrying transient failures
+func (it *PageIterator[P, T]) fetchPage() error {
+    var err error
+    for attempt := 0; attempt <= it.opts.retries; attempt++ {
+        if attempt > 0 {
+            select {
+            case <-it.ctx.Done():
+                return it.ctx.Err()
+            case <-time.After(pageRetryBackoff << (attempt - 1)):
+            }
+        }
+        page, items, next, fetchErr := it.fetch(it.ctx, it.token)
+        if fetchErr == nil {
+            it.page, it.items, it.pos = page, items, 0
+            it.token, it.last = next, next == ""
+            return nil
+        }
+        err = fetchErr
+        if !retryablePageError(err) {
+            break
+        }
+    }
+    if status.Code(err) == codes.InvalidArgument && it.token != "" {
+        return fmt.Errorf("page token rejected after %d items: %v", it.yielded, err)
+    }
+    return err
+}
+
+// Codes worth fetching the same page again for
+func retryablePageError(err error) bool {
+    switch status.Code(err) {
+    case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+        return true
+    }
+    return false
+}
+
+// NewOrganizationsIterator - iterate ListOrganizations from req's page token
+func NewOrganizationsIterator(ctx context.Context, client ComplianceClient, req *ListOrganizationsRequest, opts ...PageOption) *PageIterator[*ListOrganizationsResponse, *LatestResult] {
+    req = proto.Clone(req).(*ListOrganizationsRequest)
+    return newPageIterator(ctx, req.PageToken, func(ctx context.Context, token string) (*ListOrganizationsResponse, []*LatestResult, string, error) {
+        req.PageToken = token
+        resp, err := client.ListOrganizations(ctx, req)
+        if err != nil {
+            return nil, nil, "", err
+        }
+        return resp, resp.Organizations, resp.NextPageToken, nil
+    }, opts)
+}
+
+// NewLatestResultsIterator - iterate ListLatestResults from req's page token
+func NewLatestResultsIterator(ctx context.Context, client ComplianceClient, req *ListLatestResultsRequest, opts ...PageOption) *PageIterator[*ListLatestResultsResponse, *LatestResult] {
+    req = proto.Clone(req).(*ListLatestResultsRequest)
+    return newPageIterator(ctx, req.PageToken, func(ctx context.Context, token string) (*ListLatestResultsResponse, []*LatestResult, string, error) {
+        req.PageToken = token
+        resp, err := client.ListLatestResults(ctx, req)
+        if err != nil {
+            return nil, nil, "", err
+        }
+        return resp, resp.Results, resp.NextPageToken, nil
+    }, opts)
+}
+
+// NewStatusTimelineIterator - iterate GetStatusTimeline from req's page token
+func NewStatusTimelineIterator(ctx context.Context, client ComplianceClient, req *StatusTimelineRequest, opts ...PageOption) *PageIterator[*StatusTimelineResponse, *StatusTransition] {
+    req = proto.Clone(req).(*StatusTimelineRequest)
+    return newPageIterator(ctx, req.PageToken, func(ctx context.Context, token string) (*StatusTimelineResponse, []*StatusTransition, string, error) {
+        req.PageToken = token
+        resp, err := client.GetStatusTimeline(ctx, req)
+        if err != nil {
+            return nil, nil, "", err
+        }
+        return resp, resp.Transitions, resp.NextPageToken, nil
+    }, opts)
+}
+
+// NewScheduleRunsIterator - iterate ListScheduleRuns from req's page token
+func NewScheduleRunsIterator(ctx context.Context, client ComplianceClient, req *ListScheduleRunsRequest, opts ...PageOption) *PageIterator[*ListScheduleRunsResponse, *ScheduleRun] {
+    req = proto.Clone(req).(*ListScheduleRunsRequest)
+    return newPageIterator(ctx, req.PageToken, func(ctx context.Context, token string) (*ListScheduleRunsResponse, []*ScheduleRun, string, error) {
+        req.PageToken = token
+        resp, err := client.ListScheduleRuns(ctx, req)
+        if err != nil {
+            return nil, nil, "", err
+        }
+        return resp, resp.Runs, resp.NextPageToken, nil
+    }, opts)
+}
+
+// NewHistoryIterator - iterate GetComplianceHistory from req's page token
+func NewHistoryIterator(ctx context.Context, client ComplianceClient, req *ComplianceHistoryRequest, opts ...PageOption) *PageIterator[*ComplianceHistoryResponse, *ComplianceRunSummary] {
+    req = proto.Clone(req).(*ComplianceHistoryRequest)
+    return newPageIterator(ctx, req.PageToken, func(ctx context.Context, token string) (*ComplianceHistoryResponse, []*ComplianceRunSummary, string, error) {
+        req.PageToken = token
+        resp, err := client.GetComplianceHistory(ctx, req)
+        if err != nil {
+            return nil, nil, "", err
+        }
+        return resp, resp.Runs, resp.NextPageToken, nil
+    }, opts)
+}
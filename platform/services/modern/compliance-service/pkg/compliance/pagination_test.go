@@ -0,0 +1,300 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "strconv"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// In-memory ListOrganizations over organizations org-0..org-N, pageSize per
+// page with offsets as tokens. Fetches of a token can be made to fail, and
+// tokens to expire.
+type fakePagedClient struct {
+    ComplianceClient
+    organizations int
+    pageSize      int
+    empty         map[string]bool    // Tokens whose page holds no items
+    failures      map[string][]error // Errors returned, in turn, for a token
+    expired       map[string]bool    // Tokens rejected as expired
+
+    mu      sync.Mutex
+    fetches []string
+}
+
+func (c *fakePagedClient) ListOrganizations(ctx context.Context, req *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.fetches = append(c.fetches, req.PageToken)
+    if failures := c.failures[req.PageToken]; len(failures) > 0 {
+        c.failures[req.PageToken] = failures[1:]
+        return nil, failures[0]
+    }
+    if c.expired[req.PageToken] {
+        return nil, status.Error(codes.InvalidArgument, "page token expired")
+    }
+
+    offset := 0
+    if req.PageToken != "" {
+        offset, _ = strconv.Atoi(req.PageToken)
+    }
+    resp := &ListOrganizationsResponse{TotalCount: int64(c.organizations), HasTotalCount: true}
+    end := offset + c.pageSize
+    if end > c.organizations {
+        end = c.organizations
+    }
+    if !c.empty[req.PageToken] {
+        for i := offset; i < end; i++ {
+            resp.Organizations = append(resp.Organizations, &LatestResult{OrganizationId: "org-" + strconv.Itoa(i)})
+        }
+    }
+    if end < c.organizations {
+        resp.NextPageToken = strconv.Itoa(end)
+    }
+    return resp, nil
+}
+
+// The same pages as compliance history, one run per organization
+func (c *fakePagedClient) GetComplianceHistory(ctx context.Context, req *ComplianceHistoryRequest, opts ...grpc.CallOption) (*ComplianceHistoryResponse, error) {
+    page, err := c.ListOrganizations(ctx, &ListOrganizationsRequest{PageToken: req.PageToken})
+    if err != nil {
+        return nil, err
+    }
+    resp := &ComplianceHistoryResponse{NextPageToken: page.NextPageToken}
+    for _, organization := range page.Organizations {
+        resp.Runs = append(resp.Runs, &ComplianceRunSummary{RequestId: organization.OrganizationId})
+    }
+    return resp, nil
+}
+
+func (c *fakePagedClient) fetched() []string {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return append([]string(nil), c.fetches...)
+}
+
+// Organizations read until the iterator stops
+func collectOrganizations(it *PageIterator[*ListOrganizationsResponse, *LatestResult]) []string {
+    var ids []string
+    for it.Next() {
+        ids = append(ids, it.Item().OrganizationId)
+    }
+    return ids
+}
+
+func organizationIDs(from, to int) []string {
+    var ids []string
+    for i := from; i < to; i++ {
+        ids = append(ids, "org-"+strconv.Itoa(i))
+    }
+    return ids
+}
+
+func TestPageIteratorReadsEveryPage(t *testing.T) {
+    client := &fakePagedClient{organizations: 7, pageSize: 3, empty: map[string]bool{"3": true}}
+    req := &ListOrganizationsRequest{Sector: "banking"}
+    it := NewOrganizationsIterator(context.Background(), client, req)
+    if it.Page() != nil {
+        t.Errorf("page %v before the first fetch, want none", it.Page())
+    }
+
+    var ids []string
+    for it.Next() {
+        ids = append(ids, it.Item().OrganizationId)
+        if it.Page().TotalCount != 7 {
+            t.Errorf("page total %d, want 7", it.Page().TotalCount)
+        }
+    }
+    if it.Err() != nil {
+        t.Fatal(it.Err())
+    }
+    // The empty page between the first and last is skipped
+    want := append(organizationIDs(0, 3), "org-6")
+    if strings.Join(ids, ",") != strings.Join(want, ",") {
+        t.Errorf("read %v, want %v", ids, want)
+    }
+    if got := client.fetched(); strings.Join(got, ",") != ",3,6" {
+        t.Errorf("fetched tokens %q, want each page once", got)
+    }
+    if req.PageToken != "" {
+        t.Errorf("request token %q, want the caller's request untouched", req.PageToken)
+    }
+}
+
+func TestPageIteratorStartsAtRequestToken(t *testing.T) {
+    client := &fakePagedClient{organizations: 5, pageSize: 2}
+    ids := collectOrganizations(NewOrganizationsIterator(context.Background(), client, &ListOrganizationsRequest{PageToken: "2"}))
+    if strings.Join(ids, ",") != strings.Join(organizationIDs(2, 5), ",") {
+        t.Errorf("read %v, want org-2 onwards", ids)
+    }
+}
+
+// The item bound stops iteration without fetching pages it does not need
+func TestPageIteratorMaxItems(t *testing.T) {
+    client := &fakePagedClient{organizations: 10, pageSize: 3}
+    it := NewOrganizationsIterator(context.Background(), client, &ListOrganizationsRequest{}, WithMaxItems(4))
+    ids := collectOrganizations(it)
+    if it.Err() != nil || strings.Join(ids, ",") != strings.Join(organizationIDs(0, 4), ",") {
+        t.Errorf("read %v, %v, want the first 4 and no error", ids, it.Err())
+    }
+    if got := client.fetched(); len(got) != 2 {
+        t.Errorf("fetched tokens %q, want the 2 pages holding 4 items", got)
+    }
+}
+
+// A transient failure fetches the same page again, so nothing is skipped
+// or repeated
+func TestPageIteratorRetriesFailedPage(t *testing.T) {
+    client := &fakePagedClient{organizations: 6, pageSize: 2, failures: map[string][]error{
+        "2": {status.Error(codes.Unavailable, "connection reset")},
+    }}
+    it := NewOrganizationsIterator(context.Background(), client, &ListOrganizationsRequest{})
+    ids := collectOrganizations(it)
+    if it.Err() != nil || strings.Join(ids, ",") != strings.Join(organizationIDs(0, 6), ",") {
+        t.Errorf("read %v, %v, want every organization once", ids, it.Err())
+    }
+    if got := client.fetched(); strings.Join(got, ",") != ",2,2,4" {
+        t.Errorf("fetched tokens %q, want the failed page fetched again", got)
+    }
+}
+
+func TestPageIteratorGivesUp(t *testing.T) {
+    unavailable := status.Error(codes.Unavailable, "down")
+    tests := []struct {
+        name    string
+        err     error
+        opts    []PageOption
+        fetches int
+    }{
+        {name: "retries exhausted", err: unavailable, fetches: 1 + defaultPageRetries},
+        {name: "retries disabled", err: unavailable, opts: []PageOption{WithPageRetries(0)}, fetches: 1},
+        {name: "not transient", err: status.Error(codes.PermissionDenied, "denied"), fetches: 1},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            failures := make([]error, 5)
+            for i := range failures {
+                failures[i] = tt.err
+            }
+            client := &fakePagedClient{organizations: 4, pageSize: 2, failures: map[string][]error{"2": failures}}
+            it := NewOrganizationsIterator(context.Background(), client, &ListOrganizationsRequest{}, tt.opts...)
+            ids := collectOrganizations(it)
+            if len(ids) != 2 || status.Code(it.Err()) != status.Code(tt.err) {
+                t.Errorf("read %d, %v, want the first page then %v", len(ids), it.Err(), tt.err)
+            }
+            if got := len(client.fetched()) - 1; got != tt.fetches {
+                t.Errorf("second page fetched %d times, want %d", got, tt.fetches)
+            }
+            if it.Next() {
+                t.Error("Next() after an error = true, want false")
+            }
+        })
+    }
+}
+
+// An expired token ends iteration saying how far it got
+func TestPageIteratorTokenExpired(t *testing.T) {
+    client := &fakePagedClient{organizations: 6, pageSize: 2, expired: map[string]bool{"4": true}}
+    it := NewOrganizationsIterator(context.Background(), client, &ListOrganizationsRequest{})
+    ids := collectOrganizations(it)
+    if len(ids) != 4 || it.Err() == nil || !strings.Contains(it.Err().Error(), "after 4 items") {
+        t.Errorf("read %d, %v, want 4 then the token rejected after 4 items", len(ids), it.Err())
+    }
+    if got := client.fetched(); strings.Join(got, ",") != ",2,4" {
+        t.Errorf("fetched tokens %q, want the expired token not retried", got)
+    }
+}
+
+func TestPageIteratorCancelled(t *testing.T) {
+    t.Run("between pages", func(t *testing.T) {
+        client := &fakePagedClient{organizations: 6, pageSize: 2}
+        ctx, cancel := context.WithCancel(context.Background())
+        defer cancel()
+        it := NewOrganizationsIterator(ctx, client, &ListOrganizationsRequest{})
+        var ids []string
+        for it.Next() {
+            ids = append(ids, it.Item().OrganizationId)
+            if len(ids) == 1 {
+                cancel()
+            }
+        }
+        if len(ids) != 2 || !errors.Is(it.Err(), context.Canceled) {
+            t.Errorf("read %v, %v, want the fetched page then Canceled", ids, it.Err())
+        }
+        if got := client.fetched(); len(got) != 1 {
+            t.Errorf("fetched tokens %q after cancellation, want 1 page", got)
+        }
+    })
+
+    t.Run("while waiting to retry", func(t *testing.T) {
+        failures := make([]error, 5)
+        for i := range failures {
+            failures[i] = status.Error(codes.Unavailable, "down")
+        }
+        client := &fakePagedClient{organizations: 2, pageSize: 2, failures: map[string][]error{"": failures}}
+        ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+        defer cancel()
+        it := NewOrganizationsIterator(ctx, client, &ListOrganizationsRequest{}, WithPageRetries(5))
+
+        start := time.Now()
+        if it.Next() || !errors.Is(it.Err(), context.DeadlineExceeded) {
+            t.Errorf("Next() ended with %v, want DeadlineExceeded", it.Err())
+        }
+        if elapsed := time.Since(start); elapsed >= pageRetryBackoff {
+            t.Errorf("cancelled after %v, want before the %v backoff ends", elapsed, pageRetryBackoff)
+        }
+    })
+}
+
+// History pages are fetched again after a transient failure and end on an
+// expired token saying how far they got, like every other list
+func TestHistoryIteratorRetriesAndExpiry(t *testing.T) {
+    tests := []struct {
+        name        string
+        client      *fakePagedClient
+        wantRuns    int
+        wantErr     string
+        wantFetches string
+    }{
+        {
+            name: "failed page retried",
+            client: &fakePagedClient{organizations: 5, pageSize: 2, failures: map[string][]error{
+                "2": {status.Error(codes.Unavailable, "connection reset"), status.Error(codes.ResourceExhausted, "overloaded")},
+            }},
+            wantRuns:    5,
+            wantFetches: ",2,2,2,4",
+        },
+        {
+            name:        "token expired",
+            client:      &fakePagedClient{organizations: 5, pageSize: 2, expired: map[string]bool{"2": true}},
+            wantRuns:    2,
+            wantErr:     "after 2 items",
+            wantFetches: ",2",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            it := NewHistoryIterator(context.Background(), tt.client, &ComplianceHistoryRequest{OrganizationId: "org-1"})
+            var ids []string
+            for it.Next() {
+                ids = append(ids, it.Item().RequestId)
+            }
+            if strings.Join(ids, ",") != strings.Join(organizationIDs(0, tt.wantRuns), ",") {
+                t.Errorf("read %v, want the first %d runs once each", ids, tt.wantRuns)
+            }
+            if (it.Err() == nil) != (tt.wantErr == "") || (it.Err() != nil && !strings.Contains(it.Err().Error(), tt.wantErr)) {
+                t.Errorf("Err() = %v, want %q", it.Err(), tt.wantErr)
+            }
+            if got := strings.Join(tt.client.fetched(), ","); got != tt.wantFetches {
+                t.Errorf("fetched tokens %q, want %q", got, tt.wantFetches)
+            }
+        })
+    }
+}
@@ -0,0 +1,373 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Pin audit trail actions
+const (
+    pinAuditPinned   = "PINNED"
+    pinAuditUnpinned = "UNPINNED"
+)
+
+// Pins and their audit trail are kept forever, like attestations
+func pinnedResultsKey(organizationID string) string {
+    return "pinned-results:" + organizationID
+}
+
+func pinAuditKey(organizationID string) string {
+    return "pin-audit:" + organizationID
+}
+
+// Pins an evaluation unless the period already has a pin or the evaluation
+// has expired, and keeps the evaluation past retention. KEYS[1] is the
+// organization's pins, KEYS[2] the evaluation and KEYS[3] the audit trail;
+// ARGV is the period, the pin and the audit entry.
+var pinScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 1 then
+    return 0
+end
+if redis.call('EXISTS', KEYS[2]) == 0 then
+    return -1
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('PERSIST', KEYS[2])
+redis.call('RPUSH', KEYS[3], ARGV[3])
+return 1
+`)
+
+// Removes a pin if it still names the evaluation, handing the evaluation
+// back to retention unless ARGV[4] is negative. KEYS as for pinScript; ARGV
+// is the period, the pinned evaluation, the audit entry and the
+// evaluation's remaining retention in milliseconds.
+var unpinScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) ~= ARGV[2] then
+    return 0
+end
+redis.call('HDEL', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[4])
+if ttl > 0 then
+    redis.call('PEXPIRE', KEYS[2], ttl)
+elseif ttl == 0 then
+    redis.call('DEL', KEYS[2])
+end
+redis.call('RPUSH', KEYS[3], ARGV[3])
+return 1
+`)
+
+// Pin an evaluation for a period; AlreadyExists if the period has a pin
+func (e *EvaluationStore) Pin(ctx context.Context, pin *PinnedResult) error {
+    data, err := proto.Marshal(pin)
+    if err != nil {
+        return fmt.Errorf("failed to encode pin: %v", err)
+    }
+    entry, _ := proto.Marshal(&PinAuditEntry{
+        Action: pinAuditPinned,
+        Period: pin.Period,
+        RunId:  pin.RunId,
+        Actor:  pin.PinnedBy,
+        Reason: pin.Reason,
+        At:     pin.PinnedAt,
+    })
+    keys := []string{pinnedResultsKey(pin.OrganizationId), evaluationRecordKey(pin.OrganizationId, pin.RunId), pinAuditKey(pin.OrganizationId)}
+    result, err := pinScript.Run(ctx, e.redis, keys, pin.Period, data, entry).Int()
+    if err != nil {
+        return storeError(err, "failed to pin result")
+    }
+    switch result {
+    case 0:
+        return status.Errorf(codes.AlreadyExists, "a result is already pinned for %s; unpin it first", pin.Period)
+    case -1:
+        return status.Errorf(codes.NotFound, "no stored evaluation for run %s", pin.RunId)
+    }
+    return nil
+}
+
+// Unpin an organization's result for a period, returning the removed pin.
+// Its evaluation expires when it would have unpinned, at once if that has
+// passed, unless another period still pins it.
+func (e *EvaluationStore) Unpin(ctx context.Context, organizationID, period, actor, reason string) (*PinnedResult, error) {
+    pins, err := e.redis.HGetAll(ctx, pinnedResultsKey(organizationID)).Result()
+    if err != nil {
+        return nil, storeError(err, "failed to load pins")
+    }
+    current, ok := pins[period]
+    if !ok {
+        return nil, status.Errorf(codes.NotFound, "no result pinned for %s", period)
+    }
+    pin := &PinnedResult{}
+    if err := proto.Unmarshal([]byte(current), pin); err != nil {
+        return nil, status.Errorf(codes.DataLoss, "failed to decode pin: %v", err)
+    }
+
+    ttl, err := e.releasedTTL(ctx, organizationID, period, pin.RunId, pins)
+    if err != nil {
+        return nil, err
+    }
+
+    entry, _ := proto.Marshal(&PinAuditEntry{
+        Action: pinAuditUnpinned,
+        Period: period,
+        RunId:  pin.RunId,
+        Actor:  actor,
+        Reason: reason,
+        At:     timestamppb.Now(),
+    })
+    keys := []string{pinnedResultsKey(organizationID), evaluationRecordKey(organizationID, pin.RunId), pinAuditKey(organizationID)}
+    result, err := unpinScript.Run(ctx, e.redis, keys, period, current, entry, ttl).Int()
+    if err != nil {
+        return nil, storeError(err, "failed to unpin result")
+    }
+    if result == 0 {
+        return nil, status.Errorf(codes.Aborted, "pin for %s changed concurrently, retry", period)
+    }
+    return pin, nil
+}
+
+// Expiry in ms of an evaluation whose pin for a period is removed: what is
+// left of its retention, 0 if none is; -1 leaves it alone, as another of
+// pins still holds it
+func (e *EvaluationStore) releasedTTL(ctx context.Context, organizationID, period, runID string, pins map[string]string) (int64, error) {
+    for other, data := range pins {
+        otherPin := &PinnedResult{}
+        if other != period && proto.Unmarshal([]byte(data), otherPin) == nil && otherPin.RunId == runID {
+            return -1, nil
+        }
+    }
+    record, err := e.Load(ctx, organizationID, runID)
+    if err != nil {
+        return 0, err
+    }
+    if remaining := time.Until(time.Unix(record.Response.Timestamp, 0).Add(e.retention)); remaining > 0 {
+        return remaining.Milliseconds() + 1, nil
+    }
+    return 0, nil
+}
+
+// Moves pins between organizations. A period the target already pinned
+// keeps its pin, and the source's is returned. The source's audit trail
+// follows the target's. KEYS[1:2] are the source and target pins, KEYS[3:4]
+// their audit trails; ARGV is period and pin pairs.
+var mergePinsScript = redis.NewScript(`
+local superseded = {}
+for i = 1, #ARGV, 2 do
+    if redis.call('HSETNX', KEYS[2], ARGV[i], ARGV[i + 1]) == 0 then
+        table.insert(superseded, ARGV[i])
+    end
+end
+for _, entry in ipairs(redis.call('LRANGE', KEYS[3], 0, -1)) do
+    redis.call('RPUSH', KEYS[4], entry)
+end
+redis.call('DEL', KEYS[1], KEYS[3])
+return superseded
+`)
+
+// Move the source's pins to the target, which keeps its own pin of any
+// period both pinned. A superseded pin is recorded as unpinned in the
+// audit trail and its evaluation handed back to retention.
+func (e *EvaluationStore) mergePins(ctx context.Context, source, target string) error {
+    pins, err := e.redis.HGetAll(ctx, pinnedResultsKey(source)).Result()
+    if err != nil {
+        return err
+    }
+    args := make([]interface{}, 0, 2*len(pins))
+    moved := make(map[string]*PinnedResult, len(pins))
+    for period, data := range pins {
+        pin := &PinnedResult{}
+        if err := proto.Unmarshal([]byte(data), pin); err != nil {
+            return fmt.Errorf("failed to decode pin: %v", err)
+        }
+        pin.OrganizationId = target
+        encoded, err := proto.Marshal(pin)
+        if err != nil {
+            return fmt.Errorf("failed to encode pin: %v", err)
+        }
+        moved[period] = pin
+        args = append(args, period, encoded)
+    }
+    keys := []string{pinnedResultsKey(source), pinnedResultsKey(target), pinAuditKey(source), pinAuditKey(target)}
+    superseded, err := mergePinsScript.Run(ctx, e.redis, keys, args...).StringSlice()
+    if err != nil {
+        return err
+    }
+    if len(superseded) == 0 {
+        return nil
+    }
+
+    current, err := e.redis.HGetAll(ctx, pinnedResultsKey(target)).Result()
+    if err != nil {
+        return err
+    }
+    for _, period := range superseded {
+        pin := moved[period]
+        // The pin no longer stands for the period, but may for another
+        ttl, err := e.releasedTTL(ctx, target, "", pin.RunId, current)
+        record := evaluationRecordKey(target, pin.RunId)
+        switch {
+        case status.Code(err) == codes.NotFound:
+            // Already expired
+        case err != nil:
+            return err
+        case ttl > 0:
+            e.redis.PExpire(ctx, record, time.Duration(ttl)*time.Millisecond)
+        case ttl == 0:
+            e.redis.Del(ctx, record)
+        }
+        entry, _ := proto.Marshal(&PinAuditEntry{
+            Action: pinAuditUnpinned,
+            Period: period,
+            RunId:  pin.RunId,
+            Actor:  "merge",
+            Reason: "superseded by the pin of merged organization " + target,
+            At:     timestamppb.Now(),
+        })
+        if err := e.redis.RPush(ctx, pinAuditKey(target), entry).Err(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// The pin of an organization's period; NotFound if there is none
+func (e *EvaluationStore) Pinned(ctx context.Context, organizationID, period string) (*PinnedResult, error) {
+    data, err := e.redis.HGet(ctx, pinnedResultsKey(organizationID), period).Bytes()
+    if err == redis.Nil {
+        return nil, status.Errorf(codes.NotFound, "no result pinned for %s", period)
+    } else if err != nil {
+        return nil, storeError(err, "failed to load pin")
+    }
+    pin := &PinnedResult{}
+    if err := proto.Unmarshal(data, pin); err != nil {
+        return nil, status.Errorf(codes.DataLoss, "failed to decode pin: %v", err)
+    }
+    return pin, nil
+}
+
+// Pin audit trail of an organization, oldest first
+func (e *EvaluationStore) PinTrail(ctx context.Context, organizationID string) ([]*PinAuditEntry, error) {
+    values, err := e.redis.LRange(ctx, pinAuditKey(organizationID), 0, -1).Result()
+    if err != nil {
+        return nil, err
+    }
+    entries := make([]*PinAuditEntry, 0, len(values))
+    for _, data := range values {
+        entry := &PinAuditEntry{}
+        if err := proto.Unmarshal([]byte(data), entry); err != nil {
+            log.Printf("Ignoring undecodable pin audit entry for %s: %v", redact(fieldOrganizationID, organizationID), err)
+            continue
+        }
+        entries = append(entries, entry)
+    }
+    return entries, nil
+}
+
+// PinResult - admin RPC designating a stored evaluation the official result
+// of an organization for a reporting period, as regulator submissions for
+// the period then report. The evaluation must be a full record of the
+// organization made within the period; only complete live evaluations are
+// ever stored, so partial and degraded results cannot be pinned.
+func (s *ComplianceService) PinResult(ctx context.Context, req *PinResultRequest) (*PinnedResult, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if req.OrganizationId == "" || req.RunId == "" || req.PinnedBy == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id, run_id and pinned_by are required")
+    }
+    from, to, err := parseSubmissionPeriod(req.Period)
+    if err != nil {
+        return nil, status.Error(codes.InvalidArgument, err.Error())
+    }
+    organizationID, err := s.attestedOrganization(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+
+    record, err := s.replays.Load(ctx, organizationID, req.RunId)
+    if err != nil {
+        return nil, err
+    }
+    if err := requireFullRecord(record, "pinned"); err != nil {
+        return nil, err
+    }
+    if evaluatedAt := time.Unix(record.Response.Timestamp, 0); evaluatedAt.Before(from) || !evaluatedAt.Before(to) {
+        return nil, status.Errorf(codes.FailedPrecondition, "evaluation %s was made at %s, outside %s",
+            req.RunId, evaluatedAt.UTC().Format(time.RFC3339), req.Period)
+    }
+
+    pin := &PinnedResult{
+        OrganizationId: organizationID,
+        Period:         req.Period,
+        RunId:          req.RunId,
+        PinnedBy:       req.PinnedBy,
+        Reason:         req.Reason,
+        PinnedAt:       timestamppb.Now(),
+    }
+    if err := s.replays.Pin(ctx, pin); err != nil {
+        return nil, err
+    }
+    log.Printf("Audit: rpc=PinResult run=%s org=%s period=%s pinned_by=%s",
+        req.RunId, redact(fieldOrganizationID, organizationID), req.Period, redact(fieldPrincipal, req.PinnedBy))
+    return pin, nil
+}
+
+// UnpinResult - admin RPC removing an organization's pin for a period; its
+// evaluation falls back under retention
+func (s *ComplianceService) UnpinResult(ctx context.Context, req *UnpinResultRequest) (*PinnedResult, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if req.OrganizationId == "" || req.Period == "" || req.UnpinnedBy == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id, period and unpinned_by are required")
+    }
+    organizationID, err := s.attestedOrganization(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    pin, err := s.replays.Unpin(ctx, organizationID, req.Period, req.UnpinnedBy, req.Reason)
+    if err != nil {
+        return nil, err
+    }
+    log.Printf("Audit: rpc=UnpinResult run=%s org=%s period=%s unpinned_by=%s",
+        pin.RunId, redact(fieldOrganizationID, organizationID), req.Period, redact(fieldPrincipal, req.UnpinnedBy))
+    return pin, nil
+}
+
+// GetPinnedResult - the evaluation pinned for an organization and period,
+// and the organization's pin audit trail
+func (s *ComplianceService) GetPinnedResult(ctx context.Context, req *GetPinnedResultRequest) (*GetPinnedResultResponse, error) {
+    if req.OrganizationId == "" || req.Period == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id and period are required")
+    }
+    organizationID, err := s.attestedOrganization(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+    pin, err := s.replays.Pinned(ctx, organizationID, req.Period)
+    if err != nil {
+        return nil, err
+    }
+    record, err := s.replays.Load(ctx, organizationID, pin.RunId)
+    if err != nil {
+        return nil, err
+    }
+    trail, err := s.replays.PinTrail(ctx, organizationID)
+    if err != nil {
+        return nil, storeError(err, "pins unavailable")
+    }
+    return &GetPinnedResultResponse{Pin: pin, Evaluation: record, AuditTrail: trail}, nil
+}
@@ -0,0 +1,39 @@
+package compliance
+
+import "math"
+
+// Scores leave the service with two decimal places, rounded half-to-even.
+// A framework score is rounded as its checker returns it, before it is
+// memoized, cached, kept as a fallback or weighted, so a result read back
+// from any store is bit-identical to the one first computed. The overall
+// score is rounded once, before status and gates are judged, so a reported
+// 80.00 never sits beside a status below an 80 threshold, and the cached,
+// persisted, published and returned values are bit-identical for a run.
+const scoreDecimalPlaces = 2
+
+// Round a score according to the precision policy
+func roundScore(score float64) float64 {
+    scale := math.Pow10(scoreDecimalPlaces)
+    return math.RoundToEven(score*scale) / scale
+}
+
+// Apply the precision policy to every score in a response. This is the single
+// boundary between raw computation and anything that stores or emits results.
+func applyPrecisionPolicy(response *ComplianceResponse) {
+    response.OverallScore = roundScore(response.OverallScore)
+    for _, result := range response.FrameworkResults {
+        roundFrameworkResult(result)
+    }
+}
+
+// Apply the precision policy to a framework result and its sub-domains;
+// rounding a rounded result leaves it unchanged
+func roundFrameworkResult(result *FrameworkResult) {
+    if result == nil {
+        return
+    }
+    result.Score = roundScore(result.Score)
+    for _, subdomain := range result.GetSamaDetails().GetSubdomains() {
+        subdomain.Score = roundScore(subdomain.Score)
+    }
+}
@@ -0,0 +1,198 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "math"
+    "strconv"
+    "testing"
+    "testing/quick"
+    "time"
+
+    "google.golang.org/protobuf/encoding/protojson"
+)
+
+func newPrecisionService() *ComplianceService {
+    return &ComplianceService{engine: NewRulesEngine(DefaultRulesetVersion, nil)}
+}
+
+func precisionRuntime() *RuntimeConfig {
+    return &RuntimeConfig{
+        Weights:    map[string]float64{"SAMA": 1, "NCA": 1, "PDPL": 1},
+        Thresholds: StatusThresholds{Compliant: 80, PartiallyCompliant: 60},
+    }
+}
+
+// Status is judged on the score the response reports, not the raw average
+func TestStatusFollowsRoundedScore(t *testing.T) {
+    tests := []struct {
+        name   string
+        scores []float64
+        want   float64
+        status string
+    }{
+        {name: "rounds up onto the threshold", scores: []float64{79.99, 79.995, 80.003}, want: 80, status: "COMPLIANT"},
+        {name: "rounds down below the threshold", scores: []float64{79.99, 79.99, 79.999}, want: 79.99, status: "PARTIALLY_COMPLIANT"},
+        {name: "half to even", scores: []float64{60.005, 60.005, 60.005}, want: 60, status: "PARTIALLY_COMPLIANT"},
+    }
+    s := newPrecisionService()
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            results := []*FrameworkResult{
+                {Framework: "SAMA", Score: tt.scores[0]},
+                {Framework: "NCA", Score: tt.scores[1]},
+                {Framework: "PDPL", Score: tt.scores[2]},
+            }
+            response := s.buildResponse("org-1", results, nil, precisionRuntime())
+            if response.OverallScore != tt.want || response.Status != tt.status {
+                t.Errorf("got %v %s, want %v %s", response.OverallScore, response.Status, tt.want, tt.status)
+            }
+        })
+    }
+}
+
+// Every copy of a run's scores decodes to the same bits: cached, recorded in
+// history, published and returned over the gateway
+func TestScoresBitIdenticalAcrossOutputs(t *testing.T) {
+    s := newPrecisionService()
+    cache := NewResponseCache(NewMemoryCache(), 0)
+    ctx := context.Background()
+
+    property := func(a, b, c uint32) bool {
+        results := []*FrameworkResult{
+            {Framework: "SAMA", Score: float64(a%10000000) / 100000},
+            {Framework: "NCA", Score: float64(b%10000000) / 100000},
+            {Framework: "PDPL", Score: float64(c%10000000) / 100000},
+        }
+        response := s.buildResponse("org-1", results, nil, precisionRuntime())
+
+        if err := cache.Set(ctx, "org-1", response, time.Minute); err != nil {
+            t.Fatal(err)
+        }
+        cached, err := cache.Get(ctx, "org-1")
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        gateway, err := protojson.Marshal(response)
+        if err != nil {
+            t.Fatal(err)
+        }
+        returned := &ComplianceResponse{}
+        if err := protojson.Unmarshal(gateway, returned); err != nil {
+            t.Fatal(err)
+        }
+
+        event, err := json.Marshal(newComplianceEventV2(&ComplianceEvent{Response: response}))
+        if err != nil {
+            t.Fatal(err)
+        }
+        var published ComplianceEventV2
+        if err := json.Unmarshal(event, &published); err != nil {
+            t.Fatal(err)
+        }
+
+        want := math.Float64bits(response.OverallScore)
+        if math.Float64bits(cached.OverallScore) != want || math.Float64bits(returned.OverallScore) != want || math.Float64bits(published.OverallScore) != want {
+            return false
+        }
+        for i, result := range response.FrameworkResults {
+            // History stores the shortest decimal form of each score
+            persisted, err := strconv.ParseFloat(strconv.FormatFloat(result.Score, 'f', -1, 64), 64)
+            if err != nil || math.Float64bits(persisted) != math.Float64bits(result.Score) {
+                return false
+            }
+            if math.Float64bits(cached.FrameworkResults[i].Score) != math.Float64bits(result.Score) ||
+                math.Float64bits(returned.FrameworkResults[i].Score) != math.Float64bits(result.Score) {
+                return false
+            }
+            if result.Score != roundScore(result.Score) {
+                return false
+            }
+        }
+        return true
+    }
+    if err := quick.Check(property, &quick.Config{MaxCount: 500}); err != nil {
+        t.Error(err)
+    }
+}
+
+// Framework scores are rounded before they are stored, so a response cache
+// hit, a framework cache hit and a stale fallback all return the bits of the
+// miss that filled them
+func TestCacheHitScoresMatchMiss(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+        config.FrameworkFallbackTTL = time.Hour
+    })
+    spy := spyOnCheckers(service)
+    spy.setScore("SAMA", 71.23456)
+    spy.setScore("NCA", 64.56789)
+    spy.setScore("PDPL", 55.555)
+    ctx := context.Background()
+
+    scores := func(response *ComplianceResponse) map[string]uint64 {
+        bits := map[string]uint64{"overall": math.Float64bits(response.OverallScore)}
+        for _, result := range response.FrameworkResults {
+            bits[result.Framework] = math.Float64bits(result.Score)
+        }
+        return bits
+    }
+    miss, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := scores(miss)
+    if sama := math.Float64frombits(want["SAMA"]); sama != 71.23 {
+        t.Fatalf("SAMA scored %v, want 71.23", sama)
+    }
+
+    // What the framework and fallback caches hold is already rounded
+    req := &ComplianceRequest{OrganizationId: "org-1"}
+    inputKeys := make(map[string]string)
+    for _, framework := range service.engine.Frameworks() {
+        inputKeys[framework] = service.engine.InputKey(framework, req)
+    }
+    stored, err := service.cache.GetFrameworks(ctx, inputKeys)
+    if err != nil {
+        t.Fatal(err)
+    }
+    last, err := service.cache.GetLastResults(ctx, "org-1", service.engine.Frameworks())
+    if err != nil {
+        t.Fatal(err)
+    }
+    for name, results := range map[string]map[string]*FrameworkResult{"framework cache": stored, "fallback": last} {
+        if result := results["SAMA"]; result == nil || math.Float64bits(result.Score) != want["SAMA"] {
+            t.Errorf("%s holds SAMA %v, want the returned %v", name, result, math.Float64frombits(want["SAMA"]))
+        }
+    }
+
+    // Same organization from the response cache; another with the same
+    // evidence from the framework cache
+    for _, organizationID := range []string{"org-1", "org-2"} {
+        hit, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: organizationID})
+        if err != nil {
+            t.Fatal(err)
+        }
+        for name, bits := range scores(hit) {
+            if bits != want[name] {
+                t.Errorf("%s: %s score %v, want the miss's %v", organizationID, name, math.Float64frombits(bits), math.Float64frombits(want[name]))
+            }
+        }
+    }
+    if runs := spy.runs()["SAMA"]; runs != 1 {
+        t.Errorf("SAMA evaluated %d times, want once", runs)
+    }
+
+    // A failed check stands in with the last good result
+    service.faults.Set("SAMA", Fault{Fail: true})
+    degraded, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1", ForceRefresh: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, result := range degraded.FrameworkResults {
+        if result.Framework == "SAMA" && (result.Outcome != frameworkStale || math.Float64bits(result.Score) != want["SAMA"]) {
+            t.Errorf("fallback SAMA %s at %v, want STALE at %v", result.Outcome, result.Score, math.Float64frombits(want["SAMA"]))
+        }
+    }
+}
@@ -0,0 +1,145 @@
+package compliance
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "log"
+    "sort"
+    "strings"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Hex SHA-256 of raw evidence content
+func sha256Hex(data []byte) string {
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
+// Content hash of an evidence item: the supplied hash of its referenced
+// document, else the hash of its value
+func evidenceDigest(item *EvidenceItem) string {
+    if item.Sha256 != "" {
+        return strings.ToLower(item.Sha256)
+    }
+    return sha256Hex([]byte(item.Value))
+}
+
+// Supplied hashes must be hex SHA-256
+func validateProvenance(items []*EvidenceItem) error {
+    for _, item := range items {
+        if item.Sha256 == "" {
+            continue
+        }
+        if decoded, err := hex.DecodeString(item.Sha256); err != nil || len(decoded) != sha256.Size {
+            return fmt.Errorf("evidence %s: sha256 must be 64 hex characters", item.Key)
+        }
+    }
+    return nil
+}
+
+// Provenance index of a run's evidence, in request order
+func evidenceProvenance(items []*EvidenceItem) []*EvidenceProvenance {
+    provenance := make([]*EvidenceProvenance, 0, len(items))
+    for _, item := range items {
+        provenance = append(provenance, &EvidenceProvenance{
+            Key:          item.Key,
+            SourceSystem: item.SourceSystem,
+            Collector:    item.Collector,
+            CollectedAt:  item.CollectedAt,
+            Sha256:       evidenceDigest(item),
+            DocumentHash: item.Sha256 != "",
+        })
+    }
+    return provenance
+}
+
+// Evidence a framework declares it reads, as supplied, sorted by key
+func (e *RulesEngine) supportingEvidence(framework string, items []*EvidenceItem) []*EvidenceReference {
+    var references []*EvidenceReference
+    for _, item := range items {
+        for _, requirement := range e.requirements[framework] {
+            if item.Key == requirement.Key {
+                references = append(references, &EvidenceReference{
+                    Key:          item.Key,
+                    Sha256:       evidenceDigest(item),
+                    SourceSystem: item.SourceSystem,
+                    Collector:    item.Collector,
+                })
+                break
+            }
+        }
+    }
+    sort.SliceStable(references, func(i, j int) bool { return references[i].Key < references[j].Key })
+    return references
+}
+
+// Reference the evidence behind each evaluated result. Stale, skipped and
+// not-applicable results were not evaluated on this evidence.
+func (e *RulesEngine) attachSupportingEvidence(results []*FrameworkResult, items []*EvidenceItem) {
+    for _, result := range results {
+        if result != nil && result.Outcome == "" {
+            result.SupportedBy = e.supportingEvidence(result.Framework, items)
+        }
+    }
+}
+
+// Provenance part of the response cache key, so responses referencing
+// evidence from another source or document are not served from cache;
+// empty when no item carries provenance
+func provenanceScope(items []*EvidenceItem) string {
+    var entries []string
+    for _, item := range items {
+        if item.SourceSystem != "" || item.Collector != "" || item.Sha256 != "" {
+            entries = append(entries, item.Key+"\x00"+item.SourceSystem+"\x00"+item.Collector+"\x00"+strings.ToLower(item.Sha256))
+        }
+    }
+    sort.Strings(entries)
+    return strings.Join(entries, "\x00")
+}
+
+// VerifyEvidence - recompute the hash of a raw evidence value and compare it
+// with the one recorded when the run evaluated that evidence
+func (s *ComplianceService) VerifyEvidence(ctx context.Context, req *VerifyEvidenceRequest) (*VerifyEvidenceResponse, error) {
+    if req.RunId == "" || req.OrganizationId == "" || req.EvidenceKey == "" {
+        return nil, status.Error(codes.InvalidArgument, "run_id, organization_id and evidence_key are required")
+    }
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+    record, err := s.replays.Load(ctx, organizationID, req.RunId)
+    if err != nil {
+        return nil, err
+    }
+    if err := requireFullRecord(record, "verified"); err != nil {
+        return nil, err
+    }
+
+    var recorded *EvidenceProvenance
+    for _, entry := range record.Provenance {
+        if entry.Key == req.EvidenceKey {
+            recorded = entry
+        }
+    }
+    if recorded == nil {
+        return nil, status.Errorf(codes.NotFound, "run %s evaluated no evidence %s", req.RunId, req.EvidenceKey)
+    }
+
+    supplied := sha256Hex(req.RawValue)
+    response := &VerifyEvidenceResponse{
+        Matches:        supplied == recorded.Sha256,
+        SuppliedSha256: supplied,
+        Recorded:       recorded,
+        OrganizationId: record.Request.OrganizationId,
+        EvaluatedAt:    record.Response.GetTimestamp(),
+    }
+    log.Printf("Audit: rpc=VerifyEvidence run=%s org=%s key=%s matches=%t", req.RunId, redact(fieldOrganizationID, response.OrganizationId), req.EvidenceKey, response.Matches)
+    return response, nil
+}
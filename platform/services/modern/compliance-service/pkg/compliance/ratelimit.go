@@ -0,0 +1,154 @@
+package compliance
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "golang.org/x/time/rate"
+)
+
+// RateLimiter - decides whether a request for a key may proceed
+type RateLimiter interface {
+    Allow(ctx context.Context, key string) bool
+}
+
+// How often the local limiter drops buckets of keys gone quiet
+const localLimiterSweepInterval = time.Minute
+
+// LocalRateLimiter - per-key token buckets held in process memory. A bucket
+// idle long enough to have refilled is dropped, since a fresh one behaves
+// the same, so keys seen once do not accumulate.
+type LocalRateLimiter struct {
+    mu       sync.Mutex
+    rps      rate.Limit
+    burst    int
+    idle     time.Duration
+    swept    time.Time
+    limiters map[string]*localBucket
+}
+
+type localBucket struct {
+    limiter  *rate.Limiter
+    lastSeen time.Time
+}
+
+// Create a local limiter allowing rps requests per second with the given burst
+func NewLocalRateLimiter(rps float64, burst int) *LocalRateLimiter {
+    return &LocalRateLimiter{
+        rps:      rate.Limit(rps),
+        burst:    burst,
+        idle:     time.Duration(float64(burst)/rps*float64(time.Second)) + time.Second,
+        swept:    time.Now(),
+        limiters: make(map[string]*localBucket),
+    }
+}
+
+// Allow reports whether the key has a token available
+func (l *LocalRateLimiter) Allow(ctx context.Context, key string) bool {
+    now := time.Now()
+    l.mu.Lock()
+    if now.Sub(l.swept) >= localLimiterSweepInterval {
+        l.sweep(now)
+    }
+    bucket, ok := l.limiters[key]
+    if !ok {
+        bucket = &localBucket{limiter: rate.NewLimiter(l.rps, l.burst)}
+        l.limiters[key] = bucket
+    }
+    bucket.lastSeen = now
+    l.mu.Unlock()
+
+    return bucket.limiter.AllowN(now, 1)
+}
+
+// Drop buckets idle past their refill time; called with mu held
+func (l *LocalRateLimiter) sweep(now time.Time) {
+    for key, bucket := range l.limiters {
+        if now.Sub(bucket.lastSeen) > l.idle {
+            delete(l.limiters, key)
+        }
+    }
+    l.swept = now
+}
+
+// Token bucket refill and take, atomic within Redis. Uses the Redis clock so
+// replicas with skewed clocks share one consistent bucket.
+var tokenBucketScript = redis.NewScript(`
+local key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local requested = tonumber(ARGV[3])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+    tokens = burst
+    ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(burst, tokens + elapsed * rate / 1000)
+
+local allowed = 0
+if tokens >= requested then
+    tokens = tokens - requested
+    allowed = 1
+end
+
+redis.call('HSET', key, 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', key, math.ceil(burst / rate * 1000) + 1000)
+return allowed
+`)
+
+// RedisRateLimiter - token buckets shared by all replicas through Redis,
+// falling back to local limiting while Redis is unavailable
+type RedisRateLimiter struct {
+    redis    *redis.Client
+    rps      float64
+    burst    int
+    fallback *LocalRateLimiter
+    timeout  time.Duration
+}
+
+// Create a Redis-backed limiter with the same semantics as the local one
+func NewRedisRateLimiter(client *redis.Client, rps float64, burst int) *RedisRateLimiter {
+    return &RedisRateLimiter{
+        redis:    client,
+        rps:      rps,
+        burst:    burst,
+        fallback: NewLocalRateLimiter(rps, burst),
+        timeout:  50 * time.Millisecond,
+    }
+}
+
+// Allow takes a token from the shared bucket for the key
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string) bool {
+    ctx, cancel := context.WithTimeout(ctx, l.timeout)
+    defer cancel()
+
+    allowed, err := tokenBucketScript.Run(ctx, l.redis, []string{"ratelimit:" + key}, l.rps, l.burst, 1).Int()
+    if err != nil {
+        rateLimiterFallbacks.Inc()
+        return l.fallback.Allow(ctx, key)
+    }
+    return allowed == 1
+}
+
+// Rate limiter metrics
+var rateLimiterFallbacks = prometheus.NewCounter(
+    prometheus.CounterOpts{
+        Name: "compliance_rate_limiter_fallbacks_total",
+        Help: "Rate limit decisions made locally because Redis was unavailable",
+    },
+)
+
+func init() {
+    prometheus.MustRegister(rateLimiterFallbacks)
+}
@@ -0,0 +1,120 @@
+package compliance
+
+import (
+    "context"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis limiter on a miniredis server whose clock the test controls
+func newTestRedisLimiter(t *testing.T, rps float64, burst int) (*RedisRateLimiter, *miniredis.Miniredis) {
+    server := miniredis.RunT(t)
+    server.SetTime(time.Unix(1700000000, 0))
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    limiter := NewRedisRateLimiter(client, rps, burst)
+    limiter.timeout = time.Second
+    return limiter, server
+}
+
+func allowed(limiter RateLimiter, key string, n int) int {
+    count := 0
+    for i := 0; i < n; i++ {
+        if limiter.Allow(context.Background(), key) {
+            count++
+        }
+    }
+    return count
+}
+
+// Concurrent callers on two replicas never take more than the burst
+func TestRedisRateLimiterTakesAtomically(t *testing.T) {
+    limiter, server := newTestRedisLimiter(t, 1, 10)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    replica := NewRedisRateLimiter(client, 1, 10)
+    replica.timeout = time.Second
+
+    var granted atomic.Int64
+    var wg sync.WaitGroup
+    for i := 0; i < 40; i++ {
+        wg.Add(1)
+        go func(l RateLimiter) {
+            defer wg.Done()
+            if l.Allow(context.Background(), "tenant-a") {
+                granted.Add(1)
+            }
+        }([]RateLimiter{limiter, replica}[i%2])
+    }
+    wg.Wait()
+
+    if got := granted.Load(); got != 10 {
+        t.Errorf("%d requests allowed, want the burst of 10", got)
+    }
+    if tokens := server.HGet("ratelimit:tenant-a", "tokens"); tokens != "0" {
+        t.Errorf("bucket holds %s tokens, want 0", tokens)
+    }
+}
+
+func TestRedisRateLimiterRefills(t *testing.T) {
+    limiter, server := newTestRedisLimiter(t, 2, 4)
+    start := time.Unix(1700000000, 0)
+
+    tests := []struct {
+        name    string
+        elapsed time.Duration
+        want    int
+    }{
+        {name: "full bucket", elapsed: 0, want: 4},
+        {name: "no time passed", elapsed: 0, want: 0},
+        {name: "half a second refills one token", elapsed: 500 * time.Millisecond, want: 1},
+        {name: "refill is capped at the burst", elapsed: time.Hour, want: 4},
+    }
+    for _, tt := range tests {
+        start = start.Add(tt.elapsed)
+        server.SetTime(start)
+        if got := allowed(limiter, "tenant-a", 10); got != tt.want {
+            t.Errorf("%s: %d allowed, want %d", tt.name, got, tt.want)
+        }
+    }
+}
+
+func TestRedisRateLimiterKeysAreIndependent(t *testing.T) {
+    limiter, _ := newTestRedisLimiter(t, 1, 3)
+    if got := allowed(limiter, "tenant-a", 5); got != 3 {
+        t.Errorf("tenant-a: %d allowed, want 3", got)
+    }
+    if got := allowed(limiter, "tenant-b", 5); got != 3 {
+        t.Errorf("tenant-b: %d allowed, want 3 despite tenant-a's empty bucket", got)
+    }
+}
+
+// The shared bucket expires once it would have refilled, so idle keys do
+// not accumulate in Redis
+func TestRedisRateLimiterBucketExpires(t *testing.T) {
+    limiter, server := newTestRedisLimiter(t, 2, 4)
+    allowed(limiter, "tenant-a", 1)
+    if ttl := server.TTL("ratelimit:tenant-a"); ttl != 3*time.Second {
+        t.Errorf("bucket TTL %v, want 3s", ttl)
+    }
+}
+
+func TestRedisRateLimiterFallsBackLocally(t *testing.T) {
+    limiter, server := newTestRedisLimiter(t, 1, 3)
+    limiter.timeout = 50 * time.Millisecond
+    server.Close()
+
+    before := testutil.ToFloat64(rateLimiterFallbacks)
+    if got := allowed(limiter, "tenant-a", 5); got != 3 {
+        t.Errorf("%d allowed with Redis down, want the local burst of 3", got)
+    }
+    if got := testutil.ToFloat64(rateLimiterFallbacks) - before; got != 5 {
+        t.Errorf("%v fallbacks counted, want 5", got)
+    }
+}
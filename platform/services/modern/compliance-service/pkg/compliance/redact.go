@@ -0,0 +1,87 @@
+package compliance
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "strings"
+)
+
+// Fields that can be redacted from logs, named by their request field path
+const (
+    fieldOrganizationID = "organization_id"
+    fieldPrincipal      = "principal"
+    fieldSubjectID      = "on_behalf_of.subject_id"
+)
+
+var redactableFields = []string{fieldOrganizationID, fieldPrincipal, fieldSubjectID}
+
+// Redaction modes
+const (
+    redactHash = "hash" // Keyed hash prefix: hides the value, keeps lines correlatable
+    redactMask = "mask" // Only the last four characters stay visible
+)
+
+// Redactor - hides configured sensitive fields in log lines and audit
+// records. Only rendering is affected; evaluation, caching and events always
+// use the real values.
+type Redactor struct {
+    modes map[string]string
+    salt  []byte
+}
+
+// Process-wide redactor for log output; redacts nothing until configured
+var logRedactor = &Redactor{}
+
+// Parse a redaction spec such as "organization_id=hash,principal=mask". The
+// salt keys hashes so redacted values cannot be recovered by guessing.
+func parseRedactor(spec, salt string) (*Redactor, error) {
+    r := &Redactor{modes: make(map[string]string), salt: []byte(salt)}
+    for _, entry := range strings.Split(spec, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        field, mode, ok := strings.Cut(entry, "=")
+        if !ok {
+            mode = redactHash
+        }
+        field, mode = strings.TrimSpace(field), strings.TrimSpace(mode)
+        if !containsString(redactableFields, field) {
+            return nil, fmt.Errorf("unknown redactable field %q", field)
+        }
+        if mode != redactHash && mode != redactMask {
+            return nil, fmt.Errorf("invalid redaction mode %q for %s", mode, field)
+        }
+        r.modes[field] = mode
+    }
+    if len(r.modes) > 0 && len(r.salt) == 0 {
+        return nil, fmt.Errorf("LOG_REDACT_SALT is required when redacting fields")
+    }
+    return r, nil
+}
+
+// Redact a field value for display
+func (r *Redactor) Redact(field, value string) string {
+    if value == "" {
+        return value
+    }
+    switch r.modes[field] {
+    case redactHash:
+        mac := hmac.New(sha256.New, r.salt)
+        mac.Write([]byte(value))
+        return "h:" + hex.EncodeToString(mac.Sum(nil))[:12]
+    case redactMask:
+        if len(value) <= 4 {
+            return strings.Repeat("*", len(value))
+        }
+        return strings.Repeat("*", len(value)-4) + value[len(value)-4:]
+    }
+    return value
+}
+
+// Redact a field value for a log line
+func redact(field, value string) string {
+    return logRedactor.Redact(field, value)
+}
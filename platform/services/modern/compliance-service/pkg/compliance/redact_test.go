@@ -0,0 +1,103 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+)
+
+func TestRedact(t *testing.T) {
+    redactor, err := parseRedactor("organization_id=hash, principal=mask", "salt-1")
+    if err != nil {
+        t.Fatal(err)
+    }
+    other, err := parseRedactor("organization_id", "salt-2")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    hashed := redactor.Redact(fieldOrganizationID, "org-riyadh-001")
+    if !strings.HasPrefix(hashed, "h:") || len(hashed) != 14 || strings.Contains(hashed, "riyadh") {
+        t.Errorf("hashed organization %q, want h: and 12 hex digits", hashed)
+    }
+    if again := redactor.Redact(fieldOrganizationID, "org-riyadh-001"); again != hashed {
+        t.Errorf("same value hashed to %q and %q, want lines to stay correlatable", hashed, again)
+    }
+    if salted := other.Redact(fieldOrganizationID, "org-riyadh-001"); salted == hashed {
+        t.Error("different salts produced the same hash")
+    }
+
+    tests := []struct {
+        field string
+        value string
+        want  string
+    }{
+        {field: fieldPrincipal, value: "svc-reporting", want: "*********ting"},
+        {field: fieldPrincipal, value: "bob", want: "***"},
+        {field: fieldPrincipal, value: "", want: ""},
+        {field: fieldSubjectID, value: "user-42", want: "user-42"},
+    }
+    for _, tt := range tests {
+        if got := redactor.Redact(tt.field, tt.value); got != tt.want {
+            t.Errorf("Redact(%s, %q) = %q, want %q", tt.field, tt.value, got, tt.want)
+        }
+    }
+}
+
+func TestParseRedactorRejectsInvalidSpecs(t *testing.T) {
+    tests := []struct {
+        name string
+        spec string
+        salt string
+        err  string
+    }{
+        {name: "unknown field", spec: "evidence=hash", salt: "s", err: "unknown redactable field"},
+        {name: "unknown mode", spec: "organization_id=drop", salt: "s", err: "invalid redaction mode"},
+        {name: "no salt", spec: "organization_id=mask", err: "LOG_REDACT_SALT"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := parseRedactor(tt.spec, tt.salt); err == nil || !strings.Contains(err.Error(), tt.err) {
+                t.Errorf("parseRedactor(%q) = %v, want %q", tt.spec, err, tt.err)
+            }
+        })
+    }
+    if _, err := parseRedactor("", ""); err != nil {
+        t.Errorf("empty spec = %v, want nothing redacted", err)
+    }
+}
+
+// Log lines show the redacted organization while the response, cache and
+// history carry the real one
+func TestLogsRedactOrganization(t *testing.T) {
+    t.Cleanup(func() { logRedactor = &Redactor{} })
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.LogRedactFields = "organization_id=hash"
+        config.LogRedactSalt = "test-salt"
+    })
+    logs := captureLog(t)
+
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-riyadh-001"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.OrganizationId != "org-riyadh-001" || len(response.FrameworkResults) == 0 {
+        t.Errorf("response for %q, want the real organization evaluated", response.OrganizationId)
+    }
+
+    audit := logs.lines("Audit: rpc=CheckCompliance")
+    if len(audit) != 1 || !strings.Contains(audit[0], "org="+logRedactor.Redact(fieldOrganizationID, "org-riyadh-001")) {
+        t.Errorf("audit lines %v, want the hashed organization", audit)
+    }
+    if leaked := logs.lines("org-riyadh-001"); len(leaked) != 0 {
+        t.Errorf("organization logged in the clear: %v", leaked)
+    }
+
+    cached := false
+    for _, key := range server.Keys() {
+        cached = cached || strings.HasPrefix(key, responseKeyPrefix+"org-riyadh-001:")
+    }
+    if !cached {
+        t.Errorf("no response cached under the real organization among %v", server.Keys())
+    }
+}
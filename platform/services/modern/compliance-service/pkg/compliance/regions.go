@@ -0,0 +1,124 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strings"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RegionConfig - default frameworks of a region and any weights that differ
+// from the global ones there
+type RegionConfig struct {
+    Frameworks []string           `yaml:"frameworks" json:"frameworks"`
+    Weights    map[string]float64 `yaml:"weights" json:"weights"`
+}
+
+// Validate a region against the registered frameworks
+func (r RegionConfig) Validate(name string, frameworks []string) error {
+    if len(r.Frameworks) == 0 {
+        return fmt.Errorf("region %s has no frameworks", name)
+    }
+    for _, framework := range r.Frameworks {
+        if !containsString(frameworks, framework) {
+            return fmt.Errorf("region %s lists unknown framework %s", name, framework)
+        }
+    }
+    for framework, weight := range r.Weights {
+        if !containsString(r.Frameworks, framework) {
+            return fmt.Errorf("region %s weighs framework %s it does not list", name, framework)
+        }
+        if weight < 0 {
+            return fmt.Errorf("negative weight for framework %s in region %s", framework, name)
+        }
+    }
+    return nil
+}
+
+// Fill a request's region and locale from the deployment defaults when it
+// names none, and its frameworks from the region when it lists none;
+// framework aliases resolve to canonical IDs and unknown frameworks are
+// rejected. The request is cloned before any change.
+func (s *ComplianceService) applyRequestDefaults(req *ComplianceRequest, runtime *RuntimeConfig) (*ComplianceRequest, error) {
+    region, locale := defaultString(req.Region, s.config.DefaultRegion), defaultString(req.Locale, s.config.DefaultLocale)
+    config, ok := runtime.Regions[region]
+    if region != "" && !ok {
+        if req.Region == "" {
+            return nil, status.Errorf(codes.FailedPrecondition, "default region %s is not configured", region)
+        }
+        return nil, status.Errorf(codes.InvalidArgument, "unknown region %q", region)
+    }
+    if locale != "" && !validLocale(locale) {
+        return nil, status.Errorf(codes.InvalidArgument, "invalid locale %q, expected a BCP 47 tag such as ar-SA", locale)
+    }
+    frameworks := s.resolveFrameworks(req.Frameworks)
+    if _, err := s.engine.SelectFrameworks(frameworks); err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+    }
+    aliased := false
+    for i, framework := range frameworks {
+        aliased = aliased || framework != req.Frameworks[i]
+    }
+    useRegionFrameworks := ok && len(req.Frameworks) == 0
+    if region == req.Region && locale == req.Locale && !useRegionFrameworks && !aliased {
+        return req, nil
+    }
+
+    req = proto.Clone(req).(*ComplianceRequest)
+    req.Region, req.Locale, req.Frameworks = region, locale, frameworks
+    if useRegionFrameworks {
+        req.Frameworks = append([]string(nil), config.Frameworks...)
+    }
+    return req, nil
+}
+
+// Loose BCP 47 check: a 2-3 letter language and alphanumeric subtags
+func validLocale(locale string) bool {
+    for i, subtag := range strings.Split(locale, "-") {
+        if i == 0 && (len(subtag) < 2 || len(subtag) > 3) || len(subtag) < 1 || len(subtag) > 8 {
+            return false
+        }
+        for _, r := range subtag {
+            if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// ListRegions - configured regions with their default frameworks, in
+// evaluation order, and the weight each carries there
+func (s *ComplianceService) ListRegions(ctx context.Context, _ *emptypb.Empty) (*RegionsResponse, error) {
+    runtime := s.runtimeConfig()
+
+    names := make([]string, 0, len(runtime.Regions))
+    for name := range runtime.Regions {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    response := &RegionsResponse{ConfigVersion: runtime.Version}
+    for _, name := range names {
+        config := runtime.Regions[name]
+        region := &Region{Name: name, Weights: make(map[string]float64, len(config.Frameworks))}
+        for _, framework := range s.engine.Frameworks() {
+            if !containsString(config.Frameworks, framework) {
+                continue
+            }
+            region.Frameworks = append(region.Frameworks, framework)
+            if weight, ok := config.Weights[framework]; ok {
+                region.Weights[framework] = weight
+            } else {
+                region.Weights[framework] = runtime.Weights[framework]
+            }
+        }
+        response.Regions = append(response.Regions, region)
+    }
+    return response, nil
+}
@@ -0,0 +1,116 @@
+package compliance
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+const regionsConfig = `
+weights:
+  SAMA: 2
+  NCA: 1
+  PDPL: 1
+regions:
+  KSA:
+    frameworks: [PDPL, SAMA, NCA]
+  GCC:
+    frameworks: [NCA]
+    weights:
+      NCA: 3
+`
+
+// Service loading its runtime config from a file the test can rewrite
+func newConfigFileService(t *testing.T, config string) (*ComplianceService, string) {
+    path := filepath.Join(t.TempDir(), "config.yaml")
+    if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    service, _ := newTestService(t, func(c *ServiceConfig) {
+        c.ConfigFile = path
+        c.DefaultRegion = ""
+    })
+    return service, path
+}
+
+func TestListRegions(t *testing.T) {
+    service, path := newConfigFileService(t, regionsConfig)
+    response, err := service.ListRegions(context.Background(), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.ConfigVersion != service.runtimeConfig().Version {
+        t.Errorf("config version %q, want %q", response.ConfigVersion, service.runtimeConfig().Version)
+    }
+
+    // Sorted by name, frameworks in evaluation order, region weights over
+    // the global ones
+    var ordered []string
+    for _, framework := range service.engine.Frameworks() {
+        if framework == "SAMA" || framework == "NCA" || framework == "PDPL" {
+            ordered = append(ordered, framework)
+        }
+    }
+    want := []*Region{
+        {Name: "GCC", Frameworks: []string{"NCA"}, Weights: map[string]float64{"NCA": 3}},
+        {Name: "KSA", Frameworks: ordered, Weights: map[string]float64{"SAMA": 2, "NCA": 1, "PDPL": 1}},
+    }
+    if len(response.Regions) != len(want) {
+        t.Fatalf("%d regions, want %d", len(response.Regions), len(want))
+    }
+    for i, region := range response.Regions {
+        if region.Name != want[i].Name || !reflect.DeepEqual(region.Frameworks, want[i].Frameworks) || !reflect.DeepEqual(region.Weights, want[i].Weights) {
+            t.Errorf("region %d = %s %v %v, want %s %v %v", i, region.Name, region.Frameworks, region.Weights, want[i].Name, want[i].Frameworks, want[i].Weights)
+        }
+    }
+
+    // A reload is reflected straight away
+    reloaded := regionsConfig + "  UAE:\n    frameworks: [NCA]\n"
+    if err := os.WriteFile(path, []byte(reloaded), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := service.reloadRuntimeConfig(); err != nil {
+        t.Fatal(err)
+    }
+    response, err = service.ListRegions(context.Background(), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(response.Regions) != 3 || response.Regions[2].Name != "UAE" || response.ConfigVersion != service.runtimeConfig().Version {
+        t.Errorf("regions %v at version %q after reload, want UAE added", response.Regions, response.ConfigVersion)
+    }
+}
+
+// A check naming a region and no frameworks runs the region's frameworks
+func TestCheckComplianceUsesRegionFrameworks(t *testing.T) {
+    service, _ := newConfigFileService(t, regionsConfig)
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Region: "GCC"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(response.FrameworkResults) != 1 || response.FrameworkResults[0].Framework != "NCA" {
+        t.Errorf("results %v for GCC, want NCA only", response.FrameworkResults)
+    }
+}
+
+func TestRegionConfigValidate(t *testing.T) {
+    frameworks := []string{"SAMA", "NCA", "PDPL"}
+    tests := []struct {
+        name   string
+        region RegionConfig
+        ok     bool
+    }{
+        {name: "valid", region: RegionConfig{Frameworks: []string{"NCA"}, Weights: map[string]float64{"NCA": 2}}, ok: true},
+        {name: "no frameworks", region: RegionConfig{}},
+        {name: "unknown framework", region: RegionConfig{Frameworks: []string{"GDPR"}}},
+        {name: "weight for unlisted framework", region: RegionConfig{Frameworks: []string{"NCA"}, Weights: map[string]float64{"SAMA": 1}}},
+        {name: "negative weight", region: RegionConfig{Frameworks: []string{"NCA"}, Weights: map[string]float64{"NCA": -1}}},
+    }
+    for _, tt := range tests {
+        if err := tt.region.Validate("KSA", frameworks); (err == nil) != tt.ok {
+            t.Errorf("%s: Validate() = %v, want ok %t", tt.name, err, tt.ok)
+        }
+    }
+}
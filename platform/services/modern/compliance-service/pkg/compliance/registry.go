@@ -0,0 +1,331 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "regexp"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Redis key prefixes for the organization registry, and the channel
+// announcing alias keys whose owner changed
+const (
+    aliasKeyPrefix              = "alias:"
+    orgAliasKeyPrefix           = "org-aliases:"
+    registryInvalidationChannel = "registry-invalidations"
+)
+
+// Resolved aliases cached per replica beyond which the cache is reset
+const registryCacheMaxEntries = 100000
+
+// Organization ID normalization policies, applied to internal IDs before
+// they key caches, locks, history or events
+const (
+    orgIDNormalizeNone      = "none"      // Used exactly as sent
+    orgIDNormalizeTrim      = "trim"      // Surrounding whitespace removed
+    orgIDNormalizeLower     = "lower"     // Trimmed and lowercased
+    orgIDNormalizeCanonical = "canonical" // Lowercased, with runs of whitespace, '_' and '-' folded into one '-'
+)
+
+// Runs of separators folded by the canonical policy
+var orgIDSeparators = regexp.MustCompile(`[\s_-]+`)
+
+// Reject an unknown normalization policy
+func validOrgIDNormalization(policy string) error {
+    switch policy {
+    case "", orgIDNormalizeNone, orgIDNormalizeTrim, orgIDNormalizeLower, orgIDNormalizeCanonical:
+        return nil
+    }
+    return fmt.Errorf("unknown organization ID normalization %q", policy)
+}
+
+// Normalize an organization ID under a policy; empty means trim
+func normalizeOrgID(policy, organizationID string) string {
+    switch policy {
+    case orgIDNormalizeNone:
+        return organizationID
+    case orgIDNormalizeLower:
+        return strings.ToLower(strings.TrimSpace(organizationID))
+    case orgIDNormalizeCanonical:
+        return orgIDSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(organizationID)), "-")
+    }
+    return strings.TrimSpace(organizationID)
+}
+
+// OrganizationRegistry - maps external identifiers (CR, VAT, LEI, internal)
+// to canonical organization IDs so every entry point lands on one history.
+// Lookups are cached in process, unregistered aliases included; changes
+// are announced so every replica drops the affected entries at once, and
+// the TTL bounds staleness should an announcement be missed.
+type OrganizationRegistry struct {
+    redis         *redis.Client
+    cacheTTL      time.Duration // 0 disables the cache
+    normalization string        // Organization ID normalization policy
+
+    mu     sync.Mutex
+    cached map[string]registryEntry
+}
+
+// A cached lookup; an empty owner records an unregistered alias
+type registryEntry struct {
+    owner     string
+    expiresAt time.Time
+}
+
+// Create a Redis-backed organization registry caching lookups for cacheTTL
+// and normalizing organization IDs under a policy
+func NewOrganizationRegistry(client *redis.Client, cacheTTL time.Duration, normalization string) *OrganizationRegistry {
+    return &OrganizationRegistry{redis: client, cacheTTL: cacheTTL, normalization: normalization, cached: make(map[string]registryEntry)}
+}
+
+// NormalizeID applies the normalization policy to an organization ID
+func (r *OrganizationRegistry) NormalizeID(organizationID string) string {
+    return normalizeOrgID(r.normalization, organizationID)
+}
+
+// Internal IDs are normalized like organization IDs; external identifiers
+// are only trimmed, as registries define their own formats
+func (r *OrganizationRegistry) normalizeAlias(alias *OrganizationAlias) *OrganizationAlias {
+    if alias == nil || r.normalization == orgIDNormalizeNone {
+        return alias
+    }
+    normalized := &OrganizationAlias{Type: alias.Type, Value: strings.TrimSpace(alias.Value)}
+    if alias.Type == AliasType_INTERNAL {
+        normalized.Value = r.NormalizeID(alias.Value)
+    }
+    return normalized
+}
+
+// Register an alias; an alias owned by another organization is rejected
+// with AlreadyExists naming the owner. Re-registering is a no-op.
+func (r *OrganizationRegistry) Register(ctx context.Context, organizationID string, alias *OrganizationAlias) error {
+    alias = r.normalizeAlias(alias)
+    key, err := aliasKey(alias)
+    if err != nil {
+        return status.Errorf(codes.InvalidArgument, "%v", err)
+    }
+
+    created, err := r.redis.SetNX(ctx, key, organizationID, 0).Result()
+    if err != nil {
+        return storeError(err, "registry unavailable")
+    }
+    if created {
+        // Replicas may hold the alias as unregistered
+        r.invalidate(ctx, key)
+    } else {
+        owner, err := r.redis.Get(ctx, key).Result()
+        if err != nil {
+            return storeError(err, "registry unavailable")
+        }
+        if owner != organizationID {
+            return status.Errorf(codes.AlreadyExists, "alias %s is already registered to organization %s", aliasString(alias), owner)
+        }
+    }
+
+    return r.redis.SAdd(ctx, orgAliasKeyPrefix+organizationID, aliasString(alias)).Err()
+}
+
+// Resolve an alias to its canonical organization
+func (r *OrganizationRegistry) Resolve(ctx context.Context, alias *OrganizationAlias) (string, bool, error) {
+    key, err := aliasKey(r.normalizeAlias(alias))
+    if err != nil {
+        return "", false, status.Errorf(codes.InvalidArgument, "%v", err)
+    }
+    if owner, ok := r.lookup(key); ok {
+        registryCacheLookups.WithLabelValues("hit").Inc()
+        return owner, owner != "", nil
+    }
+    registryCacheLookups.WithLabelValues("miss").Inc()
+
+    owner, err := r.redis.Get(ctx, key).Result()
+    if err == redis.Nil {
+        r.store(key, "")
+        return "", false, nil
+    }
+    if err != nil {
+        return "", false, err
+    }
+    r.store(key, owner)
+    return owner, true, nil
+}
+
+func (r *OrganizationRegistry) lookup(key string) (string, bool) {
+    if r.cacheTTL <= 0 {
+        return "", false
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    entry, ok := r.cached[key]
+    if !ok || time.Now().After(entry.expiresAt) {
+        return "", false
+    }
+    return entry.owner, true
+}
+
+func (r *OrganizationRegistry) store(key, owner string) {
+    if r.cacheTTL <= 0 {
+        return
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if len(r.cached) >= registryCacheMaxEntries {
+        r.cached = make(map[string]registryEntry)
+    }
+    r.cached[key] = registryEntry{owner: owner, expiresAt: time.Now().Add(r.cacheTTL)}
+}
+
+func (r *OrganizationRegistry) evict(keys []string) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for _, key := range keys {
+        delete(r.cached, key)
+    }
+}
+
+// Drop alias keys from this replica's cache and announce them to the others
+func (r *OrganizationRegistry) invalidate(ctx context.Context, keys ...string) {
+    if r.cacheTTL <= 0 {
+        return
+    }
+    r.evict(keys)
+    if err := r.redis.Publish(ctx, registryInvalidationChannel, strings.Join(keys, "\n")).Err(); err != nil {
+        log.Printf("Failed to announce registry change, replicas catch up within %s: %v", r.cacheTTL, err)
+    }
+}
+
+// Run drops cached aliases as other replicas announce changes, until ctx
+// is done
+func (r *OrganizationRegistry) Run(ctx context.Context) {
+    if r.cacheTTL <= 0 {
+        return
+    }
+    subscription := r.redis.Subscribe(ctx, registryInvalidationChannel)
+    defer subscription.Close()
+    announcements := subscription.Channel()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case msg, ok := <-announcements:
+            if !ok {
+                return
+            }
+            r.evict(strings.Split(msg.Payload, "\n"))
+        }
+    }
+}
+
+// ResolveID resolves an organization ID as supplied by a caller: typed
+// identifiers ("CR:1010...", "VAT:3000...") and registered internal IDs map
+// to their canonical organization; anything else is canonical once
+// normalized.
+func (r *OrganizationRegistry) ResolveID(ctx context.Context, organizationID string) (string, error) {
+    if r.normalization != orgIDNormalizeNone {
+        organizationID = strings.TrimSpace(organizationID)
+    }
+    alias := r.normalizeAlias(parseAlias(organizationID))
+    owner, found, err := r.Resolve(ctx, alias)
+    if err != nil {
+        return "", err
+    }
+    if !found {
+        if alias.Type != AliasType_INTERNAL {
+            return "", status.Errorf(codes.NotFound, "no organization registered for %s", aliasString(alias))
+        }
+        return alias.Value, nil
+    }
+    return owner, nil
+}
+
+// Aliases of a canonical organization
+func (r *OrganizationRegistry) Aliases(ctx context.Context, organizationID string) ([]*OrganizationAlias, error) {
+    members, err := r.redis.SMembers(ctx, orgAliasKeyPrefix+organizationID).Result()
+    if err != nil {
+        return nil, err
+    }
+    aliases := make([]*OrganizationAlias, 0, len(members))
+    for _, member := range members {
+        aliases = append(aliases, parseAlias(member))
+    }
+    return aliases, nil
+}
+
+// Merge folds a duplicate organization into the target: every alias of the
+// source (and the source ID itself) now resolves to the target
+func (r *OrganizationRegistry) Merge(ctx context.Context, source, target string) error {
+    if source == target {
+        return status.Error(codes.InvalidArgument, "cannot merge an organization into itself")
+    }
+
+    members, err := r.redis.SMembers(ctx, orgAliasKeyPrefix+source).Result()
+    if err != nil {
+        return storeError(err, "registry unavailable")
+    }
+    members = append(members, aliasString(&OrganizationAlias{Type: AliasType_INTERNAL, Value: source}))
+
+    pipe := r.redis.TxPipeline()
+    keys := make([]string, 0, len(members))
+    for _, member := range members {
+        key, err := aliasKey(parseAlias(member))
+        if err != nil {
+            continue
+        }
+        keys = append(keys, key)
+        pipe.Set(ctx, key, target, 0)
+        pipe.SAdd(ctx, orgAliasKeyPrefix+target, member)
+    }
+    pipe.Del(ctx, orgAliasKeyPrefix+source)
+    if _, err := pipe.Exec(ctx); err != nil {
+        return storeError(err, "failed to merge organizations")
+    }
+    r.invalidate(ctx, keys...)
+    return nil
+}
+
+// Parse "TYPE:value"; untyped identifiers are internal IDs
+func parseAlias(identifier string) *OrganizationAlias {
+    if i := strings.Index(identifier, ":"); i > 0 {
+        if aliasType, ok := AliasType_value[strings.ToUpper(identifier[:i])]; ok {
+            return &OrganizationAlias{Type: AliasType(aliasType), Value: identifier[i+1:]}
+        }
+    }
+    return &OrganizationAlias{Type: AliasType_INTERNAL, Value: identifier}
+}
+
+func aliasString(alias *OrganizationAlias) string {
+    return alias.Type.String() + ":" + alias.Value
+}
+
+func aliasKey(alias *OrganizationAlias) (string, error) {
+    if alias == nil || alias.Value == "" {
+        return "", fmt.Errorf("alias value is required")
+    }
+    if alias.Type == AliasType_ALIAS_TYPE_UNSPECIFIED {
+        return "", fmt.Errorf("alias type is required")
+    }
+    return aliasKeyPrefix + aliasString(alias), nil
+}
+
+// Organization registry metrics
+var (
+    registryCacheLookups = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_registry_cache_lookups_total",
+            Help: "Organization alias lookups by in-process cache result: hit or miss",
+        },
+        []string{"result"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(registryCacheLookups)
+}
@@ -0,0 +1,304 @@
+package compliance
+
+import (
+    "context"
+    "strconv"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Aliases are registered by admins only, and an internal ID with history
+// of its own cannot be made an alias of another organization
+func TestRegisterAlias(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+    })
+    if _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-b"}); err != nil {
+        t.Fatal(err)
+    }
+
+    admin := adminContext("admin-secret")
+    tests := []struct {
+        name           string
+        ctx            context.Context
+        organizationID string
+        alias          *OrganizationAlias
+        code           codes.Code
+    }{
+        {name: "no credentials", ctx: context.Background(), organizationID: "org-a", alias: &OrganizationAlias{Type: AliasType_CR, Value: "1010"}, code: codes.PermissionDenied},
+        {name: "external identifier", ctx: admin, organizationID: "org-a", alias: &OrganizationAlias{Type: AliasType_CR, Value: "1010"}, code: codes.OK},
+        {name: "internal ID without history", ctx: admin, organizationID: "org-a", alias: &OrganizationAlias{Type: AliasType_INTERNAL, Value: "org-a-old"}, code: codes.OK},
+        {name: "organization with history", ctx: admin, organizationID: "org-a", alias: &OrganizationAlias{Type: AliasType_INTERNAL, Value: " org-b "}, code: codes.FailedPrecondition},
+        {name: "the organization itself", ctx: admin, organizationID: "org-b", alias: &OrganizationAlias{Type: AliasType_INTERNAL, Value: "org-b"}, code: codes.OK},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := service.RegisterAlias(tt.ctx, &RegisterAliasRequest{OrganizationId: tt.organizationID, Alias: tt.alias})
+            if status.Code(err) != tt.code {
+                t.Errorf("RegisterAlias() = %v, want %s", err, tt.code)
+            }
+        })
+    }
+    if owner, err := service.registry.ResolveID(context.Background(), "org-b"); err != nil || owner != "org-b" {
+        t.Errorf("org-b resolves to %q, %v; want itself", owner, err)
+    }
+}
+
+// Merging two organizations that both have history leaves one: the
+// source's evaluations, pins, timeline, score history and latest result
+// all move to the target
+func TestMergeOrganizationsConsolidatesHistory(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    admin := adminContext("admin-secret")
+    period := strconv.Itoa(time.Now().UTC().Year())
+
+    // The source is checked first, so the target holds the newer history
+    runs := make(map[string][]string)
+    for _, run := range []struct {
+        organizationID string
+        score          float64
+    }{
+        {"org-a", 60}, {"org-a", 50}, {"org-b", 80}, {"org-b", 90},
+    } {
+        spy.setScore("SAMA", run.score)
+        response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: run.organizationID, ForceRefresh: true})
+        if err != nil {
+            t.Fatal(err)
+        }
+        runs[run.organizationID] = append(runs[run.organizationID], response.RunId)
+    }
+    for _, organizationID := range []string{"org-a", "org-b"} {
+        pin := &PinResultRequest{OrganizationId: organizationID, Period: period, RunId: runs[organizationID][0], PinnedBy: "auditor"}
+        if _, err := service.PinResult(admin, pin); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    if _, err := service.MergeOrganizations(admin, &MergeOrganizationsRequest{SourceOrganizationId: "org-a", TargetOrganizationId: "org-b"}); err != nil {
+        t.Fatal(err)
+    }
+
+    // Every run of both is stored, and replayable, under the target
+    history, err := service.GetComplianceHistory(context.Background(), &ComplianceHistoryRequest{OrganizationId: "org-b"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(history.Runs) != 4 {
+        t.Errorf("merged history has %d runs, want 4", len(history.Runs))
+    }
+    for _, runID := range runs["org-a"] {
+        if _, err := service.ReplayCompliance(context.Background(), &ReplayRequest{RequestId: runID, OrganizationId: "org-b"}); err != nil {
+            t.Errorf("replay of source run %s under the target = %v", runID, err)
+        }
+    }
+
+    // The target keeps its own pin of the period both pinned
+    pinned, err := service.GetPinnedResult(context.Background(), &GetPinnedResultRequest{OrganizationId: "org-b", Period: period})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if pinned.Pin.RunId != runs["org-b"][0] {
+        t.Errorf("pinned run %s, want the target's %s", pinned.Pin.RunId, runs["org-b"][0])
+    }
+    var superseded bool
+    for _, entry := range pinned.AuditTrail {
+        if entry.Action == pinAuditUnpinned && entry.RunId == runs["org-a"][0] {
+            superseded = true
+        }
+    }
+    if len(pinned.AuditTrail) < 3 || !superseded {
+        t.Errorf("audit trail %v, want both pins and the source's superseded", pinned.AuditTrail)
+    }
+
+    // Both timelines, in time order; the first evaluation of each is a
+    // transition
+    timeline, err := service.GetStatusTimeline(context.Background(), &StatusTimelineRequest{OrganizationId: "org-b"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    organizations := make(map[string]bool)
+    for _, transition := range timeline.Transitions {
+        organizations[transition.OrganizationId] = true
+    }
+    if !organizations["org-a"] || !organizations["org-b"] {
+        t.Errorf("merged timeline covers %v, want both organizations' transitions", organizations)
+    }
+
+    // Score history: the target's newer scores, then the source's
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-b", IncludeTrend: true, TrendPoints: 30})
+    if err != nil {
+        t.Fatal(err)
+    }
+    var trend []float64
+    for _, result := range response.FrameworkResults {
+        if result.Framework == "SAMA" {
+            trend = result.Trend
+        }
+    }
+    want := []float64{60, 50, 80, 90}
+    if len(trend) < len(want) {
+        t.Fatalf("SAMA trend %v, want it to begin %v", trend, want)
+    }
+    for i, score := range want {
+        if trend[i] != score {
+            t.Errorf("SAMA trend %v, want it to begin %v", trend, want)
+            break
+        }
+    }
+
+    // One latest result, the target's
+    latest, err := service.ListLatestResults(context.Background(), &ListLatestResultsRequest{})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(latest.Results) != 1 || latest.Results[0].OrganizationId != "org-b" {
+        t.Errorf("latest results %v, want org-b alone", latest.Results)
+    }
+
+    // Nothing is left under the source
+    for _, key := range server.Keys() {
+        for _, prefix := range []string{"evaluations:org:org-a", "evaluation:org-a:", "pinned-results:org-a", "pin-audit:org-a", "status-timeline:org-a", "status-timeline-state:org-a", "score-history:org-a:"} {
+            if strings.HasPrefix(key, prefix) {
+                t.Errorf("key %s left behind", key)
+            }
+        }
+    }
+}
+
+// Registries of separate replicas sharing one state store, each caching
+// lookups for an hour so only an announcement can refresh them
+func newReplicaRegistries(t *testing.T, server *miniredis.Miniredis, n int) []*OrganizationRegistry {
+    t.Helper()
+    registries := make([]*OrganizationRegistry, n)
+    for i := range registries {
+        client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+        t.Cleanup(func() { client.Close() })
+        registries[i] = NewOrganizationRegistry(client, time.Hour, orgIDNormalizeTrim)
+    }
+    return registries
+}
+
+// Resolve on a replica until it reports want or a second passes
+func awaitResolved(t *testing.T, registry *OrganizationRegistry, organizationID, want string) {
+    t.Helper()
+    var got string
+    var err error
+    for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+        if got, err = registry.ResolveID(context.Background(), organizationID); err == nil && got == want {
+            return
+        }
+    }
+    t.Errorf("%s resolves to %q, %v on the other replica, want %s within a second", organizationID, got, err, want)
+}
+
+// A registration or merge on one replica clears the others' cached lookups,
+// negative entries included, through the invalidation channel; a replica
+// not listening keeps serving its cache until the TTL
+func TestRegistryInvalidationAcrossReplicas(t *testing.T) {
+    server := miniredis.RunT(t)
+    replicas := newReplicaRegistries(t, server, 3)
+    writer, listener, deaf := replicas[0], replicas[1], replicas[2]
+    ctx, cancel := context.WithCancel(context.Background())
+    t.Cleanup(cancel)
+    go listener.Run(ctx)
+    for server.PubSubNumSub(registryInvalidationChannel)[registryInvalidationChannel] == 0 {
+        time.Sleep(time.Millisecond)
+    }
+
+    // Cache the unregistered alias and the source organization everywhere
+    for _, replica := range []*OrganizationRegistry{listener, deaf} {
+        if _, err := replica.ResolveID(ctx, "CR:1010"); status.Code(err) != codes.NotFound {
+            t.Fatalf("unregistered CR:1010 = %v, want NotFound", err)
+        }
+        if owner, err := replica.ResolveID(ctx, "org-old"); err != nil || owner != "org-old" {
+            t.Fatalf("org-old resolves to %q, %v; want itself", owner, err)
+        }
+    }
+
+    if err := writer.Register(ctx, "org-old", &OrganizationAlias{Type: AliasType_CR, Value: "1010"}); err != nil {
+        t.Fatal(err)
+    }
+    awaitResolved(t, listener, "CR:1010", "org-old")
+
+    if err := writer.Merge(ctx, "org-old", "org-new"); err != nil {
+        t.Fatal(err)
+    }
+    awaitResolved(t, listener, "CR:1010", "org-new")
+    awaitResolved(t, listener, "org-old", "org-new")
+
+    if _, err := deaf.ResolveID(ctx, "CR:1010"); status.Code(err) != codes.NotFound {
+        t.Errorf("replica without a subscription resolved CR:1010 (%v), want its cached NotFound", err)
+    }
+    if owner, _ := deaf.ResolveID(ctx, "org-old"); owner != "org-old" {
+        t.Errorf("replica without a subscription resolved org-old to %q, want its cached org-old", owner)
+    }
+}
+
+// Under each normalization policy, IDs the policy folds together are
+// served from one cached response under the normalized ID, and IDs it
+// keeps apart are evaluated and cached on their own
+func TestOrgIDNormalizationSharesCacheEntry(t *testing.T) {
+    tests := []struct {
+        policy     string
+        first      string
+        normalized string
+        same       []string
+        distinct   []string
+    }{
+        {policy: orgIDNormalizeNone, first: "Org-123", normalized: "Org-123", distinct: []string{"Org-123 ", "org-123"}},
+        {policy: orgIDNormalizeTrim, first: "Org-123", normalized: "Org-123", same: []string{" Org-123", "Org-123\t"}, distinct: []string{"org-123"}},
+        {policy: orgIDNormalizeLower, first: "Org-123", normalized: "org-123", same: []string{"org-123", " ORG-123 "}, distinct: []string{"org_123"}},
+        {policy: orgIDNormalizeCanonical, first: "Org-123", normalized: "org-123", same: []string{"ORG_123", " org 123", "org -_ 123"}, distinct: []string{"org123"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.policy, func(t *testing.T) {
+            service, server := newTestService(t, func(config *ServiceConfig) {
+                config.OrgIDNormalization = tt.policy
+            })
+            check := func(organizationID string) (*ComplianceResponse, string) {
+                t.Helper()
+                info := &requestInfo{id: "req-1"}
+                response, err := service.CheckCompliance(context.WithValue(context.Background(), requestInfoKey{}, info), &ComplianceRequest{OrganizationId: organizationID})
+                if err != nil {
+                    t.Fatal(err)
+                }
+                return response, info.cacheStatus
+            }
+
+            if _, cacheStatus := check(tt.first); cacheStatus != cacheStatusMiss {
+                t.Fatalf("first request cache status %q, want %q", cacheStatus, cacheStatusMiss)
+            }
+            for _, organizationID := range tt.same {
+                response, cacheStatus := check(organizationID)
+                if cacheStatus != cacheStatusHit || response.OrganizationId != tt.normalized {
+                    t.Errorf("%q served %s for %q, want a hit for %q", organizationID, cacheStatus, response.OrganizationId, tt.normalized)
+                }
+            }
+            for _, organizationID := range tt.distinct {
+                if _, cacheStatus := check(organizationID); cacheStatus != cacheStatusMiss {
+                    t.Errorf("%q cache status %q, want its own entry", organizationID, cacheStatus)
+                }
+            }
+
+            entries := 0
+            for _, key := range server.Keys() {
+                if strings.HasPrefix(key, responseKeyPrefix) {
+                    entries++
+                }
+            }
+            if want := 1 + len(tt.distinct); entries != want {
+                t.Errorf("%d cached responses, want %d", entries, want)
+            }
+        })
+    }
+}
@@ -0,0 +1,425 @@
+package compliance
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "embed"
+    "encoding/hex"
+    "encoding/json"
+    "encoding/xml"
+    "fmt"
+    "path"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/xuri/excelize/v2"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Regulator submission layouts. A regulator format change is an update to
+// these files and their version, not a code change.
+//
+//go:embed regulator_templates/*.json
+var regulatorTemplateFiles embed.FS
+
+// Bytes per streamed submission chunk
+const submissionChunkSize = 64 << 10
+
+// Submission artifact formats
+const (
+    submissionXLSX = "xlsx"
+    submissionXML  = "xml"
+)
+
+// Control data sources a template row can read
+const (
+    sourceSubdomain = "subdomain" // Score and findings of a framework sub-domain
+    sourceEvidence  = "evidence"  // Whether a declared evidence requirement was met
+)
+
+// regulatorTemplate - layout of one regulator's submission artifact
+type regulatorTemplate struct {
+    Regulator string            `json:"regulator"`
+    Framework string            `json:"framework"`
+    Name      string            `json:"name"`
+    Version   string            `json:"version"`
+    Format    string            `json:"format"` // xlsx or xml
+    Sheet     string            `json:"sheet"`  // Worksheet name, or XML root element
+    Controls  []templateControl `json:"controls"`
+}
+
+// templateControl - a regulator control and where its result comes from
+type templateControl struct {
+    Reference string `json:"reference"`
+    Domain    string `json:"domain"`
+    Title     string `json:"title"`
+    Source    string `json:"source"` // "subdomain:NAME" or "evidence:KEY"
+}
+
+// A populated control row
+type submissionRow struct {
+    control templateControl
+    status  string
+    score   float64
+    finding string
+}
+
+// Load the embedded templates by framework and check every control source
+// against the engine, so a bad template fails startup rather than a request
+func loadRegulatorTemplates(engine *RulesEngine) (map[string]*regulatorTemplate, error) {
+    files, err := regulatorTemplateFiles.ReadDir("regulator_templates")
+    if err != nil {
+        return nil, err
+    }
+
+    templates := make(map[string]*regulatorTemplate, len(files))
+    for _, file := range files {
+        data, err := regulatorTemplateFiles.ReadFile(path.Join("regulator_templates", file.Name()))
+        if err != nil {
+            return nil, err
+        }
+        template := &regulatorTemplate{}
+        if err := json.Unmarshal(data, template); err != nil {
+            return nil, fmt.Errorf("regulator template %s: %v", file.Name(), err)
+        }
+        if err := template.validate(engine); err != nil {
+            return nil, fmt.Errorf("regulator template %s: %v", file.Name(), err)
+        }
+        if _, exists := templates[template.Framework]; exists {
+            return nil, fmt.Errorf("regulator template %s: duplicate template for %s", file.Name(), template.Framework)
+        }
+        templates[template.Framework] = template
+    }
+    return templates, nil
+}
+
+func (t *regulatorTemplate) validate(engine *RulesEngine) error {
+    if t.Version == "" {
+        return fmt.Errorf("version is required")
+    }
+    if t.Format != submissionXLSX && t.Format != submissionXML {
+        return fmt.Errorf("unsupported format %q", t.Format)
+    }
+    if _, ok := engine.checkers[t.Framework]; !ok {
+        return fmt.Errorf("unknown framework %s", t.Framework)
+    }
+    for _, control := range t.Controls {
+        kind, name, _ := strings.Cut(control.Source, ":")
+        switch kind {
+        case sourceSubdomain:
+            known := false
+            for _, subdomain := range samaSubdomains {
+                known = known || (t.Framework == "SAMA" && subdomain.name == name)
+            }
+            if !known {
+                return fmt.Errorf("control %s reads unknown %s sub-domain %s", control.Reference, t.Framework, name)
+            }
+        case sourceEvidence:
+            declared := false
+            for _, requirement := range engine.requirements[t.Framework] {
+                declared = declared || requirement.Key == name
+            }
+            if !declared {
+                return fmt.Errorf("control %s reads evidence %s that %s does not declare", control.Reference, name, t.Framework)
+            }
+        default:
+            return fmt.Errorf("control %s has invalid source %q", control.Reference, control.Source)
+        }
+    }
+    return nil
+}
+
+// Parse a reporting period: a year ("2024"), quarter ("2024-Q3") or month
+// ("2024-07"), as a half-open UTC range
+func parseSubmissionPeriod(period string) (time.Time, time.Time, error) {
+    if year, quarter, ok := strings.Cut(period, "-Q"); ok {
+        y, yerr := strconv.Atoi(year)
+        q, qerr := strconv.Atoi(quarter)
+        if yerr != nil || qerr != nil || q < 1 || q > 4 {
+            return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q", period)
+        }
+        start := time.Date(y, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, time.UTC)
+        return start, start.AddDate(0, 3, 0), nil
+    }
+    if month, err := time.Parse("2006-01", period); err == nil {
+        return month, month.AddDate(0, 1, 0), nil
+    }
+    if year, err := time.Parse("2006", period); err == nil {
+        return year, year.AddDate(1, 0, 0), nil
+    }
+    return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q, expected YYYY, YYYY-Qn or YYYY-MM", period)
+}
+
+// Populate the template's controls from a stored evaluation. Fails with
+// FailedPrecondition naming every control the evaluation has no data for.
+func (s *ComplianceService) submissionRows(template *regulatorTemplate, record *EvaluationRecord, thresholds StatusThresholds) ([]submissionRow, error) {
+    var result *FrameworkResult
+    for _, r := range record.Response.FrameworkResults {
+        if r.Framework == template.Framework {
+            result = r
+        }
+    }
+    if result == nil {
+        return nil, status.Errorf(codes.FailedPrecondition, "evaluation %s has no %s result", record.RequestId, template.Framework)
+    }
+    if result.Outcome == frameworkNotApplicable {
+        return nil, status.Errorf(codes.FailedPrecondition, "%s was attested not applicable in evaluation %s (attestation %s)",
+            template.Framework, record.RequestId, result.GetAttestation().GetAttestationId())
+    }
+    if result.Outcome == frameworkInsufficientData {
+        return nil, status.Errorf(codes.FailedPrecondition, "%s had insufficient data in evaluation %s, missing %s",
+            template.Framework, record.RequestId, strings.Join(result.MissingInputs, ", "))
+    }
+
+    // Evidence verdicts as of the evaluation, not as of today
+    evaluatedAt := time.Unix(record.Response.Timestamp, 0)
+    failed := make(map[string]string)
+    for _, verdict := range s.engine.failedControls([]string{template.Framework}, record.Request.Evidence, evaluatedAt)[template.Framework] {
+        failed[verdict.Key] = verdict.Verdict
+    }
+
+    rows := make([]submissionRow, 0, len(template.Controls))
+    var missing []string
+    for _, control := range template.Controls {
+        kind, name, _ := strings.Cut(control.Source, ":")
+        row := submissionRow{control: control}
+        switch kind {
+        case sourceSubdomain:
+            subdomain := findSubdomain(result, name)
+            if subdomain == nil {
+                missing = append(missing, control.Reference)
+                continue
+            }
+            row.score = subdomain.Score
+            row.status = s.determineStatus(subdomain.Score, thresholds)
+            if subdomain.GateMinimum > 0 && !subdomain.GatePassed && row.status == "COMPLIANT" {
+                row.status = "PARTIALLY_COMPLIANT"
+            }
+            row.finding = strings.Join(subdomain.Findings, "; ")
+        case sourceEvidence:
+            if issue, ok := failed[name]; ok {
+                row.status = "NON_COMPLIANT"
+                row.finding = name + ": " + issue
+            } else {
+                row.status = "COMPLIANT"
+                row.score = 100
+            }
+        }
+        rows = append(rows, row)
+    }
+    if len(missing) > 0 {
+        return nil, status.Errorf(codes.FailedPrecondition, "evaluation %s has no control-level data for %s controls %s",
+            record.RequestId, template.Regulator, strings.Join(missing, ", "))
+    }
+    return rows, nil
+}
+
+func findSubdomain(result *FrameworkResult, name string) *SubdomainResult {
+    details, ok := result.Details.(*FrameworkResult_SamaDetails)
+    if !ok {
+        return nil
+    }
+    for _, subdomain := range details.SamaDetails.Subdomains {
+        if subdomain.Name == name {
+            return subdomain
+        }
+    }
+    return nil
+}
+
+// Render the artifact in the template's format
+func renderSubmission(template *regulatorTemplate, record *EvaluationRecord, period string, rows []submissionRow) ([]byte, string, error) {
+    if template.Format == submissionXLSX {
+        data, err := renderSubmissionXLSX(template, record, period, rows)
+        return data, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", err
+    }
+    data, err := renderSubmissionXML(template, record, period, rows)
+    return data, "application/xml", err
+}
+
+// Workbook with the assessment sheet and a sheet identifying the submission
+func renderSubmissionXLSX(template *regulatorTemplate, record *EvaluationRecord, period string, rows []submissionRow) ([]byte, error) {
+    f := excelize.NewFile()
+    defer f.Close()
+
+    if err := f.SetSheetName("Sheet1", template.Sheet); err != nil {
+        return nil, err
+    }
+    header := []interface{}{"Domain", "Control Reference", "Control", "Compliance Status", "Score", "Findings"}
+    if err := f.SetSheetRow(template.Sheet, "A1", &header); err != nil {
+        return nil, err
+    }
+    for i, row := range rows {
+        cell, _ := excelize.CoordinatesToCellName(1, i+2)
+        values := []interface{}{row.control.Domain, row.control.Reference, row.control.Title, row.status, row.score, row.finding}
+        if err := f.SetSheetRow(template.Sheet, cell, &values); err != nil {
+            return nil, err
+        }
+    }
+
+    if _, err := f.NewSheet("Submission"); err != nil {
+        return nil, err
+    }
+    info := [][]interface{}{
+        {"Organization", record.Response.OrganizationId},
+        {"Period", period},
+        {"Overall Score", record.Response.OverallScore},
+        {"Status", record.Response.Status},
+        {"Evaluated At", time.Unix(record.Response.Timestamp, 0).UTC().Format(time.RFC3339)},
+        {"Evaluation", record.RequestId},
+        {"Template", template.Name},
+        {"Template Version", template.Version},
+    }
+    for i, values := range info {
+        cell, _ := excelize.CoordinatesToCellName(1, i+1)
+        if err := f.SetSheetRow("Submission", cell, &values); err != nil {
+            return nil, err
+        }
+    }
+
+    buf, err := f.WriteToBuffer()
+    if err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+// XML assessment document; the root element is the template's sheet name
+type xmlSubmission struct {
+    XMLName         xml.Name
+    TemplateVersion string       `xml:"templateVersion,attr"`
+    Organization    string       `xml:"organization,attr"`
+    Period          string       `xml:"period,attr"`
+    EvaluatedAt     string       `xml:"evaluatedAt,attr"`
+    Evaluation      string       `xml:"evaluation,attr"`
+    OverallScore    float64      `xml:"Summary>Score"`
+    Status          string       `xml:"Summary>Status"`
+    Controls        []xmlControl `xml:"Controls>Control"`
+}
+
+type xmlControl struct {
+    Reference string  `xml:"id,attr"`
+    Domain    string  `xml:"Domain"`
+    Title     string  `xml:"Title"`
+    Status    string  `xml:"Status"`
+    Score     float64 `xml:"Score"`
+    Finding   string  `xml:"Finding,omitempty"`
+}
+
+func renderSubmissionXML(template *regulatorTemplate, record *EvaluationRecord, period string, rows []submissionRow) ([]byte, error) {
+    doc := xmlSubmission{
+        XMLName:         xml.Name{Local: template.Sheet},
+        TemplateVersion: template.Version,
+        Organization:    record.Response.OrganizationId,
+        Period:          period,
+        EvaluatedAt:     time.Unix(record.Response.Timestamp, 0).UTC().Format(time.RFC3339),
+        Evaluation:      record.RequestId,
+        OverallScore:    record.Response.OverallScore,
+        Status:          record.Response.Status,
+    }
+    for _, row := range rows {
+        doc.Controls = append(doc.Controls, xmlControl{
+            Reference: row.control.Reference,
+            Domain:    row.control.Domain,
+            Title:     row.control.Title,
+            Status:    row.status,
+            Score:     row.score,
+            Finding:   row.finding,
+        })
+    }
+
+    var buf bytes.Buffer
+    buf.WriteString(xml.Header)
+    encoder := xml.NewEncoder(&buf)
+    encoder.Indent("", "  ")
+    if err := encoder.Encode(doc); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+// GenerateRegulatorSubmission - streams the regulator's submission artifact
+// for an organization and period, populated from the evaluation pinned for
+// the period, or else the latest evaluation stored within it. The first
+// message carries the file name, template version and SHA-256 of the
+// content; the content follows in chunks.
+func (s *ComplianceService) GenerateRegulatorSubmission(req *RegulatorSubmissionRequest, stream Compliance_GenerateRegulatorSubmissionServer) error {
+    ctx := stream.Context()
+    if req.OrganizationId == "" {
+        return status.Error(codes.InvalidArgument, "organization_id is required")
+    }
+    template, ok := s.submissions[s.resolveFramework(req.Framework)]
+    if !ok {
+        return status.Errorf(codes.InvalidArgument, "no regulator submission format for framework %q", req.Framework)
+    }
+    from, to, err := parseSubmissionPeriod(req.Period)
+    if err != nil {
+        return status.Error(codes.InvalidArgument, err.Error())
+    }
+
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if err != nil {
+        return err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return err
+    }
+
+    // The result pinned for the period is the authoritative figure; the
+    // latest evaluation stands in when there is none
+    var record *EvaluationRecord
+    pin, err := s.replays.Pinned(ctx, organizationID, req.Period)
+    switch {
+    case err == nil:
+        record, err = s.replays.Load(ctx, organizationID, pin.RunId)
+    case status.Code(err) == codes.NotFound:
+        record, err = s.replays.Latest(ctx, organizationID, from, to)
+        if status.Code(err) == codes.NotFound {
+            return status.Errorf(codes.FailedPrecondition, "no evaluation of %s in period %s to submit", organizationID, req.Period)
+        }
+    }
+    if err != nil {
+        return err
+    }
+    if err := requireFullRecord(record, "submitted"); err != nil {
+        return err
+    }
+
+    rows, err := s.submissionRows(template, record, s.tenantRuntimeConfig(ctx).Thresholds)
+    if err != nil {
+        return err
+    }
+    content, contentType, err := renderSubmission(template, record, req.Period, rows)
+    if err != nil {
+        return status.Errorf(codes.Internal, "failed to render %s submission: %v", template.Regulator, err)
+    }
+    sum := sha256.Sum256(content)
+
+    header := &SubmissionHeader{
+        FileName:        fmt.Sprintf("%s_%s_%s.%s", template.Regulator, organizationID, req.Period, template.Format),
+        ContentType:     contentType,
+        TemplateName:    template.Name,
+        TemplateVersion: template.Version,
+        Sha256:          hex.EncodeToString(sum[:]),
+        SizeBytes:       int64(len(content)),
+        EvaluationId:    record.RequestId,
+        EvaluatedAt:     timestamppb.New(time.Unix(record.Response.Timestamp, 0)),
+        Pinned:          pin != nil,
+    }
+    if err := stream.Send(&SubmissionChunk{Payload: &SubmissionChunk_Header{Header: header}}); err != nil {
+        return err
+    }
+    for offset := 0; offset < len(content); offset += submissionChunkSize {
+        end := offset + submissionChunkSize
+        if end > len(content) {
+            end = len(content)
+        }
+        if err := stream.Send(&SubmissionChunk{Payload: &SubmissionChunk_Data{Data: content[offset:end]}}); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,237 @@
+package compliance
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "fmt"
+    "strconv"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// EvaluationStore - evaluated requests and their responses by organization
+// and run ID, kept so auditors can replay them under later rulesets
+type EvaluationStore struct {
+    redis     *redis.Client
+    retention time.Duration
+    format    string
+}
+
+// Create an evaluation store; records expire after retention and are
+// persisted in format, FULL or SUMMARY
+func NewEvaluationStore(client *redis.Client, retention time.Duration, format string) *EvaluationStore {
+    return &EvaluationStore{redis: client, retention: retention, format: defaultString(format, evaluationFormatFull)}
+}
+
+// Store an evaluation under its organization and run ID, in the store's
+// format, with the principal that requested it
+func (e *EvaluationStore) Store(ctx context.Context, runID, rulesetVersion, principal string, req *ComplianceRequest, response *ComplianceResponse) error {
+    record := &EvaluationRecord{
+        RequestId:      runID,
+        Request:        req,
+        Response:       response,
+        RulesetVersion: rulesetVersion,
+        Provenance:     evidenceProvenance(req.Evidence),
+        Format:         evaluationFormatFull,
+        Principal:      principal,
+    }
+    if e.format == evaluationFormatSummary {
+        record = summarizeEvaluation(record)
+    }
+    data, err := proto.Marshal(record)
+    if err != nil {
+        return fmt.Errorf("failed to encode evaluation: %v", err)
+    }
+
+    // Index by organization and evaluation time for period lookups
+    index := evaluationIndexKey(req.OrganizationId)
+    pipe := e.redis.Pipeline()
+    pipe.Set(ctx, evaluationRecordKey(req.OrganizationId, runID), data, e.retention)
+    pipe.ZAdd(ctx, index, redis.Z{Score: float64(response.Timestamp), Member: runID})
+    pipe.ZRemRangeByScore(ctx, index, "-inf", strconv.FormatInt(time.Now().Add(-e.retention).Unix(), 10))
+    pipe.Expire(ctx, index, e.retention)
+    _, err = pipe.Exec(ctx)
+    return err
+}
+
+// Latest stored evaluation of an organization made within [from, to);
+// NotFound if there is none
+func (e *EvaluationStore) Latest(ctx context.Context, organizationID string, from, to time.Time) (*EvaluationRecord, error) {
+    ids, err := e.redis.ZRevRangeByScore(ctx, evaluationIndexKey(organizationID), &redis.ZRangeBy{
+        Min:   strconv.FormatInt(from.Unix(), 10),
+        Max:   "(" + strconv.FormatInt(to.Unix(), 10),
+        Count: 1,
+    }).Result()
+    if err != nil {
+        return nil, storeError(err, "failed to look up evaluations")
+    }
+    if len(ids) == 0 {
+        return nil, status.Errorf(codes.NotFound, "no stored evaluation for %s between %s and %s",
+            organizationID, from.Format(time.RFC3339), to.Format(time.RFC3339))
+    }
+    return e.Load(ctx, organizationID, ids[0])
+}
+
+// Load a stored evaluation of an organization; NotFound if unknown, expired
+// or of another organization
+func (e *EvaluationStore) Load(ctx context.Context, organizationID, runID string) (*EvaluationRecord, error) {
+    data, err := e.redis.Get(ctx, evaluationRecordKey(organizationID, runID)).Bytes()
+    if err == redis.Nil {
+        return nil, status.Errorf(codes.NotFound, "no stored evaluation for run %s", runID)
+    } else if err != nil {
+        return nil, storeError(err, "failed to load evaluation")
+    }
+    record := &EvaluationRecord{}
+    if err := proto.Unmarshal(data, record); err != nil {
+        return nil, status.Errorf(codes.DataLoss, "failed to decode evaluation: %v", err)
+    }
+    return record, nil
+}
+
+// Moves evaluations between organizations, keeping each record's expiry.
+// KEYS[1:2] are the source and target indexes, then a source and target
+// record key per run; ARGV is the index retention in ms, then each run's
+// ID and index score.
+var mergeEvaluationsScript = redis.NewScript(`
+local moved = 0
+for i = 3, #KEYS, 2 do
+    if redis.call('EXISTS', KEYS[i]) == 1 then
+        redis.call('RENAME', KEYS[i], KEYS[i + 1])
+        redis.call('ZADD', KEYS[2], ARGV[i], ARGV[i - 1])
+        moved = moved + 1
+    end
+end
+redis.call('DEL', KEYS[1])
+if moved > 0 then
+    redis.call('PEXPIRE', KEYS[2], ARGV[1])
+end
+return moved
+`)
+
+// Merge moves the source's stored evaluations and pins under the target.
+// Records are moved as evaluated, so replays and attestations of them
+// still match what was stored.
+func (e *EvaluationStore) Merge(ctx context.Context, source, target string) error {
+    runs, err := e.redis.ZRangeWithScores(ctx, evaluationIndexKey(source), 0, -1).Result()
+    if err != nil {
+        return err
+    }
+    keys := []string{evaluationIndexKey(source), evaluationIndexKey(target)}
+    args := []interface{}{e.retention.Milliseconds()}
+    for _, run := range runs {
+        runID := run.Member.(string)
+        keys = append(keys, evaluationRecordKey(source, runID), evaluationRecordKey(target, runID))
+        args = append(args, runID, run.Score)
+    }
+    if err := mergeEvaluationsScript.Run(ctx, e.redis, keys, args...).Err(); err != nil {
+        return err
+    }
+    return e.mergePins(ctx, source, target)
+}
+
+// Run IDs are generated server-side, so a caller cannot name, and so
+// overwrite, another run's record
+func evaluationRecordKey(organizationID, runID string) string {
+    return "evaluation:" + organizationID + ":" + runID
+}
+
+// A run ID: 128 random bits, hex encoded
+func newRunID() string {
+    b := make([]byte, 16)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
+
+func evaluationIndexKey(organizationID string) string {
+    return "evaluations:org:" + organizationID
+}
+
+// ReplayCompliance - re-evaluates a stored request under the current ruleset
+// and runtime config. Nothing is cached, published or written to history;
+// the original evaluation stays as recorded.
+func (s *ComplianceService) ReplayCompliance(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+    if req.RequestId == "" || req.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "request_id and organization_id are required")
+    }
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+    record, err := s.replays.Load(ctx, organizationID, req.RequestId)
+    if err != nil {
+        return nil, err
+    }
+    if err := requireFullRecord(record, "replayed"); err != nil {
+        return nil, err
+    }
+
+    tenant := tenantFromContext(ctx)
+    if err := s.usage.CheckQuota(tenant); err != nil {
+        return nil, err
+    }
+    s.usage.Record(tenant, usageEvaluations, 1)
+
+    runtime := s.runtimeConfig().ForTenant(tenant)
+    // Attestations stay as they were at the time of the original evaluation
+    results, err := s.engine.EvaluateAll(ctx, record.Request, notApplicableResults(responseAttestations(record.Response)))
+    if err == errLoadShed {
+        retryAfter := s.engine.scheduler.RetryAfter()
+        setRetryAfter(ctx, retryAfter)
+        return nil, reasonError(codes.ResourceExhausted, reasonOverloaded, fmt.Sprintf("service overloaded, retry after %s", retryAfter))
+    }
+    if err == errComputeTimeout {
+        return nil, status.Errorf(codes.DeadlineExceeded, "replay computation exceeded %s", s.engine.computeTimeout)
+    }
+    if err != nil {
+        // Cancelled by the caller; the results are partial
+        return nil, status.FromContextError(err).Err()
+    }
+    replayed := s.buildResponse(record.Request.OrganizationId, results, record.Request.Evidence, runtime)
+
+    response := &ReplayResponse{
+        RequestId:              req.RequestId,
+        Original:               shapeResponse(record.Response, runtime.ResultSchema),
+        Replayed:               shapeResponse(replayed, runtime.ResultSchema),
+        OriginalRulesetVersion: record.RulesetVersion,
+        RulesetVersion:         s.engine.rulesetVersion,
+        OverallScoreDelta:      roundScore(replayed.OverallScore - record.Response.OverallScore),
+        StatusChanged:          replayed.Status != record.Response.Status,
+        Frameworks:             frameworkDeltas(record.Response.FrameworkResults, replayed.FrameworkResults),
+    }
+    return response, nil
+}
+
+// Per-framework deltas in original order, followed by frameworks that only
+// the replay produced
+func frameworkDeltas(original, replayed []*FrameworkResult) []*FrameworkDelta {
+    byFramework := make(map[string]*FrameworkResult, len(replayed))
+    for _, result := range replayed {
+        byFramework[result.Framework] = result
+    }
+
+    deltas := make([]*FrameworkDelta, 0, len(original))
+    seen := make(map[string]bool, len(original))
+    for _, before := range original {
+        seen[before.Framework] = true
+        delta := &FrameworkDelta{Framework: before.Framework, OriginalScore: before.Score}
+        if after, ok := byFramework[before.Framework]; ok {
+            delta.ReplayedScore = after.Score
+        }
+        delta.ScoreDelta = roundScore(delta.ReplayedScore - delta.OriginalScore)
+        deltas = append(deltas, delta)
+    }
+    for _, after := range replayed {
+        if !seen[after.Framework] {
+            deltas = append(deltas, &FrameworkDelta{Framework: after.Framework, ReplayedScore: after.Score, ScoreDelta: after.Score})
+        }
+    }
+    return deltas
+}
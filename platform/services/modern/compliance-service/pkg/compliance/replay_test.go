@@ -0,0 +1,176 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+)
+
+// Deltas follow the original's framework order, then frameworks only the
+// replay produced; a framework the replay dropped scores 0
+func TestFrameworkDeltas(t *testing.T) {
+    tests := []struct {
+        name     string
+        original []*FrameworkResult
+        replayed []*FrameworkResult
+        want     []*FrameworkDelta
+    }{
+        {
+            name:     "changed score",
+            original: []*FrameworkResult{{Framework: "NCA", Score: 70}, {Framework: "SAMA", Score: 60}},
+            replayed: []*FrameworkResult{{Framework: "SAMA", Score: 80.5}, {Framework: "NCA", Score: 70}},
+            want: []*FrameworkDelta{
+                {Framework: "NCA", OriginalScore: 70, ReplayedScore: 70},
+                {Framework: "SAMA", OriginalScore: 60, ReplayedScore: 80.5, ScoreDelta: 20.5},
+            },
+        },
+        {
+            name:     "framework only in the replay",
+            original: []*FrameworkResult{{Framework: "NCA", Score: 70}},
+            replayed: []*FrameworkResult{{Framework: "NCA", Score: 70}, {Framework: "PDPL", Score: 45}},
+            want: []*FrameworkDelta{
+                {Framework: "NCA", OriginalScore: 70, ReplayedScore: 70},
+                {Framework: "PDPL", ReplayedScore: 45, ScoreDelta: 45},
+            },
+        },
+        {
+            name:     "framework only in the original",
+            original: []*FrameworkResult{{Framework: "NCA", Score: 70}, {Framework: "PDPL", Score: 45}},
+            replayed: []*FrameworkResult{{Framework: "NCA", Score: 72}},
+            want: []*FrameworkDelta{
+                {Framework: "NCA", OriginalScore: 70, ReplayedScore: 72, ScoreDelta: 2},
+                {Framework: "PDPL", OriginalScore: 45, ScoreDelta: -45},
+            },
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := frameworkDeltas(tt.original, tt.replayed)
+            if len(got) != len(tt.want) {
+                t.Fatalf("frameworkDeltas() = %v, want %v", got, tt.want)
+            }
+            for i, want := range tt.want {
+                if got[i].Framework != want.Framework || got[i].OriginalScore != want.OriginalScore ||
+                    got[i].ReplayedScore != want.ReplayedScore || got[i].ScoreDelta != want.ScoreDelta {
+                    t.Errorf("delta %d = %v, want %v", i, got[i], want)
+                }
+            }
+        })
+    }
+}
+
+// A replay under a changed ruleset recomputes the stored request and
+// reports the delta, leaving the stored evaluation as it was
+func TestReplayUnderChangedRuleset(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    ctx := context.Background()
+
+    spy.setScore("SAMA", 60)
+    original, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if original.RunId == "" {
+        t.Fatal("response carries no run_id")
+    }
+
+    // New rules: SAMA scores higher and a framework is added
+    spy.setScore("SAMA", 80)
+    service.engine.rulesetVersion = "2099.1"
+    service.engine.Register("PDPL2", func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        return &FrameworkResult{Framework: "PDPL2", Score: 40}
+    })
+
+    replay, err := service.ReplayCompliance(ctx, &ReplayRequest{RequestId: original.RunId, OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if replay.OriginalRulesetVersion != DefaultRulesetVersion || replay.RulesetVersion != "2099.1" {
+        t.Errorf("ruleset versions %q -> %q, want %q -> 2099.1", replay.OriginalRulesetVersion, replay.RulesetVersion, DefaultRulesetVersion)
+    }
+    if replay.Original.OverallScore != original.OverallScore {
+        t.Errorf("original overall %v, want the stored %v", replay.Original.OverallScore, original.OverallScore)
+    }
+    if want := roundScore(replay.Replayed.OverallScore - original.OverallScore); replay.OverallScoreDelta != want {
+        t.Errorf("overall delta %v, want %v", replay.OverallScoreDelta, want)
+    }
+    deltas := make(map[string]*FrameworkDelta)
+    for _, delta := range replay.Frameworks {
+        deltas[delta.Framework] = delta
+    }
+    if sama := deltas["SAMA"]; sama == nil || sama.OriginalScore != 60 || sama.ReplayedScore != 80 || sama.ScoreDelta != 20 {
+        t.Errorf("SAMA delta %v, want 60 -> 80", sama)
+    }
+    if added := deltas["PDPL2"]; added == nil || added.OriginalScore != 0 || added.ReplayedScore != 40 || added.ScoreDelta != 40 {
+        t.Errorf("PDPL2 delta %v, want only replayed at 40", added)
+    }
+    if last := replay.Frameworks[len(replay.Frameworks)-1]; last.Framework != "PDPL2" {
+        t.Errorf("last delta %s, want the replay-only framework after the original's", last.Framework)
+    }
+
+    // History still holds the original evaluation alone
+    history, err := service.GetComplianceHistory(ctx, &ComplianceHistoryRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(history.Runs) != 1 || history.Runs[0].RequestId != original.RunId || history.Runs[0].FrameworkScores["SAMA"] != 60 {
+        t.Errorf("history %v, want only the original run", history.Runs)
+    }
+}
+
+// Runs are keyed by a server-assigned ID within their organization: a
+// reused request ID does not overwrite an earlier run, and a run cannot be
+// read through another organization
+func TestReplayRunsScopedToOrganization(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "reused"))
+
+    spy.setScore("SAMA", 60)
+    first, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    spy.setScore("SAMA", 30)
+    second, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-2"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if first.RunId == second.RunId || first.RunId == "reused" {
+        t.Fatalf("run IDs %q and %q, want distinct server-assigned IDs", first.RunId, second.RunId)
+    }
+
+    replay, err := service.ReplayCompliance(ctx, &ReplayRequest{RequestId: first.RunId, OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if replay.Original.OrganizationId != "org-1" || replay.Original.OverallScore != first.OverallScore {
+        t.Errorf("replayed original %s at %v, want org-1's run at %v", replay.Original.OrganizationId, replay.Original.OverallScore, first.OverallScore)
+    }
+
+    tests := []struct {
+        name string
+        req  *ReplayRequest
+        code codes.Code
+    }{
+        {name: "run of another organization", req: &ReplayRequest{RequestId: first.RunId, OrganizationId: "org-2"}, code: codes.NotFound},
+        {name: "caller's request ID", req: &ReplayRequest{RequestId: "reused", OrganizationId: "org-1"}, code: codes.NotFound},
+        {name: "no organization", req: &ReplayRequest{RequestId: first.RunId}, code: codes.InvalidArgument},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := service.ReplayCompliance(ctx, tt.req)
+            if status.Code(err) != tt.code {
+                t.Errorf("ReplayCompliance() = %v, want %s", err, tt.code)
+            }
+        })
+    }
+}
@@ -0,0 +1,141 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis hash holding each organization's latest result
+const rollupLatestKey = "rollup:latest"
+
+// Latest result of one organization, as stored for rollups
+type rollupEntry struct {
+    Status     string             `json:"status"`
+    Frameworks map[string]float64 `json:"frameworks"`
+    At         time.Time          `json:"at"`
+}
+
+// RollupAggregator - keeps each organization's latest result in Redis and
+// periodically exports cross-organization rollups as low-cardinality gauges,
+// so dashboards need not aggregate per-organization series. Every replica
+// exports the same cluster-wide values; aggregate them with max, not sum.
+type RollupAggregator struct {
+    redis    *redis.Client
+    interval time.Duration
+    window   time.Duration
+}
+
+// Create an aggregator; results older than window drop out of the rollups
+func NewRollupAggregator(client *redis.Client, interval, window time.Duration) *RollupAggregator {
+    return &RollupAggregator{redis: client, interval: interval, window: window}
+}
+
+// Record a response as its organization's latest result
+func (a *RollupAggregator) Record(ctx context.Context, response *ComplianceResponse) {
+    entry := rollupEntry{
+        Status:     response.Status,
+        Frameworks: make(map[string]float64, len(response.FrameworkResults)),
+        At:         time.Unix(response.Timestamp, 0),
+    }
+    for _, result := range response.FrameworkResults {
+        if result.Outcome != frameworkNotApplicable && result.Outcome != frameworkInsufficientData {
+            entry.Frameworks[result.Framework] = result.Score
+        }
+    }
+    data, _ := json.Marshal(entry)
+    if err := a.redis.HSet(ctx, rollupLatestKey, response.OrganizationId, data).Err(); err != nil {
+        log.Printf("Failed to record rollup entry for %s: %v", redact(fieldOrganizationID, response.OrganizationId), err)
+    }
+}
+
+// Run refreshes the rollup gauges every interval until ctx is done
+func (a *RollupAggregator) Run(ctx context.Context) {
+    if a.interval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(a.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := a.refresh(ctx); err != nil {
+                log.Printf("Rollup refresh failed: %v", err)
+            }
+        }
+    }
+}
+
+func (a *RollupAggregator) refresh(ctx context.Context) error {
+    cutoff := time.Now().Add(-a.window)
+    sums := make(map[string]float64)
+    counts := make(map[string]int)
+    statuses := make(map[string]int)
+    var expired []string
+
+    iter := a.redis.HScan(ctx, rollupLatestKey, 0, "", 500).Iterator()
+    for iter.Next(ctx) {
+        organizationID := iter.Val()
+        if !iter.Next(ctx) {
+            break
+        }
+        var entry rollupEntry
+        if err := json.Unmarshal([]byte(iter.Val()), &entry); err != nil || entry.At.Before(cutoff) {
+            expired = append(expired, organizationID)
+            continue
+        }
+        statuses[entry.Status]++
+        for framework, score := range entry.Frameworks {
+            sums[framework] += score
+            counts[framework]++
+        }
+    }
+    if err := iter.Err(); err != nil {
+        return err
+    }
+
+    rollupFrameworkScore.Reset()
+    for framework, sum := range sums {
+        rollupFrameworkScore.WithLabelValues(framework).Set(sum / float64(counts[framework]))
+    }
+    rollupOrganizations.Reset()
+    for status, count := range statuses {
+        rollupOrganizations.WithLabelValues(status).Set(float64(count))
+    }
+
+    if len(expired) > 0 {
+        return a.redis.HDel(ctx, rollupLatestKey, expired...).Err()
+    }
+    return nil
+}
+
+// Rollup metrics
+var (
+    rollupFrameworkScore = prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "compliance_rollup_framework_score_avg",
+            Help: "Average latest score per framework across organizations checked within the rollup window",
+        },
+        []string{"framework"},
+    )
+
+    rollupOrganizations = prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "compliance_rollup_organizations",
+            Help: "Organizations checked within the rollup window by latest status",
+        },
+        []string{"status"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(rollupFrameworkScore)
+    prometheus.MustRegister(rollupOrganizations)
+}
@@ -0,0 +1,61 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/redis/go-redis/v9"
+)
+
+func rollupResponse(org, status string, at time.Time, results ...*FrameworkResult) *ComplianceResponse {
+    return &ComplianceResponse{OrganizationId: org, Status: status, Timestamp: at.Unix(), FrameworkResults: results}
+}
+
+func TestRollupGaugesReflectSeededResults(t *testing.T) {
+    server := miniredis.RunT(t)
+    client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+    t.Cleanup(func() { client.Close() })
+    aggregator := NewRollupAggregator(client, time.Minute, time.Hour)
+    ctx := context.Background()
+    now := time.Now()
+
+    aggregator.Record(ctx, rollupResponse("org-1", "COMPLIANT", now,
+        &FrameworkResult{Framework: "NCA", Score: 90}, &FrameworkResult{Framework: "SAMA", Score: 80}))
+    aggregator.Record(ctx, rollupResponse("org-2", "NON_COMPLIANT", now,
+        &FrameworkResult{Framework: "NCA", Score: 50}, &FrameworkResult{Framework: "SAMA", Outcome: frameworkNotApplicable}))
+    // Only org-3's latest result counts
+    aggregator.Record(ctx, rollupResponse("org-3", "NON_COMPLIANT", now, &FrameworkResult{Framework: "NCA", Score: 10}))
+    aggregator.Record(ctx, rollupResponse("org-3", "COMPLIANT", now, &FrameworkResult{Framework: "NCA", Score: 100}))
+    // Outside the window, and unreadable: both dropped and pruned
+    aggregator.Record(ctx, rollupResponse("org-old", "NON_COMPLIANT", now.Add(-2*time.Hour), &FrameworkResult{Framework: "NCA", Score: 0}))
+    server.HSet(rollupLatestKey, "org-corrupt", "{")
+
+    if err := aggregator.refresh(ctx); err != nil {
+        t.Fatal(err)
+    }
+
+    scores := map[string]float64{"NCA": 80, "SAMA": 80}
+    for framework, want := range scores {
+        if got := testutil.ToFloat64(rollupFrameworkScore.WithLabelValues(framework)); got != want {
+            t.Errorf("%s average %v, want %v", framework, got, want)
+        }
+    }
+    organizations := map[string]float64{"COMPLIANT": 2, "NON_COMPLIANT": 1}
+    for status, want := range organizations {
+        if got := testutil.ToFloat64(rollupOrganizations.WithLabelValues(status)); got != want {
+            t.Errorf("%s organizations %v, want %v", status, got, want)
+        }
+    }
+    if got := testutil.CollectAndCount(rollupOrganizations); got != len(organizations) {
+        t.Errorf("%d status series, want %d", got, len(organizations))
+    }
+
+    for _, org := range []string{"org-old", "org-corrupt"} {
+        if server.HGet(rollupLatestKey, org) != "" {
+            t.Errorf("%s not pruned", org)
+        }
+    }
+}
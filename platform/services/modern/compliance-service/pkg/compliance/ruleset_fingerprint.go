@@ -0,0 +1,61 @@
+package compliance
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "sort"
+    "strings"
+)
+
+// Checksum of the rules a framework is evaluated by under a ruleset version:
+// the version together with the evidence it declares, its prerequisites and
+// its score scale. Checker logic is compiled in and identified by the
+// version, so a rule change that keeps the version and these declarations
+// keeps the checksum.
+func (e *RulesEngine) rulesetChecksum(framework, version string) string {
+    requirements := append([]EvidenceRequirement(nil), e.requirements[framework]...)
+    sort.Slice(requirements, func(i, j int) bool { return requirements[i].Key < requirements[j].Key })
+    dependencies := append([]string(nil), e.dependencies[framework]...)
+    sort.Strings(dependencies)
+    var scale *ScoreScale
+    if s, ok := e.scales[framework]; ok {
+        scale = &s
+    }
+
+    data, _ := json.Marshal(struct {
+        Framework    string
+        Version      string
+        Requirements []EvidenceRequirement
+        DependsOn    []string
+        Scale        *ScoreScale
+    }{framework, version, requirements, dependencies, scale})
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
+// Stamp a result with the ruleset version and checksum it was evaluated by
+func (e *RulesEngine) stampRuleset(framework string, result *FrameworkResult) {
+    version := e.frameworkVersion(framework)
+    result.RulesetVersion = version
+    result.RulesetChecksum = e.rulesetChecksum(framework, version)
+}
+
+// Fingerprint of the rules behind a response: a hash of each stamped
+// result's framework, version and checksum. Results not evaluated by rules,
+// such as INSUFFICIENT_DATA, carry no stamp and do not contribute.
+func rulesetFingerprint(results []*FrameworkResult) string {
+    var entries []string
+    for _, result := range results {
+        if result == nil || result.RulesetChecksum == "" {
+            continue
+        }
+        entries = append(entries, result.Framework+"@"+result.RulesetVersion+":"+result.RulesetChecksum)
+    }
+    if len(entries) == 0 {
+        return ""
+    }
+    sort.Strings(entries)
+    sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+    return hex.EncodeToString(sum[:])[:16]
+}
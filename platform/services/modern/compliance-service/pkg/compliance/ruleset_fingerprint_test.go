@@ -0,0 +1,127 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+)
+
+// The checksum is stable for an identical ruleset and changes with the
+// version or any declaration it covers
+func TestRulesetChecksum(t *testing.T) {
+    newEngine := func(version string, configure func(*RulesEngine)) *RulesEngine {
+        engine := NewRulesEngine(version, nil)
+        engine.Register("NCA", countingChecker("NCA", nil))
+        engine.Register("SAMA", countingChecker("SAMA", nil))
+        engine.RequireEvidence("SAMA", EvidenceRequirement{Key: "mfa_enabled", Type: evidenceBool}, EvidenceRequirement{Key: "encryption_at_rest", Type: evidenceBool})
+        if configure != nil {
+            configure(engine)
+        }
+        return engine
+    }
+    base := newEngine("2024.1", nil).rulesetChecksum("SAMA", "2024.1")
+
+    tests := []struct {
+        name    string
+        engine  *RulesEngine
+        version string
+        same    bool
+    }{
+        {name: "identical ruleset", engine: newEngine("2024.1", nil), version: "2024.1", same: true},
+        {name: "requirements declared in another order", engine: newEngine("2024.1", func(e *RulesEngine) {
+            e.requirements["SAMA"] = []EvidenceRequirement{e.requirements["SAMA"][1], e.requirements["SAMA"][0]}
+        }), version: "2024.1", same: true},
+        {name: "another framework's declarations", engine: newEngine("2024.1", func(e *RulesEngine) {
+            e.RequireEvidence("NCA", EvidenceRequirement{Key: "asset_inventory", Type: evidenceBool})
+        }), version: "2024.1", same: true},
+        {name: "new version", engine: newEngine("2024.2", nil), version: "2024.2"},
+        {name: "added requirement", engine: newEngine("2024.1", func(e *RulesEngine) {
+            e.RequireEvidence("SAMA", EvidenceRequirement{Key: "aml_program", Type: evidenceBool})
+        }), version: "2024.1"},
+        {name: "reweighted requirement", engine: newEngine("2024.1", func(e *RulesEngine) {
+            e.requirements["SAMA"][0].Severity = severityCritical
+        }), version: "2024.1"},
+        {name: "added prerequisite", engine: newEngine("2024.1", func(e *RulesEngine) {
+            e.AddDependencies("SAMA", "NCA")
+        }), version: "2024.1"},
+        {name: "score scale", engine: newEngine("2024.1", func(e *RulesEngine) {
+            e.SetScoreScale("SAMA", ScoreScale{Name: "1-5", Kind: scaleLinear, Min: 1, Max: 5})
+        }), version: "2024.1"},
+    }
+    for _, tt := range tests {
+        if got := tt.engine.rulesetChecksum("SAMA", tt.version); (got == base) != tt.same {
+            t.Errorf("%s: checksum %s against %s, want same %t", tt.name, got, base, tt.same)
+        }
+    }
+}
+
+func TestRulesetFingerprint(t *testing.T) {
+    sama := &FrameworkResult{Framework: "SAMA", RulesetVersion: "2024.1", RulesetChecksum: "aaaa"}
+    nca := &FrameworkResult{Framework: "NCA", RulesetVersion: "2024.1", RulesetChecksum: "bbbb"}
+    unstamped := &FrameworkResult{Framework: "PDPL", Outcome: frameworkInsufficientData}
+
+    fingerprint := rulesetFingerprint([]*FrameworkResult{sama, nca})
+    if len(fingerprint) != 16 {
+        t.Errorf("fingerprint %q, want 16 hex characters", fingerprint)
+    }
+    if got := rulesetFingerprint([]*FrameworkResult{nca, unstamped, sama, nil}); got != fingerprint {
+        t.Errorf("fingerprint %s reordered with an unstamped result, want %s", got, fingerprint)
+    }
+    promoted := &FrameworkResult{Framework: "SAMA", RulesetVersion: "2024.2", RulesetChecksum: "aaaa"}
+    if got := rulesetFingerprint([]*FrameworkResult{promoted, nca}); got == fingerprint {
+        t.Errorf("fingerprint %s unchanged by a new SAMA version", got)
+    }
+    if got := rulesetFingerprint([]*FrameworkResult{unstamped}); got != "" {
+        t.Errorf("fingerprint %q of unstamped results, want none", got)
+    }
+}
+
+// Evaluated results carry the rules that produced them; promoting a
+// framework's shadow changes its stamp and the response fingerprint
+func TestResponsesStampedWithRuleset(t *testing.T) {
+    service, _ := newConfigFileService(t, "minimum_inputs:\n  PDPL: [dpo_appointed]\n")
+    ctx := context.Background()
+    engine := service.engine
+
+    response, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    checksums := make(map[string]string)
+    for _, result := range response.FrameworkResults {
+        if result.Outcome == frameworkInsufficientData {
+            if result.RulesetVersion != "" || result.RulesetChecksum != "" {
+                t.Errorf("%s INSUFFICIENT_DATA stamped %s %s, want no stamp", result.Framework, result.RulesetVersion, result.RulesetChecksum)
+            }
+            continue
+        }
+        if result.RulesetVersion != engine.rulesetVersion || result.RulesetChecksum != engine.rulesetChecksum(result.Framework, engine.rulesetVersion) {
+            t.Errorf("%s stamped %s %s, want the active ruleset %s", result.Framework, result.RulesetVersion, result.RulesetChecksum, engine.rulesetVersion)
+        }
+        checksums[result.Framework] = result.RulesetChecksum
+    }
+    if _, ok := checksums["PDPL"]; ok {
+        t.Fatal("PDPL evaluated, want it INSUFFICIENT_DATA without dpo_appointed")
+    }
+    if response.RulesetFingerprint == "" || response.RulesetFingerprint != rulesetFingerprint(response.FrameworkResults) {
+        t.Errorf("fingerprint %q, want one over the stamped results", response.RulesetFingerprint)
+    }
+
+    engine.RegisterShadow("SAMA", "2099.1", service.checkSAMA)
+    engine.SetPromoted(map[string]string{"SAMA": "2099.1"})
+    promoted, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-2"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, result := range promoted.FrameworkResults {
+        if result.Framework == "SAMA" {
+            if result.RulesetVersion != "2099.1" || result.RulesetChecksum == checksums["SAMA"] {
+                t.Errorf("SAMA stamped %s %s after promotion, want 2099.1 with a new checksum", result.RulesetVersion, result.RulesetChecksum)
+            }
+        } else if checksum, ok := checksums[result.Framework]; ok && result.RulesetChecksum != checksum {
+            t.Errorf("%s checksum changed by promoting SAMA", result.Framework)
+        }
+    }
+    if promoted.RulesetFingerprint == response.RulesetFingerprint {
+        t.Errorf("fingerprint %s unchanged by promoting SAMA", promoted.RulesetFingerprint)
+    }
+}
@@ -0,0 +1,299 @@
+package compliance
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "os"
+    "sort"
+    "time"
+
+    "gopkg.in/yaml.v3"
+)
+
+// Built-in framework weights used when no config file overrides them
+var defaultFrameworkWeights = map[string]float64{
+    "NCA":      0.25,
+    "SAMA":     0.25,
+    "PDPL":     0.20,
+    "ISO27001": 0.15,
+    "NIST":     0.15,
+}
+
+// StatusThresholds - minimum overall scores for each status band
+type StatusThresholds struct {
+    Compliant          float64 `yaml:"compliant" json:"compliant"`
+    PartiallyCompliant float64 `yaml:"partially_compliant" json:"partially_compliant"`
+}
+
+// RuntimeConfig - settings that can change without a restart. A loaded
+// config is immutable; reloads build a new one and swap it in atomically.
+type RuntimeConfig struct {
+    Weights    map[string]float64 `yaml:"weights" json:"weights"`
+    Thresholds StatusThresholds   `yaml:"thresholds" json:"thresholds"`
+    Cache      CacheTTLs          `yaml:"cache" json:"cache"`
+
+    // Minimum scores per SAMA sub-domain, e.g. {BCM: 85}
+    SamaSubdomainGates map[string]float64 `yaml:"sama_subdomain_gates" json:"sama_subdomain_gates"`
+
+    // Minimum scores per status band for gating frameworks, e.g.
+    // {NCA: {compliant: 80}}; one below its minimum caps the overall status
+    FrameworkGates map[string]StatusThresholds `yaml:"framework_gates" json:"framework_gates"`
+
+    // Evidence keys each framework needs before it is scored, e.g.
+    // {PDPL: [dpo_appointed]}; a framework missing one is INSUFFICIENT_DATA
+    // and left out of the overall score
+    MinimumInputs map[string][]string `yaml:"minimum_inputs" json:"minimum_inputs"`
+
+    // Other names clients use for frameworks, e.g. {"ISO 27001": ISO27001};
+    // names and aliases match case-insensitively
+    FrameworkAliases map[string]string `yaml:"framework_aliases" json:"framework_aliases"`
+
+    // Organizations served; empty serves all
+    OrgAllowlist OrgAllowlist `yaml:"org_allowlist" json:"org_allowlist"`
+
+    // FrameworkResult shape served and published (legacy, dual or new), and
+    // the fraction of dual-written responses verified for lossless round trips
+    ResultSchema         string  `yaml:"result_schema" json:"result_schema"`
+    SchemaValidationRate float64 `yaml:"schema_validation_sample_rate" json:"schema_validation_sample_rate"`
+
+    // Trace sample ratio and the latency beyond which traces are always kept
+    Tracing TraceSamplingConfig `yaml:"tracing" json:"tracing"`
+
+    // Regions offered to clients, each with its default frameworks
+    Regions map[string]RegionConfig `yaml:"regions" json:"regions"`
+
+    // How far ahead regulatory milestones are listed in upcoming_obligations,
+    // 0 disables them; the calendar itself is read from its own file
+    ObligationHorizon time.Duration      `yaml:"obligation_horizon" json:"obligation_horizon"`
+    Calendar          RegulatoryCalendar `yaml:"-" json:"calendar"`
+
+    // Fraction of fresh evaluations also run through a framework's shadow
+    // ruleset, off the request path
+    ShadowSampleRate float64 `yaml:"shadow_sample_rate" json:"shadow_sample_rate"`
+
+    // Per-tenant overrides of weights, thresholds, TTLs and enabled
+    // frameworks, keyed by tenant ID; tenants without one use the above
+    TenantProfiles map[string]TenantProfile `yaml:"tenant_profiles" json:"tenant_profiles"`
+
+    // Operational overrides layered over the config file, the frameworks
+    // they disabled and the shadow rulesets they promoted
+    OperationalVersion int64             `yaml:"-" json:"operational_version"`
+    DisabledFrameworks []string          `yaml:"-" json:"disabled_frameworks"`
+    PromotedRulesets   map[string]string `yaml:"-" json:"promoted_rulesets"`
+
+    // Top-level keys the config file set
+    FileKeys []string `yaml:"-" json:"file_keys"`
+
+    // Tenant whose profile this config was resolved for, empty for the
+    // global config
+    Profile string `yaml:"-" json:"profile,omitempty"`
+
+    Version  string    `yaml:"-" json:"version"`
+    LoadedAt time.Time `yaml:"-" json:"loaded_at"`
+}
+
+// Defaults from the built-in weights and environment configuration
+func defaultRuntimeConfig(config ServiceConfig) (*RuntimeConfig, error) {
+    frameworkTTLs, err := parseFrameworkTTLs(config.FrameworkCacheTTLs)
+    if err != nil {
+        return nil, err
+    }
+
+    weights := make(map[string]float64, len(defaultFrameworkWeights))
+    for framework, weight := range defaultFrameworkWeights {
+        weights[framework] = weight
+    }
+
+    return &RuntimeConfig{
+        Weights:    weights,
+        Thresholds: StatusThresholds{Compliant: 90, PartiallyCompliant: 70},
+        Cache:      CacheTTLs{Default: config.CacheTTL, Frameworks: frameworkTTLs},
+
+        OrgAllowlist: parseOrgAllowlist(config.OrgAllowlist),
+
+        ResultSchema:         resultSchemaDual,
+        SchemaValidationRate: 0.01,
+        ShadowSampleRate:     0.1,
+
+        Tracing: TraceSamplingConfig{SampleRatio: config.TraceSampleRatio, LatencyThreshold: config.TraceLatencyThreshold},
+
+        ObligationHorizon: config.ObligationHorizon,
+    }, nil
+}
+
+// Load the runtime config: defaults, overlaid with the config file if set
+// and then with the operational overrides, validated against the registered
+// frameworks, together with the regulatory calendar
+func loadRuntimeConfig(config ServiceConfig, frameworks []string, state *OperationalState) (*RuntimeConfig, error) {
+    runtime, err := defaultRuntimeConfig(config)
+    if err != nil {
+        return nil, err
+    }
+
+    if config.ConfigFile != "" {
+        data, err := os.ReadFile(config.ConfigFile)
+        if err != nil {
+            return nil, fmt.Errorf("failed to read config file: %v", err)
+        }
+        if err := yaml.Unmarshal(data, runtime); err != nil {
+            return nil, fmt.Errorf("failed to parse config file: %v", err)
+        }
+        var keys map[string]interface{}
+        yaml.Unmarshal(data, &keys)
+        for key := range keys {
+            runtime.FileKeys = append(runtime.FileKeys, key)
+        }
+        sort.Strings(runtime.FileKeys)
+    }
+    applyOperationalState(runtime, state)
+
+    if err := runtime.Validate(frameworks); err != nil {
+        return nil, err
+    }
+    if runtime.Calendar, err = loadRegulatoryCalendar(config.RegulatoryCalendar, frameworks); err != nil {
+        return nil, err
+    }
+
+    runtime.LoadedAt = time.Now()
+    runtime.Version = runtime.fingerprint()
+    return runtime, nil
+}
+
+// Validate weights, thresholds, gates, allow-list, result schema, tracing,
+// obligation horizon, tenant profiles and TTLs
+func (c *RuntimeConfig) Validate(frameworks []string) error {
+    totalWeight := 0.0
+    for framework, weight := range c.Weights {
+        if !containsString(frameworks, framework) {
+            return fmt.Errorf("weight configured for unknown framework %s", framework)
+        }
+        if weight < 0 {
+            return fmt.Errorf("negative weight for framework %s", framework)
+        }
+        totalWeight += weight
+    }
+    if totalWeight <= 0 {
+        return fmt.Errorf("framework weights must sum to a positive value")
+    }
+
+    t := c.Thresholds
+    if t.PartiallyCompliant < 0 || t.Compliant > 100 || t.PartiallyCompliant > t.Compliant {
+        return fmt.Errorf("thresholds must satisfy 0 <= partially_compliant <= compliant <= 100")
+    }
+
+    for subdomain, minimum := range c.SamaSubdomainGates {
+        known := false
+        for _, s := range samaSubdomains {
+            known = known || s.name == subdomain
+        }
+        if !known {
+            return fmt.Errorf("gate configured for unknown SAMA sub-domain %s", subdomain)
+        }
+        if minimum < 0 || minimum > 100 {
+            return fmt.Errorf("gate for SAMA sub-domain %s must be within [0, 100]", subdomain)
+        }
+    }
+
+    if err := validateFrameworkGates(c.FrameworkGates, frameworks); err != nil {
+        return err
+    }
+
+    if err := c.OrgAllowlist.Validate(); err != nil {
+        return err
+    }
+
+    if err := validResultSchema(c.ResultSchema); err != nil {
+        return err
+    }
+    if c.SchemaValidationRate < 0 || c.SchemaValidationRate > 1 {
+        return fmt.Errorf("schema_validation_sample_rate must be within [0, 1]")
+    }
+
+    if c.ShadowSampleRate < 0 || c.ShadowSampleRate > 1 {
+        return fmt.Errorf("shadow_sample_rate must be within [0, 1]")
+    }
+
+    if err := c.Tracing.Validate(); err != nil {
+        return err
+    }
+
+    for name, region := range c.Regions {
+        if err := region.Validate(name, frameworks); err != nil {
+            return err
+        }
+    }
+
+    if err := validateFrameworkAliases(c.FrameworkAliases, frameworks); err != nil {
+        return err
+    }
+
+    if c.ObligationHorizon < 0 {
+        return fmt.Errorf("obligation_horizon must not be negative")
+    }
+
+    for tenant, profile := range c.TenantProfiles {
+        if err := profile.Validate(tenant, frameworks); err != nil {
+            return err
+        }
+    }
+
+    if c.Cache.Default <= 0 {
+        return fmt.Errorf("cache default_ttl must be positive")
+    }
+    for framework, ttl := range c.Cache.Frameworks {
+        if ttl <= 0 {
+            return fmt.Errorf("cache TTL for framework %s must be positive", framework)
+        }
+    }
+    return nil
+}
+
+// Short content hash identifying a config
+func (c *RuntimeConfig) fingerprint() string {
+    data, _ := json.Marshal(struct {
+        Weights    map[string]float64
+        Thresholds StatusThresholds
+        Cache      CacheTTLs
+        SamaGates  map[string]float64
+        Gates      map[string]StatusThresholds
+        Minimums   map[string][]string
+        Aliases    map[string]string
+        Allowlist  OrgAllowlist
+        Schema     string
+        SchemaRate float64
+        Tracing    TraceSamplingConfig
+        Regions    map[string]RegionConfig
+        Horizon    time.Duration
+        Calendar   RegulatoryCalendar
+        ShadowRate float64
+        Promoted   map[string]string
+        Tenants    map[string]TenantProfile
+    }{c.Weights, c.Thresholds, c.Cache, c.SamaSubdomainGates, c.FrameworkGates, c.MinimumInputs, c.FrameworkAliases, c.OrgAllowlist, c.ResultSchema, c.SchemaValidationRate, c.Tracing, c.Regions, c.ObligationHorizon, c.Calendar, c.ShadowSampleRate, c.PromotedRulesets, c.TenantProfiles})
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])[:12]
+}
+
+// Current live runtime config
+func (s *ComplianceService) runtimeConfig() *RuntimeConfig {
+    return s.runtime.Load()
+}
+
+// Reload re-reads the config source, applies the current operational
+// overrides and swaps the result in only if it is valid; an invalid config
+// leaves the running one untouched
+func (s *ComplianceService) reloadRuntimeConfig() (*RuntimeConfig, error) {
+    runtime, err := loadRuntimeConfig(s.config, s.engine.Frameworks(), s.operational.Current())
+    if err != nil {
+        return nil, err
+    }
+    if err := s.engine.validateMinimumInputs(runtime.MinimumInputs); err != nil {
+        return nil, err
+    }
+    s.runtime.Store(runtime)
+    s.sampler.Configure(runtime.Tracing)
+    s.engine.SetPromoted(runtime.PromotedRulesets)
+    operationalStateVersion.Set(float64(runtime.OperationalVersion))
+    return runtime, nil
+}
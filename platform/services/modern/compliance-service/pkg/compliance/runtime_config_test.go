@@ -0,0 +1,148 @@
+package compliance
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "sync"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Two configs an evaluation can tell apart: the first scores SAMA alone and
+// calls every score compliant, the second scores NCA alone and calls every
+// score below 100 non-compliant. A mix of the two scores SAMA as
+// non-compliant, or NCA as compliant.
+const (
+    samaOnlyConfig = `
+weights: {NCA: 0, SAMA: 1, PDPL: 0, ISO27001: 0, NIST: 0}
+thresholds: {compliant: 0, partially_compliant: 0}
+`
+    ncaOnlyConfig = `
+weights: {NCA: 1, SAMA: 0, PDPL: 0, ISO27001: 0, NIST: 0}
+thresholds: {compliant: 100, partially_compliant: 100}
+`
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+    t.Helper()
+    if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+        t.Fatal(err)
+    }
+}
+
+// A valid config is swapped in, and an invalid one is refused with the
+// running config left in effect
+func TestReloadConfig(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "config.yaml")
+    writeConfigFile(t, path, samaOnlyConfig)
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+        config.ConfigFile = path
+    })
+    admin := adminContext("admin-secret")
+    loaded := service.runtimeConfig()
+
+    writeConfigFile(t, path, ncaOnlyConfig)
+    reloaded, err := service.ReloadConfig(admin, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    current := service.runtimeConfig()
+    if reloaded.ConfigVersion == loaded.Version || reloaded.ConfigVersion != current.Version {
+        t.Errorf("reload reported version %q, running %q, was %q", reloaded.ConfigVersion, current.Version, loaded.Version)
+    }
+    if current.Weights["NCA"] != 1 || current.Thresholds.Compliant != 100 {
+        t.Errorf("running weights %v thresholds %v, want the reloaded file's", current.Weights, current.Thresholds)
+    }
+
+    for name, content := range map[string]string{
+        "unknown framework":   "weights: {GDPR: 1}\n",
+        "inverted thresholds": "thresholds: {compliant: 50, partially_compliant: 80}\n",
+        "unparseable":         "weights: [\n",
+    } {
+        t.Run(name, func(t *testing.T) {
+            writeConfigFile(t, path, content)
+            if _, err := service.ReloadConfig(admin, nil); status.Code(err) != codes.FailedPrecondition {
+                t.Errorf("ReloadConfig() = %v, want FailedPrecondition", err)
+            }
+            if service.runtimeConfig() != current {
+                t.Error("a rejected config replaced the running one")
+            }
+        })
+    }
+
+    if _, err := service.ReloadConfig(context.Background(), nil); status.Code(err) != codes.PermissionDenied {
+        t.Errorf("ReloadConfig() without credentials = %v, want PermissionDenied", err)
+    }
+}
+
+// Evaluations running across reloads each see one whole config, never
+// weights of one and thresholds of the other
+func TestReloadConfigAtomicUnderEvaluations(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "config.yaml")
+    writeConfigFile(t, path, samaOnlyConfig)
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.Admin.Token = "admin-secret"
+        config.ConfigFile = path
+        config.ComputeCacheTTL = 0
+    })
+    spy := spyOnCheckers(service)
+    spy.setScore("SAMA", 80)
+    spy.setScore("NCA", 40)
+    admin := adminContext("admin-secret")
+
+    done := make(chan struct{})
+    var wg sync.WaitGroup
+    var mu sync.Mutex
+    seen := make(map[string]int)
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for {
+                select {
+                case <-done:
+                    return
+                default:
+                }
+                response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", ForceRefresh: true})
+                if err != nil {
+                    if status.Code(err) != codes.Aborted {
+                        t.Error(err)
+                    }
+                    continue
+                }
+                samaOnly := response.OverallScore == 80 && response.Status == "COMPLIANT"
+                ncaOnly := response.OverallScore == 40 && response.Status == "NON_COMPLIANT"
+                if !samaOnly && !ncaOnly {
+                    t.Errorf("overall %v scored %s, a mix of two configs", response.OverallScore, response.Status)
+                }
+                mu.Lock()
+                seen[response.Status]++
+                mu.Unlock()
+            }
+        }()
+    }
+
+    for i := 0; i < 50; i++ {
+        content := samaOnlyConfig
+        if i%2 == 0 {
+            content = ncaOnlyConfig
+        }
+        writeConfigFile(t, path, content)
+        if _, err := service.ReloadConfig(admin, nil); err != nil {
+            t.Error(err)
+            break
+        }
+        time.Sleep(time.Millisecond)
+    }
+    close(done)
+    wg.Wait()
+    if seen["COMPLIANT"] == 0 || seen["NON_COMPLIANT"] == 0 {
+        t.Errorf("evaluations saw %v, want both configs in effect at some point", seen)
+    }
+}
@@ -0,0 +1,97 @@
+package compliance
+
+import (
+    "context"
+    "log"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// RunTimingsStore - stage timings of CheckCompliance runs by organization
+// and run ID, kept for performance forensics after the slow-request log has rotated
+type RunTimingsStore struct {
+    redis     *redis.Client
+    retention time.Duration
+}
+
+// Create a run timings store; entries expire after retention
+func NewRunTimingsStore(client *redis.Client, retention time.Duration) *RunTimingsStore {
+    return &RunTimingsStore{redis: client, retention: retention}
+}
+
+// Store the timings of a finished run. Framework entries are limited to
+// registered frameworks so a run cannot grow the record unboundedly.
+func (r *RunTimingsStore) Store(ctx context.Context, runID, organizationID string, startedAt time.Time, total time.Duration, timings *RequestTimings, frameworks []string) {
+    timings.mu.Lock()
+    record := &RunTimings{
+        RunId:          runID,
+        OrganizationId: organizationID,
+        StartedAt:      startedAt.Unix(),
+        TotalMs:        milliseconds(total),
+        CacheReadMs:    milliseconds(timings.Cache),
+        EvidenceMs:     milliseconds(timings.Evidence),
+        FrameworkMs:    make(map[string]int32, len(timings.Frameworks)),
+        ScoringMs:      milliseconds(timings.Scoring),
+        CacheWriteMs:   milliseconds(timings.CacheWrite),
+        PublishMs:      milliseconds(timings.Publish),
+    }
+    for framework, elapsed := range timings.Frameworks {
+        if containsString(frameworks, framework) {
+            record.FrameworkMs[framework] = milliseconds(elapsed)
+        }
+    }
+    timings.mu.Unlock()
+
+    data, err := proto.Marshal(record)
+    if err != nil {
+        log.Printf("Failed to encode run timings for %s: %v", runID, err)
+        return
+    }
+    if err := r.redis.Set(ctx, runTimingsKey(organizationID, runID), data, r.retention).Err(); err != nil {
+        log.Printf("Failed to store run timings for %s: %v", runID, err)
+    }
+}
+
+// Load the timings of an organization's run; NotFound if unknown, expired
+// or of another organization
+func (r *RunTimingsStore) Load(ctx context.Context, organizationID, runID string) (*RunTimings, error) {
+    data, err := r.redis.Get(ctx, runTimingsKey(organizationID, runID)).Bytes()
+    if err == redis.Nil {
+        return nil, status.Errorf(codes.NotFound, "no timings for run %s", runID)
+    } else if err != nil {
+        return nil, storeError(err, "failed to load run timings")
+    }
+    record := &RunTimings{}
+    if err := proto.Unmarshal(data, record); err != nil {
+        return nil, status.Errorf(codes.DataLoss, "failed to decode run timings: %v", err)
+    }
+    return record, nil
+}
+
+func runTimingsKey(organizationID, runID string) string {
+    return "run-timings:" + organizationID + ":" + runID
+}
+
+// Whole milliseconds, rounded to nearest
+func milliseconds(d time.Duration) int32 {
+    return int32(d.Round(time.Millisecond) / time.Millisecond)
+}
+
+// GetRunTimings - stage timing breakdown of a past CheckCompliance run
+func (s *ComplianceService) GetRunTimings(ctx context.Context, req *RunTimingsRequest) (*RunTimings, error) {
+    if req.RunId == "" || req.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "run_id and organization_id are required")
+    }
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+    return s.runTimings.Load(ctx, organizationID, req.RunId)
+}
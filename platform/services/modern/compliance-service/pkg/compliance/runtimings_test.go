@@ -0,0 +1,109 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Timings of a run once its background write has landed
+func awaitRunTimings(t *testing.T, service *ComplianceService, organizationID, runID string) *RunTimings {
+    t.Helper()
+    deadline := time.Now().Add(2 * time.Second)
+    for {
+        timings, err := service.GetRunTimings(context.Background(), &RunTimingsRequest{RunId: runID, OrganizationId: organizationID})
+        if err == nil {
+            return timings
+        }
+        if status.Code(err) != codes.NotFound || time.Now().After(deadline) {
+            t.Fatalf("GetRunTimings() = %v", err)
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+}
+
+// Sequential stages and the slowest of the parallel framework checks fit
+// within the total, and the record reads back the same from a restarted
+// replica
+func TestRunTimingsWithinTotal(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    service.faults.Set("SAMA", Fault{Delay: 30 * time.Millisecond})
+    response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    timings := awaitRunTimings(t, service, "org-1", response.RunId)
+
+    if timings.RunId != response.RunId || timings.OrganizationId != "org-1" {
+        t.Errorf("timings of run %q for %q, want %q for org-1", timings.RunId, timings.OrganizationId, response.RunId)
+    }
+    if timings.FrameworkMs["SAMA"] < 30 {
+        t.Errorf("SAMA took %dms, want at least the injected 30ms", timings.FrameworkMs["SAMA"])
+    }
+    var slowest int32
+    for _, ms := range timings.FrameworkMs {
+        if ms > slowest {
+            slowest = ms
+        }
+    }
+    stages := []int32{timings.CacheReadMs, timings.EvidenceMs, slowest, timings.ScoringMs, timings.CacheWriteMs, timings.PublishMs}
+    var sum int32
+    for _, ms := range stages {
+        sum += ms
+    }
+    // Each stage is rounded to the nearest millisecond on its own
+    if slack := int32(len(stages)); sum > timings.TotalMs+slack {
+        t.Errorf("stages sum to %dms, over the %dms total", sum, timings.TotalMs)
+    }
+
+    config := service.config
+    config.RedisAddr = server.Addr()
+    restarted, err := New(config)
+    if err != nil {
+        t.Fatal(err)
+    }
+    reloaded, err := restarted.GetRunTimings(context.Background(), &RunTimingsRequest{RunId: response.RunId, OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !proto.Equal(reloaded, timings) {
+        t.Errorf("restarted replica read %v, want %v", reloaded, timings)
+    }
+}
+
+// Timings are keyed by the server-assigned run ID within the organization,
+// so a reused request ID neither collides nor exposes another run
+func TestRunTimingsScopedToOrganization(t *testing.T) {
+    service, _ := newTestService(t)
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "reused"))
+    first, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    second, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-2"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got := awaitRunTimings(t, service, "org-1", first.RunId); got.RunId != first.RunId {
+        t.Errorf("org-1 timings of run %q, want %q", got.RunId, first.RunId)
+    }
+    if got := awaitRunTimings(t, service, "org-2", second.RunId); got.OrganizationId != "org-2" {
+        t.Errorf("org-2 run timings of %q", got.OrganizationId)
+    }
+
+    for _, req := range []*RunTimingsRequest{
+        {RunId: first.RunId, OrganizationId: "org-2"},
+        {RunId: "reused", OrganizationId: "org-1"},
+    } {
+        if _, err := service.GetRunTimings(context.Background(), req); status.Code(err) != codes.NotFound {
+            t.Errorf("GetRunTimings(%v) = %v, want NotFound", req, err)
+        }
+    }
+}
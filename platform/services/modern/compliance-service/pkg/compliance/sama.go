@@ -0,0 +1,88 @@
+package compliance
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// SAMA sub-domains reported as separate findings
+const (
+    samaCyberResilience   = "CYBER_RESILIENCE"
+    samaBCM               = "BCM"
+    samaIncidentReporting = "INCIDENT_REPORTING"
+    samaThirdPartyRisk    = "THIRD_PARTY_RISK"
+)
+
+// Maximum hours between detecting and notifying SAMA of a major incident
+const samaIncidentNotificationHours = 4
+
+// A SAMA sub-domain: its baseline score and the evidence it relies on
+type samaSubdomain struct {
+    name     string
+    score    float64
+    evidence []string
+}
+
+// Sub-domains in reporting order
+var samaSubdomains = []samaSubdomain{
+    {name: samaCyberResilience, score: 94.0, evidence: []string{"cyber_resilience_assessment_date"}},
+    {name: samaBCM, score: 90.5, evidence: []string{"bcp_test_date"}},
+    {name: samaIncidentReporting, score: 93.0, evidence: []string{"incident_notification_hours"}},
+    {name: samaThirdPartyRisk, score: 91.7, evidence: []string{"third_party_register"}},
+}
+
+// Evaluate SAMA sub-domains; the framework score is their average
+func evaluateSamaSubdomains(req *ComplianceRequest) ([]*SubdomainResult, float64) {
+    supplied := make(map[string]*EvidenceItem, len(req.Evidence))
+    for _, item := range req.Evidence {
+        supplied[item.Key] = item
+    }
+
+    results := make([]*SubdomainResult, 0, len(samaSubdomains))
+    total := 0.0
+    for _, subdomain := range samaSubdomains {
+        result := &SubdomainResult{Name: subdomain.name, Score: subdomain.score, GatePassed: true}
+        for _, key := range subdomain.evidence {
+            if _, ok := supplied[key]; !ok {
+                result.Findings = append(result.Findings, fmt.Sprintf("no %s evidence supplied", key))
+            }
+        }
+        if item, ok := supplied["incident_notification_hours"]; ok && subdomain.name == samaIncidentReporting {
+            if hours, err := strconv.ParseFloat(strings.TrimSpace(item.Value), 64); err == nil && hours > samaIncidentNotificationHours {
+                result.Findings = append(result.Findings,
+                    fmt.Sprintf("incident notification after %.0fh exceeds the %dh SAMA timeline", hours, samaIncidentNotificationHours))
+            }
+        }
+        results = append(results, result)
+        total += result.Score
+    }
+    return results, total / float64(len(results))
+}
+
+// Apply configured minimum sub-domain scores to SAMA results. Gates are
+// applied after evaluation so memoized results never carry stale gates.
+// Returns the names of failed gates.
+func applySamaSubdomainGates(results []*FrameworkResult, gates map[string]float64) []string {
+    var failed []string
+    for _, result := range results {
+        details := result.GetSamaDetails()
+        if details == nil {
+            continue
+        }
+        for _, subdomain := range details.Subdomains {
+            minimum, ok := gates[subdomain.Name]
+            if !ok {
+                subdomain.GateMinimum = 0
+                subdomain.GatePassed = true
+                continue
+            }
+            subdomain.GateMinimum = minimum
+            subdomain.GatePassed = subdomain.Score >= minimum
+            if !subdomain.GatePassed {
+                failed = append(failed, "SAMA/"+subdomain.Name)
+            }
+        }
+    }
+    return failed
+}
@@ -0,0 +1,577 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "hash/fnv"
+    "log"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Shaping decisions of a scheduled run
+const (
+    scheduleDelayedJitter   = "DELAYED_JITTER"
+    scheduleSkippedBlackout = "SKIPPED_BLACKOUT"
+)
+
+// Scheduled run outcomes
+const (
+    scheduleRunning   = "RUNNING"
+    scheduleSucceeded = "SUCCEEDED"
+    scheduleFailed    = "FAILED"
+    scheduleSkipped   = "SKIPPED"
+)
+
+const (
+    // Runs holding a slot under the concurrent-runs ceiling, scored by
+    // when their lease lapses
+    scheduleSlotsKey = "schedule:slots"
+    // A slot held by a replica that died is reclaimed after this long
+    scheduleSlotLease = 15 * time.Minute
+    // How often a run waiting for a slot tries again
+    scheduleSlotPoll = time.Second
+)
+
+// Page sizes of ListScheduleRuns
+const (
+    scheduleRunsDefaultPageSize = 50
+    scheduleRunsMaxPageSize     = 500
+)
+
+// ScheduleConfig - nightly re-evaluation of every organization in each
+// tenant's latest results, from its latest stored evaluation
+type ScheduleConfig struct {
+    // UTC time of day the nightly batch is due, "HH:MM"; empty disables it
+    RunAt string `env:"SCHEDULE_RUN_AT"`
+
+    // Window the batch is spread over: each run is delayed by a stable
+    // offset within it, so the batch does not hit Redis, evidence sources
+    // and Kafka at once; 0 runs everything when due
+    JitterWindow time.Duration `env:"SCHEDULE_JITTER_WINDOW"`
+
+    // Scheduled runs in progress across all replicas; runs beyond it wait.
+    // Their evaluations run in the job queue's bulk tier, which leaves
+    // workers free for interactive jobs, and queue at bulk priority behind
+    // interactive traffic in the shared evaluation scheduler.
+    MaxConcurrentRuns int `env:"SCHEDULE_MAX_CONCURRENT_RUNS"`
+
+    // How long run history is kept for ListScheduleRuns
+    RunRetention time.Duration `env:"SCHEDULE_RUN_RETENTION"`
+}
+
+func (c ScheduleConfig) Validate() error {
+    if c.RunAt == "" {
+        return nil
+    }
+    if _, err := parseTimeOfDay(c.RunAt); err != nil {
+        return err
+    }
+    if c.JitterWindow < 0 || c.JitterWindow >= 24*time.Hour {
+        return fmt.Errorf("jitter window must be within [0, 24h)")
+    }
+    if c.MaxConcurrentRuns < 1 {
+        return fmt.Errorf("max concurrent runs must be at least 1")
+    }
+    return nil
+}
+
+// BlackoutWindow - UTC time of day range in which a tenant's scheduled runs
+// are skipped, wrapping past midnight when end is before start
+type BlackoutWindow struct {
+    Start string `yaml:"start" json:"start"` // "HH:MM"
+    End   string `yaml:"end" json:"end"`
+}
+
+func (w BlackoutWindow) Validate() error {
+    start, err := parseTimeOfDay(w.Start)
+    if err != nil {
+        return fmt.Errorf("blackout window: %v", err)
+    }
+    end, err := parseTimeOfDay(w.End)
+    if err != nil {
+        return fmt.Errorf("blackout window: %v", err)
+    }
+    if start == end {
+        return fmt.Errorf("blackout window %s-%s is empty", w.Start, w.End)
+    }
+    return nil
+}
+
+// Whether t falls inside the window
+func (w BlackoutWindow) contains(t time.Time) bool {
+    start, _ := parseTimeOfDay(w.Start)
+    end, _ := parseTimeOfDay(w.End)
+    t = t.UTC()
+    at := t.Sub(t.Truncate(24 * time.Hour))
+    if start < end {
+        return at >= start && at < end
+    }
+    return at >= start || at < end
+}
+
+// Parse "HH:MM" as an offset from midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+    t, err := time.Parse("15:04", strings.TrimSpace(value))
+    if err != nil {
+        return 0, fmt.Errorf("invalid time of day %q, want HH:MM", value)
+    }
+    return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// The tenant's blackout window containing t, if any
+func blackoutAt(runtime *RuntimeConfig, tenant string, t time.Time) (BlackoutWindow, bool) {
+    for _, window := range runtime.TenantProfiles[tenant].Blackouts {
+        if window.contains(t) {
+            return window, true
+        }
+    }
+    return BlackoutWindow{}, false
+}
+
+// Takes a slot under the concurrent-runs ceiling after reclaiming lapsed
+// ones. KEYS[1] is the slot set; ARGV is now, the lease expiry, the
+// ceiling and the run ID.
+var scheduleSlotScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+    return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[4])
+return 1
+`)
+
+// ScheduleRunStore - history of scheduled runs. Each run is kept under its
+// own key and indexed by scheduled time, due time plus jitter, per tenant
+// and per organization.
+type ScheduleRunStore struct {
+    redis     *redis.Client
+    retention time.Duration
+}
+
+// Create the schedule run store; runs expire after retention
+func NewScheduleRunStore(client *redis.Client, retention time.Duration) *ScheduleRunStore {
+    return &ScheduleRunStore{redis: client, retention: retention}
+}
+
+// Record a run, or its update as it progresses
+func (r *ScheduleRunStore) Record(ctx context.Context, run *ScheduleRun) error {
+    data, err := proto.Marshal(run)
+    if err != nil {
+        return fmt.Errorf("failed to encode schedule run: %v", err)
+    }
+    scheduled := float64(run.DueAt + run.JitterSeconds)
+    cutoff := "(" + strconv.FormatInt(time.Now().Add(-r.retention).Unix(), 10)
+
+    pipe := r.redis.TxPipeline()
+    pipe.Set(ctx, scheduleRunKey(run.RunId), data, r.retention)
+    for _, index := range []string{scheduleRunsKey(run.TenantId, ""), scheduleRunsKey(run.TenantId, run.OrganizationId)} {
+        pipe.ZAdd(ctx, index, redis.Z{Score: scheduled, Member: run.RunId})
+        pipe.ZRemRangeByScore(ctx, index, "-inf", cutoff)
+        pipe.Expire(ctx, index, r.retention)
+    }
+    _, err = pipe.Exec(ctx)
+    return err
+}
+
+// Runs of a tenant, or of one of its organizations, scheduled within
+// [from, to), newest first, and whether more follow
+func (r *ScheduleRunStore) List(ctx context.Context, tenant, organizationID string, from, to time.Time, offset, limit int64) ([]*ScheduleRun, bool, error) {
+    ids, err := r.redis.ZRevRangeByScore(ctx, scheduleRunsKey(tenant, organizationID), &redis.ZRangeBy{
+        Min:    strconv.FormatInt(from.Unix(), 10),
+        Max:    "(" + strconv.FormatInt(to.Unix(), 10),
+        Offset: offset,
+        Count:  limit + 1,
+    }).Result()
+    if err != nil {
+        return nil, false, err
+    }
+    more := int64(len(ids)) > limit
+    if more {
+        ids = ids[:limit]
+    }
+    if len(ids) == 0 {
+        return nil, false, nil
+    }
+    keys := make([]string, len(ids))
+    for i, id := range ids {
+        keys[i] = scheduleRunKey(id)
+    }
+    values, err := r.redis.MGet(ctx, keys...).Result()
+    if err != nil {
+        return nil, false, err
+    }
+
+    runs := make([]*ScheduleRun, 0, len(values))
+    for i, value := range values {
+        data, ok := value.(string)
+        if !ok {
+            continue
+        }
+        run := &ScheduleRun{}
+        if err := proto.Unmarshal([]byte(data), run); err != nil {
+            log.Printf("Ignoring undecodable schedule run %s: %v", ids[i], err)
+            continue
+        }
+        runs = append(runs, run)
+    }
+    return runs, more, nil
+}
+
+func scheduleRunKey(runID string) string {
+    return "schedule-run:" + runID
+}
+
+// Run index of a tenant, or of one of its organizations
+func scheduleRunsKey(tenant, organizationID string) string {
+    if organizationID == "" {
+        return "schedule-runs:" + tenant
+    }
+    return "schedule-runs:" + tenant + ":" + organizationID
+}
+
+// An organization due a nightly run, and the run's delay into the batch
+type nightlySchedule struct {
+    tenant         string
+    organizationID string
+    jitter         time.Duration
+}
+
+// Stable offset within the jitter window, so every replica agrees on when
+// each run is due
+func scheduleJitter(due time.Time, tenant, organizationID string, window time.Duration) time.Duration {
+    if window < time.Second {
+        return 0
+    }
+    h := fnv.New64a()
+    h.Write([]byte(due.Format("2006-01-02") + "|" + tenant + "|" + organizationID))
+    return time.Duration(h.Sum64()%uint64(window/time.Second)) * time.Second
+}
+
+// Run nightly batches until ctx is done. Every replica follows the same
+// schedule and races for each run, so a run happens once however many
+// replicas there are and the batch is spread across them. A replica
+// started partway through a batch's jitter window joins that batch.
+func (s *ComplianceService) runSchedules(ctx context.Context) {
+    config := s.config.Schedule
+    if config.RunAt == "" {
+        return
+    }
+    runAt, _ := parseTimeOfDay(config.RunAt)
+    now := time.Now().UTC()
+    due := now.Truncate(24 * time.Hour).Add(runAt)
+    if due.After(now) {
+        due = due.Add(-24 * time.Hour)
+    }
+    if now.Sub(due) >= config.JitterWindow {
+        due = due.Add(24 * time.Hour)
+    }
+
+    for {
+        timer := time.NewTimer(time.Until(due))
+        select {
+        case <-ctx.Done():
+            timer.Stop()
+            return
+        case <-timer.C:
+        }
+        s.runNightlyBatch(ctx, due)
+        due = due.Add(24 * time.Hour)
+    }
+}
+
+// Start each group of the batch at its jittered time: runs due at the same
+// moment form a group. Returns once all groups have started.
+func (s *ComplianceService) runNightlyBatch(ctx context.Context, due time.Time) {
+    config := s.config.Schedule
+    var schedules []nightlySchedule
+    tenants, err := s.redis.SMembers(ctx, latestTenantsKey).Result()
+    if err != nil {
+        log.Printf("Nightly batch due %s skipped: failed to list tenants: %v", due.Format(time.RFC3339), err)
+        return
+    }
+    for _, tenant := range tenants {
+        organizations, err := s.redis.HKeys(ctx, latestResultsKey(tenant)).Result()
+        if err != nil {
+            log.Printf("Nightly batch due %s skipped tenant %s: %v", due.Format(time.RFC3339), tenant, err)
+            continue
+        }
+        for _, organizationID := range organizations {
+            schedules = append(schedules, nightlySchedule{tenant, organizationID, scheduleJitter(due, tenant, organizationID, config.JitterWindow)})
+        }
+    }
+    sort.Slice(schedules, func(i, j int) bool { return schedules[i].jitter < schedules[j].jitter })
+    log.Printf("Nightly batch due %s: %d scheduled runs spread over %s", due.Format(time.RFC3339), len(schedules), config.JitterWindow)
+
+    local := make(chan struct{}, config.MaxConcurrentRuns)
+    for len(schedules) > 0 {
+        size := 1
+        for size < len(schedules) && schedules[size].jitter == schedules[0].jitter {
+            size++
+        }
+        group := schedules[:size]
+        schedules = schedules[size:]
+
+        timer := time.NewTimer(time.Until(due.Add(group[0].jitter)))
+        select {
+        case <-ctx.Done():
+            timer.Stop()
+            return
+        case <-timer.C:
+        }
+        go s.runScheduleGroup(ctx, due, group, local)
+    }
+}
+
+// A claimed run and the stored request it re-evaluates
+type scheduledRun struct {
+    run      *ScheduleRun
+    schedule nightlySchedule
+    req      *ComplianceRequest
+    key      string // Response cache key of req
+}
+
+// Claim a group's runs, read their cached responses in one batch and
+// evaluate only the misses, at most local's capacity at a time from this
+// replica. Responses the misses produce are cached in one batch write once
+// the group is done.
+func (s *ComplianceService) runScheduleGroup(ctx context.Context, due time.Time, group []nightlySchedule, local chan struct{}) {
+    var runs []*scheduledRun
+    for _, schedule := range group {
+        if run := s.claimScheduleRun(ctx, due, schedule); run != nil {
+            runs = append(runs, run)
+        }
+    }
+    if len(runs) == 0 {
+        return
+    }
+
+    keys := make([]string, len(runs))
+    for i, run := range runs {
+        keys[i] = run.key
+    }
+    cached, err := s.cache.GetMany(ctx, keys)
+    if err != nil {
+        log.Printf("Nightly batch due %s: cache unavailable, evaluating %d runs: %v", due.Format(time.RFC3339), len(runs), err)
+    }
+
+    batch := &cacheBatch{}
+    var wg sync.WaitGroup
+    for _, run := range runs {
+        if response, ok := cached[run.key]; ok {
+            // Inputs unchanged since the cached evaluation
+            s.usage.Record(run.schedule.tenant, usageCacheHits, 1)
+            run.run.StartedAt = time.Now().Unix()
+            run.run.Status = response.Status
+            s.finishScheduleRun(ctx, run.run, scheduleSucceeded, "")
+            continue
+        }
+        select {
+        case <-ctx.Done():
+            wg.Wait()
+            return
+        case local <- struct{}{}:
+        }
+        wg.Add(1)
+        go func(run *scheduledRun) {
+            defer wg.Done()
+            defer func() { <-local }()
+            s.runSchedule(withCacheBatch(ctx, batch), due, run)
+        }(run)
+    }
+    wg.Wait()
+    if err := s.cache.SetMany(context.WithoutCancel(ctx), batch.writes); err != nil {
+        log.Printf("Nightly batch due %s: failed to cache %d responses: %v", due.Format(time.RFC3339), len(batch.writes), err)
+    }
+}
+
+// Claim a run and load the organization's latest stored request. A run
+// inside a blackout window, or without a full stored request, is recorded
+// as skipped; nil if it was skipped or another replica claimed it.
+func (s *ComplianceService) claimScheduleRun(ctx context.Context, due time.Time, schedule nightlySchedule) *scheduledRun {
+    claim := "schedule-claim:" + strconv.FormatInt(due.Unix(), 10) + ":" + schedule.tenant + ":" + schedule.organizationID
+    claimed, err := s.redis.SetNX(ctx, claim, s.config.ClusterNode, 48*time.Hour).Result()
+    if err != nil || !claimed {
+        return nil
+    }
+    run := &ScheduleRun{
+        RunId:          newRequestID(),
+        TenantId:       schedule.tenant,
+        OrganizationId: schedule.organizationID,
+        DueAt:          due.Unix(),
+        JitterSeconds:  int64(schedule.jitter / time.Second),
+        Decision:       scheduleDelayedJitter,
+    }
+    runtime := s.runtimeConfig()
+    if window, ok := blackoutAt(runtime, schedule.tenant, time.Now()); ok {
+        run.Decision = scheduleSkippedBlackout
+        s.finishScheduleRun(ctx, run, scheduleSkipped, fmt.Sprintf("inside blackout window %s-%s UTC", window.Start, window.End))
+        return nil
+    }
+
+    now := time.Now()
+    record, err := s.replays.Latest(ctx, schedule.organizationID, now.Add(-s.config.ReplayRetention), now.Add(time.Second))
+    if err == nil {
+        err = requireFullRecord(record, "re-run on schedule")
+    }
+    if err != nil {
+        s.finishScheduleRun(ctx, run, scheduleSkipped, status.Convert(err).Message())
+        return nil
+    }
+    req := proto.Clone(record.Request).(*ComplianceRequest)
+    req.Priority = 0
+    req.ForceRefresh = false
+    req.OnBehalfOf = nil
+    return &scheduledRun{run: run, schedule: schedule, req: req, key: runtime.ForTenant(schedule.tenant).responseKey(req)}
+}
+
+// Wait for a slot under the ceiling, then re-evaluate the stored request as
+// a background evaluation of its tenant, through the job queue's bulk tier
+func (s *ComplianceService) runSchedule(ctx context.Context, due time.Time, scheduled *scheduledRun) {
+    run, schedule := scheduled.run, scheduled.schedule
+
+    // The ceiling can hold a run past the start of a blackout window, so
+    // the windows are checked on every attempt
+    for {
+        if window, ok := blackoutAt(s.runtimeConfig(), schedule.tenant, time.Now()); ok {
+            run.Decision = scheduleSkippedBlackout
+            s.finishScheduleRun(ctx, run, scheduleSkipped, fmt.Sprintf("inside blackout window %s-%s UTC", window.Start, window.End))
+            return
+        }
+        now := time.Now()
+        acquired, err := scheduleSlotScript.Run(ctx, s.redis, []string{scheduleSlotsKey},
+            now.Unix(), now.Add(scheduleSlotLease).Unix(), s.config.Schedule.MaxConcurrentRuns, run.RunId).Bool()
+        if err != nil {
+            s.finishScheduleRun(ctx, run, scheduleFailed, fmt.Sprintf("concurrent-runs ceiling unavailable: %v", err))
+            return
+        }
+        if acquired {
+            break
+        }
+        select {
+        case <-ctx.Done():
+            return
+        case <-time.After(scheduleSlotPoll):
+        }
+    }
+    defer s.redis.ZRem(context.WithoutCancel(ctx), scheduleSlotsKey, run.RunId)
+
+    if queued := time.Since(due.Add(schedule.jitter)); queued > 0 {
+        run.QueuedSeconds = int64(queued / time.Second)
+    }
+    scheduleQueueWait.Observe(float64(run.QueuedSeconds))
+    run.StartedAt = time.Now().Unix()
+    run.RequestId = newRequestID()
+    run.Outcome = scheduleRunning
+    if err := s.schedules.Record(ctx, run); err != nil {
+        log.Printf("Failed to record schedule run %s: %v", run.RunId, err)
+    }
+    log.Printf("Scheduled run %s tenant=%s org=%s: delayed %ds by jitter, queued %ds for a slot",
+        run.RunId, schedule.tenant, redact(fieldOrganizationID, schedule.organizationID), run.JitterSeconds, run.QueuedSeconds)
+
+    md := metadata.Pairs(tenantMetadataKey, schedule.tenant, "x-request-id", run.RequestId)
+    response, err := s.jobs.Run(withBackgroundEvaluation(metadata.NewIncomingContext(ctx, md)), scheduled.req)
+    switch {
+    case err == errEvaluationInProgress:
+        s.finishScheduleRun(ctx, run, scheduleSkipped, "organization already being evaluated")
+    case err != nil:
+        s.finishScheduleRun(ctx, run, scheduleFailed, status.Convert(err).Message())
+    default:
+        run.Status = response.Status
+        s.finishScheduleRun(ctx, run, scheduleSucceeded, "")
+    }
+}
+
+func (s *ComplianceService) finishScheduleRun(ctx context.Context, run *ScheduleRun, outcome, reason string) {
+    run.Outcome = outcome
+    run.Error = reason
+    run.FinishedAt = time.Now().Unix()
+    ctx = context.WithoutCancel(ctx)
+    if err := s.schedules.Record(ctx, run); err != nil {
+        log.Printf("Failed to record schedule run %s: %v", run.RunId, err)
+    }
+    scheduleRuns.WithLabelValues(run.Decision, outcome).Inc()
+    if outcome != scheduleSucceeded {
+        log.Printf("Scheduled run %s tenant=%s org=%s %s (%s): %s",
+            run.RunId, run.TenantId, redact(fieldOrganizationID, run.OrganizationId), strings.ToLower(outcome), run.Decision, reason)
+    }
+}
+
+// ListScheduleRuns - the calling tenant's scheduled runs, newest first,
+// optionally of one organization, paginated by offset
+func (s *ComplianceService) ListScheduleRuns(ctx context.Context, req *ListScheduleRunsRequest) (*ListScheduleRunsResponse, error) {
+    if req.From < 0 || req.To < 0 || (req.To > 0 && req.From >= req.To) {
+        return nil, status.Error(codes.InvalidArgument, "range must satisfy 0 <= from < to")
+    }
+    tenant := tenantFromContext(ctx)
+    organizationID := req.OrganizationId
+    if organizationID != "" {
+        var err error
+        if organizationID, err = s.registry.ResolveID(ctx, organizationID); err != nil {
+            return nil, err
+        }
+    }
+
+    to := time.Now().Add(time.Second)
+    if req.To > 0 {
+        to = time.Unix(req.To, 0)
+    }
+    pageSize := int64(req.PageSize)
+    if pageSize <= 0 {
+        pageSize = scheduleRunsDefaultPageSize
+    }
+    if pageSize > scheduleRunsMaxPageSize {
+        pageSize = scheduleRunsMaxPageSize
+    }
+    var offset int64
+    if req.PageToken != "" {
+        var err error
+        if offset, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil || offset < 0 {
+            return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+        }
+    }
+
+    runs, more, err := s.schedules.List(ctx, tenant, organizationID, time.Unix(req.From, 0), to, offset, pageSize)
+    if err != nil {
+        return nil, storeError(err, "failed to load schedule runs")
+    }
+    response := &ListScheduleRunsResponse{Runs: runs}
+    if more {
+        response.NextPageToken = strconv.FormatInt(offset+pageSize, 10)
+    }
+    return response, nil
+}
+
+// Schedule metrics
+var (
+    scheduleRuns = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_schedule_runs_total",
+            Help: "Nightly scheduled runs by shaping decision and outcome",
+        },
+        []string{"decision", "outcome"},
+    )
+
+    scheduleQueueWait = prometheus.NewHistogram(
+        prometheus.HistogramOpts{
+            Name:    "compliance_schedule_queue_seconds",
+            Help:    "Time scheduled runs waited beyond their jittered start for a slot under the concurrent-runs ceiling",
+            Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(scheduleRuns)
+    prometheus.MustRegister(scheduleQueueWait)
+}
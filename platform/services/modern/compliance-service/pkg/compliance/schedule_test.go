@@ -0,0 +1,218 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/metadata"
+)
+
+// Counts response cache reads and writes by call, other keys passing through
+type responseCacheCalls struct {
+    Cache
+    mu                               sync.Mutex
+    gets, sets, batchGets, batchSets int
+    batchGetKeys, batchSetKeys       int
+}
+
+func (c *responseCacheCalls) Get(ctx context.Context, key string) ([]byte, error) {
+    if strings.HasPrefix(key, responseKeyPrefix) {
+        c.mu.Lock()
+        c.gets++
+        c.mu.Unlock()
+    }
+    return c.Cache.Get(ctx, key)
+}
+
+func (c *responseCacheCalls) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    if strings.HasPrefix(key, responseKeyPrefix) {
+        c.mu.Lock()
+        c.sets++
+        c.mu.Unlock()
+    }
+    return c.Cache.Set(ctx, key, value, ttl)
+}
+
+func (c *responseCacheCalls) BatchGet(ctx context.Context, keys []string) ([][]byte, error) {
+    if len(keys) > 0 && strings.HasPrefix(keys[0], responseKeyPrefix) {
+        c.mu.Lock()
+        c.batchGets++
+        c.batchGetKeys += len(keys)
+        c.mu.Unlock()
+    }
+    return c.Cache.BatchGet(ctx, keys)
+}
+
+func (c *responseCacheCalls) BatchSet(ctx context.Context, entries []CacheEntry) error {
+    if len(entries) > 0 && strings.HasPrefix(entries[0].Key, responseKeyPrefix) {
+        c.mu.Lock()
+        c.batchSets++
+        c.batchSetKeys += len(entries)
+        c.mu.Unlock()
+    }
+    return c.Cache.BatchSet(ctx, entries)
+}
+
+// Start the service's job queue, which scheduled runs evaluate through,
+// until the test ends
+func startJobs(t *testing.T, service *ComplianceService) {
+    ctx, cancel := context.WithCancel(context.Background())
+    t.Cleanup(cancel)
+    service.jobs.Start(ctx)
+}
+
+// Finished runs of a tenant's nightly batch once all expected have finished
+func awaitScheduleRuns(t *testing.T, service *ComplianceService, want int) map[string]*ScheduleRun {
+    t.Helper()
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenantMetadataKey, defaultTenant))
+    deadline := time.Now().Add(5 * time.Second)
+    for {
+        page, err := service.ListScheduleRuns(ctx, &ListScheduleRunsRequest{PageSize: 500})
+        if err != nil {
+            t.Fatal(err)
+        }
+        finished := make(map[string]*ScheduleRun)
+        for _, run := range page.Runs {
+            if run.Outcome != scheduleRunning && run.Outcome != "" {
+                finished[run.OrganizationId] = run
+            }
+        }
+        if len(finished) >= want {
+            return finished
+        }
+        if time.Now().After(deadline) {
+            t.Fatalf("%d of %d scheduled runs finished", len(finished), want)
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+}
+
+// Runs due together read their cached responses in one batch read; only
+// the misses are evaluated, and their responses are cached in one batch
+// write
+func TestNightlyGroupBatchesCacheAccess(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+        config.Schedule = ScheduleConfig{RunAt: "00:00", MaxConcurrentRuns: 2, RunRetention: time.Hour}
+    })
+    startJobs(t, service)
+    ctx := context.Background()
+    for _, organizationID := range []string{"org-1", "org-2", "org-3"} {
+        if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: organizationID}); err != nil {
+            t.Fatal(err)
+        }
+    }
+    // org-1 keeps its cached response; the others must be evaluated again
+    cached := cachedAggregate(t, service, server, "org-1")
+    dropCachedResults(server)
+    if err := service.cache.Set(ctx, responseKey(&ComplianceRequest{OrganizationId: "org-1"}), cached, time.Hour); err != nil {
+        t.Fatal(err)
+    }
+    var mu sync.Mutex
+    evaluated := make(map[string]int)
+    checker, _ := service.engine.Checker("SAMA")
+    service.engine.Register("SAMA", func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        mu.Lock()
+        evaluated[req.OrganizationId]++
+        mu.Unlock()
+        return checker(ctx, req)
+    })
+    calls := &responseCacheCalls{Cache: service.cache.backend}
+    service.cache.backend = calls
+
+    service.runNightlyBatch(ctx, time.Now())
+    runs := awaitScheduleRuns(t, service, 3)
+
+    for organizationID, run := range runs {
+        if run.Outcome != scheduleSucceeded || run.Status == "" {
+            t.Errorf("%s run %s with status %q: %s", organizationID, run.Outcome, run.Status, run.Error)
+        }
+    }
+    mu.Lock()
+    if evaluated["org-1"] != 0 || evaluated["org-2"]+evaluated["org-3"] == 0 {
+        t.Errorf("SAMA evaluated for %v, want only the misses", evaluated)
+    }
+    mu.Unlock()
+
+    // The group's write lands once every miss is done
+    deadline := time.Now().Add(2 * time.Second)
+    for {
+        calls.mu.Lock()
+        written := calls.batchSets
+        calls.mu.Unlock()
+        if written > 0 || time.Now().After(deadline) {
+            break
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    calls.mu.Lock()
+    defer calls.mu.Unlock()
+    if calls.batchGets != 1 || calls.batchGetKeys != 3 || calls.gets != 0 {
+        t.Errorf("%d batch reads of %d responses and %d single reads, want one batch read of 3", calls.batchGets, calls.batchGetKeys, calls.gets)
+    }
+    if calls.batchSets != 1 || calls.batchSetKeys != 2 || calls.sets != 0 {
+        t.Errorf("%d batch writes of %d responses and %d single writes, want one batch write of 2", calls.batchSets, calls.batchSetKeys, calls.sets)
+    }
+}
+
+// Interactive checks stay fast while a large nightly batch saturates the
+// shared evaluation limiter: the batch runs in the job queue's bulk tier,
+// so it never fills the limiter's queue and interactive work is not shed
+func TestNightlyBatchBoundsInteractiveLatency(t *testing.T) {
+    const (
+        batch = 60
+        work  = 20 * time.Millisecond
+    )
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+        config.WorkerPoolSize = 2
+        config.MaxQueuedEvaluations = 32
+        config.JobWorkers = 3
+        config.Schedule = ScheduleConfig{RunAt: "00:00", MaxConcurrentRuns: batch, RunRetention: time.Hour}
+    })
+    startJobs(t, service)
+    ctx := context.Background()
+    // Distinct SAMA evidence, so no run reuses another's framework result
+    for i := 0; i < batch; i++ {
+        organizationID := fmt.Sprintf("org-%d", i)
+        req := &ComplianceRequest{OrganizationId: organizationID, Evidence: []*EvidenceItem{{Key: "third_party_register", Value: organizationID}}}
+        if _, err := service.CheckCompliance(ctx, req); err != nil {
+            t.Fatal(err)
+        }
+    }
+    dropCachedResults(server)
+    service.faults.Set("SAMA", Fault{Delay: work})
+
+    service.runNightlyBatch(ctx, time.Now())
+    var worst time.Duration
+    var saturated bool
+    for i := 0; i < 10; i++ {
+        // Watch the limiter between checks rather than sampling it once
+        for deadline := time.Now().Add(work); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+            _, inUse, capacity := service.engine.scheduler.Stats()
+            saturated = saturated || inUse == capacity
+        }
+        start := time.Now()
+        if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: fmt.Sprintf("interactive-%d", i), Priority: 5}); err != nil {
+            t.Fatalf("interactive check during the batch: %v", err)
+        }
+        worst = max(worst, time.Since(start))
+    }
+    runs := awaitScheduleRuns(t, service, batch)
+
+    if !saturated {
+        t.Error("the batch never saturated the evaluation limiter")
+    }
+    if worst > 10*work {
+        t.Errorf("interactive check took %v during the batch, want under %v", worst, 10*work)
+    }
+    for organizationID, run := range runs {
+        if run.Outcome != scheduleSucceeded {
+            t.Errorf("%s run %s: %s", organizationID, run.Outcome, run.Error)
+        }
+    }
+}
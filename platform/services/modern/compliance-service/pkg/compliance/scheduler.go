@@ -0,0 +1,234 @@
+package compliance
+
+import (
+    "context"
+    "errors"
+    "math"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// Returned by Acquire when the queue is full or the wait limit passes
+var errLoadShed = errors.New("evaluation queue overloaded")
+
+// PriorityScheduler - shared pool of evaluation slots handed out by priority.
+// Waiting raises a request's effective priority by one per aging interval,
+// so bulk work cannot be starved indefinitely by interactive traffic. Under
+// overload the queue is bounded in length and in wait time, and excess work
+// is shed rather than queued without limit.
+type PriorityScheduler struct {
+    mu       sync.Mutex
+    capacity int
+    inUse    int
+    aging    time.Duration
+    maxQueue int           // Waiters beyond this are shed immediately, 0 is unbounded
+    maxWait  time.Duration // Waiters are shed after this long, 0 waits indefinitely
+    seq      uint64
+    waiters  []*slotWaiter
+}
+
+type slotWaiter struct {
+    priority int32
+    seq      uint64
+    enqueued time.Time
+    ready    chan struct{}
+}
+
+// Create a scheduler with capacity concurrent slots and shedding limits
+func NewPriorityScheduler(capacity int, aging time.Duration, maxQueue int, maxWait time.Duration) *PriorityScheduler {
+    if capacity < 1 {
+        capacity = 1
+    }
+    return &PriorityScheduler{capacity: capacity, aging: aging, maxQueue: maxQueue, maxWait: maxWait}
+}
+
+// Acquire blocks until a slot is granted, ctx is done, or the request is
+// shed with errLoadShed
+func (s *PriorityScheduler) Acquire(ctx context.Context, priority int32) error {
+    s.mu.Lock()
+    if s.inUse < s.capacity && len(s.waiters) == 0 {
+        s.inUse++
+        s.observeLocked()
+        s.mu.Unlock()
+        return nil
+    }
+    if s.maxQueue > 0 && len(s.waiters) >= s.maxQueue {
+        s.mu.Unlock()
+        schedulerShed.WithLabelValues(priorityClass(priority), "queue_full").Inc()
+        return errLoadShed
+    }
+
+    s.seq++
+    waiter := &slotWaiter{priority: priority, seq: s.seq, enqueued: time.Now(), ready: make(chan struct{})}
+    s.waiters = append(s.waiters, waiter)
+    s.observeLocked()
+    s.mu.Unlock()
+
+    var timeout <-chan time.Time
+    if s.maxWait > 0 {
+        timer := time.NewTimer(s.maxWait)
+        defer timer.Stop()
+        timeout = timer.C
+    }
+
+    select {
+    case <-waiter.ready:
+        schedulerWaits.get(priorityClass(priority)).Observe(time.Since(waiter.enqueued).Seconds())
+        return nil
+    case <-timeout:
+        schedulerShed.WithLabelValues(priorityClass(priority), "wait_exceeded").Inc()
+        return s.abandon(waiter, errLoadShed)
+    case <-ctx.Done():
+        return s.abandon(waiter, ctx.Err())
+    }
+}
+
+// Remove a waiter that gave up, returning err
+func (s *PriorityScheduler) abandon(waiter *slotWaiter, err error) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    for i, w := range s.waiters {
+        if w == waiter {
+            s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+            s.observeLocked()
+            return err
+        }
+    }
+    // Granted concurrently with giving up: hand the slot on
+    s.releaseLocked()
+    return err
+}
+
+// Suggested client back-off after being shed
+func (s *PriorityScheduler) RetryAfter() time.Duration {
+    if s.maxWait > time.Second {
+        return s.maxWait
+    }
+    return time.Second
+}
+
+// Release returns a slot to the pool, granting it to the best waiter
+func (s *PriorityScheduler) Release() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.releaseLocked()
+}
+
+func (s *PriorityScheduler) releaseLocked() {
+    if len(s.waiters) == 0 {
+        s.inUse--
+        s.observeLocked()
+        return
+    }
+
+    now := time.Now()
+    best := 0
+    for i := 1; i < len(s.waiters); i++ {
+        if s.before(s.waiters[i], s.waiters[best], now) {
+            best = i
+        }
+    }
+    waiter := s.waiters[best]
+    s.waiters = append(s.waiters[:best], s.waiters[best+1:]...)
+    s.observeLocked()
+    close(waiter.ready)
+}
+
+// Whether a should be granted before b, using aged priorities
+func (s *PriorityScheduler) before(a, b *slotWaiter, now time.Time) bool {
+    pa, pb := s.effectivePriority(a, now), s.effectivePriority(b, now)
+    if pa != pb {
+        return pa > pb
+    }
+    return a.seq < b.seq
+}
+
+func (s *PriorityScheduler) effectivePriority(w *slotWaiter, now time.Time) int64 {
+    p := int64(w.priority)
+    if s.aging > 0 {
+        p += int64(now.Sub(w.enqueued) / s.aging)
+    }
+    return p
+}
+
+// Waiting requests and slots in use
+func (s *PriorityScheduler) Stats() (waiting, inUse, capacity int) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return len(s.waiters), s.inUse, s.capacity
+}
+
+// Saturation normalizes load to [0, 1]: slot utilization fills the first
+// half and queue occupancy the second, so 0.5 means every slot is busy and
+// 1 means new work is being shed. An unbounded queue counts as full once
+// it is as long as the pool is wide.
+func (s *PriorityScheduler) Saturation() float64 {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.saturationLocked()
+}
+
+func (s *PriorityScheduler) saturationLocked() float64 {
+    queueLimit := s.maxQueue
+    if queueLimit <= 0 {
+        queueLimit = s.capacity
+    }
+    queued := math.Min(float64(len(s.waiters))/float64(queueLimit), 1)
+    return 0.5*float64(s.inUse)/float64(s.capacity) + 0.5*queued
+}
+
+// Refresh occupancy gauges; called with mu held whenever slots or waiters change
+func (s *PriorityScheduler) observeLocked() {
+    schedulerWaiting.Set(float64(len(s.waiters)))
+    schedulerSaturation.Set(s.saturationLocked())
+}
+
+func priorityClass(priority int32) string {
+    if priority > 0 {
+        return tierInteractive
+    }
+    return tierBulk
+}
+
+// Scheduler metrics
+var (
+    schedulerWaiting = prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "compliance_scheduler_waiting",
+            Help: "Framework evaluations waiting for a worker slot",
+        },
+    )
+
+    schedulerWait = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name: "compliance_scheduler_wait_seconds",
+            Help: "Time framework evaluations waited for a worker slot",
+        },
+        []string{"tier"},
+    )
+    schedulerWaits = newObserverCache(schedulerWait, tierInteractive, tierBulk)
+
+    schedulerSaturation = prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "compliance_saturation",
+            Help: "Normalized evaluation load from 0 (idle) to 1 (shedding), see GetCapacity",
+        },
+    )
+
+    schedulerShed = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_scheduler_shed_total",
+            Help: "Framework evaluations rejected under overload",
+        },
+        []string{"tier", "reason"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(schedulerWaiting)
+    prometheus.MustRegister(schedulerWait)
+    prometheus.MustRegister(schedulerShed)
+    prometheus.MustRegister(schedulerSaturation)
+}
@@ -0,0 +1,215 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Queue a waiter and report its name on granted once it holds a slot
+func acquireAsync(t *testing.T, s *PriorityScheduler, name string, priority int32, granted chan<- string) {
+    t.Helper()
+    waiting, _, _ := s.Stats()
+    go func() {
+        if err := s.Acquire(context.Background(), priority); err == nil {
+            granted <- name
+        }
+    }()
+    for deadline := time.Now().Add(time.Second); ; {
+        if now, _, _ := s.Stats(); now > waiting {
+            return
+        }
+        if time.Now().After(deadline) {
+            t.Fatalf("%s never queued", name)
+        }
+        time.Sleep(time.Millisecond)
+    }
+}
+
+// Order in which releasing the held slot one at a time grants waiters
+func grantOrder(s *PriorityScheduler, granted <-chan string, n int) []string {
+    var order []string
+    for i := 0; i < n; i++ {
+        s.Release()
+        order = append(order, <-granted)
+    }
+    return order
+}
+
+func TestSchedulerGrantsByPriority(t *testing.T) {
+    s := NewPriorityScheduler(1, 0, 0, 0)
+    s.Acquire(context.Background(), 0)
+    granted := make(chan string)
+
+    acquireAsync(t, s, "bulk-1", 0, granted)
+    acquireAsync(t, s, "bulk-2", 0, granted)
+    acquireAsync(t, s, "interactive", 5, granted)
+    acquireAsync(t, s, "bulk-3", 0, granted)
+
+    got := fmt.Sprint(grantOrder(s, granted, 4))
+    if want := "[interactive bulk-1 bulk-2 bulk-3]"; got != want {
+        t.Errorf("granted %s, want %s", got, want)
+    }
+}
+
+// A bulk waiter queued long enough outranks interactive work arriving later
+func TestSchedulerAgingPreventsStarvation(t *testing.T) {
+    s := NewPriorityScheduler(1, 10*time.Millisecond, 0, 0)
+    s.Acquire(context.Background(), 0)
+    granted := make(chan string)
+
+    acquireAsync(t, s, "bulk", 0, granted)
+    time.Sleep(60 * time.Millisecond)
+    acquireAsync(t, s, "interactive", 3, granted)
+
+    got := fmt.Sprint(grantOrder(s, granted, 2))
+    if want := "[bulk interactive]"; got != want {
+        t.Errorf("granted %s, want %s", got, want)
+    }
+}
+
+// Interactive requests arriving behind a deep bulk backlog wait about one
+// evaluation, not for the backlog to drain
+func TestSchedulerBoundsInteractiveLatency(t *testing.T) {
+    const (
+        workers = 2
+        work    = 5 * time.Millisecond
+        bulk    = 80
+    )
+    s := NewPriorityScheduler(workers, time.Minute, 0, 0)
+    evaluate := func(priority int32) time.Duration {
+        start := time.Now()
+        if err := s.Acquire(context.Background(), priority); err != nil {
+            t.Error(err)
+            return 0
+        }
+        waited := time.Since(start)
+        time.Sleep(work)
+        s.Release()
+        return waited
+    }
+
+    var wg sync.WaitGroup
+    for i := 0; i < bulk; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            evaluate(0)
+        }()
+    }
+    for waiting, _, _ := s.Stats(); waiting < bulk-workers; waiting, _, _ = s.Stats() {
+        time.Sleep(time.Millisecond)
+    }
+
+    // Draining the backlog takes bulk/workers*work = 200ms
+    var worst time.Duration
+    for i := 0; i < 5; i++ {
+        if waited := evaluate(10); waited > worst {
+            worst = waited
+        }
+    }
+    wg.Wait()
+    if limit := 8 * work; worst > limit {
+        t.Errorf("interactive request waited %v behind the bulk backlog, want under %v", worst, limit)
+    }
+}
+
+func TestSchedulerSheds(t *testing.T) {
+    tests := []struct {
+        name     string
+        maxQueue int
+        maxWait  time.Duration
+    }{
+        {name: "queue full", maxQueue: 1},
+        {name: "wait exceeded", maxWait: 20 * time.Millisecond},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            s := NewPriorityScheduler(1, 0, tt.maxQueue, tt.maxWait)
+            s.Acquire(context.Background(), 0)
+            if tt.maxQueue > 0 {
+                acquireAsync(t, s, "queued", 0, make(chan string, 1))
+            }
+            if err := s.Acquire(context.Background(), 0); err != errLoadShed {
+                t.Errorf("Acquire() = %v, want errLoadShed", err)
+            }
+        })
+    }
+}
+
+// A waiter whose caller gives up leaves the queue, and its slot is not lost
+func TestSchedulerCancelledWaiterLeavesQueue(t *testing.T) {
+    s := NewPriorityScheduler(1, 0, 0, 0)
+    s.Acquire(context.Background(), 0)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan error)
+    go func() { done <- s.Acquire(ctx, 0) }()
+    for waiting, _, _ := s.Stats(); waiting == 0; waiting, _, _ = s.Stats() {
+        time.Sleep(time.Millisecond)
+    }
+    cancel()
+    if err := <-done; err != context.Canceled {
+        t.Errorf("Acquire() = %v, want context.Canceled", err)
+    }
+
+    s.Release()
+    if waiting, inUse, _ := s.Stats(); waiting != 0 || inUse != 0 {
+        t.Errorf("%d waiting, %d in use after release, want 0 and 0", waiting, inUse)
+    }
+}
+
+// An evaluation shed by a saturated scheduler fails with ResourceExhausted
+// and tells the client when to retry: the wait limit, or a second when that
+// is shorter
+func TestCheckComplianceShedWhenSaturated(t *testing.T) {
+    tests := []struct {
+        name           string
+        maxQueue       int
+        maxWait        time.Duration
+        wantRetryAfter time.Duration
+    }{
+        {name: "queue full", maxQueue: 1, maxWait: 3 * time.Second, wantRetryAfter: 3 * time.Second},
+        {name: "wait exceeded", maxWait: 20 * time.Millisecond, wantRetryAfter: time.Second},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service, _ := newTestService(t, func(config *ServiceConfig) {
+                config.WorkerPoolSize = 1
+                config.MaxQueuedEvaluations = tt.maxQueue
+                config.MaxQueueWait = tt.maxWait
+            })
+            scheduler := service.engine.scheduler
+            scheduler.Acquire(context.Background(), 0)
+            if tt.maxQueue > 0 {
+                ctx, cancel := context.WithCancel(context.Background())
+                t.Cleanup(cancel)
+                for i := 0; i < tt.maxQueue; i++ {
+                    go scheduler.Acquire(ctx, 0)
+                }
+                for waiting, _, _ := scheduler.Stats(); waiting < tt.maxQueue; waiting, _, _ = scheduler.Stats() {
+                    time.Sleep(time.Millisecond)
+                }
+                if depth := testutil.ToFloat64(schedulerWaiting); depth != float64(tt.maxQueue) {
+                    t.Errorf("queue depth gauge %v, want %d", depth, tt.maxQueue)
+                }
+            }
+
+            info := &requestInfo{id: "req-1"}
+            ctx := context.WithValue(context.Background(), requestInfoKey{}, info)
+            _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"})
+            if st := status.Convert(err); st.Code() != codes.ResourceExhausted || errorReason(st) != reasonOverloaded {
+                t.Errorf("CheckCompliance() = %v, want ResourceExhausted %s", err, reasonOverloaded)
+            }
+            if info.retryAfter != tt.wantRetryAfter {
+                t.Errorf("retry-after %v, want %v", info.retryAfter, tt.wantRetryAfter)
+            }
+        })
+    }
+}
@@ -0,0 +1,155 @@
+package compliance
+
+import (
+    "fmt"
+    "math/rand"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/protobuf/proto"
+)
+
+// Result schema migration modes: which FrameworkResult shape is served over
+// gRPC and written to events while consumers move to typed details
+const (
+    resultSchemaLegacy = "legacy" // Flat fields only
+    resultSchemaDual   = "dual"   // Flat fields and typed details
+    resultSchemaNew    = "new"    // Typed details only
+)
+
+// Validate a result schema mode
+func validResultSchema(mode string) error {
+    switch mode {
+    case resultSchemaLegacy, resultSchemaDual, resultSchemaNew:
+        return nil
+    }
+    return fmt.Errorf("invalid result_schema %q, expected legacy, dual or new", mode)
+}
+
+// Copy of a response in the shape the mode calls for. Checkers, caches and
+// content hashes always work on typed details; only egress is shaped.
+func shapeResponse(response *ComplianceResponse, mode string) *ComplianceResponse {
+    if mode == resultSchemaNew {
+        return response
+    }
+    shaped := proto.Clone(response).(*ComplianceResponse)
+    for _, result := range shaped.FrameworkResults {
+        setLegacyFields(result)
+        if mode == resultSchemaLegacy {
+            result.Details = nil
+        }
+    }
+    return shaped
+}
+
+// Fill the legacy flat fields from typed details
+func setLegacyFields(result *FrameworkResult) {
+    switch details := result.Details.(type) {
+    case *FrameworkResult_NcaDetails:
+        result.RequirementsMet = details.NcaDetails.RequirementsMet
+        result.RequirementsTotal = details.NcaDetails.RequirementsTotal
+        result.CriticalIssues = details.NcaDetails.CriticalIssues
+    case *FrameworkResult_SamaDetails:
+        result.BaselCompliant = details.SamaDetails.BaselCompliant
+        result.AmlStatus = details.SamaDetails.AmlStatus
+    case *FrameworkResult_PdplDetails:
+        result.DataProtectionLevel = details.PdplDetails.DataProtectionLevel
+        result.ConsentManagement = details.PdplDetails.ConsentManagement
+    case *FrameworkResult_IsoDetails:
+        result.ControlsImplemented = details.IsoDetails.ControlsImplemented
+        result.ControlsTotal = details.IsoDetails.ControlsTotal
+    case *FrameworkResult_NistDetails:
+        result.Identify = details.NistDetails.IdentifyScore
+        result.Protect = details.NistDetails.ProtectScore
+        result.Detect = details.NistDetails.DetectScore
+        result.Respond = details.NistDetails.RespondScore
+        result.Recover = details.NistDetails.RecoverScore
+    }
+}
+
+// Typed details rebuilt from legacy flat fields, for records written before
+// the migration. Fields the flat shape never carried stay empty.
+func detailsFromLegacy(result *FrameworkResult) isFrameworkResult_Details {
+    switch result.Framework {
+    case "NCA":
+        return &FrameworkResult_NcaDetails{NcaDetails: &NCADetails{
+            RequirementsMet:   result.RequirementsMet,
+            RequirementsTotal: result.RequirementsTotal,
+            CriticalIssues:    result.CriticalIssues,
+        }}
+    case "SAMA":
+        return &FrameworkResult_SamaDetails{SamaDetails: &SAMADetails{
+            BaselCompliant: result.BaselCompliant,
+            AmlStatus:      result.AmlStatus,
+        }}
+    case "PDPL":
+        return &FrameworkResult_PdplDetails{PdplDetails: &PDPLDetails{
+            DataProtectionLevel: result.DataProtectionLevel,
+            ConsentManagement:   result.ConsentManagement,
+        }}
+    case "ISO27001":
+        return &FrameworkResult_IsoDetails{IsoDetails: &ISO27001Details{
+            ControlsImplemented: result.ControlsImplemented,
+            ControlsTotal:       result.ControlsTotal,
+        }}
+    case "NIST":
+        return &FrameworkResult_NistDetails{NistDetails: &NISTDetails{
+            IdentifyScore: result.Identify,
+            ProtectScore:  result.Protect,
+            DetectScore:   result.Detect,
+            RespondScore:  result.Respond,
+            RecoverScore:  result.Recover,
+        }}
+    }
+    return nil
+}
+
+// Verify a sample of dual-written responses: the flat fields must match the
+// typed details they were derived from, and must survive a round trip
+// through typed details unchanged
+func verifyDualWritten(response *ComplianceResponse, sampleRate float64) {
+    if sampleRate <= 0 || rand.Float64() >= sampleRate {
+        return
+    }
+    for _, written := range response.FrameworkResults {
+        if !scoredResult(written) {
+            continue
+        }
+        outcome := "lossless"
+
+        derived := &FrameworkResult{Framework: written.Framework, Details: written.Details}
+        setLegacyFields(derived)
+        roundTrip := &FrameworkResult{Framework: written.Framework, Details: detailsFromLegacy(written)}
+        setLegacyFields(roundTrip)
+
+        if !proto.Equal(legacyOnly(derived), legacyOnly(written)) {
+            outcome = "inconsistent"
+        } else if !proto.Equal(legacyOnly(roundTrip), legacyOnly(written)) {
+            outcome = "lossy"
+        }
+        schemaRoundTrips.WithLabelValues(written.Framework, outcome).Inc()
+    }
+}
+
+// A result reduced to its framework and legacy flat fields
+func legacyOnly(result *FrameworkResult) *FrameworkResult {
+    reduced := proto.Clone(result).(*FrameworkResult)
+    reduced.Score = 0
+    reduced.Details = nil
+    reduced.Trend = nil
+    return reduced
+}
+
+// Schema migration metrics
+var (
+    schemaRoundTrips = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_schema_roundtrip_checks_total",
+            Help: "Sampled dual-written results by round-trip outcome",
+        },
+        []string{"framework", "outcome"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(schemaRoundTrips)
+}
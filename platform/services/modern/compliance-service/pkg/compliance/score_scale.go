@@ -0,0 +1,167 @@
+package compliance
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// Score scale kinds
+const (
+    scaleLinear = "linear"
+    scaleBanded = "banded"
+    scaleLookup = "lookup"
+)
+
+// Evidence carrying a native maturity value, read by the built-in checkers
+const (
+    samaMaturityEvidence = "sama_maturity_level"
+    nistTierEvidence     = "nist_csf_tier"
+)
+
+// ScoreScale - how a framework's native scale maps onto the 0-100 score.
+// linear maps [Min, Max] proportionally; banded gives a native value up to
+// Max the score of the highest band starting at or below it; lookup gives
+// each whole native level the score in its table. Native values outside
+// the scale are rejected.
+type ScoreScale struct {
+    Name     string          // Reported as the result's native_scale
+    Kind     string
+    Min, Max float64         // linear; Max also tops a banded scale
+    Bands    []ScoreBand     // banded, ascending by From
+    Table    map[int]float64 // lookup
+}
+
+// ScoreBand - the score of native values from From up to the next band
+type ScoreBand struct {
+    From  float64
+    Score float64
+}
+
+// Native scales of the built-in ruleset. SAMA maturity level 3, the level
+// SAMA expects of member organizations, scores exactly the default
+// partially compliant threshold; NIST CSF tiers may be fractional when
+// averaged across functions and score by the tier they reach.
+var builtinScoreScales = map[string]ScoreScale{
+    "SAMA": {
+        Name:  "SAMA maturity 0-5",
+        Kind:  scaleLookup,
+        Table: map[int]float64{0: 0, 1: 20, 2: 45, 3: 70, 4: 90, 5: 100},
+    },
+    "NIST": {
+        Name:  "NIST CSF tier 0-4",
+        Kind:  scaleBanded,
+        Bands: []ScoreBand{{From: 0, Score: 0}, {From: 1, Score: 40}, {From: 2, Score: 65}, {From: 3, Score: 85}, {From: 4, Score: 100}},
+        Max:   4,
+    },
+}
+
+// Validate a scale: scores within [0, 100] and, for linear, a non-empty
+// range; for banded, ascending bands ending at or below Max; for lookup, a
+// non-empty table
+func (s ScoreScale) Validate(framework string) error {
+    if s.Name == "" {
+        return fmt.Errorf("score scale for %s has no name", framework)
+    }
+    validScore := func(score float64) bool { return score >= 0 && score <= 100 }
+    switch s.Kind {
+    case scaleLinear:
+        if !(s.Max > s.Min) {
+            return fmt.Errorf("linear score scale for %s must have max above min", framework)
+        }
+    case scaleBanded:
+        if len(s.Bands) == 0 {
+            return fmt.Errorf("banded score scale for %s has no bands", framework)
+        }
+        for i, band := range s.Bands {
+            if i > 0 && band.From <= s.Bands[i-1].From {
+                return fmt.Errorf("banded score scale for %s must list bands in ascending order", framework)
+            }
+            if !validScore(band.Score) {
+                return fmt.Errorf("banded score scale for %s scores outside [0, 100]", framework)
+            }
+        }
+        if s.Max < s.Bands[len(s.Bands)-1].From {
+            return fmt.Errorf("banded score scale for %s must have max at or above its last band", framework)
+        }
+    case scaleLookup:
+        if len(s.Table) == 0 {
+            return fmt.Errorf("lookup score scale for %s has no levels", framework)
+        }
+        for level, score := range s.Table {
+            if !validScore(score) {
+                return fmt.Errorf("lookup score scale for %s scores level %d outside [0, 100]", framework, level)
+            }
+        }
+    default:
+        return fmt.Errorf("score scale for %s has unknown kind %q", framework, s.Kind)
+    }
+    return nil
+}
+
+// Normalize a native value onto the 0-100 score
+func (s ScoreScale) Normalize(native float64) (float64, error) {
+    if math.IsNaN(native) || math.IsInf(native, 0) {
+        return 0, fmt.Errorf("native score %v is not a number", native)
+    }
+    switch s.Kind {
+    case scaleLinear:
+        if native < s.Min || native > s.Max {
+            return 0, fmt.Errorf("native score %v outside %s", native, s.Name)
+        }
+        return (native - s.Min) / (s.Max - s.Min) * 100, nil
+    case scaleBanded:
+        i := sort.Search(len(s.Bands), func(i int) bool { return s.Bands[i].From > native })
+        if i == 0 || native > s.Max {
+            return 0, fmt.Errorf("native score %v outside %s", native, s.Name)
+        }
+        return s.Bands[i-1].Score, nil
+    case scaleLookup:
+        if native != math.Trunc(native) {
+            return 0, fmt.Errorf("native score %v is not a level of %s", native, s.Name)
+        }
+        score, ok := s.Table[int(native)]
+        if !ok {
+            return 0, fmt.Errorf("native score %v is not a level of %s", native, s.Name)
+        }
+        return score, nil
+    }
+    return 0, fmt.Errorf("unknown score scale kind %q", s.Kind)
+}
+
+// Replace the score of a result reporting a native value with the value
+// normalized by the framework's scale. A native value without a scale, or
+// outside it, fails the evaluation.
+func (e *RulesEngine) normalizeScore(framework string, result *FrameworkResult) error {
+    if result.NativeScore == nil {
+        return nil
+    }
+    scale, ok := e.scales[framework]
+    if !ok {
+        return fmt.Errorf("native score reported without a score scale")
+    }
+    score, err := scale.Normalize(*result.NativeScore)
+    if err != nil {
+        return err
+    }
+    result.Score = score
+    result.NativeScale = scale.Name
+    return nil
+}
+
+// Native value supplied as numeric evidence, if any
+func nativeEvidence(req *ComplianceRequest, key string) (*float64, bool) {
+    for _, item := range req.Evidence {
+        if item.Key != key {
+            continue
+        }
+        value, err := strconv.ParseFloat(strings.TrimSpace(item.Value), 64)
+        if err != nil {
+            return nil, false
+        }
+        return &value, true
+    }
+    return nil, false
+}
@@ -0,0 +1,129 @@
+package compliance
+
+import (
+    "context"
+    "math"
+    "testing"
+)
+
+// Each kind of scale maps its bottom to 0 and its top to its highest
+// score, and rejects a native value just past either end
+func TestScoreScaleBoundaries(t *testing.T) {
+    const tick = 1e-9
+    linear := ScoreScale{Name: "linear 0-4", Kind: scaleLinear, Min: 0, Max: 4}
+    tests := []struct {
+        name      string
+        scale     ScoreScale
+        native    float64
+        wantScore float64
+        wantErr   bool
+    }{
+        {name: "linear at min", scale: linear, native: 0, wantScore: 0},
+        {name: "linear midway", scale: linear, native: 2, wantScore: 50},
+        {name: "linear at max", scale: linear, native: 4, wantScore: 100},
+        {name: "linear just below min", scale: linear, native: -tick, wantErr: true},
+        {name: "linear just past max", scale: linear, native: 4 + tick, wantErr: true},
+
+        {name: "NIST tier 0", scale: builtinScoreScales["NIST"], native: 0, wantScore: 0},
+        {name: "NIST just below tier 1", scale: builtinScoreScales["NIST"], native: 1 - tick, wantScore: 0},
+        {name: "NIST tier 1", scale: builtinScoreScales["NIST"], native: 1, wantScore: 40},
+        {name: "NIST just below tier 4", scale: builtinScoreScales["NIST"], native: 4 - tick, wantScore: 85},
+        {name: "NIST tier 4", scale: builtinScoreScales["NIST"], native: 4, wantScore: 100},
+        {name: "NIST just below tier 0", scale: builtinScoreScales["NIST"], native: -tick, wantErr: true},
+        {name: "NIST just past tier 4", scale: builtinScoreScales["NIST"], native: 4 + tick, wantErr: true},
+
+        {name: "SAMA level 0", scale: builtinScoreScales["SAMA"], native: 0, wantScore: 0},
+        {name: "SAMA level 3, the partially compliant threshold", scale: builtinScoreScales["SAMA"], native: 3, wantScore: 70},
+        {name: "SAMA level 5", scale: builtinScoreScales["SAMA"], native: 5, wantScore: 100},
+        {name: "SAMA between levels", scale: builtinScoreScales["SAMA"], native: 3 + tick, wantErr: true},
+        {name: "SAMA level -1", scale: builtinScoreScales["SAMA"], native: -1, wantErr: true},
+        {name: "SAMA level 6, past the top", scale: builtinScoreScales["SAMA"], native: 6, wantErr: true},
+
+        {name: "not a number", scale: linear, native: math.NaN(), wantErr: true},
+        {name: "infinite", scale: builtinScoreScales["NIST"], native: math.Inf(1), wantErr: true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            score, err := tt.scale.Normalize(tt.native)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("Normalize(%v) error = %v, want error %v", tt.native, err, tt.wantErr)
+            }
+            if !tt.wantErr && score != tt.wantScore {
+                t.Errorf("Normalize(%v) = %v, want %v", tt.native, score, tt.wantScore)
+            }
+        })
+    }
+}
+
+func TestScoreScaleValidate(t *testing.T) {
+    tests := []struct {
+        name    string
+        scale   ScoreScale
+        wantErr bool
+    }{
+        {name: "builtin NIST", scale: builtinScoreScales["NIST"]},
+        {name: "builtin SAMA", scale: builtinScoreScales["SAMA"]},
+        {name: "linear without range", scale: ScoreScale{Name: "flat", Kind: scaleLinear, Min: 2, Max: 2}, wantErr: true},
+        {
+            name:    "banded topped below its last band",
+            scale:   ScoreScale{Name: "tiers", Kind: scaleBanded, Bands: []ScoreBand{{From: 0, Score: 0}, {From: 4, Score: 100}}, Max: 3},
+            wantErr: true,
+        },
+        {
+            name:    "banded out of order",
+            scale:   ScoreScale{Name: "tiers", Kind: scaleBanded, Bands: []ScoreBand{{From: 2, Score: 50}, {From: 1, Score: 20}}, Max: 4},
+            wantErr: true,
+        },
+        {name: "lookup scoring past 100", scale: ScoreScale{Name: "levels", Kind: scaleLookup, Table: map[int]float64{1: 101}}, wantErr: true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if err := tt.scale.Validate("TEST"); (err != nil) != tt.wantErr {
+                t.Errorf("Validate() = %v, want error %v", err, tt.wantErr)
+            }
+        })
+    }
+}
+
+// A native value at the top of its scale reaches the response normalized,
+// alongside the native value; one past the top is not scored
+func TestNativeScoreNormalizedInResponse(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    tests := []struct {
+        name       string
+        tier       string
+        wantScored bool
+    }{
+        {name: "tier 4", tier: "4", wantScored: true},
+        {name: "past tier 4", tier: "4.5"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            response, err := service.CheckCompliance(context.Background(), &ComplianceRequest{
+                OrganizationId: "org-" + tt.tier,
+                Frameworks:     []string{"NIST"},
+                Evidence:       []*EvidenceItem{{Key: nistTierEvidence, Value: tt.tier}},
+            })
+            if err != nil {
+                t.Fatal(err)
+            }
+            var nist *FrameworkResult
+            for _, result := range response.FrameworkResults {
+                if result.Framework == "NIST" && scoredResult(result) {
+                    nist = result
+                }
+            }
+            if !tt.wantScored {
+                if nist != nil {
+                    t.Errorf("NIST scored %v at tier %s, want it left unscored", nist.Score, tt.tier)
+                }
+                return
+            }
+            if nist == nil || nist.Score != 100 || nist.NativeScore == nil || *nist.NativeScore != 4 || nist.NativeScale != builtinScoreScales["NIST"].Name {
+                t.Errorf("NIST result %v, want native tier 4 scored 100", nist)
+            }
+        })
+    }
+}
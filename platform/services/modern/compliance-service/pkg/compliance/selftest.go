@@ -0,0 +1,149 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "math"
+    "os"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/protobuf/encoding/protojson"
+)
+
+// Default allowed difference between expected and evaluated scores
+const defaultSelfTestTolerance = 0.01
+
+// SelfTestConfig - synthetic evaluation run before the service reports ready
+type SelfTestConfig struct {
+    // JSON fixture: a request and the result it must evaluate to; empty
+    // disables the self-test
+    Fixture string `env:"SELF_TEST_FIXTURE"`
+
+    // Longest the synthetic evaluation may take
+    Timeout time.Duration `env:"SELF_TEST_TIMEOUT"`
+}
+
+// selfTestFixture - a known organization's request and expected result.
+// Evidence should not expire, or the expectations drift with the clock.
+type selfTestFixture struct {
+    Request   json.RawMessage `json:"request"` // ComplianceRequest in proto JSON
+    Expect    selfTestExpect  `json:"expect"`
+    Tolerance float64         `json:"tolerance"`
+
+    request *ComplianceRequest
+}
+
+type selfTestExpect struct {
+    OverallScore *float64          `json:"overall_score"`
+    Status       string             `json:"status"`
+    Frameworks   map[string]float64 `json:"frameworks"` // Score per framework
+}
+
+// Load and check a self-test fixture; nil when none is configured
+func loadSelfTestFixture(path string, engine *RulesEngine) (*selfTestFixture, error) {
+    if path == "" {
+        return nil, nil
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read self-test fixture: %v", err)
+    }
+    fixture := &selfTestFixture{}
+    if err := json.Unmarshal(data, fixture); err != nil {
+        return nil, fmt.Errorf("failed to parse self-test fixture: %v", err)
+    }
+    fixture.request = &ComplianceRequest{}
+    if err := protojson.Unmarshal(fixture.Request, fixture.request); err != nil {
+        return nil, fmt.Errorf("invalid self-test request: %v", err)
+    }
+    if fixture.request.OrganizationId == "" {
+        return nil, fmt.Errorf("invalid self-test request: organization_id is required")
+    }
+    if fixture.request.EvidenceRef != "" {
+        return nil, fmt.Errorf("invalid self-test request: evidence must be inline")
+    }
+    if fixture.Tolerance < 0 {
+        return nil, fmt.Errorf("invalid self-test fixture: tolerance must not be negative")
+    }
+    if fixture.Tolerance == 0 {
+        fixture.Tolerance = defaultSelfTestTolerance
+    }
+    if fixture.Expect.OverallScore == nil && fixture.Expect.Status == "" && len(fixture.Expect.Frameworks) == 0 {
+        return nil, fmt.Errorf("invalid self-test fixture: expect names nothing to check")
+    }
+    for framework := range fixture.Expect.Frameworks {
+        if !containsString(engine.Frameworks(), framework) {
+            return nil, fmt.Errorf("invalid self-test fixture: framework %s not registered", framework)
+        }
+    }
+    return fixture, nil
+}
+
+// Evaluate the fixture request through the rules engine and scoring under
+// the current runtime config, and compare the response with the fixture's
+// expectations. Nothing is cached, recorded or published.
+func (s *ComplianceService) runSelfTest(ctx context.Context) error {
+    fixture := s.selfTest
+    if fixture == nil {
+        return nil
+    }
+    if s.config.SelfTest.Timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, s.config.SelfTest.Timeout)
+        defer cancel()
+    }
+
+    start := time.Now()
+    runtime := s.runtimeConfig()
+    results, err := s.engine.EvaluateAll(ctx, fixture.request, s.engine.insufficientDataResults(runtime.MinimumInputs, fixture.request.Evidence, start))
+    if err != nil {
+        return fmt.Errorf("evaluation failed: %v", err)
+    }
+    response := s.buildResponse(fixture.request.OrganizationId, results, fixture.request.Evidence, runtime)
+
+    var mismatches []string
+    if want := fixture.Expect.OverallScore; want != nil && math.Abs(response.OverallScore-*want) > fixture.Tolerance {
+        mismatches = append(mismatches, fmt.Sprintf("overall score %v, want %v", response.OverallScore, *want))
+    }
+    if want := fixture.Expect.Status; want != "" && response.Status != want {
+        mismatches = append(mismatches, fmt.Sprintf("status %s, want %s", response.Status, want))
+    }
+    frameworks := make([]string, 0, len(fixture.Expect.Frameworks))
+    for framework := range fixture.Expect.Frameworks {
+        frameworks = append(frameworks, framework)
+    }
+    sort.Strings(frameworks)
+    for _, framework := range frameworks {
+        want := fixture.Expect.Frameworks[framework]
+        result := findFrameworkResult(response.FrameworkResults, framework)
+        if result == nil {
+            mismatches = append(mismatches, fmt.Sprintf("%s not evaluated", framework))
+        } else if math.Abs(result.Score-want) > fixture.Tolerance {
+            mismatches = append(mismatches, fmt.Sprintf("%s score %v, want %v", framework, result.Score, want))
+        }
+    }
+    if len(mismatches) > 0 {
+        return fmt.Errorf("%s", strings.Join(mismatches, "; "))
+    }
+    log.Printf("Startup self-test passed in %s", time.Since(start).Round(time.Millisecond))
+    return nil
+}
+
+// Self-test metrics
+var (
+    selfTestPassed = prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "compliance_self_test_passed",
+            Help: "Whether the startup self-test matched its fixture (1) or not (0)",
+        },
+    )
+)
+
+func init() {
+    prometheus.MustRegister(selfTestPassed)
+}
@@ -0,0 +1,125 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "google.golang.org/grpc/health"
+    "google.golang.org/grpc/health/grpc_health_v1"
+    "google.golang.org/protobuf/encoding/protojson"
+)
+
+const selfTestRequest = `{"organizationId": "fixture-org", "evidence": [
+    {"key": "asset_inventory", "value": "true"},
+    {"key": "mfa_enforced", "value": "true"},
+    {"key": "aml_program", "value": "true"},
+    {"key": "dpo_appointed", "value": "false"}
+]}`
+
+func writeFixture(t *testing.T, fixture string) string {
+    path := filepath.Join(t.TempDir(), "fixture.json")
+    if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    return path
+}
+
+// Fixture expecting what the built-in rules give the fixture request
+func expectedFixture(t *testing.T) string {
+    service, _ := newTestService(t)
+    req := &ComplianceRequest{}
+    if err := protojson.Unmarshal([]byte(selfTestRequest), req); err != nil {
+        t.Fatal(err)
+    }
+    response, err := service.CheckCompliance(context.Background(), req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    expect := selfTestExpect{OverallScore: &response.OverallScore, Status: response.Status, Frameworks: make(map[string]float64)}
+    for _, result := range response.FrameworkResults {
+        expect.Frameworks[result.Framework] = result.Score
+    }
+    data, err := json.Marshal(map[string]interface{}{"request": json.RawMessage(selfTestRequest), "expect": expect})
+    if err != nil {
+        t.Fatal(err)
+    }
+    return string(data)
+}
+
+// Readiness as reportReady leaves it, with no warmup
+func reportedReady(t *testing.T, service *ComplianceService) bool {
+    service.ready.Store(false)
+    healthServer := health.NewServer()
+    healthServer.SetServingStatus("compliance", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+    service.reportReady(context.Background(), time.Minute, healthServer)
+    serving := servingStatus(t, healthServer) == grpc_health_v1.HealthCheckResponse_SERVING
+    if serving != service.ready.Load() {
+        t.Errorf("health serving %t but ready %t", serving, service.ready.Load())
+    }
+    return serving
+}
+
+func TestSelfTestGatesReadiness(t *testing.T) {
+    fixture := writeFixture(t, expectedFixture(t))
+
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.SelfTest.Fixture = fixture
+    })
+    if !reportedReady(t, service) || testutil.ToFloat64(selfTestPassed) != 1 {
+        t.Error("not ready although the rules match the fixture")
+    }
+
+    // A wrong SAMA ruleset no longer scores the fixture as expected
+    broken, _ := newTestService(t, func(config *ServiceConfig) {
+        config.SelfTest.Fixture = fixture
+    })
+    broken.engine.Register("SAMA", func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        return &FrameworkResult{Framework: "SAMA", Score: 100}
+    })
+    if reportedReady(t, broken) || testutil.ToFloat64(selfTestPassed) != 0 {
+        t.Error("ready although the SAMA ruleset no longer matches the fixture")
+    }
+    if err := broken.runSelfTest(context.Background()); err == nil {
+        t.Error("runSelfTest() passed with a broken ruleset")
+    }
+}
+
+// Without a fixture the self-test is skipped
+func TestSelfTestDisabled(t *testing.T) {
+    service, _ := newTestService(t)
+    if !reportedReady(t, service) {
+        t.Error("not ready without a self-test fixture")
+    }
+}
+
+func TestSelfTestFixtureRejected(t *testing.T) {
+    tests := []struct {
+        name    string
+        fixture string
+    }{
+        {name: "malformed", fixture: `{"request":`},
+        {name: "no organization", fixture: `{"request": {}, "expect": {"status": "COMPLIANT"}}`},
+        {name: "evidence by reference", fixture: `{"request": {"organizationId": "o", "evidenceRef": "s3://x"}, "expect": {"status": "COMPLIANT"}}`},
+        {name: "negative tolerance", fixture: `{"request": {"organizationId": "o"}, "expect": {"status": "COMPLIANT"}, "tolerance": -1}`},
+        {name: "nothing expected", fixture: `{"request": {"organizationId": "o"}, "expect": {}}`},
+        {name: "unknown framework", fixture: `{"request": {"organizationId": "o"}, "expect": {"frameworks": {"GDPR": 50}}}`},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := ConfigFromEnv()
+            config.RedisAddr = miniredis.RunT(t).Addr()
+            config.KafkaAddr = "127.0.0.1:1"
+            config.SelfTest.Fixture = writeFixture(t, tt.fixture)
+            if _, err := New(config); err == nil || !strings.Contains(err.Error(), "self-test") {
+                t.Errorf("New() = %v, want the fixture rejected", err)
+            }
+        })
+    }
+}
@@ -0,0 +1,187 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "net"
+    "net/http"
+    "time"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/health"
+    "google.golang.org/grpc/health/grpc_health_v1"
+    "google.golang.org/grpc/orca"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Start - flush usage accounting and run async jobs in the background until
+// ctx is done; Wait returns once they have stopped
+func (s *ComplianceService) Start(ctx context.Context) {
+    s.goWorker(func() { s.usage.Run(ctx) })
+    s.goWorker(func() { s.anomalies.Run(ctx, s.engine.Frameworks()) })
+    s.goWorker(func() { s.rollups.Run(ctx) })
+    s.goWorker(func() { s.latest.Run(ctx) })
+    s.goWorker(func() { s.operational.Run(ctx, s.rebuildRuntimeConfig) })
+    s.goWorker(func() { s.registry.Run(ctx) })
+    s.goWorker(func() { s.backfillStatusTimeline(ctx) })
+    s.goWorker(func() { s.runSchedules(ctx) })
+    s.goWorker(func() { s.runHistoryPruning(ctx) })
+    s.goWorker(func() { s.events.Run(ctx) })
+    s.goWorker(func() { s.mirror.Run(ctx) })
+    s.jobs.Start(ctx)
+}
+
+// Wait - block until the background workers have returned after their
+// context is done. The usage tracker flushes its last batch and the event
+// spool writes events still in memory to disk on the way out.
+func (s *ComplianceService) Wait() {
+    s.workers.Wait()
+}
+
+func (s *ComplianceService) goWorker(run func()) {
+    s.workers.Add(1)
+    go func() {
+        defer s.workers.Done()
+        run()
+    }()
+}
+
+// Engine - the rules engine the service evaluates with
+func (s *ComplianceService) Engine() *RulesEngine {
+    return s.engine
+}
+
+// ServerOptions - interceptors, compression and load reporting the service
+// expects of the gRPC server it is registered on
+func (s *ComplianceService) ServerOptions() []grpc.ServerOption {
+    unary := []grpc.UnaryServerInterceptor{s.sampler.UnaryInterceptor, s.errorInterceptor, s.tenantAuthInterceptor, s.loadReportInterceptor}
+    stream := []grpc.StreamServerInterceptor{s.streamErrorInterceptor, s.streamTenantAuthInterceptor}
+    if !s.config.GRPCCompression {
+        unary = append(unary, uncompressedUnaryInterceptor)
+        stream = append(stream, uncompressedStreamInterceptor)
+    }
+    return []grpc.ServerOption{
+        grpc.ChainUnaryInterceptor(unary...),
+        grpc.ChainStreamInterceptor(stream...),
+        orca.CallMetricsServerOption(nil),
+    }
+}
+
+// RegisterGRPC - register the compliance API on a gRPC server
+func (s *ComplianceService) RegisterGRPC(srv grpc.ServiceRegistrar) {
+    RegisterComplianceServer(srv, s)
+}
+
+// Serve - run the service on its configured ports until ctx is done: gRPC,
+// the HTTP gateway, metrics scraping and push, the admin listener and the
+// Kafka consumer.
+// Cancelling ctx stops intake so the consumer leaves its group cleanly,
+// drains in-flight requests, then stops the background workers and waits
+// for them, so nothing they hold is lost on exit.
+func (s *ComplianceService) Serve(ctx context.Context) error {
+    config := s.config
+    pusher, err := newMetricsPusher(config.Name, config.Version, config.MetricsExport)
+    if err != nil {
+        return err
+    }
+    // Workers outlive ctx until requests have drained, since those still
+    // record usage and publish events
+    workers, stopWorkers := context.WithCancel(context.Background())
+    defer func() {
+        stopWorkers()
+        s.Wait()
+    }()
+    s.Start(workers)
+
+    // Consume compliance requests from Kafka when configured
+    consumerDone := make(chan struct{})
+    if config.Consumer.RequestTopic != "" {
+        consumer := NewRequestConsumer(s, config.KafkaAddr, config.Consumer)
+        go func() {
+            defer close(consumerDone)
+            consumer.Run(ctx)
+        }()
+    } else {
+        close(consumerDone)
+    }
+
+    // Start metrics server, serving only /metrics and probes; /metrics is
+    // left out when metrics are only pushed
+    var scraped http.Handler
+    if config.MetricsExport.Prometheus {
+        scraped = promhttp.Handler()
+    }
+    go func() {
+        log.Printf("Metrics server listening on :%s", config.MetricsPort)
+        http.ListenAndServe(":"+config.MetricsPort, newMetricsMux(s, scraped))
+    }()
+
+    // Push metrics over OTLP when configured, flushing once more on shutdown
+    s.goWorker(func() { pusher.Run(workers) })
+
+    // Start admin server when configured
+    if config.Admin.Port != "" {
+        go func() {
+            log.Printf("Admin server listening on :%s", config.Admin.Port)
+            if err := NewAdminServer(s, config.Admin).ListenAndServe(); err != nil {
+                log.Printf("Admin server stopped: %v", err)
+            }
+        }()
+    }
+
+    // Start HTTP/JSON gateway
+    gateway := &http.Server{Addr: ":" + config.HTTPPort, Handler: NewGateway(s)}
+    go func() {
+        log.Printf("HTTP gateway listening on :%s", config.HTTPPort)
+        if err := gateway.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+            log.Printf("HTTP gateway stopped: %v", err)
+        }
+    }()
+
+    // Create gRPC server
+    lis, err := net.Listen("tcp", ":"+config.Port)
+    if err != nil {
+        return fmt.Errorf("failed to listen: %v", err)
+    }
+
+    grpcServer := grpc.NewServer(s.ServerOptions()...)
+    s.RegisterGRPC(grpcServer)
+
+    // Register health check
+    healthServer := health.NewServer()
+    grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+    healthServer.SetServingStatus("compliance", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+    go s.reportReady(context.Background(), config.Warmup.MaxDuration, healthServer)
+
+    // On shutdown, finish the in-flight Kafka message before draining
+    // gateway requests and RPCs
+    go func() {
+        <-ctx.Done()
+        log.Printf("Shutting down")
+        healthServer.SetServingStatus("compliance", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+        <-consumerDone
+        if err := gateway.Shutdown(context.Background()); err != nil {
+            log.Printf("HTTP gateway shutdown failed: %v", err)
+        }
+        grpcServer.GracefulStop()
+    }()
+
+    log.Printf("Compliance service listening on :%s", config.Port)
+    return grpcServer.Serve(lis)
+}
+
+// Report ready once the cache is primed, or straight away without warmup,
+// unless the self-test shows a broken ruleset or wiring
+func (s *ComplianceService) reportReady(ctx context.Context, warmupMax time.Duration, healthServer *health.Server) {
+    s.warmUp(ctx, s.warmup, warmupMax)
+    if err := s.runSelfTest(ctx); err != nil {
+        selfTestPassed.Set(0)
+        log.Printf("Startup self-test failed, not reporting ready: %v", err)
+        return
+    }
+    selfTestPassed.Set(1)
+    healthServer.SetServingStatus("compliance", grpc_health_v1.HealthCheckResponse_SERVING)
+    s.ready.Store(true)
+}
@@ -0,0 +1,1385 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "net/http"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/events"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/emptypb"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ComplianceService - Modern microservice for compliance checking
+type ComplianceService struct {
+    UnimplementedComplianceServer
+    cache          *ResponseCache
+    kafkaProducer  *events.Producer
+    engine         *RulesEngine
+    redis          *redis.Client
+    usage          *UsageTracker
+    limiter        RateLimiter
+    faults         *FaultInjector
+    events         *EventPublisher
+    runtime        atomic.Pointer[RuntimeConfig]
+    registry       *OrganizationRegistry
+    jobs           *JobQueue
+    evidence       *EvidenceStore
+    sampler        *TraceSampler
+    anomalies      *AnomalyDetector
+    delegates      delegatePrincipals
+    rollups        *RollupAggregator
+    history        *ScoreHistory
+    locks          *EvaluationLocks
+    replays        *EvaluationStore
+    runTimings     *RunTimingsStore
+    latest         *LatestResults
+    timeline       *StatusTimeline
+    schedules      *ScheduleRunStore
+    attestations   *AttestationStore
+    operational    *OperationalStateStore
+    serviceConfig  string // gRPC service config JSON advertised to clients
+    submissions    map[string]*regulatorTemplate
+    knowledge      KnowledgeBase
+    outbound       *http.Client
+    tenantAuth     *TenantVerifier
+    connectors     *EvidenceConnectors
+    mirror         *RequestMirror
+    selfTest       *selfTestFixture
+    config         ServiceConfig
+    warmup         warmupTarget
+    ready          atomic.Bool
+    workers        sync.WaitGroup // Background workers started by Start
+}
+
+// Service configuration
+type ServiceConfig struct {
+    Name        string
+    Version     string
+    Port        string `env:"SERVICE_PORT"`
+    MetricsPort string `env:"METRICS_PORT"`
+    HTTPPort    string `env:"HTTP_PORT"`
+    RedisAddr   string `env:"REDIS_ADDR"`
+    KafkaAddr   string `env:"KAFKA_ADDR"`
+    ClusterNode string `env:"CLUSTER_NODE"`
+
+    // TTL of the rules engine compute cache, 0 disables memoization
+    ComputeCacheTTL time.Duration `env:"COMPUTE_CACHE_TTL"`
+
+    // Extra framework prerequisites, e.g. "PDPL:NCA;COMPOSITE:NCA,SAMA"
+    FrameworkDependencies string `env:"FRAMEWORK_DEPENDENCIES"`
+
+    // Concurrent evaluations per framework across all requests, e.g. "SAMA:2"
+    FrameworkConcurrency string `env:"FRAMEWORK_CONCURRENCY"`
+
+    // Hard monthly evaluation quotas, e.g. "tenant-a:10000,tenant-b:500"
+    TenantQuotas string `env:"TENANT_MONTHLY_QUOTAS"`
+
+    // Per-tenant request rate limit shared across replicas, 0 disables it
+    RateLimitRPS   float64 `env:"RATE_LIMIT_RPS"`
+    RateLimitBurst int     `env:"RATE_LIMIT_BURST"`
+
+    // CheckCompliance calls slower than this log a stage breakdown, 0 disables
+    SlowRequestThreshold time.Duration `env:"SLOW_REQUEST_THRESHOLD"`
+
+    // YAML file with reloadable weights, thresholds and cache TTLs
+    ConfigFile string `env:"CONFIG_FILE"`
+
+    // YAML/JSON file of regulatory milestones, re-read on every config reload,
+    // and the default horizon for listing them in responses
+    RegulatoryCalendar string        `env:"REGULATORY_CALENDAR"`
+    ObligationHorizon  time.Duration `env:"OBLIGATION_HORIZON"`
+
+    // Region and locale of checks that name neither, e.g. KSA and ar-SA for
+    // a single-region deployment; empty leaves them unset
+    DefaultRegion string `env:"DEFAULT_REGION"`
+    DefaultLocale string `env:"DEFAULT_LOCALE"`
+
+    // Remediation guidance per framework and control: a YAML/JSON file or
+    // an http(s) URL serving one; empty gives generic hints only
+    KnowledgeBase string `env:"KNOWLEDGE_BASE"`
+
+    // Response cache TTL and per-framework overrides, e.g. "SAMA:1h,ISO27001:720h"
+    CacheTTL           time.Duration `env:"CACHE_TTL"`
+    FrameworkCacheTTLs string        `env:"FRAMEWORK_CACHE_TTLS"`
+
+    // Largest encoded value written to the cache, in bytes; 0 disables the guard
+    CacheMaxValueSize int `env:"CACHE_MAX_VALUE_BYTES"`
+
+    // Response cache backend (redis, memcached or memory) and, for
+    // memcached, its nodes as "host:port,host:port"
+    CacheBackend     string `env:"CACHE_BACKEND"`
+    MemcachedServers string `env:"MEMCACHED_SERVERS"`
+
+    // Event payload versions and routing
+    Events EventConfig
+
+    // How long uploaded evidence sets stay referenceable
+    EvidenceTTL time.Duration `env:"EVIDENCE_TTL"`
+
+    // How far ahead evidence expiry is reported in expiring_soon and
+    // published as a notice; 0 disables both
+    EvidenceExpiryWarning time.Duration `env:"EVIDENCE_EXPIRY_WARNING"`
+
+    // Organizations served, e.g. "acme-*,org-1234"; empty serves all
+    OrgAllowlist string `env:"ORG_ALLOWLIST"`
+
+    // Services allowed to act on behalf of end users, e.g. "portal:<token>"
+    DelegatePrincipals string `env:"DELEGATE_PRINCIPALS" secret:"pairs"`
+
+    // Fraction of traces sampled, decided deterministically from the trace ID,
+    // and the latency beyond which a trace is always exported; both are
+    // defaults for the reloadable runtime config
+    TraceSampleRatio      float64       `env:"TRACE_SAMPLE_RATIO"`
+    TraceLatencyThreshold time.Duration `env:"TRACE_LATENCY_THRESHOLD"`
+
+    // Spans buffered across in-flight requests; traces overflowing it are
+    // dropped. 0 leaves the buffer unbounded.
+    TraceBufferSpans int `env:"TRACE_BUFFER_SPANS"`
+
+    // Return internal error detail to clients; for non-production only.
+    // Full detail is always logged.
+    VerboseErrors bool `env:"VERBOSE_ERRORS"`
+
+    // Serve the gateway's OpenAPI document at /openapi.json and Swagger UI
+    // at /docs on the HTTP port
+    GatewayDocs bool `env:"GATEWAY_DOCS"`
+
+    // Compress gRPC responses for clients that compress their requests
+    // (gzip); off sends every response uncompressed
+    GRPCCompression bool `env:"GRPC_COMPRESSION"`
+
+    // Fields hidden in logs, e.g. "organization_id=hash,principal=mask",
+    // and the key for hashed values
+    LogRedactFields string `env:"LOG_REDACT_FIELDS"`
+    LogRedactSalt   string `env:"LOG_REDACT_SALT" secret:"true"`
+
+    // Concurrent framework evaluations across all requests, and how long a
+    // waiting evaluation takes to gain one priority level
+    WorkerPoolSize int           `env:"WORKER_POOL_SIZE"`
+    PriorityAging  time.Duration `env:"PRIORITY_AGING"`
+
+    // Load shedding: evaluations waiting for a slot beyond this count or
+    // this long are rejected with ResourceExhausted; 0 disables each limit
+    MaxQueuedEvaluations int           `env:"MAX_QUEUED_EVALUATIONS"`
+    MaxQueueWait         time.Duration `env:"MAX_QUEUE_WAIT"`
+
+    // Hard limit on computing one evaluation, applied even when the client
+    // set no deadline; 0 disables it
+    ComputeTimeout time.Duration `env:"COMPUTE_TIMEOUT"`
+
+    // Workers evaluating asynchronously submitted checks, how many of them
+    // bulk (priority 0) jobs may occupy, 0 reserving one for interactive
+    // jobs, and the pending jobs beyond which submissions are rejected
+    JobWorkers     int `env:"JOB_WORKERS"`
+    JobBulkWorkers int `env:"JOB_BULK_WORKERS"`
+    JobMaxPending  int `env:"JOB_MAX_PENDING"`
+
+    // How long an organization's last good result per framework stands in
+    // for a failed check, marked STALE; 0 disables the fallback
+    FrameworkFallbackTTL time.Duration `env:"FRAMEWORK_FALLBACK_TTL"`
+
+    // Lifetime of a per-organization evaluation lock whose holder died
+    EvaluationLockTTL time.Duration `env:"EVALUATION_LOCK_TTL"`
+
+    // How long resolved organization aliases are cached per replica, 0
+    // disables the cache; changes are announced, so this only bounds
+    // staleness after a missed announcement
+    RegistryCacheTTL time.Duration `env:"REGISTRY_CACHE_TTL"`
+
+    // How organization IDs are normalized before they key caches and
+    // evaluations: none, trim, lower or canonical
+    OrgIDNormalization string `env:"ORG_ID_NORMALIZATION"`
+
+    // How long evaluated requests are kept for ReplayCompliance, and run
+    // timings for GetRunTimings
+    ReplayRetention time.Duration `env:"REPLAY_RETENTION"`
+
+    // What is persisted per evaluation: FULL, or SUMMARY for scores only,
+    // which cannot be replayed, verified or submitted to a regulator
+    HistoryFormat string `env:"HISTORY_FORMAT"`
+
+    // Stored evaluations kept per organization beyond pinned ones, oldest
+    // pruned first; 0 keeps every evaluation within retention
+    HistoryMaxRuns int `env:"HISTORY_MAX_RUNS"`
+
+    // How often evaluation history is pruned, by one replica, and the keys
+    // handled per batch; 0 disables pruning
+    HistoryPruneInterval time.Duration `env:"HISTORY_PRUNE_INTERVAL"`
+    HistoryPruneBatch    int           `env:"HISTORY_PRUNE_BATCH"`
+
+    // How long status transitions are kept for GetStatusTimeline
+    TimelineRetention time.Duration `env:"STATUS_TIMELINE_RETENTION"`
+
+    // Kafka request consumption
+    Consumer ConsumerConfig
+
+    // Load balancing and retry policy advertised to gRPC clients
+    Client ClientConfig
+
+    // Outlier detection on framework scores
+    Anomaly AnomalyConfig
+
+    // How often cross-organization rollup gauges refresh, 0 disables them,
+    // and how recent a result must be to count
+    RollupInterval time.Duration `env:"ROLLUP_INTERVAL"`
+    RollupWindow   time.Duration `env:"ROLLUP_WINDOW"`
+
+    // How long daily snapshots of each tenant's latest results are kept,
+    // 0 disables them
+    SnapshotRetention time.Duration `env:"SNAPSHOT_RETENTION"`
+
+    // Largest ListOrganizations page served
+    OrgListMaxPageSize int `env:"ORG_LIST_MAX_PAGE_SIZE"`
+
+    // How often the shared operational overrides are polled, as a backstop
+    // for missed change announcements
+    OperationalStatePoll time.Duration `env:"OPERATIONAL_STATE_POLL"`
+
+    // Cache priming before readiness
+    Warmup WarmupConfig
+
+    // Synthetic evaluation that must pass before readiness
+    SelfTest SelfTestConfig
+
+    // Shared HTTP client for outbound calls
+    Outbound OutboundConfig
+
+    // HTTP sources of evidence queried on every check
+    Connectors ConnectorConfig
+
+    // Mirroring of sampled requests to another instance
+    Mirror MirrorConfig
+
+    // Prometheus scraping and OTLP push of metrics
+    MetricsExport MetricsExportConfig
+
+    // Nightly scheduled runs and their shaping
+    Schedule ScheduleConfig
+
+    // Admin listener for pprof, fault injection and operational endpoints
+    Admin AdminConfig
+
+    // Verification of the calling tenant
+    TenantAuth TenantAuthConfig
+}
+
+// Service - the compliance service, for serving or embedding in-process
+type Service = ComplianceService
+
+// Option - customizes a service built by New
+type Option func(*serviceOptions)
+
+type serviceOptions struct {
+    redis *redis.Client
+    cache Cache
+}
+
+// WithRedisClient - share an existing Redis connection instead of dialing
+// RedisAddr
+func WithRedisClient(client *redis.Client) Option {
+    return func(o *serviceOptions) { o.redis = client }
+}
+
+// WithCacheBackend - cache responses in this backend instead of the one
+// CacheBackend selects
+func WithCacheBackend(backend Cache) Option {
+    return func(o *serviceOptions) { o.cache = backend }
+}
+
+// Connect to Redis and verify the connection
+func connectRedis(addr string) (*redis.Client, error) {
+    client := redis.NewClient(&redis.Options{Addr: addr})
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := client.Ping(ctx).Err(); err != nil {
+        return nil, err
+    }
+    return client, nil
+}
+
+// New - service with all dependencies, ready to Start and register on a
+// gRPC server, or to Serve on its configured ports
+func New(config ServiceConfig, opts ...Option) (*Service, error) {
+    var options serviceOptions
+    for _, opt := range opts {
+        opt(&options)
+    }
+
+    // Redact sensitive fields from log output before any request is served
+    redactor, err := parseRedactor(config.LogRedactFields, config.LogRedactSalt)
+    if err != nil {
+        return nil, fmt.Errorf("invalid log redaction config: %v", err)
+    }
+    logRedactor = redactor
+
+    if config.Admin.Open && config.Admin.Token == "" {
+        log.Printf("WARNING: ADMIN_OPEN is set without ADMIN_TOKEN; admin gRPC methods are open to every caller on port %s, and the admin listener to anyone who reaches it", config.Port)
+    }
+
+    warmup, err := parseWarmupTarget(config.Warmup.Organizations)
+    if err != nil {
+        return nil, fmt.Errorf("invalid warmup config: %v", err)
+    }
+
+    // Connect to Redis, which also backs state beyond the response cache
+    redisClient := options.redis
+    if redisClient == nil {
+        redisClient, err = connectRedis(config.RedisAddr)
+        if err != nil {
+            return nil, fmt.Errorf("failed to connect to Redis: %v", err)
+        }
+    }
+
+    if err := validOrgIDNormalization(config.OrgIDNormalization); err != nil {
+        return nil, err
+    }
+    if err := validEvaluationFormat(config.HistoryFormat); err != nil {
+        return nil, err
+    }
+    if config.HistoryMaxRuns < 0 || (config.HistoryPruneInterval > 0 && config.HistoryPruneBatch < 1) {
+        return nil, fmt.Errorf("history max runs must not be negative and the prune batch must be positive")
+    }
+    if err := config.Schedule.Validate(); err != nil {
+        return nil, fmt.Errorf("invalid schedule config: %v", err)
+    }
+
+    // Initialize the response cache on the configured backend
+    backend := options.cache
+    if backend == nil {
+        backend, err = newCacheBackend(config, redisClient)
+        if err != nil {
+            return nil, err
+        }
+    }
+    cache := NewResponseCache(backend, config.CacheMaxValueSize)
+
+    // Initialize Kafka producer
+    producer, err := events.NewProducer(config.KafkaAddr)
+    if err != nil {
+        return nil, fmt.Errorf("failed to connect to Kafka: %v", err)
+    }
+
+    publisher, err := NewEventPublisher(producer, redisClient, config.Events)
+    if err != nil {
+        return nil, err
+    }
+
+    delegates, err := parseDelegatePrincipals(config.DelegatePrincipals)
+    if err != nil {
+        return nil, err
+    }
+
+    sampler, err := NewTraceSampler(TraceSamplingConfig{
+        SampleRatio:      config.TraceSampleRatio,
+        LatencyThreshold: config.TraceLatencyThreshold,
+    }, producer, config.TraceBufferSpans)
+    if err != nil {
+        return nil, err
+    }
+
+    serviceConfig, err := buildServiceConfig(config.Client)
+    if err != nil {
+        return nil, err
+    }
+
+    // Initialize usage accounting
+    quotas, err := parseTenantQuotas(config.TenantQuotas)
+    if err != nil {
+        return nil, err
+    }
+
+    // Initialize rules engine
+    var memo *ComputeCache
+    if config.ComputeCacheTTL > 0 {
+        memo = NewComputeCache(config.ComputeCacheTTL)
+    }
+
+    service := &ComplianceService{
+        cache:         cache,
+        kafkaProducer: producer,
+        engine:        NewRulesEngine(DefaultRulesetVersion, memo),
+        redis:         redisClient,
+        usage:         NewUsageTracker(redisClient, quotas),
+        faults:        NewFaultInjector(),
+        events:        publisher,
+        registry:      NewOrganizationRegistry(redisClient, config.RegistryCacheTTL, config.OrgIDNormalization),
+        evidence:      NewEvidenceStore(redisClient, config.EvidenceTTL),
+        sampler:       sampler,
+        delegates:     delegates,
+        rollups:       NewRollupAggregator(redisClient, config.RollupInterval, config.RollupWindow),
+        history:       NewScoreHistory(redisClient),
+        locks:         NewEvaluationLocks(redisClient, config.EvaluationLockTTL),
+        replays:       NewEvaluationStore(redisClient, config.ReplayRetention, config.HistoryFormat),
+        runTimings:    NewRunTimingsStore(redisClient, config.ReplayRetention),
+        latest:        NewLatestResults(redisClient, config.SnapshotRetention),
+        timeline:      NewStatusTimeline(redisClient, config.TimelineRetention),
+        schedules:     NewScheduleRunStore(redisClient, config.Schedule.RunRetention),
+        attestations:  NewAttestationStore(redisClient),
+        operational:   NewOperationalStateStore(redisClient, config.OperationalStatePoll),
+        serviceConfig: serviceConfig,
+        anomalies:     NewAnomalyDetector(redisClient, producer, config.ClusterNode, config.Anomaly),
+        config:        config,
+        warmup:        warmup,
+    }
+    service.engine.faults = service.faults
+    service.engine.scheduler = NewPriorityScheduler(config.WorkerPoolSize, config.PriorityAging, config.MaxQueuedEvaluations, config.MaxQueueWait)
+    service.engine.computeTimeout = config.ComputeTimeout
+    service.jobs = NewJobQueue(config.JobWorkers, config.JobBulkWorkers, config.JobMaxPending, service.CheckCompliance)
+    service.registerCheckers()
+
+    if config.RateLimitRPS > 0 {
+        service.limiter = NewRedisRateLimiter(redisClient, config.RateLimitRPS, config.RateLimitBurst)
+    }
+
+    // Apply configured dependencies and reject cycles before serving
+    deps, err := parseFrameworkDependencies(config.FrameworkDependencies)
+    if err != nil {
+        return nil, err
+    }
+    for framework, prerequisites := range deps {
+        service.engine.AddDependencies(framework, prerequisites...)
+    }
+    if err := service.engine.Validate(); err != nil {
+        return nil, fmt.Errorf("invalid rules engine configuration: %v", err)
+    }
+
+    limits, err := parseFrameworkConcurrency(config.FrameworkConcurrency)
+    if err != nil {
+        return nil, err
+    }
+    for framework, limit := range limits {
+        if err := service.engine.SetConcurrencyLimit(framework, limit); err != nil {
+            return nil, err
+        }
+    }
+    if err := service.engine.CheckConformance(); err != nil {
+        return nil, err
+    }
+
+    // Submission layouts must match the registered frameworks and evidence
+    service.submissions, err = loadRegulatorTemplates(service.engine)
+    if err != nil {
+        return nil, err
+    }
+
+    service.outbound, err = newOutboundClient(config.Outbound)
+    if err != nil {
+        return nil, err
+    }
+
+    service.tenantAuth, err = newTenantVerifier(config.TenantAuth, service.outbound)
+    if err != nil {
+        return nil, fmt.Errorf("invalid tenant auth config: %v", err)
+    }
+    switch {
+    case service.tenantAuth == nil:
+        log.Printf("Tenant identity is taken unverified from %s; port %s must only be reachable through the API gateway", tenantMetadataKey, config.Port)
+    case config.TenantAuth.FailOpen:
+        log.Printf("WARNING: TENANT_AUTH_FAIL_OPEN is set; while %s is unreachable, calls are served with %s unverified", config.TenantAuth.JWKSURL, tenantMetadataKey)
+    }
+
+    // Curated remediation guidance must name declared controls
+    service.knowledge, err = loadKnowledgeBase(config.KnowledgeBase, service.engine, service.outbound)
+    if err != nil {
+        return nil, err
+    }
+
+    service.connectors, err = loadEvidenceConnectors(config.Connectors, service.engine, service.outbound)
+    if err != nil {
+        return nil, err
+    }
+
+    service.mirror, err = newRequestMirror(config.Mirror)
+    if err != nil {
+        return nil, err
+    }
+
+    service.selfTest, err = loadSelfTestFixture(config.SelfTest.Fixture, service.engine)
+    if err != nil {
+        return nil, err
+    }
+
+    // Start from the shared operational overrides; without Redis the config
+    // file alone applies until the watcher catches up
+    if state, err := service.operational.Load(context.Background()); err != nil {
+        log.Printf("Operational state unavailable, starting without overrides: %v", err)
+    } else {
+        service.operational.current.Store(state)
+    }
+
+    // Load weights, thresholds and TTLs that can be reloaded at runtime
+    if _, err := service.reloadRuntimeConfig(); err != nil {
+        return nil, fmt.Errorf("invalid runtime configuration: %v", err)
+    }
+
+    return service, nil
+}
+
+// Register the built-in framework checkers with the rules engine
+func (s *ComplianceService) registerCheckers() {
+    s.engine.Register("NCA", s.checkNCA)
+    s.engine.Register("SAMA", s.checkSAMA)
+    s.engine.Register("PDPL", s.checkPDPL)
+    s.engine.Register("ISO27001", s.checkISO27001)
+    s.engine.Register("NIST", s.checkNIST)
+
+    for framework, requirements := range builtinEvidenceRequirements {
+        s.engine.RequireEvidence(framework, requirements...)
+    }
+    for framework, scale := range builtinScoreScales {
+        s.engine.SetScoreScale(framework, scale)
+    }
+}
+
+// Reload the ruleset: rules are compiled in, so this drops memoized
+// evaluations and re-validates the dependency graph
+func (s *ComplianceService) reloadRuleset() error {
+    if s.engine.memo != nil {
+        s.engine.memo.Purge()
+    }
+    if err := s.engine.Validate(); err != nil {
+        return fmt.Errorf("invalid rules engine configuration: %v", err)
+    }
+    log.Printf("Ruleset %s reloaded", s.engine.rulesetVersion)
+    return nil
+}
+
+// CheckCompliance - Main RPC method for compliance checking. Results are
+// served in the shape selected by the result_schema migration mode.
+func (s *ComplianceService) CheckCompliance(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+    s.mirror.Mirror(ctx, req)
+    response, err := s.checkCompliance(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+    runtime := s.runtimeConfig()
+    shaped := shapeResponse(response, runtime.ResultSchema)
+    if runtime.ResultSchema == resultSchemaDual {
+        go verifyDualWritten(shaped, runtime.SchemaValidationRate)
+    }
+    return shaped, nil
+}
+
+// Evaluate a request, with results always carrying typed details
+func (s *ComplianceService) checkCompliance(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+    startTime := time.Now()
+    defer s.recordMetrics(startTime, "check_compliance")
+
+    runtime := s.runtimeConfig()
+    ctx, timings := withRequestTimings(ctx)
+    reqID := requestID(ctx)
+    // Stored records are keyed by a run ID of our own, never the caller's
+    // request ID, which another caller may reuse
+    runID := newRunID()
+    // A mirrored request is evaluated and answered but leaves no trace:
+    // nothing it produces is cached, recorded or published
+    mirrored, err := s.checkMirrorAccepted(ctx)
+    if err != nil {
+        return nil, err
+    }
+    defer func() {
+        total := time.Since(startTime)
+        s.logIfSlow(reqID, req.OrganizationId, total, timings)
+        if !mirrored {
+            go s.runTimings.Store(context.WithoutCancel(ctx), runID, req.OrganizationId, startTime, total, timings, s.engine.RequestFrameworks(req))
+        }
+    }()
+
+    requestedBy, err := s.requestedBy(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+    log.Printf("Audit: rpc=CheckCompliance request_id=%s run=%s org=%s principal=%s on_behalf_of=%s mirrored=%t",
+        reqID, runID, redact(fieldOrganizationID, req.OrganizationId), redact(fieldPrincipal, requestedBy.Principal), redact(fieldSubjectID, requestedBy.SubjectID), mirrored)
+
+    tenant := tenantFromContext(ctx)
+    runtime = runtime.ForTenant(tenant)
+    if s.limiter != nil && !s.limiter.Allow(ctx, tenant) {
+        return nil, reasonError(codes.ResourceExhausted, reasonRateLimited, "rate limit exceeded")
+    }
+    // Resolve aliases so cache and history land under the canonical ID
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if status.Code(err) == codes.NotFound {
+        return nil, err
+    } else if err != nil {
+        log.Printf("Alias resolution failed for %s, using it as-is: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        markTraceDegraded(ctx, "alias resolution failed")
+        organizationID = s.registry.NormalizeID(req.OrganizationId)
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+    if organizationID != req.OrganizationId {
+        req = proto.Clone(req).(*ComplianceRequest)
+        req.OrganizationId = organizationID
+    }
+    // Keep req intact on error; the deferred slow-request log reads it
+    defaulted, err := s.applyRequestDefaults(req, runtime)
+    if err != nil {
+        return nil, err
+    }
+    req = defaulted
+
+    // Merge uploaded evidence with any inline items; inline items win
+    if req.EvidenceRef != "" {
+        evidenceStart := time.Now()
+        uploaded, err := s.evidence.Load(ctx, req.OrganizationId, req.EvidenceRef)
+        timings.Evidence = time.Since(evidenceStart)
+        if err != nil {
+            return nil, err
+        }
+        req = proto.Clone(req).(*ComplianceRequest)
+        inline := make(map[string]bool, len(req.Evidence))
+        for _, item := range req.Evidence {
+            inline[item.Key] = true
+        }
+        for _, item := range uploaded {
+            if !inline[item.Key] {
+                req.Evidence = append(req.Evidence, item)
+            }
+        }
+    }
+
+    // Fetch the rest from evidence connectors; a connector that supplies
+    // nothing leaves its keys missing and the response incomplete
+    connectorStart := time.Now()
+    given := make(map[string]bool, len(req.Evidence))
+    for _, item := range req.Evidence {
+        given[item.Key] = true
+    }
+    fetched, degraded, err := s.connectors.Fetch(ctx, req.OrganizationId, given)
+    timings.Evidence += time.Since(connectorStart)
+    if err != nil {
+        return nil, err
+    }
+    if len(fetched) > 0 {
+        req = proto.Clone(req).(*ComplianceRequest)
+        for _, item := range fetched {
+            if !given[item.Key] {
+                req.Evidence = append(req.Evidence, item)
+            }
+        }
+    }
+    for _, degradation := range degraded {
+        log.Printf("Evidence connector %s degraded for %s: %s", degradation.Connector, redact(fieldOrganizationID, req.OrganizationId), degradation.Reason)
+        markTraceDegraded(ctx, "evidence connector "+degradation.Connector+" degraded")
+    }
+    if err := validateProvenance(req.Evidence); err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+    }
+
+    // Framework results are cached by the inputs each framework consumes
+    inputKeys := make(map[string]string, len(s.engine.Frameworks()))
+    for _, framework := range s.engine.Frameworks() {
+        inputKeys[framework] = s.engine.InputKey(framework, req)
+    }
+
+    // Check cache first, then reuse any still-valid framework results
+    reuse := make(map[string]*FrameworkResult)
+    if !req.ForceRefresh {
+        cacheStart := time.Now()
+        cached, err := s.cachedResponse(ctx, runtime.responseKey(req))
+        if err == nil && cached != nil && len(degraded) == 0 {
+            timings.Cache = time.Since(cacheStart)
+            recordSpan(ctx, "cache read", cacheStart, nil)
+            s.usage.Record(tenant, usageCacheHits, 1)
+            setCacheStatus(ctx, cacheStatusHit)
+            cached.RunId = runID
+            s.attachTrends(ctx, req, cached)
+            s.attachObligations(req, cached)
+            return cached, nil
+        }
+        if results, err := s.cache.GetFrameworks(ctx, inputKeys); err == nil {
+            reuse = results
+        }
+        timings.Cache = time.Since(cacheStart)
+        recordSpan(ctx, "cache read", cacheStart, nil)
+        setCacheStatus(ctx, cacheStatusMiss)
+    }
+
+    // Frameworks missing their minimum inputs are not scored
+    for framework, result := range s.engine.insufficientDataResults(runtime.MinimumInputs, req.Evidence, time.Now()) {
+        reuse[framework] = result
+    }
+
+    // Frameworks attested not applicable are not evaluated; an unreadable
+    // attestation store scores every framework
+    attested, err := s.attestations.Active(ctx, req.OrganizationId, time.Now())
+    if err != nil {
+        log.Printf("Attestations unavailable for %s, scoring every framework: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        markTraceDegraded(ctx, "attestations unavailable")
+    }
+    for framework, result := range notApplicableResults(attested) {
+        reuse[framework] = result
+    }
+
+    // Serialize evaluations of the organization across entry points; an
+    // interactive caller that had to wait serves the result just produced
+    lease, err := s.locks.Acquire(ctx, req.OrganizationId, evaluationScope(req))
+    if err != nil && (err == errEvaluationInProgress || ctx.Err() != nil) {
+        return nil, err
+    } else if err != nil {
+        log.Printf("Evaluation lock unavailable for %s, evaluating unlocked: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        markTraceDegraded(ctx, "evaluation lock unavailable")
+    } else {
+        defer lease.Release(context.WithoutCancel(ctx))
+        if lease.Waited {
+            if cached, err := s.cache.Get(ctx, runtime.responseKey(req)); err == nil && cached != nil && len(degraded) == 0 {
+                s.usage.Record(tenant, usageCacheHits, 1)
+                setCacheStatus(ctx, cacheStatusHit)
+                cached.RunId = runID
+                s.attachTrends(ctx, req, cached)
+                s.attachObligations(req, cached)
+                return cached, nil
+            }
+        }
+    }
+    // Cache hits are served past the quota; only evaluations count
+    if err := s.usage.CheckQuota(tenant); err != nil {
+        return nil, err
+    }
+    s.usage.Record(tenant, usageEvaluations, 1)
+
+    // Perform compliance checks in parallel, respecting dependencies; an
+    // opted-in interactive request stops as soon as its status is decided
+    var decided func([]*FrameworkResult, []string) bool
+    if shortCircuitEnabled(req) {
+        decided = func(results []*FrameworkResult, outstanding []string) bool {
+            _, ok := s.decidedStatus(results, outstanding, runtime)
+            return ok
+        }
+    }
+    complianceResults, skipped, err := s.engine.EvaluateUntil(ctx, req, reuse, decided)
+    if err == errLoadShed {
+        retryAfter := s.engine.scheduler.RetryAfter()
+        setRetryAfter(ctx, retryAfter)
+        return nil, reasonError(codes.ResourceExhausted, reasonOverloaded, fmt.Sprintf("service overloaded, retry after %s", retryAfter))
+    }
+    if err == errComputeTimeout {
+        return nil, status.Errorf(codes.DeadlineExceeded, "compliance computation exceeded %s", s.engine.computeTimeout)
+    }
+    if err != nil {
+        // The caller cancelled or its deadline passed: the results are
+        // partial, so nothing is cached, recorded or published
+        return nil, status.FromContextError(err).Err()
+    }
+
+    // Cache freshly evaluated framework results, each with its own TTL
+    cacheWriteStart := time.Now()
+    var fresh []*FrameworkResult
+    for _, result := range complianceResults {
+        if _, reused := reuse[result.Framework]; !reused {
+            fresh = append(fresh, proto.Clone(result).(*FrameworkResult))
+        }
+    }
+    if !mirrored {
+        if err := s.cache.SetFrameworks(ctx, inputKeys, fresh, runtime.Cache); err != nil {
+            log.Printf("Failed to cache framework results for %s: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        }
+        if s.config.FrameworkFallbackTTL > 0 {
+            if err := s.cache.SetLastResults(ctx, req.OrganizationId, complianceResults, s.config.FrameworkFallbackTTL); err != nil {
+                log.Printf("Failed to keep last framework results for %s: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+            }
+        }
+    }
+    timings.CacheWrite = time.Since(cacheWriteStart)
+
+    // Compare a sample of fresh results against any shadow rulesets
+    if !mirrored {
+        s.shadowEvaluate(ctx, req, complianceResults, fresh, runtime.ShadowSampleRate)
+    }
+
+    // Stand in for failed checks with the organization's last good results
+    stale := s.staleFallbacks(ctx, req.OrganizationId, failedFrameworks(s.engine.RequestFrameworks(req), complianceResults, skipped))
+
+    // Feed fresh outcomes to the anomaly detector off the request path
+    var evaluated []string
+    for _, framework := range s.engine.RequestFrameworks(req) {
+        if _, reused := reuse[framework]; !reused && !containsString(skipped, framework) {
+            evaluated = append(evaluated, framework)
+        }
+    }
+    if !mirrored {
+        go s.anomalies.Record(context.WithoutCancel(ctx), evaluated, fresh)
+    }
+
+    scoringStart := time.Now()
+    results := append(append(complianceResults, stale...), shortCircuitedResults(skipped)...)
+    response := s.buildResponse(req.OrganizationId, results, req.Evidence, runtime)
+    response.EvidenceDegradation = degraded
+    response.RunId = runID
+    timings.Scoring = time.Since(scoringStart)
+
+    // Cache result, record it for cross-organization rollups and the status
+    // timeline and keep the evaluated request so it can be replayed under
+    // later rulesets. A short-circuited, partly stale or evidence-degraded
+    // response is incomplete and serves only this caller.
+    var transition *StatusTransition
+    if len(skipped) == 0 && len(stale) == 0 && len(degraded) == 0 && !mirrored {
+        cacheWriteStart := time.Now()
+        if ttl, mode := s.responseTTL(ctx, runtime, response, req.Evidence); ttl > 0 {
+            recordCacheTTL(response, ttl, mode)
+            s.cacheResponse(ctx, runtime.responseKey(req), response, ttl)
+        }
+        timings.CacheWrite += time.Since(cacheWriteStart)
+        s.rollups.Record(ctx, response)
+        if err := s.replays.Store(ctx, runID, s.engine.rulesetVersion, requestedBy.Principal, req, response); err != nil {
+            log.Printf("Failed to store evaluation %s for replay: %v", runID, err)
+        }
+        if err := s.latest.Record(ctx, tenant, req, response); err != nil {
+            log.Printf("Failed to record latest result for %s: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        }
+        transition = s.recordStatusTransition(ctx, runID, response, runtime)
+    }
+
+    // Publish to Kafka for real-time monitoring
+    if !mirrored {
+        publishStart := time.Now()
+        event := s.newComplianceEvent(req, response, requestedBy)
+        event.Transition = transition
+        s.events.Publish(context.WithoutCancel(ctx), resultsTopic, event)
+        s.publishEvidenceExpiry(context.WithoutCancel(ctx), response)
+        s.publishAttestationExpiry(context.WithoutCancel(ctx), response)
+        timings.Publish = time.Since(publishStart)
+        recordSpan(ctx, "publish", publishStart, nil)
+    }
+
+    // Trends and obligations are per-request extras, never cached or published
+    if lease != nil && !mirrored {
+        if err := s.history.Record(ctx, response, lease); err == errStaleFence {
+            log.Printf("Dropped score history for %s: superseded by a newer evaluation", redact(fieldOrganizationID, req.OrganizationId))
+        } else if err != nil {
+            log.Printf("Failed to record score history for %s: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+        }
+    }
+    s.attachTrends(ctx, req, response)
+    s.attachObligations(req, response)
+
+    return response, nil
+}
+
+// Assemble a response from framework results: weighted overall score,
+// ruleset fingerprint, rounding, status, SAMA sub-domain and framework
+// gates and evidence expiring soon, then the content hash
+func (s *ComplianceService) buildResponse(organizationID string, results []*FrameworkResult, evidence []*EvidenceItem, runtime *RuntimeConfig) *ComplianceResponse {
+    now := time.Now()
+    response := &ComplianceResponse{
+        OrganizationId:   organizationID,
+        Timestamp:        now.Unix(),
+        FrameworkResults: results,
+        OverallScore:     s.calculateOverallScore(results, runtime.Weights),
+        SchemaVersion:    responseSchemaVersion,
+        ExpiringSoon:     s.expiringSoon(results, evidence, now),
+
+        OperationalStateVersion: runtime.OperationalVersion,
+        RulesetFingerprint:      rulesetFingerprint(results),
+    }
+
+    s.engine.attachSupportingEvidence(results, evidence)
+
+    // Round scores once, before the response is cached, published or returned
+    applyPrecisionPolicy(response)
+    response.Status = s.determineStatus(response.OverallScore, runtime.Thresholds)
+
+    // A failed SAMA sub-domain gate rules out COMPLIANT
+    if failed := applySamaSubdomainGates(results, runtime.SamaSubdomainGates); len(failed) > 0 && response.Status == "COMPLIANT" {
+        response.Status = "PARTIALLY_COMPLIANT"
+    }
+
+    // A gating framework below its minimum caps the status whatever the average
+    response.Status, _ = applyFrameworkGates(response.Status, results, runtime.FrameworkGates)
+
+    // Hash the final content so downstream systems can detect change
+    if hash, err := responseContentHash(response); err != nil {
+        log.Printf("Failed to hash response for %s: %v", redact(fieldOrganizationID, organizationID), err)
+    } else {
+        response.ContentHash = hash
+    }
+    return response
+}
+
+// Fill each framework's trend when the request asks for it
+func (s *ComplianceService) attachTrends(ctx context.Context, req *ComplianceRequest, response *ComplianceResponse) {
+    if !req.IncludeTrend {
+        return
+    }
+    if err := s.history.AttachTrends(ctx, response, int(req.TrendPoints)); err != nil {
+        log.Printf("Failed to load score trends for %s: %v", redact(fieldOrganizationID, req.OrganizationId), err)
+    }
+}
+
+// Build the event for a response: evidence coverage per evaluated framework,
+// leaving out frameworks attested not applicable, and a degradation entry
+// for every framework that produced no result or reads evidence of a
+// degraded connector
+func (s *ComplianceService) newComplianceEvent(req *ComplianceRequest, response *ComplianceResponse, requestedBy *RequestedBy) *ComplianceEvent {
+    frameworks := s.engine.RequestFrameworks(req)
+    set, _ := s.engine.assembleEvidence(frameworks, req.Evidence, time.Now())
+
+    event := &ComplianceEvent{
+        Response:    shapeResponse(response, s.runtimeConfig().ResultSchema),
+        Evidence:    make(map[string]*FrameworkEvidenceStatus, len(frameworks)),
+        RequestedBy: requestedBy,
+        Region:      req.Region,
+        Locale:      req.Locale,
+    }
+    evaluated := make(map[string]bool, len(response.FrameworkResults))
+    for _, result := range response.FrameworkResults {
+        evaluated[result.Framework] = true
+        if result.Outcome == frameworkShortCircuited {
+            event.Degradation = append(event.Degradation, DegradationEntry{Framework: result.Framework, Reason: "short-circuited"})
+            continue
+        }
+        if result.Outcome == frameworkNotApplicable {
+            continue
+        }
+        if result.Outcome == frameworkStale {
+            event.Degradation = append(event.Degradation, DegradationEntry{Framework: result.Framework, Reason: "evaluation failed, last result reused"})
+        }
+        event.Evidence[result.Framework] = s.engine.evidenceStatus(result.Framework, set)
+    }
+    for _, framework := range frameworks {
+        if !evaluated[framework] {
+            event.Degradation = append(event.Degradation, DegradationEntry{Framework: framework, Reason: "evaluation failed"})
+        }
+    }
+    for _, degradation := range response.EvidenceDegradation {
+        for _, framework := range frameworks {
+            for _, requirement := range s.engine.requirements[framework] {
+                if containsString(degradation.Keys, requirement.Key) {
+                    event.Degradation = append(event.Degradation, DegradationEntry{Framework: framework, Reason: "evidence connector " + degradation.Connector + ": " + degradation.Reason})
+                    break
+                }
+            }
+        }
+    }
+    return event
+}
+
+// SubmitComplianceCheck - queues a check for asynchronous evaluation
+func (s *ComplianceService) SubmitComplianceCheck(ctx context.Context, req *SubmitCheckRequest) (*CheckJob, error) {
+    if req.Request == nil || req.Request.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "request.organization_id is required")
+    }
+    if err := s.checkOrgAllowed(req.Request.OrganizationId); err != nil {
+        return nil, err
+    }
+    if _, err := s.requestedBy(ctx, req.Request); err != nil {
+        return nil, err
+    }
+    return s.jobs.Submit(ctx, req.Request, req.Priority)
+}
+
+// GetCheckJob - state of an asynchronous check, with queue position and
+// estimated start while it is pending
+func (s *ComplianceService) GetCheckJob(ctx context.Context, req *GetCheckJobRequest) (*CheckJob, error) {
+    job, ok := s.jobs.Get(req.JobId)
+    if !ok {
+        return nil, status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+    }
+    return job, nil
+}
+
+// ValidateEvidence - runs only validation and evidence assembly so clients can
+// pre-check a payload. Nothing is cached, persisted or scored.
+func (s *ComplianceService) ValidateEvidence(ctx context.Context, req *ValidateEvidenceRequest) (*ValidateEvidenceResponse, error) {
+    defer s.recordMetrics(time.Now(), "validate_evidence")
+
+    frameworks, err := s.engine.SelectFrameworks(s.resolveFrameworks(req.Frameworks))
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+    }
+
+    set, verdicts := s.engine.assembleEvidence(frameworks, req.Evidence, time.Now())
+    response := &ValidateEvidenceResponse{Verdicts: verdicts}
+
+    var required, satisfied int32
+    for _, framework := range frameworks {
+        frameworkStatus := s.engine.evidenceStatus(framework, set)
+        response.Frameworks = append(response.Frameworks, frameworkStatus)
+        required += frameworkStatus.RequiredTotal
+        satisfied += frameworkStatus.RequiredSatisfied
+    }
+
+    response.EstimatedCoverage = 100
+    if required > 0 {
+        response.EstimatedCoverage = roundScore(100 * float64(satisfied) / float64(required))
+    }
+    return response, nil
+}
+
+// ReloadConfig - admin RPC re-reading the config source; an invalid config is
+// rejected and the running one stays in effect
+func (s *ComplianceService) ReloadConfig(ctx context.Context, _ *emptypb.Empty) (*ReloadResponse, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+
+    runtime, err := s.reloadRuntimeConfig()
+    if err != nil {
+        log.Printf("Config reload rejected: %v", err)
+        return nil, status.Errorf(codes.FailedPrecondition, "invalid config: %v", err)
+    }
+    log.Printf("Config %s loaded", runtime.Version)
+    return &ReloadResponse{
+        ConfigVersion: runtime.Version,
+        LoadedAt:      timestamppb.New(runtime.LoadedAt),
+    }, nil
+}
+
+// RegisterAlias - admin RPC mapping an external identifier to a canonical
+// organization. An internal ID with history of its own is an organization,
+// not an alias: registering it would hide that history, so it is refused in
+// favour of MergeOrganizations.
+func (s *ComplianceService) RegisterAlias(ctx context.Context, req *RegisterAliasRequest) (*OrganizationAliases, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    organizationID := s.registry.NormalizeID(req.OrganizationId)
+    if organizationID == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+    }
+    if req.Alias.GetType() == AliasType_INTERNAL {
+        aliased := s.registry.NormalizeID(req.Alias.Value)
+        exists, err := s.organizationExists(ctx, aliased)
+        if err != nil {
+            return nil, storeError(err, "registry unavailable")
+        }
+        if exists && aliased != organizationID {
+            return nil, status.Errorf(codes.FailedPrecondition, "%s is an organization with its own history; merge it into %s instead", aliased, organizationID)
+        }
+    }
+    if err := s.registry.Register(ctx, organizationID, req.Alias); err != nil {
+        return nil, err
+    }
+    return s.organizationAliases(ctx, organizationID)
+}
+
+// ResolveOrganization - returns the canonical organization for an alias
+func (s *ComplianceService) ResolveOrganization(ctx context.Context, req *ResolveOrganizationRequest) (*OrganizationAliases, error) {
+    organizationID, found, err := s.registry.Resolve(ctx, req.Alias)
+    if err != nil {
+        return nil, err
+    }
+    if !found {
+        return nil, status.Error(codes.NotFound, "alias is not registered")
+    }
+    return s.organizationAliases(ctx, organizationID)
+}
+
+// MergeOrganizations - admin RPC folding a duplicate organization into the
+// canonical one; the duplicate's cached results are dropped
+func (s *ComplianceService) MergeOrganizations(ctx context.Context, req *MergeOrganizationsRequest) (*OrganizationAliases, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    source, target := s.registry.NormalizeID(req.SourceOrganizationId), s.registry.NormalizeID(req.TargetOrganizationId)
+    if source == "" || target == "" {
+        return nil, status.Error(codes.InvalidArgument, "source and target organization IDs are required")
+    }
+    if err := s.registry.Merge(ctx, source, target); err != nil {
+        return nil, err
+    }
+    if err := s.mergeHistory(ctx, source, target); err != nil {
+        return nil, storeError(err, "failed to merge organization history; retry to complete the merge")
+    }
+    for _, organizationID := range []string{source, target} {
+        if err := s.cache.Invalidate(ctx, organizationID); err != nil {
+            log.Printf("Failed to invalidate cache for merged organization %s: %v", redact(fieldOrganizationID, organizationID), err)
+        }
+    }
+    log.Printf("Merged organization %s into %s", redact(fieldOrganizationID, source), redact(fieldOrganizationID, target))
+    return s.organizationAliases(ctx, target)
+}
+
+// Fold the source's stored evaluations, pins, status timeline, score
+// history and latest results into the target's. Each step moves whatever
+// the source has left, so retrying a merge that failed part way completes
+// it.
+func (s *ComplianceService) mergeHistory(ctx context.Context, source, target string) error {
+    sourceNewer, err := s.timeline.Merge(ctx, source, target)
+    if err != nil {
+        return err
+    }
+    if err := s.history.Merge(ctx, source, target, s.engine.Frameworks(), sourceNewer); err != nil {
+        return err
+    }
+    if err := s.replays.Merge(ctx, source, target); err != nil {
+        return err
+    }
+    return s.latest.Merge(ctx, source, target)
+}
+
+// Whether an internal ID is an organization in its own right: it has
+// aliases, stored evaluations, pins or a status timeline
+func (s *ComplianceService) organizationExists(ctx context.Context, organizationID string) (bool, error) {
+    n, err := s.redis.Exists(ctx,
+        orgAliasKeyPrefix+organizationID,
+        evaluationIndexKey(organizationID),
+        pinnedResultsKey(organizationID),
+        statusTimelineStateKey(organizationID),
+    ).Result()
+    return n > 0, err
+}
+
+func (s *ComplianceService) organizationAliases(ctx context.Context, organizationID string) (*OrganizationAliases, error) {
+    aliases, err := s.registry.Aliases(ctx, organizationID)
+    if err != nil {
+        return nil, storeError(err, "registry unavailable")
+    }
+    return &OrganizationAliases{OrganizationId: organizationID, Aliases: aliases}, nil
+}
+
+// GetUsageReport - daily usage breakdown and totals for a tenant and month;
+// only admins read another tenant's usage
+func (s *ComplianceService) GetUsageReport(ctx context.Context, req *UsageReportRequest) (*UsageReportResponse, error) {
+    tenant := tenantFromContext(ctx)
+    if req.TenantId != "" && req.TenantId != tenant {
+        if err := s.requireAdmin(ctx); err != nil {
+            return nil, err
+        }
+        tenant = req.TenantId
+    }
+    month := req.Month
+    if month == "" {
+        month = time.Now().UTC().Format("2006-01")
+    }
+
+    report, err := s.usage.Report(ctx, tenant, month)
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+    }
+    return report, nil
+}
+
+// ListFrameworks - registered frameworks with weights, prerequisites and
+// any suspect flag raised by the anomaly detector
+func (s *ComplianceService) ListFrameworks(ctx context.Context, _ *emptypb.Empty) (*ListFrameworksResponse, error) {
+    suspects, err := s.anomalies.Suspects(ctx)
+    if err != nil {
+        log.Printf("Failed to read suspect frameworks: %v", err)
+    }
+
+    response := &ListFrameworksResponse{}
+    for _, framework := range s.engine.Frameworks() {
+        info := s.frameworkInfo(framework)
+        if suspect, ok := suspects[framework]; ok {
+            info.Suspect = true
+            info.SuspectReason = suspect.Reason
+            info.SuspectSince = timestamppb.New(suspect.Since)
+        }
+        response.Frameworks = append(response.Frameworks, info)
+    }
+    return response, nil
+}
+
+// ClearSuspectFramework - admin RPC acknowledging a suspect framework
+func (s *ComplianceService) ClearSuspectFramework(ctx context.Context, req *ClearSuspectFrameworkRequest) (*FrameworkInfo, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if framework := s.resolveFramework(req.Framework); framework != req.Framework {
+        req = proto.Clone(req).(*ClearSuspectFrameworkRequest)
+        req.Framework = framework
+    }
+    if !containsString(s.engine.Frameworks(), req.Framework) {
+        return nil, status.Errorf(codes.NotFound, "framework %s not registered", req.Framework)
+    }
+    if err := s.anomalies.Clear(ctx, req.Framework); err != nil {
+        return nil, storeError(err, "failed to clear suspect flag")
+    }
+    log.Printf("Suspect flag cleared for framework %s", req.Framework)
+    return s.frameworkInfo(req.Framework), nil
+}
+
+func (s *ComplianceService) frameworkInfo(framework string) *FrameworkInfo {
+    return &FrameworkInfo{
+        Name:      framework,
+        Weight:    s.runtimeConfig().Weights[framework],
+        DependsOn: s.engine.dependencies[framework],
+    }
+}
+
+// Saudi NCA compliance check
+func (s *ComplianceService) checkNCA(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+    if ctx.Err() != nil {
+        return nil
+    }
+    // Score NCA controls by severity, so a failed critical control costs
+    // more than a trivial one
+    now := time.Now()
+    score, met, total := s.engine.controlScore("NCA", req.Evidence, now)
+    return &FrameworkResult{
+        Framework: "NCA",
+        Score:     score,
+        Details: &FrameworkResult_NcaDetails{
+            NcaDetails: &NCADetails{
+                RequirementsMet:   int32(met),
+                RequirementsTotal: int32(total),
+                CriticalIssues:    int32(s.engine.failedCritical("NCA", req.Evidence, now)),
+            },
+        },
+    }
+}
+
+// SAMA compliance check
+func (s *ComplianceService) checkSAMA(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+    if ctx.Err() != nil {
+        return nil
+    }
+    subdomains, score := evaluateSamaSubdomains(req)
+    result := &FrameworkResult{
+        Framework: "SAMA",
+        Score:     score,
+        Details: &FrameworkResult_SamaDetails{
+            SamaDetails: &SAMADetails{
+                BaselCompliant: true,
+                AmlStatus:      "compliant",
+                Subdomains:     subdomains,
+            },
+        },
+    }
+    // An assessed maturity level, when supplied, is the framework score
+    if level, ok := nativeEvidence(req, samaMaturityEvidence); ok {
+        result.NativeScore = level
+    }
+    return result
+}
+
+// PDPL compliance check
+func (s *ComplianceService) checkPDPL(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+    if ctx.Err() != nil {
+        return nil
+    }
+    score := 88.7
+    return &FrameworkResult{
+        Framework: "PDPL",
+        Score:     score,
+        Details: &FrameworkResult_PdplDetails{
+            PdplDetails: &PDPLDetails{
+                DataProtectionLevel: "high",
+                ConsentManagement:   "implemented",
+            },
+        },
+    }
+}
+
+// ISO 27001 compliance check
+func (s *ComplianceService) checkISO27001(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+    if ctx.Err() != nil {
+        return nil
+    }
+    score := 91.2
+    return &FrameworkResult{
+        Framework: "ISO27001",
+        Score:     score,
+        Details: &FrameworkResult_IsoDetails{
+            IsoDetails: &ISO27001Details{
+                ControlsImplemented: 114,
+                ControlsTotal:       114,
+            },
+        },
+    }
+}
+
+// NIST framework compliance check
+func (s *ComplianceService) checkNIST(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+    if ctx.Err() != nil {
+        return nil
+    }
+    score := 89.8
+    result := &FrameworkResult{
+        Framework: "NIST",
+        Score:     score,
+        Details: &FrameworkResult_NistDetails{
+            NistDetails: &NISTDetails{
+                IdentifyScore: 92,
+                ProtectScore:  88,
+                DetectScore:   90,
+                RespondScore:  87,
+                RecoverScore:  91,
+            },
+        },
+    }
+    // An assessed CSF tier, when supplied, is the framework score
+    if tier, ok := nativeEvidence(req, nistTierEvidence); ok {
+        result.NativeScore = tier
+    }
+    return result
+}
+
+// Weighted mean of the scored results. Frameworks reporting a native score
+// contribute its normalized 0-100 score, never the native value.
+func (s *ComplianceService) calculateOverallScore(results []*FrameworkResult, weights map[string]float64) float64 {
+    totalScore := 0.0
+    totalWeight := 0.0
+
+    for _, result := range results {
+        if !scoredResult(result) {
+            continue
+        }
+        if weight, ok := weights[result.Framework]; ok {
+            totalScore += result.Score * weight
+            totalWeight += weight
+        }
+    }
+
+    if totalWeight > 0 {
+        return totalScore / totalWeight
+    }
+    return 0
+}
+
+func (s *ComplianceService) determineStatus(score float64, thresholds StatusThresholds) string {
+    if score >= thresholds.Compliant {
+        return "COMPLIANT"
+    } else if score >= thresholds.PartiallyCompliant {
+        return "PARTIALLY_COMPLIANT"
+    }
+    return "NON_COMPLIANT"
+}
+
+func (s *ComplianceService) recordMetrics(startTime time.Time, operation string) {
+    duration := time.Since(startTime).Seconds()
+    requestDurations.get(operation).Observe(duration)
+    requestCounts.get(operation).Inc()
+}
+
+// Prometheus metrics
+var (
+    requestDuration = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name: "compliance_request_duration_seconds",
+            Help: "Duration of compliance requests in seconds",
+        },
+        []string{"operation"},
+    )
+    
+    requestCount = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_request_total",
+            Help: "Total number of compliance requests",
+        },
+        []string{"operation"},
+    )
+
+    // Children for the operations recorded on every request
+    requestDurations = newObserverCache(requestDuration, "check_compliance", "validate_evidence", "upload_evidence")
+    requestCounts    = newCounterCache(requestCount, "check_compliance", "validate_evidence", "upload_evidence")
+)
+
+func init() {
+    prometheus.MustRegister(requestDuration)
+    prometheus.MustRegister(requestCount)
+}
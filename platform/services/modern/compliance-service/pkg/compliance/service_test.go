@@ -0,0 +1,104 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "github.com/alicebob/miniredis/v2"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Service built by New against miniredis, with Kafka unreachable; events
+// go to the spool
+func newTestService(t *testing.T, configure ...func(*ServiceConfig)) (*ComplianceService, *miniredis.Miniredis) {
+    t.Helper()
+    server := miniredis.RunT(t)
+    config := ConfigFromEnv()
+    config.RedisAddr = server.Addr()
+    config.KafkaAddr = "127.0.0.1:1"
+    for _, c := range configure {
+        c(&config)
+    }
+    service, err := New(config)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return service, server
+}
+
+// Requests whose defaults cannot be applied are refused, not evaluated
+func TestCheckComplianceRejectsInvalidDefaults(t *testing.T) {
+    service, _ := newTestService(t)
+    tests := []struct {
+        name string
+        req  *ComplianceRequest
+    }{
+        {name: "unknown framework", req: &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"GDPR"}}},
+        {name: "unknown region", req: &ComplianceRequest{OrganizationId: "org-1", Region: "mars"}},
+        {name: "invalid locale", req: &ComplianceRequest{OrganizationId: "org-1", Locale: "not a locale!"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := service.CheckCompliance(context.Background(), tt.req)
+            if status.Code(err) != codes.InvalidArgument {
+                t.Errorf("CheckCompliance() = %v, want InvalidArgument", err)
+            }
+        })
+    }
+}
+
+// Responses carry the current schema version; cached responses of another
+// version are evaluated again instead of served
+func TestResponseSchemaVersion(t *testing.T) {
+    service, server := newTestService(t)
+    ctx := context.Background()
+    req := &ComplianceRequest{OrganizationId: "org-1"}
+
+    response, err := service.CheckCompliance(ctx, req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.SchemaVersion != responseSchemaVersion {
+        t.Fatalf("schema_version %d, want %d", response.SchemaVersion, responseSchemaVersion)
+    }
+
+    // Rewrite the cached response as an older deploy would have stored it
+    var key string
+    for _, k := range server.Keys() {
+        if strings.HasPrefix(k, responseKeyPrefix+"org-1:") {
+            key = k
+        }
+    }
+    data, _ := server.Get(key)
+    old := &ComplianceResponse{}
+    if err := proto.Unmarshal([]byte(data), old); err != nil {
+        t.Fatal(err)
+    }
+    old.SchemaVersion = responseSchemaVersion - 1
+    encoded, _ := proto.Marshal(old)
+    server.Set(key, string(encoded))
+
+    tests := []struct {
+        name        string
+        cacheStatus string
+    }{
+        {name: "older cached version re-evaluated", cacheStatus: cacheStatusMiss},
+        {name: "current cached version served", cacheStatus: cacheStatusHit},
+    }
+    for _, tt := range tests {
+        info := &requestInfo{id: "req-1"}
+        response, err := service.CheckCompliance(context.WithValue(ctx, requestInfoKey{}, info), req)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if info.cacheStatus != tt.cacheStatus {
+            t.Errorf("%s: cache status %q, want %q", tt.name, info.cacheStatus, tt.cacheStatus)
+        }
+        if response.SchemaVersion != responseSchemaVersion {
+            t.Errorf("%s: schema_version %d, want %d", tt.name, response.SchemaVersion, responseSchemaVersion)
+        }
+    }
+}
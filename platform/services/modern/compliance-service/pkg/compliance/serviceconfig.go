@@ -0,0 +1,197 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Fully qualified gRPC service name the advertised config applies to
+const complianceServiceName = "doganai.compliance.v1.Compliance"
+
+// Client policy for a method. Idempotent methods only read state, or
+// evaluate with no side effects a repeat would not reproduce; only they get
+// the retry policy and wait for a ready connection instead of failing fast.
+type methodPolicy struct {
+    Method     string
+    Idempotent bool
+    Timeout    time.Duration // 0 uses the client default, negative sets none
+}
+
+// Every method of the service, in proto order
+var methodPolicies = []methodPolicy{
+    {Method: "CheckCompliance", Idempotent: true},
+    {Method: "StreamCompliance", Idempotent: true, Timeout: -1},
+    {Method: "GenerateReport", Idempotent: true},
+    {Method: "GetAuditTrail", Idempotent: true},
+    {Method: "SubmitComplianceCheck"}, // Every call queues a new job
+    {Method: "GetCheckJob", Idempotent: true},
+    {Method: "UploadEvidence", Timeout: 5 * time.Minute},
+    {Method: "ValidateEvidence", Idempotent: true},
+    {Method: "RegisterAlias", Idempotent: true}, // Re-registering is a no-op
+    {Method: "ResolveOrganization", Idempotent: true},
+    {Method: "MergeOrganizations"},
+    {Method: "ReloadConfig"},
+    {Method: "GetEffectiveConfig", Idempotent: true},
+    {Method: "GetUsageReport", Idempotent: true},
+    {Method: "ListFrameworks", Idempotent: true},
+    {Method: "GetGapAnalysis", Idempotent: true},
+    {Method: "ClearSuspectFramework"},
+    {Method: "ReplayCompliance", Idempotent: true},
+    {Method: "GetCapacity", Idempotent: true},
+    {Method: "GetServiceConfig", Idempotent: true},
+    {Method: "GenerateRegulatorSubmission", Idempotent: true, Timeout: 5 * time.Minute},
+    {Method: "GetRunTimings", Idempotent: true},
+    {Method: "ListLatestResults", Idempotent: true},
+    {Method: "RecordAttestation"}, // Every call records a new attestation
+    {Method: "RevokeAttestation"},
+    {Method: "ListAttestations", Idempotent: true},
+    {Method: "GetOperationalState", Idempotent: true},
+    {Method: "UpdateOperationalState"}, // Compare-and-set; retrying a lost race fails the same way
+    {Method: "ListRegions", Idempotent: true},
+    {Method: "GetShadowComparison", Idempotent: true},
+    {Method: "PromoteRuleset"},
+    {Method: "ListRegulatoryMilestones", Idempotent: true},
+    {Method: "ListOrganizations", Idempotent: true},
+    {Method: "GetFrameworkResult", Idempotent: true},
+    {Method: "VerifyEvidence", Idempotent: true},
+    {Method: "CheckComplianceStream", Idempotent: true},
+    {Method: "GetComplianceHistory", Idempotent: true},
+    {Method: "GetStatusTimeline", Idempotent: true},
+    {Method: "ListScheduleRuns", Idempotent: true},
+    {Method: "PinResult"},
+    {Method: "UnpinResult"},
+    {Method: "GetPinnedResult", Idempotent: true},
+    {Method: "GetCacheStats", Idempotent: true},
+}
+
+// Load balancing policies clients may be told to use. weighted_round_robin
+// weighs replicas by the ORCA load reports attached to every response.
+var supportedLBPolicies = []string{"pick_first", "round_robin", "weighted_round_robin"}
+
+// ClientConfig - load balancing and retry settings advertised to clients
+type ClientConfig struct {
+    LBPolicy            string        `env:"CLIENT_LB_POLICY"`
+    Timeout             time.Duration `env:"CLIENT_TIMEOUT"`               // Default per-call deadline, 0 sets none
+    RetryMaxAttempts    int           `env:"CLIENT_RETRY_MAX_ATTEMPTS"`    // Below 2 disables client retries
+    RetryInitialBackoff time.Duration `env:"CLIENT_RETRY_INITIAL_BACKOFF"`
+    RetryMaxBackoff     time.Duration `env:"CLIENT_RETRY_MAX_BACKOFF"`
+    RetryableCodes      string        `env:"CLIENT_RETRYABLE_CODES"`       // Comma-separated status codes, e.g. "UNAVAILABLE"
+}
+
+// gRPC service config JSON, see grpc/doc/service_config.md
+type serviceConfig struct {
+    LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+    MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+}
+
+type methodConfig struct {
+    Name         []methodName `json:"name"`
+    WaitForReady bool         `json:"waitForReady"`
+    Timeout      string       `json:"timeout,omitempty"`
+    RetryPolicy  *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type methodName struct {
+    Service string `json:"service"`
+    Method  string `json:"method,omitempty"`
+}
+
+type retryPolicy struct {
+    MaxAttempts          int      `json:"maxAttempts"`
+    InitialBackoff       string   `json:"initialBackoff"`
+    MaxBackoff           string   `json:"maxBackoff"`
+    BackoffMultiplier    float64  `json:"backoffMultiplier"`
+    RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// Build the service config JSON advertised to clients, validating settings
+func buildServiceConfig(config ClientConfig) (string, error) {
+    if !containsString(supportedLBPolicies, config.LBPolicy) {
+        return "", fmt.Errorf("unsupported load balancing policy %q", config.LBPolicy)
+    }
+    sc := serviceConfig{LoadBalancingConfig: []map[string]struct{}{{config.LBPolicy: {}}}}
+
+    // gRPC ignores retry policies with fewer than two attempts
+    var retry *retryPolicy
+    if config.RetryMaxAttempts >= 2 {
+        if config.RetryInitialBackoff <= 0 || config.RetryMaxBackoff < config.RetryInitialBackoff {
+            return "", fmt.Errorf("retry backoff must satisfy 0 < initial <= max")
+        }
+        var retryable []string
+        for _, name := range strings.Split(config.RetryableCodes, ",") {
+            if name = strings.ToUpper(strings.TrimSpace(name)); name == "" {
+                continue
+            }
+            var code codes.Code
+            if err := code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+                return "", fmt.Errorf("invalid retryable status code %q", name)
+            }
+            retryable = append(retryable, name)
+        }
+        if len(retryable) == 0 {
+            return "", fmt.Errorf("retry policy needs at least one retryable status code")
+        }
+        retry = &retryPolicy{
+            MaxAttempts:          config.RetryMaxAttempts,
+            InitialBackoff:       protoDuration(config.RetryInitialBackoff),
+            MaxBackoff:           protoDuration(config.RetryMaxBackoff),
+            BackoffMultiplier:    2,
+            RetryableStatusCodes: retryable,
+        }
+    }
+    if config.Timeout < 0 {
+        return "", fmt.Errorf("client timeout must not be negative")
+    }
+
+    // One method config per distinct (idempotency, timeout), in first-use order
+    type policyKey struct {
+        idempotent bool
+        timeout    time.Duration
+    }
+    index := make(map[policyKey]int)
+    for _, policy := range methodPolicies {
+        timeout := policy.Timeout
+        if timeout == 0 {
+            timeout = config.Timeout
+        }
+        key := policyKey{policy.Idempotent, timeout}
+        i, ok := index[key]
+        if !ok {
+            mc := methodConfig{WaitForReady: policy.Idempotent}
+            if timeout > 0 {
+                mc.Timeout = protoDuration(timeout)
+            }
+            if policy.Idempotent {
+                mc.RetryPolicy = retry
+            }
+            i = len(sc.MethodConfig)
+            index[key] = i
+            sc.MethodConfig = append(sc.MethodConfig, mc)
+        }
+        sc.MethodConfig[i].Name = append(sc.MethodConfig[i].Name, methodName{Service: complianceServiceName, Method: policy.Method})
+    }
+
+    data, err := json.Marshal(sc)
+    if err != nil {
+        return "", err
+    }
+    return string(data), nil
+}
+
+// Duration in the JSON form of google.protobuf.Duration, e.g. "0.1s"
+func protoDuration(d time.Duration) string {
+    return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// GetServiceConfig - the gRPC service config clients should apply, for
+// example with grpc.WithDefaultServiceConfig, to balance and retry correctly
+func (s *ComplianceService) GetServiceConfig(ctx context.Context, _ *emptypb.Empty) (*ServiceConfigResponse, error) {
+    return &ServiceConfigResponse{ServiceConfig: s.serviceConfig}, nil
+}
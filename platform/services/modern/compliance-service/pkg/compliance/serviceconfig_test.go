@@ -0,0 +1,220 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "io"
+    "net"
+    "reflect"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Method configs of an advertised service config, by method name
+func methodConfigs(t *testing.T, data string) (serviceConfig, map[string]methodConfig) {
+    t.Helper()
+    var sc serviceConfig
+    if err := json.Unmarshal([]byte(data), &sc); err != nil {
+        t.Fatalf("service config is not JSON: %v\n%s", err, data)
+    }
+    byMethod := make(map[string]methodConfig)
+    for _, mc := range sc.MethodConfig {
+        for _, name := range mc.Name {
+            if _, ok := byMethod[name.Method]; ok {
+                t.Errorf("%s configured twice", name.Method)
+            }
+            byMethod[name.Method] = mc
+        }
+    }
+    return sc, byMethod
+}
+
+func TestAdvertisedServiceConfig(t *testing.T) {
+    service, _ := newTestService(t)
+    response, err := service.GetServiceConfig(context.Background(), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    sc, byMethod := methodConfigs(t, response.ServiceConfig)
+
+    if len(sc.LoadBalancingConfig) != 1 {
+        t.Fatalf("loadBalancingConfig %v, want one policy", sc.LoadBalancingConfig)
+    }
+    if _, ok := sc.LoadBalancingConfig[0]["round_robin"]; !ok {
+        t.Errorf("loadBalancingConfig %v, want round_robin", sc.LoadBalancingConfig)
+    }
+
+    check := byMethod["CheckCompliance"]
+    want := &retryPolicy{MaxAttempts: 3, InitialBackoff: "0.1s", MaxBackoff: "2s", BackoffMultiplier: 2, RetryableStatusCodes: []string{"UNAVAILABLE"}}
+    if !reflect.DeepEqual(check.RetryPolicy, want) || !check.WaitForReady || check.Timeout != "30s" {
+        t.Errorf("CheckCompliance config %+v, want retries, wait for ready and a 30s timeout", check)
+    }
+    if submit := byMethod["SubmitComplianceCheck"]; submit.RetryPolicy != nil || submit.WaitForReady {
+        t.Errorf("SubmitComplianceCheck config %+v, want no retries for a non-idempotent method", submit)
+    }
+    if stream := byMethod["StreamCompliance"]; stream.Timeout != "" {
+        t.Errorf("StreamCompliance timeout %q, want none", stream.Timeout)
+    }
+
+    // Clients accept it as their default service config
+    conn, err := grpc.NewClient("passthrough:///compliance", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultServiceConfig(response.ServiceConfig))
+    if err != nil {
+        t.Fatalf("grpc rejected the advertised config: %v", err)
+    }
+    conn.Close()
+}
+
+// Every RPC of the service has exactly one client policy
+func TestMethodPoliciesCoverService(t *testing.T) {
+    var methods []string
+    for _, method := range Compliance_ServiceDesc.Methods {
+        methods = append(methods, method.MethodName)
+    }
+    for _, stream := range Compliance_ServiceDesc.Streams {
+        methods = append(methods, stream.StreamName)
+    }
+    if Compliance_ServiceDesc.ServiceName != complianceServiceName {
+        t.Errorf("service %s, config names %s", Compliance_ServiceDesc.ServiceName, complianceServiceName)
+    }
+
+    data, err := buildServiceConfig(ClientConfig{LBPolicy: "pick_first"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    _, byMethod := methodConfigs(t, data)
+    for _, method := range methods {
+        if _, ok := byMethod[method]; !ok {
+            t.Errorf("no client policy for %s", method)
+        }
+    }
+    if len(byMethod) != len(methods) {
+        t.Errorf("%d methods configured, the service has %d", len(byMethod), len(methods))
+    }
+}
+
+func TestBuildServiceConfigRejectsInvalidSettings(t *testing.T) {
+    valid := ClientConfig{LBPolicy: "round_robin", RetryMaxAttempts: 3, RetryInitialBackoff: 100 * time.Millisecond, RetryMaxBackoff: time.Second, RetryableCodes: "UNAVAILABLE"}
+    tests := []struct {
+        name   string
+        modify func(*ClientConfig)
+        err    string
+    }{
+        {name: "unknown policy", modify: func(c *ClientConfig) { c.LBPolicy = "random" }, err: "unsupported load balancing policy"},
+        {name: "unknown status code", modify: func(c *ClientConfig) { c.RetryableCodes = "UNAVAILABLE,SOMETIMES" }, err: "invalid retryable status code"},
+        {name: "no status codes", modify: func(c *ClientConfig) { c.RetryableCodes = " , " }, err: "at least one retryable status code"},
+        {name: "backoff inverted", modify: func(c *ClientConfig) { c.RetryMaxBackoff = time.Millisecond }, err: "retry backoff"},
+        {name: "negative timeout", modify: func(c *ClientConfig) { c.Timeout = -time.Second }, err: "client timeout"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := valid
+            tt.modify(&config)
+            if _, err := buildServiceConfig(config); err == nil || !strings.Contains(err.Error(), tt.err) {
+                t.Errorf("buildServiceConfig() = %v, want %q", err, tt.err)
+            }
+        })
+    }
+
+    // A single attempt disables retries instead of failing
+    config := valid
+    config.RetryMaxAttempts = 1
+    config.RetryableCodes = "SOMETIMES"
+    data, err := buildServiceConfig(config)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if strings.Contains(data, "retryPolicy") {
+        t.Errorf("retry policy advertised with one attempt: %s", data)
+    }
+}
+
+// Against a server failing every call with Unavailable, a client using the
+// advertised config retries exactly the methods declared idempotent, up to
+// the configured attempts, and calls every other method once
+func TestClientRetriesOnlyIdempotentMethods(t *testing.T) {
+    const attempts = 3
+    var mu sync.Mutex
+    calls := make(map[string]int)
+    lis, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    server := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+        method, _ := grpc.MethodFromServerStream(stream)
+        mu.Lock()
+        calls[method]++
+        mu.Unlock()
+        return status.Error(codes.Unavailable, "replica restarting")
+    }))
+    go server.Serve(lis)
+    t.Cleanup(server.Stop)
+
+    config, err := buildServiceConfig(ClientConfig{
+        LBPolicy:            "pick_first",
+        RetryMaxAttempts:    attempts,
+        RetryInitialBackoff: time.Millisecond,
+        RetryMaxBackoff:     5 * time.Millisecond,
+        RetryableCodes:      "UNAVAILABLE",
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultServiceConfig(config))
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { conn.Close() })
+
+    ctx := context.Background()
+    streams := make(map[string]grpc.StreamDesc)
+    for _, desc := range Compliance_ServiceDesc.Streams {
+        streams[desc.StreamName] = desc
+    }
+    for _, policy := range methodPolicies {
+        t.Run(policy.Method, func(t *testing.T) {
+            fullMethod := "/" + complianceServiceName + "/" + policy.Method
+            var err error
+            if desc, ok := streams[policy.Method]; ok {
+                err = callStream(ctx, conn, &desc, fullMethod)
+            } else {
+                err = conn.Invoke(ctx, fullMethod, &emptypb.Empty{}, &emptypb.Empty{})
+            }
+            if status.Code(err) != codes.Unavailable {
+                t.Fatalf("call = %v, want Unavailable", err)
+            }
+
+            want := 1
+            if policy.Idempotent {
+                want = attempts
+            }
+            mu.Lock()
+            defer mu.Unlock()
+            if got := calls[fullMethod]; got != want {
+                t.Errorf("%d attempts, want %d", got, want)
+            }
+        })
+    }
+}
+
+// Send one empty message on a stream and read until it fails
+func callStream(ctx context.Context, conn *grpc.ClientConn, desc *grpc.StreamDesc, fullMethod string) error {
+    stream, err := conn.NewStream(ctx, desc, fullMethod)
+    if err != nil {
+        return err
+    }
+    if err := stream.SendMsg(&emptypb.Empty{}); err != nil && err != io.EOF {
+        return err
+    }
+    if err := stream.CloseSend(); err != nil {
+        return err
+    }
+    return stream.RecvMsg(&emptypb.Empty{})
+}
@@ -0,0 +1,322 @@
+package compliance
+
+import (
+    "context"
+    "log"
+    "math"
+    "math/rand"
+    "sort"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Comparison samples kept per shadow ruleset, newest first, and how long
+// they outlive the last one recorded
+const (
+    shadowComparisonSamples   = 10000
+    shadowComparisonRetention = 30 * 24 * time.Hour
+)
+
+// Score deltas below this are reported as unchanged
+const shadowDeltaEpsilon = 0.01
+
+// A candidate ruleset for a framework. Rules are compiled in, so a new
+// version ships as a checker registered as the framework's shadow; it is
+// sampled alongside the active checker until promoted.
+type shadowRuleset struct {
+    version string
+    checker FrameworkChecker
+}
+
+// RegisterShadow registers a candidate checker for a registered framework.
+// Shadows are registered at startup, before the engine serves requests.
+func (e *RulesEngine) RegisterShadow(framework, version string, checker FrameworkChecker) {
+    e.shadows[framework] = shadowRuleset{version: version, checker: checker}
+}
+
+// Checker currently serving a framework: its shadow once promoted
+func (e *RulesEngine) activeChecker(framework string) (FrameworkChecker, bool) {
+    if shadow, ok := e.promotedShadow(framework); ok {
+        return shadow.checker, true
+    }
+    checker, ok := e.checkers[framework]
+    return checker, ok
+}
+
+// Ruleset version serving a framework
+func (e *RulesEngine) frameworkVersion(framework string) string {
+    if shadow, ok := e.promotedShadow(framework); ok {
+        return shadow.version
+    }
+    return e.rulesetVersion
+}
+
+func (e *RulesEngine) promotedShadow(framework string) (shadowRuleset, bool) {
+    promoted := e.promoted.Load()
+    if promoted == nil {
+        return shadowRuleset{}, false
+    }
+    shadow, ok := e.shadows[framework]
+    return shadow, ok && (*promoted)[framework] == shadow.version
+}
+
+// Shadow still being compared for a framework, if any
+func (e *RulesEngine) pendingShadow(framework string) (shadowRuleset, bool) {
+    shadow, ok := e.shadows[framework]
+    if !ok {
+        return shadowRuleset{}, false
+    }
+    if _, promoted := e.promotedShadow(framework); promoted {
+        return shadowRuleset{}, false
+    }
+    return shadow, true
+}
+
+// SetPromoted activates the shadows named by framework -> version; any other
+// framework reverts to its built-in checker. Memoized results are dropped
+// when the active rulesets change. Versions this binary does not have are
+// skipped, so a replica running an older build keeps serving.
+func (e *RulesEngine) SetPromoted(promoted map[string]string) {
+    active := make(map[string]string, len(promoted))
+    for framework, version := range promoted {
+        if shadow, ok := e.shadows[framework]; ok && shadow.version == version {
+            active[framework] = version
+        } else {
+            log.Printf("Promoted ruleset %s %s is not registered here, keeping %s", framework, version, e.rulesetVersion)
+        }
+    }
+
+    previous := e.promoted.Swap(&active)
+    if previous != nil && mapsEqual(*previous, active) {
+        return
+    }
+    if previous == nil && len(active) == 0 {
+        return
+    }
+    if e.memo != nil {
+        e.memo.Purge()
+    }
+    for framework, version := range active {
+        log.Printf("Ruleset %s %s active", framework, version)
+    }
+}
+
+func mapsEqual(a, b map[string]string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    for key, value := range a {
+        if other, ok := b[key]; !ok || other != value {
+            return false
+        }
+    }
+    return true
+}
+
+// Run a shadow checker off the request path for a sample of freshly
+// evaluated frameworks, recording how its score differs from the served one.
+// Shadow runs take bulk worker slots and are dropped when shed.
+func (s *ComplianceService) shadowEvaluate(ctx context.Context, req *ComplianceRequest, results []*FrameworkResult, fresh []*FrameworkResult, rate float64) {
+    if rate <= 0 || len(s.engine.shadows) == 0 {
+        return
+    }
+    for _, active := range fresh {
+        shadow, ok := s.engine.pendingShadow(active.Framework)
+        if !ok || rand.Float64() >= rate {
+            continue
+        }
+        go s.runShadow(context.WithoutCancel(ctx), req, results, active, shadow)
+    }
+}
+
+func (s *ComplianceService) runShadow(ctx context.Context, req *ComplianceRequest, results []*FrameworkResult, active *FrameworkResult, shadow shadowRuleset) {
+    framework := active.Framework
+    if s.engine.scheduler != nil {
+        if err := s.engine.scheduler.Acquire(ctx, 0); err != nil {
+            shadowEvaluations.WithLabelValues(framework, "shed").Inc()
+            return
+        }
+        defer s.engine.scheduler.Release()
+    }
+
+    // The shadow reads the same prerequisite results the active checker did
+    completed := &dependencyResults{results: make(map[string]*FrameworkResult, len(results))}
+    for _, result := range results {
+        completed.set(result.Framework, result)
+    }
+    result, err := runChecker(context.WithValue(ctx, dependencyResultsKey{}, completed), shadow.checker, req)
+    if err != nil || result == nil {
+        shadowEvaluations.WithLabelValues(framework, "failed").Inc()
+        return
+    }
+
+    sample := &ShadowSample{
+        OrganizationId: req.OrganizationId,
+        ActiveScore:    roundScore(active.Score),
+        ShadowScore:    roundScore(result.Score),
+        EvaluatedAt:    time.Now().Unix(),
+    }
+    shadowEvaluations.WithLabelValues(framework, "compared").Inc()
+    shadowScoreDelta.WithLabelValues(framework).Observe(sample.ShadowScore - sample.ActiveScore)
+    if err := s.recordShadowSample(ctx, framework, shadow.version, sample); err != nil {
+        log.Printf("Failed to record shadow comparison for %s %s: %v", framework, shadow.version, err)
+    }
+}
+
+func shadowComparisonKey(framework, version string) string {
+    return "shadow-comparison:" + framework + ":" + version
+}
+
+// Append a comparison sample, keeping the newest ones
+func (s *ComplianceService) recordShadowSample(ctx context.Context, framework, version string, sample *ShadowSample) error {
+    data, err := proto.Marshal(sample)
+    if err != nil {
+        return err
+    }
+    key := shadowComparisonKey(framework, version)
+    pipe := s.redis.TxPipeline()
+    pipe.LPush(ctx, key, data)
+    pipe.LTrim(ctx, key, 0, shadowComparisonSamples-1)
+    pipe.Expire(ctx, key, shadowComparisonRetention)
+    _, err = pipe.Exec(ctx)
+    return err
+}
+
+// GetShadowComparison - admin RPC summarizing how a framework's shadow
+// ruleset scores against the active one on sampled evaluations
+func (s *ComplianceService) GetShadowComparison(ctx context.Context, req *ShadowComparisonRequest) (*ShadowComparison, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    shadow, ok := s.engine.shadows[req.Framework]
+    if !ok {
+        return nil, status.Errorf(codes.NotFound, "no shadow ruleset registered for %s", req.Framework)
+    }
+
+    values, err := s.redis.LRange(ctx, shadowComparisonKey(req.Framework, shadow.version), 0, -1).Result()
+    if err != nil {
+        return nil, storeError(err, "failed to load shadow comparison")
+    }
+    deltas := make([]float64, 0, len(values))
+    for _, value := range values {
+        sample := &ShadowSample{}
+        if err := proto.Unmarshal([]byte(value), sample); err != nil {
+            continue
+        }
+        deltas = append(deltas, sample.ShadowScore-sample.ActiveScore)
+    }
+
+    _, promoted := s.engine.promotedShadow(req.Framework)
+    comparison := summarizeShadowDeltas(deltas)
+    comparison.Framework = req.Framework
+    comparison.ActiveVersion = s.engine.frameworkVersion(req.Framework)
+    comparison.ShadowVersion = shadow.version
+    comparison.Promoted = promoted
+    comparison.SampleRate = s.runtimeConfig().ShadowSampleRate
+    return comparison, nil
+}
+
+// Distribution of shadow-minus-active score deltas
+func summarizeShadowDeltas(deltas []float64) *ShadowComparison {
+    comparison := &ShadowComparison{Samples: int32(len(deltas))}
+    if len(deltas) == 0 {
+        return comparison
+    }
+    sort.Float64s(deltas)
+
+    absolute := make([]float64, len(deltas))
+    for i, delta := range deltas {
+        absolute[i] = math.Abs(delta)
+        switch {
+        case delta > shadowDeltaEpsilon:
+            comparison.Improved++
+        case delta < -shadowDeltaEpsilon:
+            comparison.Worsened++
+        default:
+            comparison.Unchanged++
+        }
+    }
+    mean, _ := meanStdDev(deltas)
+    meanAbs, _ := meanStdDev(absolute)
+
+    comparison.MeanDelta = roundScore(mean)
+    comparison.MeanAbsDelta = roundScore(meanAbs)
+    comparison.MinDelta = roundScore(deltas[0])
+    comparison.MaxDelta = roundScore(deltas[len(deltas)-1])
+    comparison.P05Delta = roundScore(deltas[int(0.05*float64(len(deltas)-1))])
+    comparison.P50Delta = roundScore(deltas[int(0.50*float64(len(deltas)-1))])
+    comparison.P95Delta = roundScore(deltas[int(0.95*float64(len(deltas)-1))])
+    return comparison
+}
+
+// PromoteRuleset - admin RPC activating a framework's shadow ruleset on
+// every replica through the operational overrides. Reverting is an
+// UpdateOperationalState that drops the framework from promoted_rulesets.
+func (s *ComplianceService) PromoteRuleset(ctx context.Context, req *PromoteRulesetRequest) (*OperationalState, error) {
+    if err := s.requireAdmin(ctx); err != nil {
+        return nil, err
+    }
+    if req.Framework == "" || req.Version == "" || req.UpdatedBy == "" {
+        return nil, status.Error(codes.InvalidArgument, "framework, version and updated_by are required")
+    }
+    shadow, ok := s.engine.shadows[req.Framework]
+    if !ok || shadow.version != req.Version {
+        return nil, status.Errorf(codes.NotFound, "no shadow ruleset %s registered for %s", req.Version, req.Framework)
+    }
+
+    current, err := s.operational.Load(ctx)
+    if err != nil {
+        return nil, storeError(err, "failed to load operational state")
+    }
+    state := proto.Clone(current).(*OperationalState)
+    if state.PromotedRulesets == nil {
+        state.PromotedRulesets = make(map[string]string)
+    }
+    state.PromotedRulesets[req.Framework] = req.Version
+    state.UpdatedBy = req.UpdatedBy
+    state.UpdatedAt = timestamppb.Now()
+
+    stored, err := s.operational.CompareAndSet(ctx, current.Version, state)
+    if conflict, ok := err.(*stateConflictError); ok {
+        operationalStateConflicts.Inc()
+        return nil, reasonError(codes.Aborted, reasonVersionConflict, conflict.Error()+"; retry")
+    } else if err != nil {
+        return nil, storeError(err, "failed to store operational state")
+    }
+    log.Printf("Audit: rpc=PromoteRuleset framework=%s version=%s state_version=%d updated_by=%s", req.Framework, req.Version, stored.Version, redact(fieldPrincipal, req.UpdatedBy))
+
+    if err := s.operational.refresh(ctx, s.rebuildRuntimeConfig); err != nil {
+        log.Printf("Operational state refresh failed: %v", err)
+    }
+    return stored, nil
+}
+
+// Shadow ruleset metrics
+var (
+    shadowEvaluations = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_shadow_evaluations_total",
+            Help: "Sampled shadow ruleset evaluations, by outcome: compared, failed or shed",
+        },
+        []string{"framework", "outcome"},
+    )
+
+    shadowScoreDelta = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "compliance_shadow_score_delta",
+            Help:    "Shadow ruleset score minus the served score on sampled evaluations",
+            Buckets: []float64{-50, -20, -10, -5, -2, -1, -0.1, 0.1, 1, 2, 5, 10, 20, 50},
+        },
+        []string{"framework"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(shadowEvaluations)
+    prometheus.MustRegister(shadowScoreDelta)
+}
@@ -0,0 +1,140 @@
+package compliance
+
+import (
+    "sort"
+    "sync"
+    "time"
+)
+
+// Outcome of a framework skipped because the status was already decided
+const frameworkShortCircuited = "SHORT_CIRCUITED"
+
+// Weight of the newest sample in checker latency averages
+const latencyEWMAAlpha = 0.2
+
+// Whether a request may stop evaluating once its status is decided. Only
+// interactive requests opt in; trends need every framework's score.
+func shortCircuitEnabled(req *ComplianceRequest) bool {
+    return req.ShortCircuit && req.Priority > 0 && !req.IncludeTrend
+}
+
+// Range of overall scores still reachable when only some frameworks have
+// results. An outstanding framework may score anything in [0, 100] or fail
+// and drop out of the weighted mean. Including it at 100 never lowers the
+// mean and including it at 0 never raises it, so the extremes are every
+// outstanding framework at 100 and every one at 0.
+func overallScoreBounds(known []*FrameworkResult, outstanding []string, weights map[string]float64) (lo, hi float64) {
+    knownScore, knownWeight := 0.0, 0.0
+    for _, result := range known {
+        if !scoredResult(result) {
+            continue
+        }
+        if weight, ok := weights[result.Framework]; ok {
+            knownScore += result.Score * weight
+            knownWeight += weight
+        }
+    }
+    outstandingWeight := 0.0
+    for _, framework := range outstanding {
+        outstandingWeight += weights[framework]
+    }
+
+    total := knownWeight + outstandingWeight
+    if total <= 0 {
+        return 0, 0
+    }
+    if knownWeight == 0 {
+        // Everything outstanding failing leaves an overall score of 0
+        return 0, 100
+    }
+    return knownScore / total, (knownScore + 100*outstandingWeight) / total
+}
+
+// The status if no outstanding result can change it. Bounds are rounded
+// like the final score, which is monotone, so their bands bound the final
+// band. A failed SAMA sub-domain gate caps COMPLIANT, so COMPLIANT is only
+// decided once SAMA is known when gates are configured; likewise a gating
+// framework must be known unless the status is already NON_COMPLIANT.
+func (s *ComplianceService) decidedStatus(known []*FrameworkResult, outstanding []string, runtime *RuntimeConfig) (string, bool) {
+    lo, hi := overallScoreBounds(known, outstanding, runtime.Weights)
+    status := s.determineStatus(roundScore(lo), runtime.Thresholds)
+    if s.determineStatus(roundScore(hi), runtime.Thresholds) != status {
+        return "", false
+    }
+    // Known gating frameworks cap the status; an outstanding one may still
+    // cap it unless it is already the lowest band
+    if len(runtime.FrameworkGates) > 0 {
+        status, _ = applyFrameworkGates(status, known, runtime.FrameworkGates)
+        for _, framework := range outstanding {
+            if _, gated := runtime.FrameworkGates[framework]; gated && statusRank(status) > 0 {
+                return "", false
+            }
+        }
+    }
+    if status == "COMPLIANT" && len(runtime.SamaSubdomainGates) > 0 {
+        if samaGateFailed(known, runtime.SamaSubdomainGates) {
+            return "PARTIALLY_COMPLIANT", true
+        }
+        if containsString(outstanding, "SAMA") {
+            return "", false
+        }
+    }
+    return status, true
+}
+
+// Whether a known SAMA result fails a sub-domain gate, without recording
+// gate outcomes on the result
+func samaGateFailed(results []*FrameworkResult, gates map[string]float64) bool {
+    for _, result := range results {
+        for _, subdomain := range result.GetSamaDetails().GetSubdomains() {
+            if minimum, ok := gates[subdomain.Name]; ok && roundScore(subdomain.Score) < minimum {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// Results marking frameworks skipped by a short circuit
+func shortCircuitedResults(frameworks []string) []*FrameworkResult {
+    results := make([]*FrameworkResult, len(frameworks))
+    for i, framework := range frameworks {
+        results[i] = &FrameworkResult{Framework: framework, Outcome: frameworkShortCircuited}
+    }
+    return results
+}
+
+// checkerLatency - exponentially weighted average run time per checker
+type checkerLatency struct {
+    mu      sync.Mutex
+    average map[string]time.Duration
+}
+
+func (l *checkerLatency) observe(framework string, d time.Duration) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if l.average == nil {
+        l.average = make(map[string]time.Duration)
+    }
+    if previous, ok := l.average[framework]; ok {
+        d = time.Duration(latencyEWMAAlpha*float64(d) + (1-latencyEWMAAlpha)*float64(previous))
+    }
+    l.average[framework] = d
+}
+
+// Frameworks ordered fastest first; unmeasured ones keep registration order
+// ahead of measured ones, so they get measured
+func (l *checkerLatency) order(frameworks []string) []string {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    ordered := append([]string(nil), frameworks...)
+    sort.SliceStable(ordered, func(i, j int) bool {
+        a, aok := l.average[ordered[i]]
+        b, bok := l.average[ordered[j]]
+        if aok != bok {
+            return !aok
+        }
+        return a < b
+    })
+    return ordered
+}
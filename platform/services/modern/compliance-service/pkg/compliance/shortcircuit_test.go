@@ -0,0 +1,285 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+var shortCircuitWeights = map[string]float64{"NCA": 0.25, "SAMA": 0.25, "PDPL": 0.20, "ISO27001": 0.15, "NIST": 0.15}
+
+func TestOverallScoreBounds(t *testing.T) {
+    tests := []struct {
+        name        string
+        known       []*FrameworkResult
+        outstanding []string
+        wantLo      float64
+        wantHi      float64
+    }{
+        {
+            // (80×.25 + 60×.25) / .7 and (35 + 100×.2) / .7
+            name:        "one outstanding",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 80}, {Framework: "SAMA", Score: 60}},
+            outstanding: []string{"PDPL"},
+            wantLo:      35 / 0.7,
+            wantHi:      55 / 0.7,
+        },
+        {
+            name:   "none outstanding",
+            known:  []*FrameworkResult{{Framework: "NCA", Score: 80}, {Framework: "SAMA", Score: 60}},
+            wantLo: 70,
+            wantHi: 70,
+        },
+        {
+            name:        "nothing known",
+            outstanding: []string{"NCA", "SAMA"},
+            wantLo:      0,
+            wantHi:      100,
+        },
+        {
+            // The short-circuited and not applicable results carry no weight:
+            // 90×.25 / .4 and (22.5 + 100×.15) / .4
+            name: "unscored results ignored",
+            known: []*FrameworkResult{
+                {Framework: "NCA", Score: 90},
+                {Framework: "SAMA", Outcome: frameworkNotApplicable},
+                {Framework: "PDPL", Outcome: frameworkShortCircuited},
+            },
+            outstanding: []string{"NIST"},
+            wantLo:      22.5 / 0.4,
+            wantHi:      37.5 / 0.4,
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            lo, hi := overallScoreBounds(tt.known, tt.outstanding, shortCircuitWeights)
+            if !approxEqual(lo, tt.wantLo) || !approxEqual(hi, tt.wantHi) {
+                t.Errorf("overallScoreBounds() = [%v, %v], want [%v, %v]", lo, hi, tt.wantLo, tt.wantHi)
+            }
+        })
+    }
+}
+
+// Scores an outstanding framework is tried at when checking a decided
+// status, either side of each default threshold; -1 fails the framework
+var outstandingScores = []float64{-1, 0, 69.994, 69.995, 70, 89.994, 89.995, 90, 100}
+
+// Every way the outstanding frameworks can finish
+func completions(outstanding []string) [][]*FrameworkResult {
+    if len(outstanding) == 0 {
+        return [][]*FrameworkResult{nil}
+    }
+    var all [][]*FrameworkResult
+    for _, rest := range completions(outstanding[1:]) {
+        for _, score := range outstandingScores {
+            if score < 0 {
+                all = append(all, rest)
+                continue
+            }
+            result := &FrameworkResult{Framework: outstanding[0], Score: score}
+            if outstanding[0] == "SAMA" {
+                result.Details = &FrameworkResult_SamaDetails{SamaDetails: &SAMADetails{
+                    Subdomains: []*SubdomainResult{{Name: "BCM", Score: score}},
+                }}
+            }
+            all = append(all, append([]*FrameworkResult{result}, rest...))
+        }
+    }
+    return all
+}
+
+// The status a finished evaluation reports, as the response builder derives it
+func finalStatus(s *ComplianceService, results []*FrameworkResult, runtime *RuntimeConfig) string {
+    status := s.determineStatus(roundScore(s.calculateOverallScore(results, runtime.Weights)), runtime.Thresholds)
+    if failed := applySamaSubdomainGates(results, runtime.SamaSubdomainGates); len(failed) > 0 && status == "COMPLIANT" {
+        status = "PARTIALLY_COMPLIANT"
+    }
+    status, _ = applyFrameworkGates(status, results, runtime.FrameworkGates)
+    return status
+}
+
+// A status is decided only when no way of finishing the outstanding
+// frameworks could report another; each decided case is checked against
+// every completion
+func TestDecidedStatus(t *testing.T) {
+    sama := func(score, bcm float64) *FrameworkResult {
+        return &FrameworkResult{Framework: "SAMA", Score: score, Details: &FrameworkResult_SamaDetails{SamaDetails: &SAMADetails{
+            Subdomains: []*SubdomainResult{{Name: "BCM", Score: bcm}},
+        }}}
+    }
+    tests := []struct {
+        name        string
+        known       []*FrameworkResult
+        outstanding []string
+        thresholds  StatusThresholds
+        gates       map[string]StatusThresholds
+        samaGates   map[string]float64
+        wantStatus  string
+        wantDecided bool
+    }{
+        {
+            // [14, 44] lies below 70
+            name:        "non-compliant whatever the rest score",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 20}, {Framework: "SAMA", Score: 20}, {Framework: "PDPL", Score: 20}},
+            outstanding: []string{"ISO27001", "NIST"},
+            wantStatus:  "NON_COMPLIANT",
+            wantDecided: true,
+        },
+        {
+            // [40, 90] spans every band
+            name:        "outstanding weight spans bands",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 80}, {Framework: "SAMA", Score: 80}},
+            outstanding: []string{"PDPL", "ISO27001", "NIST"},
+        },
+        {
+            // [51, 66] stays below 70
+            name:        "upper bound just short of a band",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 60}, {Framework: "SAMA", Score: 60}, {Framework: "PDPL", Score: 60}, {Framework: "ISO27001", Score: 60}},
+            outstanding: []string{"NIST"},
+            wantStatus:  "NON_COMPLIANT",
+            wantDecided: true,
+        },
+        {
+            // [69.98, 84.98]: NIST at 0 pulls the score just under 70,
+            // though NIST failing or at 100 stays partially compliant
+            name:        "outstanding at zero reaches the lower band",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 82.33}, {Framework: "SAMA", Score: 82.33}, {Framework: "PDPL", Score: 82.33}, {Framework: "ISO27001", Score: 82.33}},
+            outstanding: []string{"NIST"},
+            thresholds:  StatusThresholds{Compliant: 90, PartiallyCompliant: 70},
+        },
+        {
+            // [85, 100] clears 80
+            name:        "compliant whatever the rest score",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 100}, {Framework: "SAMA", Score: 100}, {Framework: "PDPL", Score: 100}, {Framework: "ISO27001", Score: 100}},
+            outstanding: []string{"NIST"},
+            thresholds:  StatusThresholds{Compliant: 80, PartiallyCompliant: 70},
+            wantStatus:  "COMPLIANT",
+            wantDecided: true,
+        },
+        {
+            name:        "outstanding gating framework may cap",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 100}, {Framework: "SAMA", Score: 100}, {Framework: "PDPL", Score: 100}, {Framework: "ISO27001", Score: 100}},
+            outstanding: []string{"NIST"},
+            thresholds:  StatusThresholds{Compliant: 80, PartiallyCompliant: 70},
+            gates:       map[string]StatusThresholds{"NIST": {Compliant: 90, PartiallyCompliant: 70}},
+        },
+        {
+            // [72.5, 87.5] is compliant above 70, but NCA's gate caps it
+            name:        "known gating framework caps to the lowest band",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 50}, {Framework: "SAMA", Score: 100}, {Framework: "PDPL", Score: 100}, {Framework: "ISO27001", Score: 100}},
+            outstanding: []string{"NIST"},
+            thresholds:  StatusThresholds{Compliant: 70, PartiallyCompliant: 60},
+            gates:       map[string]StatusThresholds{"NCA": {Compliant: 90, PartiallyCompliant: 70}, "NIST": {Compliant: 90, PartiallyCompliant: 70}},
+            wantStatus:  "NON_COMPLIANT",
+            wantDecided: true,
+        },
+        {
+            name:        "outstanding SAMA may fail a sub-domain gate",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 100}, {Framework: "PDPL", Score: 100}, {Framework: "ISO27001", Score: 100}, {Framework: "NIST", Score: 100}},
+            outstanding: []string{"SAMA"},
+            thresholds:  StatusThresholds{Compliant: 70, PartiallyCompliant: 60},
+            samaGates:   map[string]float64{"BCM": 85},
+        },
+        {
+            name:        "known SAMA failing a sub-domain gate",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 100}, sama(100, 60), {Framework: "PDPL", Score: 100}, {Framework: "ISO27001", Score: 100}},
+            outstanding: []string{"NIST"},
+            thresholds:  StatusThresholds{Compliant: 80, PartiallyCompliant: 70},
+            samaGates:   map[string]float64{"BCM": 85},
+            wantStatus:  "PARTIALLY_COMPLIANT",
+            wantDecided: true,
+        },
+        {
+            // Sub-domain gates only rule out COMPLIANT
+            name:        "non-compliant with SAMA outstanding",
+            known:       []*FrameworkResult{{Framework: "NCA", Score: 10}, {Framework: "PDPL", Score: 10}, {Framework: "ISO27001", Score: 10}},
+            outstanding: []string{"SAMA", "NIST"},
+            samaGates:   map[string]float64{"BCM": 85},
+            wantStatus:  "NON_COMPLIANT",
+            wantDecided: true,
+        },
+    }
+
+    s := &ComplianceService{}
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            runtime := &RuntimeConfig{
+                Weights:            shortCircuitWeights,
+                Thresholds:         tt.thresholds,
+                FrameworkGates:     tt.gates,
+                SamaSubdomainGates: tt.samaGates,
+            }
+            if runtime.Thresholds == (StatusThresholds{}) {
+                runtime.Thresholds = StatusThresholds{Compliant: 90, PartiallyCompliant: 70}
+            }
+            status, decided := s.decidedStatus(tt.known, tt.outstanding, runtime)
+            if decided != tt.wantDecided || status != tt.wantStatus {
+                t.Fatalf("decidedStatus() = %q, %v, want %q, %v", status, decided, tt.wantStatus, tt.wantDecided)
+            }
+            if !decided {
+                return
+            }
+            for _, rest := range completions(tt.outstanding) {
+                results := append(append([]*FrameworkResult(nil), tt.known...), rest...)
+                if got := finalStatus(s, results, runtime); got != status {
+                    t.Errorf("finishing with %v reports %s, but %s was decided", scoresOf(rest), got, status)
+                }
+            }
+        })
+    }
+}
+
+func scoresOf(results []*FrameworkResult) map[string]float64 {
+    scores := make(map[string]float64, len(results))
+    for _, result := range results {
+        scores[result.Framework] = result.Score
+    }
+    return scores
+}
+
+// An opted-in interactive request stops once its status is decided: the
+// slow frameworks are short-circuited and the status matches a full run
+func TestCheckComplianceShortCircuits(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeCacheTTL = 0
+    })
+    // Any two of NCA, SAMA and PDPL at 40 leave 70 reachable; all three
+    // bound the score to [28, 58]
+    spy := spyOnCheckers(service)
+    for _, framework := range []string{"NCA", "SAMA", "PDPL"} {
+        spy.setScore(framework, 40)
+    }
+    for _, framework := range []string{"ISO27001", "NIST"} {
+        service.faults.Set(framework, Fault{Delay: time.Second})
+    }
+
+    ctx := context.Background()
+    start := time.Now()
+    response, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1", ShortCircuit: true, Priority: 1})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if elapsed := time.Since(start); elapsed >= time.Second {
+        t.Errorf("short-circuited request took %v, want less than the slow checks", elapsed)
+    }
+    skipped := map[string]bool{}
+    for _, result := range response.FrameworkResults {
+        if result.Outcome == frameworkShortCircuited {
+            skipped[result.Framework] = true
+        }
+    }
+    if !skipped["ISO27001"] || !skipped["NIST"] || len(skipped) != 2 {
+        t.Errorf("short-circuited %v, want ISO27001 and NIST", skipped)
+    }
+
+    for _, framework := range []string{"ISO27001", "NIST"} {
+        service.faults.Set(framework, Fault{})
+    }
+    full, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1", ForceRefresh: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if response.Status != full.Status {
+        t.Errorf("short-circuited status %s, full evaluation %s", response.Status, full.Status)
+    }
+}
@@ -0,0 +1,74 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+)
+
+// RequestTimings - stage breakdown of a single CheckCompliance call
+type RequestTimings struct {
+    mu         sync.Mutex
+    Cache      time.Duration // Response and framework cache reads
+    Evidence   time.Duration // Loading uploaded evidence and querying connectors
+    Frameworks map[string]time.Duration
+    Scoring    time.Duration
+    CacheWrite time.Duration
+    Publish    time.Duration
+}
+
+type requestTimingsKey struct{}
+
+// Attach a timings collector to the request context
+func withRequestTimings(ctx context.Context) (context.Context, *RequestTimings) {
+    timings := &RequestTimings{Frameworks: make(map[string]time.Duration)}
+    return context.WithValue(ctx, requestTimingsKey{}, timings), timings
+}
+
+// Record how long a framework check took, if the request is being timed
+func recordFrameworkTiming(ctx context.Context, framework string, d time.Duration) {
+    timings, ok := ctx.Value(requestTimingsKey{}).(*RequestTimings)
+    if !ok {
+        return
+    }
+    timings.mu.Lock()
+    timings.Frameworks[framework] = d
+    timings.mu.Unlock()
+}
+
+// Breakdown such as "cache=1.2ms NCA=3ms SAMA=2.1s scoring=80µs cache_write=1ms publish=400µs"
+func (t *RequestTimings) String() string {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    frameworks := make([]string, 0, len(t.Frameworks))
+    for framework := range t.Frameworks {
+        frameworks = append(frameworks, framework)
+    }
+    sort.Strings(frameworks)
+
+    parts := []string{fmt.Sprintf("cache=%s", t.Cache)}
+    if t.Evidence > 0 {
+        parts = append(parts, fmt.Sprintf("evidence=%s", t.Evidence))
+    }
+    for _, framework := range frameworks {
+        parts = append(parts, fmt.Sprintf("%s=%s", framework, t.Frameworks[framework]))
+    }
+    parts = append(parts, fmt.Sprintf("scoring=%s cache_write=%s publish=%s", t.Scoring, t.CacheWrite, t.Publish))
+    return strings.Join(parts, " ")
+}
+
+// Log a WARN line with the stage breakdown when a request exceeds the
+// configured slow-request threshold
+func (s *ComplianceService) logIfSlow(requestID, organizationID string, total time.Duration, timings *RequestTimings) {
+    threshold := s.config.SlowRequestThreshold
+    if threshold <= 0 || total < threshold {
+        return
+    }
+    log.Printf("WARN slow request: request_id=%s org=%s total=%s threshold=%s %s",
+        requestID, redact(fieldOrganizationID, organizationID), total, threshold, timings)
+}
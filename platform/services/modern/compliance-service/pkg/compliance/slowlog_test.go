@@ -0,0 +1,101 @@
+package compliance
+
+import (
+    "bytes"
+    "context"
+    "log"
+    "regexp"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/metadata"
+)
+
+// Log output captured for the duration of a test
+type logCapture struct {
+    mu  sync.Mutex
+    buf bytes.Buffer
+}
+
+func (c *logCapture) Write(p []byte) (int, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.buf.Write(p)
+}
+
+// Captured lines containing substr
+func (c *logCapture) lines(substr string) []string {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    var lines []string
+    for _, line := range strings.Split(c.buf.String(), "\n") {
+        if strings.Contains(line, substr) {
+            lines = append(lines, line)
+        }
+    }
+    return lines
+}
+
+func captureLog(t *testing.T) *logCapture {
+    capture := &logCapture{}
+    output := log.Writer()
+    log.SetOutput(capture)
+    t.Cleanup(func() { log.SetOutput(output) })
+    return capture
+}
+
+func TestSlowRequestLogged(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.SlowRequestThreshold = 40 * time.Millisecond
+    })
+    logs := captureLog(t)
+
+    // Slow: SAMA is held up past the threshold. Evaluated first, since
+    // results are shared between organizations with the same evidence.
+    service.faults.Set("SAMA", Fault{Delay: 60 * time.Millisecond})
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-slow"))
+    if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-slow"}); err != nil {
+        t.Fatal(err)
+    }
+    service.faults.Set("SAMA", Fault{})
+
+    // Fast: under the threshold, nothing more is logged
+    ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-fast"))
+    if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-fast"}); err != nil {
+        t.Fatal(err)
+    }
+
+    lines := logs.lines("slow request")
+    if len(lines) != 1 || strings.Contains(lines[0], "req-fast") {
+        t.Fatalf("slow request lines %v, want only req-slow's", lines)
+    }
+    line := lines[0]
+    for _, want := range []string{"WARN slow request:", "request_id=req-slow", "threshold=40ms", "cache=", "NCA=", "scoring=", "cache_write=", "publish="} {
+        if !strings.Contains(line, want) {
+            t.Errorf("slow request line missing %q: %s", want, line)
+        }
+    }
+    match := regexp.MustCompile(`SAMA=(\S+)`).FindStringSubmatch(line)
+    if match == nil {
+        t.Fatalf("no SAMA timing in %s", line)
+    }
+    if d, err := time.ParseDuration(match[1]); err != nil || d < 60*time.Millisecond {
+        t.Errorf("SAMA took %s, want at least the injected 60ms", match[1])
+    }
+}
+
+func TestSlowRequestLogDisabled(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.SlowRequestThreshold = 0
+    })
+    logs := captureLog(t)
+    service.faults.Set("SAMA", Fault{Delay: 20 * time.Millisecond})
+    if _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1"}); err != nil {
+        t.Fatal(err)
+    }
+    if lines := logs.lines("slow request"); len(lines) != 0 {
+        t.Errorf("slow request logged with the threshold disabled: %v", lines)
+    }
+}
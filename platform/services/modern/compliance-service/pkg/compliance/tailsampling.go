@@ -0,0 +1,171 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// Topic receiving exported traces
+const tracesTopic = "compliance-traces"
+
+// Tail sampling reasons, in precedence order
+const (
+    traceReasonError    = "error"
+    traceReasonSlow     = "slow"
+    traceReasonDegraded = "degraded"
+    traceReasonSampled  = "sampled"
+)
+
+// TraceSpan - one timed operation within a request
+type TraceSpan struct {
+    Name       string    `json:"name"`
+    Start      time.Time `json:"start"`
+    DurationMs float64   `json:"duration_ms"`
+    Error      string    `json:"error,omitempty"`
+}
+
+// ExportedTrace - a completed request's spans as published
+type ExportedTrace struct {
+    TraceID    string      `json:"trace_id"`
+    RPC        string      `json:"rpc"`
+    Reason     string      `json:"reason"`
+    DurationMs float64     `json:"duration_ms"`
+    Degraded   []string    `json:"degraded,omitempty"`
+    Spans      []TraceSpan `json:"spans"`
+}
+
+// Spans of an in-flight request. A trace that cannot buffer a span because
+// the sampler is at capacity is dropped outright rather than exported
+// incomplete.
+type traceRecord struct {
+    sampler  *TraceSampler
+    id       string
+    mu       sync.Mutex
+    spans    []TraceSpan
+    degraded []string
+    dropped  bool
+}
+
+type traceRecordKey struct{}
+
+// Begin recording a request's spans
+func (t *TraceSampler) Begin(ctx context.Context, traceID string) (context.Context, *traceRecord) {
+    trace := &traceRecord{sampler: t, id: traceID}
+    return context.WithValue(ctx, traceRecordKey{}, trace), trace
+}
+
+// Finish a request: record its root span, then export the trace if it
+// errored, was slow, was degraded or falls within the sample ratio
+func (t *TraceSampler) Finish(trace *traceRecord, rpc string, startTime time.Time, err error) {
+    duration := time.Since(startTime)
+    trace.add(rpc, startTime, duration, err)
+
+    trace.mu.Lock()
+    defer trace.mu.Unlock()
+    defer t.buffered.Add(-int64(len(trace.spans)))
+    if trace.dropped {
+        return
+    }
+
+    policy := t.policy.Load()
+    reason := ""
+    switch {
+    case err != nil:
+        reason = traceReasonError
+    case policy.LatencyThreshold > 0 && duration >= policy.LatencyThreshold:
+        reason = traceReasonSlow
+    case len(trace.degraded) > 0:
+        reason = traceReasonDegraded
+    case t.Sampled(trace.id):
+        reason = traceReasonSampled
+    default:
+        tracesDropped.WithLabelValues("unsampled").Inc()
+        return
+    }
+    tracesExported.WithLabelValues(reason).Inc()
+
+    exported := &ExportedTrace{
+        TraceID:    trace.id,
+        RPC:        rpc,
+        Reason:     reason,
+        DurationMs: float64(duration) / float64(time.Millisecond),
+        Degraded:   trace.degraded,
+        Spans:      trace.spans,
+    }
+    if t.producer == nil {
+        return
+    }
+    go func() {
+        value, _ := json.Marshal(exported)
+        if err := t.producer.PublishMessage(context.Background(), tracesTopic, []byte(exported.TraceID), value, nil); err != nil {
+            log.Printf("Failed to export trace %s: %v", exported.TraceID, err)
+        }
+    }()
+}
+
+func (r *traceRecord) add(name string, start time.Time, duration time.Duration, err error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.dropped {
+        return
+    }
+    if buffered := r.sampler.buffered.Add(1); r.sampler.maxSpans > 0 && buffered > r.sampler.maxSpans {
+        // Free the whole trace so buffer pressure cannot keep growing
+        r.sampler.buffered.Add(-int64(len(r.spans) + 1))
+        r.spans = nil
+        r.dropped = true
+        tracesDropped.WithLabelValues("buffer_full").Inc()
+        return
+    }
+    span := TraceSpan{Name: name, Start: start, DurationMs: float64(duration) / float64(time.Millisecond)}
+    if err != nil {
+        span.Error = err.Error()
+    }
+    r.spans = append(r.spans, span)
+}
+
+// Record a span on the request's trace, if it is being traced
+func recordSpan(ctx context.Context, name string, start time.Time, err error) {
+    if trace, ok := ctx.Value(traceRecordKey{}).(*traceRecord); ok {
+        trace.add(name, start, time.Since(start), err)
+    }
+}
+
+// Mark the request's trace as having taken a degradation path, which
+// exports it regardless of sampling
+func markTraceDegraded(ctx context.Context, reason string) {
+    if trace, ok := ctx.Value(traceRecordKey{}).(*traceRecord); ok {
+        trace.mu.Lock()
+        trace.degraded = append(trace.degraded, reason)
+        trace.mu.Unlock()
+    }
+}
+
+// Tail sampling metrics
+var (
+    tracesExported = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_traces_exported_total",
+            Help: "Traces exported by tail sampling reason",
+        },
+        []string{"reason"},
+    )
+
+    tracesDropped = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_traces_dropped_total",
+            Help: "Traces not exported, as unsampled or because the span buffer was full",
+        },
+        []string{"cause"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(tracesExported)
+    prometheus.MustRegister(tracesDropped)
+}
@@ -0,0 +1,159 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "time"
+
+    "google.golang.org/grpc/metadata"
+)
+
+// Metadata key carrying the calling tenant. Set by the API gateway and
+// trusted as sent unless TENANT_JWKS_URL is configured, in which case
+// authenticateTenant overwrites it with the tenant of a verified token.
+const tenantMetadataKey = "x-tenant-id"
+
+// Tenant used when a caller does not identify one
+const defaultTenant = "default"
+
+// Resolve the calling tenant from incoming gRPC metadata
+func tenantFromContext(ctx context.Context) string {
+    md, ok := metadata.FromIncomingContext(ctx)
+    if !ok {
+        return defaultTenant
+    }
+    if values := md.Get(tenantMetadataKey); len(values) > 0 {
+        if tenant := strings.TrimSpace(values[0]); tenant != "" {
+            return tenant
+        }
+    }
+    return defaultTenant
+}
+
+// The caller's tenant token, empty if none
+func tenantTokenFromContext(ctx context.Context) string {
+    md, _ := metadata.FromIncomingContext(ctx)
+    if values := md.Get(tenantTokenMetadataKey); len(values) > 0 {
+        return values[0]
+    }
+    return ""
+}
+
+// TenantProfile - a tenant's overrides of the global runtime config. Weights
+// and framework TTLs are merged per framework; thresholds and the default
+// TTL replace the global ones when set. Frameworks outside a non-empty
+// enabled list are still evaluated and reported but carry no weight or gate.
+// Nightly scheduled runs due inside a blackout window are skipped. An
+// adaptive TTL replaces the static ones for the tenant's responses.
+type TenantProfile struct {
+    Weights    map[string]float64       `yaml:"weights" json:"weights"`
+    Thresholds *StatusThresholds        `yaml:"thresholds" json:"thresholds"`
+    CacheTTL   time.Duration            `yaml:"default_ttl" json:"default_ttl"`
+    CacheTTLs  map[string]time.Duration `yaml:"framework_ttls" json:"framework_ttls"`
+    Frameworks []string                 `yaml:"frameworks" json:"frameworks"`
+    Blackouts  []BlackoutWindow         `yaml:"schedule_blackouts" json:"schedule_blackouts"`
+
+    // Cache TTLs scaled by score volatility instead of the static ones
+    AdaptiveTTL *AdaptiveTTL `yaml:"adaptive_ttl" json:"adaptive_ttl,omitempty"`
+}
+
+// Validate a profile against the registered frameworks
+func (p *TenantProfile) Validate(tenant string, frameworks []string) error {
+    for framework, weight := range p.Weights {
+        if !containsString(frameworks, framework) {
+            return fmt.Errorf("tenant %s weighs unknown framework %s", tenant, framework)
+        }
+        if weight < 0 {
+            return fmt.Errorf("negative weight for framework %s in tenant %s", framework, tenant)
+        }
+    }
+    if t := p.Thresholds; t != nil && (t.PartiallyCompliant < 0 || t.Compliant > 100 || t.PartiallyCompliant > t.Compliant) {
+        return fmt.Errorf("tenant %s thresholds must satisfy 0 <= partially_compliant <= compliant <= 100", tenant)
+    }
+    if p.CacheTTL < 0 {
+        return fmt.Errorf("tenant %s default_ttl must not be negative", tenant)
+    }
+    for framework, ttl := range p.CacheTTLs {
+        if ttl <= 0 {
+            return fmt.Errorf("cache TTL for framework %s in tenant %s must be positive", framework, tenant)
+        }
+    }
+    for _, framework := range p.Frameworks {
+        if !containsString(frameworks, framework) {
+            return fmt.Errorf("tenant %s enables unknown framework %s", tenant, framework)
+        }
+    }
+    for _, window := range p.Blackouts {
+        if err := window.Validate(); err != nil {
+            return fmt.Errorf("tenant %s: %v", tenant, err)
+        }
+    }
+    if p.AdaptiveTTL != nil {
+        if err := p.AdaptiveTTL.Validate(tenant); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// ForTenant - the runtime config as the tenant sees it: the global config
+// when it has no profile, else a copy with the profile layered over it.
+// Frameworks disabled by the operational overrides stay disabled.
+func (c *RuntimeConfig) ForTenant(tenant string) *RuntimeConfig {
+    profile, ok := c.TenantProfiles[tenant]
+    if !ok {
+        return c
+    }
+
+    tenantConfig := *c
+    tenantConfig.Profile = tenant
+    tenantConfig.Weights = make(map[string]float64, len(c.Weights))
+    for framework, weight := range c.Weights {
+        tenantConfig.Weights[framework] = weight
+    }
+    for framework, weight := range profile.Weights {
+        tenantConfig.Weights[framework] = weight
+    }
+    if profile.Thresholds != nil {
+        tenantConfig.Thresholds = *profile.Thresholds
+    }
+
+    tenantConfig.Cache = CacheTTLs{Default: c.Cache.Default, Frameworks: make(map[string]time.Duration, len(c.Cache.Frameworks)), Adaptive: profile.AdaptiveTTL}
+    if profile.CacheTTL > 0 {
+        tenantConfig.Cache.Default = profile.CacheTTL
+    }
+    for framework, ttl := range c.Cache.Frameworks {
+        tenantConfig.Cache.Frameworks[framework] = ttl
+    }
+    for framework, ttl := range profile.CacheTTLs {
+        tenantConfig.Cache.Frameworks[framework] = ttl
+    }
+
+    tenantConfig.FrameworkGates = make(map[string]StatusThresholds, len(c.FrameworkGates))
+    for framework, gate := range c.FrameworkGates {
+        tenantConfig.FrameworkGates[framework] = gate
+    }
+    for framework := range tenantConfig.Weights {
+        if containsString(c.DisabledFrameworks, framework) || (len(profile.Frameworks) > 0 && !containsString(profile.Frameworks, framework)) {
+            delete(tenantConfig.Weights, framework)
+            delete(tenantConfig.FrameworkGates, framework)
+        }
+    }
+    return &tenantConfig
+}
+
+// Runtime config for the calling tenant
+func (s *ComplianceService) tenantRuntimeConfig(ctx context.Context) *RuntimeConfig {
+    return s.runtimeConfig().ForTenant(tenantFromContext(ctx))
+}
+
+// Response cache key; responses scored under a tenant profile are cached
+// apart from the global ones
+func (c *RuntimeConfig) responseKey(req *ComplianceRequest) string {
+    key := responseKey(req)
+    if c.Profile != "" {
+        key += "@" + c.Profile
+    }
+    return key
+}
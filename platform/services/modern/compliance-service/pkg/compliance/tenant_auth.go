@@ -0,0 +1,393 @@
+package compliance
+
+import (
+    "context"
+    "crypto"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rsa"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "math/big"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+)
+
+// Metadata key carrying the caller's tenant token, a JWT signed by a key in
+// the configured JWKS
+const tenantTokenMetadataKey = "x-tenant-token"
+
+// Limits on talking to the key source
+const (
+    jwksFetchTimeout  = 5 * time.Second
+    jwksMinRefetch    = 10 * time.Second
+    jwksMaxBytes      = 1 << 20
+    tenantTokenLeeway = 30 * time.Second
+)
+
+// TenantAuthConfig - how the calling tenant is established. With a JWKS URL
+// every call must carry a tenant token, and the tenant claim it was signed
+// with replaces x-tenant-id. Without one x-tenant-id is trusted as sent, so
+// the port must only be reachable through the API gateway that sets it.
+type TenantAuthConfig struct {
+    JWKSURL  string        `env:"TENANT_JWKS_URL"`
+    Issuer   string        `env:"TENANT_JWT_ISSUER"`   // Required iss claim, when set
+    Audience string        `env:"TENANT_JWT_AUDIENCE"` // Required in the aud claim, when set
+    Claim    string        `env:"TENANT_JWT_CLAIM"`    // Claim holding the tenant
+    Refresh  time.Duration `env:"TENANT_JWKS_REFRESH"` // Age after which the keys are fetched again
+
+    // While the key source cannot be reached, accept calls with x-tenant-id
+    // taken as sent instead of refusing them with Unavailable
+    FailOpen bool `env:"TENANT_AUTH_FAIL_OPEN"`
+}
+
+// Returned when no key could be obtained to check a token against
+var errTenantKeysUnavailable = errors.New("tenant key source unavailable")
+
+// TenantVerifier - checks tenant tokens against the keys published at a JWKS
+// URL, caching them and fetching again when stale or when a token names an
+// unknown key
+type TenantVerifier struct {
+    config TenantAuthConfig
+    client *http.Client
+
+    mu        sync.Mutex
+    keys      map[string]crypto.PublicKey
+    fetched   time.Time
+    attempted time.Time
+}
+
+// Create a verifier; nil when no JWKS URL is configured
+func newTenantVerifier(config TenantAuthConfig, client *http.Client) (*TenantVerifier, error) {
+    if config.JWKSURL == "" {
+        return nil, nil
+    }
+    if config.Claim == "" {
+        return nil, fmt.Errorf("tenant JWT claim must be set")
+    }
+    if config.Refresh <= 0 {
+        return nil, fmt.Errorf("tenant JWKS refresh must be positive")
+    }
+    return &TenantVerifier{config: config, client: client}, nil
+}
+
+// Verify a tenant token and return the tenant it was issued to. Wraps
+// errTenantKeysUnavailable when the token could not be checked at all.
+func (v *TenantVerifier) Verify(token string) (string, error) {
+    parts := strings.Split(token, ".")
+    if len(parts) != 3 {
+        return "", fmt.Errorf("malformed token")
+    }
+    var header struct {
+        Alg string `json:"alg"`
+        Kid string `json:"kid"`
+    }
+    if err := decodeTokenSegment(parts[0], &header); err != nil {
+        return "", fmt.Errorf("malformed token header: %v", err)
+    }
+    signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+    if err != nil {
+        return "", fmt.Errorf("malformed token signature")
+    }
+
+    key, err := v.key(header.Kid)
+    if err != nil {
+        return "", err
+    }
+    digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+    if !verifyTokenSignature(header.Alg, key, digest[:], signature) {
+        return "", fmt.Errorf("invalid token signature")
+    }
+
+    var claims map[string]interface{}
+    if err := decodeTokenSegment(parts[1], &claims); err != nil {
+        return "", fmt.Errorf("malformed token claims: %v", err)
+    }
+    return v.tenantClaim(claims, time.Now())
+}
+
+// Check the registered claims and pull out the tenant
+func (v *TenantVerifier) tenantClaim(claims map[string]interface{}, now time.Time) (string, error) {
+    exp, ok := claims["exp"].(float64)
+    if !ok {
+        return "", fmt.Errorf("token has no expiry")
+    }
+    if now.After(time.Unix(int64(exp), 0).Add(tenantTokenLeeway)) {
+        return "", fmt.Errorf("token expired")
+    }
+    if nbf, ok := claims["nbf"].(float64); ok && now.Add(tenantTokenLeeway).Before(time.Unix(int64(nbf), 0)) {
+        return "", fmt.Errorf("token not yet valid")
+    }
+    if v.config.Issuer != "" && claims["iss"] != v.config.Issuer {
+        return "", fmt.Errorf("token issuer not accepted")
+    }
+    if v.config.Audience != "" && !tokenAudience(claims["aud"], v.config.Audience) {
+        return "", fmt.Errorf("token audience not accepted")
+    }
+    tenant, _ := claims[v.config.Claim].(string)
+    if strings.TrimSpace(tenant) == "" {
+        return "", fmt.Errorf("token carries no %s claim", v.config.Claim)
+    }
+    return tenant, nil
+}
+
+// The key with the given ID, fetching the JWKS when the cached keys are stale
+// or lack it. Stale keys keep verifying while the key source is down.
+func (v *TenantVerifier) key(kid string) (crypto.PublicKey, error) {
+    v.mu.Lock()
+    defer v.mu.Unlock()
+
+    key, ok := v.keys[kid]
+    stale := time.Since(v.fetched) > v.config.Refresh
+    if (ok && !stale) || time.Since(v.attempted) < jwksMinRefetch {
+        if ok {
+            return key, nil
+        }
+        if v.keys == nil {
+            return nil, errTenantKeysUnavailable
+        }
+        return nil, fmt.Errorf("unknown signing key %q", kid)
+    }
+
+    v.attempted = time.Now()
+    keys, err := v.fetch()
+    if err != nil {
+        jwksFetches.WithLabelValues("error").Inc()
+        log.Printf("Failed to fetch tenant JWKS from %s: %v", v.config.JWKSURL, err)
+        if ok {
+            return key, nil
+        }
+        return nil, fmt.Errorf("%w: %v", errTenantKeysUnavailable, err)
+    }
+    jwksFetches.WithLabelValues("ok").Inc()
+    v.keys, v.fetched = keys, time.Now()
+
+    if key, ok = keys[kid]; !ok {
+        return nil, fmt.Errorf("unknown signing key %q", kid)
+    }
+    return key, nil
+}
+
+// Fetch and parse the key set; keys of unsupported types are skipped. Not
+// bound to any caller's context, so one abandoned call cannot fail it for all.
+func (v *TenantVerifier) fetch() (map[string]crypto.PublicKey, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+    defer cancel()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.config.JWKSURL, nil)
+    if err != nil {
+        return nil, err
+    }
+    resp, err := v.client.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+    }
+    var set struct {
+        Keys []struct {
+            Kty string `json:"kty"`
+            Kid string `json:"kid"`
+            Use string `json:"use"`
+            N   string `json:"n"`
+            E   string `json:"e"`
+            Crv string `json:"crv"`
+            X   string `json:"x"`
+            Y   string `json:"y"`
+        } `json:"keys"`
+    }
+    if err := json.NewDecoder(io.LimitReader(resp.Body, jwksMaxBytes)).Decode(&set); err != nil {
+        return nil, fmt.Errorf("invalid JWKS: %v", err)
+    }
+
+    keys := make(map[string]crypto.PublicKey)
+    for _, jwk := range set.Keys {
+        if jwk.Use != "" && jwk.Use != "sig" {
+            continue
+        }
+        switch {
+        case jwk.Kty == "RSA":
+            n, nErr := decodeBigInt(jwk.N)
+            e, eErr := decodeBigInt(jwk.E)
+            if nErr != nil || eErr != nil || !e.IsInt64() {
+                return nil, fmt.Errorf("invalid RSA key %q", jwk.Kid)
+            }
+            keys[jwk.Kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+        case jwk.Kty == "EC" && jwk.Crv == "P-256":
+            x, xErr := decodeBigInt(jwk.X)
+            y, yErr := decodeBigInt(jwk.Y)
+            if xErr != nil || yErr != nil || !elliptic.P256().IsOnCurve(x, y) {
+                return nil, fmt.Errorf("invalid EC key %q", jwk.Kid)
+            }
+            keys[jwk.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+        }
+    }
+    return keys, nil
+}
+
+// Check a signature by the algorithm the token names, which must match the
+// key's type; RS256 and ES256 only
+func verifyTokenSignature(alg string, key crypto.PublicKey, digest, signature []byte) bool {
+    switch alg {
+    case "RS256":
+        rsaKey, ok := key.(*rsa.PublicKey)
+        return ok && rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, signature) == nil
+    case "ES256":
+        ecKey, ok := key.(*ecdsa.PublicKey)
+        if !ok || len(signature) != 64 {
+            return false
+        }
+        r := new(big.Int).SetBytes(signature[:32])
+        s := new(big.Int).SetBytes(signature[32:])
+        return ecdsa.Verify(ecKey, digest, r, s)
+    }
+    return false
+}
+
+// aud is a string or a list of strings
+func tokenAudience(aud interface{}, want string) bool {
+    switch aud := aud.(type) {
+    case string:
+        return aud == want
+    case []interface{}:
+        for _, value := range aud {
+            if value == want {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+func decodeTokenSegment(segment string, into interface{}) error {
+    data, err := base64.RawURLEncoding.DecodeString(segment)
+    if err != nil {
+        return err
+    }
+    return json.Unmarshal(data, into)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+    data, err := base64.RawURLEncoding.DecodeString(value)
+    if err != nil || len(data) == 0 {
+        return nil, fmt.Errorf("invalid integer")
+    }
+    return new(big.Int).SetBytes(data), nil
+}
+
+// Establish the calling tenant from its token and pin x-tenant-id in the
+// incoming metadata to it, so every later reader of the tenant, including
+// jobs replaying the metadata, sees the verified one. A no-op without a
+// verifier.
+func (s *ComplianceService) authenticateTenant(ctx context.Context) (context.Context, error) {
+    if s.tenantAuth == nil {
+        return ctx, nil
+    }
+    md, _ := metadata.FromIncomingContext(ctx)
+    md = md.Copy()
+    tokens := md.Get(tenantTokenMetadataKey)
+    if len(tokens) == 0 {
+        tenantAuthResults.WithLabelValues("missing").Inc()
+        return ctx, status.Error(codes.Unauthenticated, "tenant token required")
+    }
+
+    tenant, err := s.tenantAuth.Verify(tokens[0])
+    switch {
+    case errors.Is(err, errTenantKeysUnavailable) && s.config.TenantAuth.FailOpen:
+        // x-tenant-id stays as the caller sent it
+        tenantAuthResults.WithLabelValues("fail_open").Inc()
+        return ctx, nil
+    case errors.Is(err, errTenantKeysUnavailable):
+        tenantAuthResults.WithLabelValues("fail_closed").Inc()
+        return ctx, status.Error(codes.Unavailable, "tenant token cannot be verified right now")
+    case err != nil:
+        tenantAuthResults.WithLabelValues("invalid").Inc()
+        return ctx, status.Errorf(codes.Unauthenticated, "invalid tenant token: %v", err)
+    }
+
+    if claimed := md.Get(tenantMetadataKey); len(claimed) > 0 && strings.TrimSpace(claimed[0]) != "" && strings.TrimSpace(claimed[0]) != tenant {
+        tenantAuthResults.WithLabelValues("mismatch").Inc()
+        return ctx, status.Error(codes.PermissionDenied, "x-tenant-id does not match the tenant token")
+    }
+    tenantAuthResults.WithLabelValues("verified").Inc()
+    md.Set(tenantMetadataKey, tenant)
+    return metadata.NewIncomingContext(ctx, md), nil
+}
+
+// Unary interceptor authenticating the tenant of every compliance RPC;
+// health and reflection stay reachable without a token
+func (s *ComplianceService) tenantAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    if !complianceMethod(info.FullMethod) {
+        return handler(ctx, req)
+    }
+    ctx, err := s.authenticateTenant(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return handler(ctx, req)
+}
+
+// Stream interceptor authenticating the tenant of every streaming RPC
+func (s *ComplianceService) streamTenantAuthInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+    if !complianceMethod(info.FullMethod) {
+        return handler(srv, stream)
+    }
+    ctx, err := s.authenticateTenant(stream.Context())
+    if err != nil {
+        return err
+    }
+    return handler(srv, &tenantStream{ServerStream: stream, ctx: ctx})
+}
+
+// Server stream carrying the authenticated context
+type tenantStream struct {
+    grpc.ServerStream
+    ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+    return s.ctx
+}
+
+// "/doganai.compliance.v1.Compliance/CheckCompliance" -> true
+func complianceMethod(fullMethod string) bool {
+    return strings.HasPrefix(fullMethod, "/"+complianceServiceName+"/")
+}
+
+// Tenant authentication metrics
+var (
+    tenantAuthResults = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_tenant_auth_total",
+            Help: "Tenant token checks by outcome: verified, missing, invalid, mismatch, or fail_open and fail_closed while the key source is down",
+        },
+        []string{"outcome"},
+    )
+
+    jwksFetches = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_tenant_jwks_fetches_total",
+            Help: "Fetches of the tenant JWKS by result",
+        },
+        []string{"result"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(tenantAuthResults)
+    prometheus.MustRegister(jwksFetches)
+}
@@ -0,0 +1,212 @@
+package compliance
+
+import (
+    "context"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+)
+
+// JWKS server publishing one P-256 key, which can be taken down
+type testKeySource struct {
+    key    *ecdsa.PrivateKey
+    server *httptest.Server
+}
+
+func newTestKeySource(t *testing.T) *testKeySource {
+    key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+    source := &testKeySource{key: key}
+    source.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+            "kty": "EC",
+            "kid": "k1",
+            "crv": "P-256",
+            "x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+            "y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+        }}})
+    }))
+    t.Cleanup(source.server.Close)
+    return source
+}
+
+func (k *testKeySource) sign(t *testing.T, claims map[string]interface{}) string {
+    header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": "k1"})
+    payload, _ := json.Marshal(claims)
+    signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+    digest := sha256.Sum256([]byte(signed))
+    r, s, err := ecdsa.Sign(rand.Reader, k.key, digest[:])
+    if err != nil {
+        t.Fatal(err)
+    }
+    signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+    return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTenantAuthService(t *testing.T, url string, failOpen bool) *ComplianceService {
+    config := TenantAuthConfig{JWKSURL: url, Claim: "tenant", Audience: "compliance", Refresh: time.Minute, FailOpen: failOpen}
+    verifier, err := newTenantVerifier(config, http.DefaultClient)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return &ComplianceService{config: ServiceConfig{TenantAuth: config}, tenantAuth: verifier}
+}
+
+func tenantCallContext(pairs ...string) context.Context {
+    return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func TestAuthenticateTenant(t *testing.T) {
+    source := newTestKeySource(t)
+    valid := map[string]interface{}{"tenant": "acme", "aud": "compliance", "exp": float64(time.Now().Add(time.Hour).Unix())}
+
+    tests := []struct {
+        name   string
+        ctx    func() context.Context
+        code   codes.Code
+        tenant string
+    }{
+        {
+            name:   "verified tenant replaces missing header",
+            ctx:    func() context.Context { return tenantCallContext(tenantTokenMetadataKey, source.sign(t, valid)) },
+            tenant: "acme",
+        },
+        {
+            name:   "matching header accepted",
+            ctx:    func() context.Context { return tenantCallContext(tenantTokenMetadataKey, source.sign(t, valid), tenantMetadataKey, "acme") },
+            tenant: "acme",
+        },
+        {
+            name: "spoofed header refused",
+            ctx:  func() context.Context { return tenantCallContext(tenantTokenMetadataKey, source.sign(t, valid), tenantMetadataKey, "other") },
+            code: codes.PermissionDenied,
+        },
+        {
+            name: "missing token refused",
+            ctx:  func() context.Context { return tenantCallContext(tenantMetadataKey, "acme") },
+            code: codes.Unauthenticated,
+        },
+        {
+            name: "expired token refused",
+            ctx: func() context.Context {
+                return tenantCallContext(tenantTokenMetadataKey, source.sign(t, map[string]interface{}{"tenant": "acme", "aud": "compliance", "exp": float64(time.Now().Add(-time.Hour).Unix())}))
+            },
+            code: codes.Unauthenticated,
+        },
+        {
+            name: "wrong audience refused",
+            ctx: func() context.Context {
+                return tenantCallContext(tenantTokenMetadataKey, source.sign(t, map[string]interface{}{"tenant": "acme", "aud": "billing", "exp": valid["exp"]}))
+            },
+            code: codes.Unauthenticated,
+        },
+        {
+            name: "tampered token refused",
+            ctx: func() context.Context {
+                token := source.sign(t, valid)
+                forged, _ := json.Marshal(map[string]interface{}{"tenant": "other", "aud": "compliance", "exp": valid["exp"]})
+                parts := strings.Split(token, ".")
+                return tenantCallContext(tenantTokenMetadataKey, parts[0]+"."+base64.RawURLEncoding.EncodeToString(forged)+"."+parts[2])
+            },
+            code: codes.Unauthenticated,
+        },
+    }
+
+    s := newTenantAuthService(t, source.server.URL, false)
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx, err := s.authenticateTenant(tt.ctx())
+            if status.Code(err) != tt.code {
+                t.Fatalf("code = %v, want %v (%v)", status.Code(err), tt.code, err)
+            }
+            if err == nil && tenantFromContext(ctx) != tt.tenant {
+                t.Errorf("tenant = %q, want %q", tenantFromContext(ctx), tt.tenant)
+            }
+        })
+    }
+}
+
+func TestAuthenticateTenantKeySourceDown(t *testing.T) {
+    source := newTestKeySource(t)
+    token := source.sign(t, map[string]interface{}{"tenant": "acme", "aud": "compliance", "exp": float64(time.Now().Add(time.Hour).Unix())})
+    source.server.Close()
+
+    tests := []struct {
+        name     string
+        failOpen bool
+        code     codes.Code
+        outcome  string
+    }{
+        {name: "fail closed", failOpen: false, code: codes.Unavailable, outcome: "fail_closed"},
+        {name: "fail open", failOpen: true, code: codes.OK, outcome: "fail_open"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            s := newTenantAuthService(t, source.server.URL, tt.failOpen)
+            before := testutil.ToFloat64(tenantAuthResults.WithLabelValues(tt.outcome))
+
+            ctx, err := s.authenticateTenant(tenantCallContext(tenantTokenMetadataKey, token, tenantMetadataKey, "claimed"))
+            if status.Code(err) != tt.code {
+                t.Fatalf("code = %v, want %v (%v)", status.Code(err), tt.code, err)
+            }
+            if err == nil && tenantFromContext(ctx) != "claimed" {
+                t.Errorf("fail-open tenant = %q, want the header as sent", tenantFromContext(ctx))
+            }
+            if got := testutil.ToFloat64(tenantAuthResults.WithLabelValues(tt.outcome)) - before; got != 1 {
+                t.Errorf("%s outcomes = %v, want 1", tt.outcome, got)
+            }
+        })
+    }
+}
+
+func TestTenantVerifierKeepsKeysWhileSourceDown(t *testing.T) {
+    source := newTestKeySource(t)
+    token := source.sign(t, map[string]interface{}{"tenant": "acme", "exp": float64(time.Now().Add(time.Hour).Unix())})
+    verifier, err := newTenantVerifier(TenantAuthConfig{JWKSURL: source.server.URL, Claim: "tenant", Refresh: time.Minute}, http.DefaultClient)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, err := verifier.Verify(token); err != nil {
+        t.Fatalf("verify with key source up: %v", err)
+    }
+
+    // Stale keys, key source gone: the cached key still verifies
+    source.server.Close()
+    verifier.fetched = time.Now().Add(-time.Hour)
+    verifier.attempted = time.Time{}
+    if tenant, err := verifier.Verify(token); err != nil || tenant != "acme" {
+        t.Fatalf("verify with key source down = %q, %v; want acme", tenant, err)
+    }
+}
+
+func TestLocalRateLimiterEvictsIdleKeys(t *testing.T) {
+    limiter := NewLocalRateLimiter(10, 5)
+    for _, key := range []string{"a", "b", "c"} {
+        limiter.Allow(context.Background(), key)
+    }
+    limiter.sweep(time.Now().Add(limiter.idle + time.Second))
+    if len(limiter.limiters) != 0 {
+        t.Errorf("%d buckets left after idle sweep, want 0", len(limiter.limiters))
+    }
+
+    limiter.Allow(context.Background(), "a")
+    limiter.sweep(time.Now())
+    if len(limiter.limiters) != 1 {
+        t.Errorf("%d buckets left after sweep, want the active one", len(limiter.limiters))
+    }
+}
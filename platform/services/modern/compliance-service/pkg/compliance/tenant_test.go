@@ -0,0 +1,106 @@
+package compliance
+
+import (
+    "context"
+    "reflect"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/metadata"
+)
+
+const tenantProfilesConfig = `
+tenant_profiles:
+  acme:
+    frameworks: [NCA]
+  beta:
+    frameworks: [SAMA]
+    thresholds:
+      compliant: 10
+      partially_compliant: 5
+`
+
+func tenantContext(tenant string) context.Context {
+    return metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenantMetadataKey, tenant))
+}
+
+// The same organization and evidence score differently under two tenants'
+// profiles; a tenant without a profile gets the global config
+func TestTenantProfilesScoreSameInputsDifferently(t *testing.T) {
+    service, _ := newConfigFileService(t, tenantProfilesConfig)
+    req := &ComplianceRequest{
+        OrganizationId: "org-1",
+        Frameworks:     []string{"NCA", "SAMA"},
+        Evidence: []*EvidenceItem{
+            {Key: "asset_inventory", Value: "true"},
+            {Key: "mfa_enforced", Value: "true"},
+        },
+    }
+    check := func(tenant string) *ComplianceResponse {
+        t.Helper()
+        response, err := service.CheckCompliance(tenantContext(tenant), req)
+        if err != nil {
+            t.Fatal(err)
+        }
+        return response
+    }
+
+    global := check("other")
+    nca, sama := resultFor(global, "NCA"), resultFor(global, "SAMA")
+    if nca == nil || sama == nil || nca.Score == sama.Score {
+        t.Fatalf("NCA %v and SAMA %v, want two differently scored frameworks", nca, sama)
+    }
+
+    acme, beta := check("acme"), check("beta")
+    if acme.OverallScore != nca.Score {
+        t.Errorf("acme overall %v, want NCA's %v alone", acme.OverallScore, nca.Score)
+    }
+    if beta.OverallScore != sama.Score {
+        t.Errorf("beta overall %v, want SAMA's %v alone", beta.OverallScore, sama.Score)
+    }
+    if len(acme.FrameworkResults) != 2 || len(beta.FrameworkResults) != 2 {
+        t.Errorf("%d and %d results, want disabled frameworks still reported", len(acme.FrameworkResults), len(beta.FrameworkResults))
+    }
+    if want := service.determineStatus(sama.Score, StatusThresholds{Compliant: 10, PartiallyCompliant: 5}); beta.Status != want {
+        t.Errorf("beta status %s, want %s under its thresholds", beta.Status, want)
+    }
+
+    // Profiled responses are cached apart, so a repeat keeps its profile
+    if again := check("acme"); again.OverallScore != acme.OverallScore {
+        t.Errorf("repeat acme overall %v, want %v", again.OverallScore, acme.OverallScore)
+    }
+    if again := check("other"); again.OverallScore != global.OverallScore {
+        t.Errorf("repeat global overall %v, want %v", again.OverallScore, global.OverallScore)
+    }
+}
+
+func TestForTenantLayersProfile(t *testing.T) {
+    global := &RuntimeConfig{
+        Weights:    map[string]float64{"SAMA": 1, "NCA": 1, "PDPL": 1},
+        Thresholds: StatusThresholds{Compliant: 80, PartiallyCompliant: 60},
+        Cache:      CacheTTLs{Default: time.Minute, Frameworks: map[string]time.Duration{"SAMA": time.Hour}},
+        TenantProfiles: map[string]TenantProfile{"acme": {
+            Weights:    map[string]float64{"SAMA": 3},
+            CacheTTL:   5 * time.Minute,
+            CacheTTLs:  map[string]time.Duration{"NCA": 2 * time.Hour},
+            Frameworks: []string{"SAMA", "NCA"},
+        }},
+    }
+    if global.ForTenant("other") != global {
+        t.Error("tenant without a profile should get the global config")
+    }
+
+    acme := global.ForTenant("acme")
+    if want := map[string]float64{"SAMA": 3, "NCA": 1}; !reflect.DeepEqual(acme.Weights, want) {
+        t.Errorf("weights %v, want %v", acme.Weights, want)
+    }
+    if acme.Thresholds != global.Thresholds {
+        t.Errorf("thresholds %v, want the global %v without a profile override", acme.Thresholds, global.Thresholds)
+    }
+    if want := map[string]time.Duration{"SAMA": time.Hour, "NCA": 2 * time.Hour}; acme.Cache.Default != 5*time.Minute || !reflect.DeepEqual(acme.Cache.Frameworks, want) {
+        t.Errorf("cache TTLs %v %v, want 5m0s and %v", acme.Cache.Default, acme.Cache.Frameworks, want)
+    }
+    if len(global.Weights) != 3 || global.Weights["SAMA"] != 1 || len(global.Cache.Frameworks) != 1 {
+        t.Errorf("global config modified: %v %v", global.Weights, global.Cache.Frameworks)
+    }
+}
@@ -0,0 +1,419 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// What drove a status transition
+const (
+    causeFirstEvaluation = "FIRST_EVALUATION"
+    causeFrameworkGate   = "FRAMEWORK_GATE"
+    causeSubdomainGate   = "SUBDOMAIN_GATE"
+    causeFrameworkScore  = "FRAMEWORK_SCORE"
+)
+
+const (
+    // Attempts to record a run against a timeline another replica is
+    // writing, before giving up on it
+    statusTimelineAttempts = 3
+
+    // Claim on the one-off backfill from stored evaluations: held while it
+    // runs, so a replica that dies mid-way lets another retry, then kept
+    statusTimelineBackfillKey   = "status-timeline:backfill"
+    statusTimelineBackfillClaim = time.Hour
+
+    timelineDefaultPageSize = 50
+    timelineMaxPageSize     = 500
+)
+
+// StatusTimeline - each organization's status transitions with their
+// causes, in a sorted set by time, next to the last status and framework
+// scores recorded, which the next run is compared against
+type StatusTimeline struct {
+    redis     *redis.Client
+    retention time.Duration
+}
+
+// Create a status timeline store; transitions expire after retention
+func NewStatusTimeline(client *redis.Client, retention time.Duration) *StatusTimeline {
+    return &StatusTimeline{redis: client, retention: retention}
+}
+
+// Replaces the last recorded state only if it is still the one the caller
+// compared against, then appends transitions and trims expired ones.
+// KEYS[1] is the state, KEYS[2] the timeline; ARGV is the expected state,
+// the new state, retention in ms, the trim cutoff, then score/transition
+// pairs.
+var recordTimelineScript = redis.NewScript(`
+if (redis.call('GET', KEYS[1]) or '') ~= ARGV[1] then
+    return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+for i = 5, #ARGV, 2 do
+    redis.call('ZADD', KEYS[2], ARGV[i], ARGV[i + 1])
+end
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', '(' .. ARGV[4])
+redis.call('PEXPIRE', KEYS[2], ARGV[3])
+return 1
+`)
+
+// Status and scores of a run, as the next run is compared against
+func timelineState(response *ComplianceResponse) *LatestResult {
+    state := &LatestResult{
+        OrganizationId:  response.OrganizationId,
+        OverallScore:    response.OverallScore,
+        Status:          response.Status,
+        CheckedAt:       response.Timestamp,
+        FrameworkScores: make(map[string]float64, len(response.FrameworkResults)),
+    }
+    for _, result := range response.FrameworkResults {
+        if scoredResult(result) {
+            state.FrameworkScores[result.Framework] = result.Score
+        }
+    }
+    return state
+}
+
+// Compare a run with the last one recorded and append a transition if its
+// status differs. Returns the transition, nil when the status held or the
+// run is older than the last recorded. cause explains a transition from
+// the previous state, nil for the first run.
+func (t *StatusTimeline) Record(ctx context.Context, runID string, response *ComplianceResponse, cause func(previous *LatestResult) *StatusCause) (*StatusTransition, error) {
+    for attempt := 0; attempt < statusTimelineAttempts; attempt++ {
+        expected, previous, err := t.lastState(ctx, response.OrganizationId)
+        if err != nil {
+            return nil, err
+        }
+        if previous != nil && previous.CheckedAt > response.Timestamp {
+            return nil, nil
+        }
+
+        var transition *StatusTransition
+        if previous == nil || previous.Status != response.Status {
+            transition = newStatusTransition(runID, previous, response, cause(previous))
+        }
+        ok, err := t.write(ctx, response.OrganizationId, expected, timelineState(response), transition)
+        if err != nil {
+            return nil, err
+        }
+        if ok {
+            return transition, nil
+        }
+    }
+    return nil, fmt.Errorf("status timeline of %s kept changing under the write", response.OrganizationId)
+}
+
+// The last recorded state of an organization, as stored and decoded; nil
+// if there is none or it cannot be decoded
+func (t *StatusTimeline) lastState(ctx context.Context, organizationID string) (string, *LatestResult, error) {
+    stored, err := t.redis.Get(ctx, statusTimelineStateKey(organizationID)).Result()
+    if err != nil && err != redis.Nil {
+        return "", nil, err
+    }
+    if stored == "" {
+        return "", nil, nil
+    }
+    state := &LatestResult{}
+    if err := proto.Unmarshal([]byte(stored), state); err != nil {
+        return stored, nil, nil
+    }
+    return stored, state, nil
+}
+
+// Merge folds the source's transitions into the target's timeline, and its
+// last state too if checked after the target's. Returns whether it was.
+func (t *StatusTimeline) Merge(ctx context.Context, source, target string) (bool, error) {
+    _, sourceState, err := t.lastState(ctx, source)
+    if err != nil {
+        return false, err
+    }
+    expected, targetState, err := t.lastState(ctx, target)
+    if err != nil {
+        return false, err
+    }
+
+    timeline := statusTimelineKey(target)
+    pipe := t.redis.TxPipeline()
+    pipe.ZUnionStore(ctx, timeline, &redis.ZStore{Keys: []string{timeline, statusTimelineKey(source)}})
+    pipe.PExpire(ctx, timeline, t.retention)
+    if _, err := pipe.Exec(ctx); err != nil {
+        return false, err
+    }
+
+    sourceNewer := sourceState != nil && (targetState == nil || sourceState.CheckedAt > targetState.CheckedAt)
+    if sourceNewer {
+        sourceState.OrganizationId = target
+        // A target run recorded meanwhile is newer still and stays
+        if _, err := t.write(ctx, target, expected, sourceState); err != nil {
+            return false, err
+        }
+    }
+    return sourceNewer, t.redis.Del(ctx, statusTimelineStateKey(source), statusTimelineKey(source)).Err()
+}
+
+func newStatusTransition(runID string, previous *LatestResult, response *ComplianceResponse, cause *StatusCause) *StatusTransition {
+    transition := &StatusTransition{
+        OrganizationId: response.OrganizationId,
+        NewStatus:      response.Status,
+        Timestamp:      response.Timestamp,
+        RunId:          runID,
+        NewScore:       response.OverallScore,
+        Cause:          cause,
+    }
+    if previous != nil {
+        transition.OldStatus = previous.Status
+        transition.OldScore = previous.OverallScore
+    }
+    return transition
+}
+
+// Compare-and-set the state, appending the transitions. False means the
+// state was not the expected one.
+func (t *StatusTimeline) write(ctx context.Context, organizationID, expected string, state *LatestResult, transitions ...*StatusTransition) (bool, error) {
+    data, err := proto.Marshal(state)
+    if err != nil {
+        return false, err
+    }
+    args := []interface{}{expected, data, t.retention.Milliseconds(), time.Now().Add(-t.retention).Unix()}
+    for _, transition := range transitions {
+        if transition == nil {
+            continue
+        }
+        member, err := proto.Marshal(transition)
+        if err != nil {
+            return false, err
+        }
+        args = append(args, transition.Timestamp, member)
+    }
+    keys := []string{statusTimelineStateKey(organizationID), statusTimelineKey(organizationID)}
+    written, err := recordTimelineScript.Run(ctx, t.redis, keys, args...).Int()
+    return written == 1, err
+}
+
+// Transitions within [from, to), oldest first, from offset. Returns the
+// page and whether more follow.
+func (t *StatusTimeline) Transitions(ctx context.Context, organizationID string, from, to time.Time, offset, limit int64) ([]*StatusTransition, bool, error) {
+    members, err := t.redis.ZRangeByScore(ctx, statusTimelineKey(organizationID), &redis.ZRangeBy{
+        Min:    strconv.FormatInt(from.Unix(), 10),
+        Max:    "(" + strconv.FormatInt(to.Unix(), 10),
+        Offset: offset,
+        Count:  limit + 1,
+    }).Result()
+    if err != nil {
+        return nil, false, err
+    }
+    more := int64(len(members)) > limit
+    if more {
+        members = members[:limit]
+    }
+    transitions := make([]*StatusTransition, 0, len(members))
+    for _, member := range members {
+        transition := &StatusTransition{}
+        if err := proto.Unmarshal([]byte(member), transition); err != nil {
+            return nil, false, err
+        }
+        transitions = append(transitions, transition)
+    }
+    return transitions, more, nil
+}
+
+func statusTimelineStateKey(organizationID string) string {
+    return "status-timeline-state:" + organizationID
+}
+
+func statusTimelineKey(organizationID string) string {
+    return "status-timeline:" + organizationID
+}
+
+// Primary cause of a run's status against the previous one. A worse status
+// capped below the weighted average is put down to the gate that capped
+// it; otherwise to the framework whose weighted score moved furthest the
+// same way.
+func (s *ComplianceService) statusCause(previous *LatestResult, response *ComplianceResponse, runtime *RuntimeConfig) *StatusCause {
+    if previous == nil || statusRank(response.Status) < statusRank(previous.Status) {
+        base := s.determineStatus(response.OverallScore, runtime.Thresholds)
+        if _, gated := applyFrameworkGates(base, response.FrameworkResults, runtime.FrameworkGates); len(gated) > 0 && statusRank(response.Status) < statusRank(base) {
+            result := findFrameworkResult(response.FrameworkResults, gated[0])
+            return &StatusCause{
+                Kind:      causeFrameworkGate,
+                Framework: gated[0],
+                Detail:    fmt.Sprintf("%s scored %v, below its gate for %s", gated[0], result.Score, base),
+            }
+        }
+        if base == "COMPLIANT" && response.Status != base {
+            for _, result := range response.FrameworkResults {
+                for _, subdomain := range result.GetSamaDetails().GetSubdomains() {
+                    if !subdomain.GatePassed {
+                        return &StatusCause{
+                            Kind:      causeSubdomainGate,
+                            Framework: result.Framework,
+                            Subject:   subdomain.Name,
+                            Detail:    fmt.Sprintf("%s sub-domain %s scored %v, below its minimum %v", result.Framework, subdomain.Name, subdomain.Score, subdomain.GateMinimum),
+                        }
+                    }
+                }
+            }
+        }
+    }
+    if previous == nil {
+        return &StatusCause{Kind: causeFirstEvaluation, Detail: fmt.Sprintf("overall score %v", response.OverallScore)}
+    }
+
+    direction := 1.0
+    if statusRank(response.Status) < statusRank(previous.Status) {
+        direction = -1
+    }
+    var driver string
+    var driverMove float64
+    frameworks := make([]string, 0, len(runtime.Weights))
+    for framework := range runtime.Weights {
+        frameworks = append(frameworks, framework)
+    }
+    sort.Strings(frameworks)
+    for _, framework := range frameworks {
+        result := findFrameworkResult(response.FrameworkResults, framework)
+        before, ok := previous.FrameworkScores[framework]
+        if result == nil || !scoredResult(result) || !ok {
+            continue
+        }
+        if move := direction * (result.Score - before) * runtime.Weights[framework]; move > driverMove {
+            driver, driverMove = framework, move
+        }
+    }
+    if driver == "" {
+        return &StatusCause{Kind: causeFrameworkScore, Detail: fmt.Sprintf("overall score %v -> %v", previous.OverallScore, response.OverallScore)}
+    }
+    result := findFrameworkResult(response.FrameworkResults, driver)
+    detail := fmt.Sprintf("%s %v -> %v", driver, previous.FrameworkScores[driver], result.Score)
+    if critical := result.GetNcaDetails().GetCriticalIssues(); critical > 0 {
+        detail += fmt.Sprintf(", %d critical issues", critical)
+    }
+    return &StatusCause{Kind: causeFrameworkScore, Framework: driver, Detail: detail}
+}
+
+// Record a complete run on the organization's status timeline
+func (s *ComplianceService) recordStatusTransition(ctx context.Context, runID string, response *ComplianceResponse, runtime *RuntimeConfig) *StatusTransition {
+    transition, err := s.timeline.Record(ctx, runID, response, func(previous *LatestResult) *StatusCause {
+        return s.statusCause(previous, response, runtime)
+    })
+    if err != nil {
+        log.Printf("Failed to record status timeline for %s: %v", redact(fieldOrganizationID, response.OrganizationId), err)
+        return nil
+    }
+    return transition
+}
+
+// Derive the timelines of organizations evaluated before the timeline
+// existed from their stored evaluations, once across replicas. Causes are
+// judged under the current runtime config. An organization already on the
+// timeline is left as it is.
+func (s *ComplianceService) backfillStatusTimeline(ctx context.Context) {
+    claimed, err := s.redis.SetNX(ctx, statusTimelineBackfillKey, "running", statusTimelineBackfillClaim).Result()
+    if err != nil || !claimed {
+        return
+    }
+    start := time.Now()
+    runtime := s.runtimeConfig()
+    organizations := 0
+
+    iter := s.redis.Scan(ctx, 0, evaluationIndexKey("*"), 500).Iterator()
+    for iter.Next(ctx) {
+        organizationID := strings.TrimPrefix(iter.Val(), evaluationIndexKey(""))
+        records, _, err := s.replays.History(ctx, organizationID, time.Unix(0, 0), start, nil, -1)
+        if err != nil {
+            log.Printf("Status timeline backfill stopped: %v", err)
+            return
+        }
+        if s.backfillOrganization(ctx, organizationID, records, runtime) {
+            organizations++
+        }
+    }
+    if err := iter.Err(); err != nil {
+        log.Printf("Status timeline backfill stopped: %v", err)
+        return
+    }
+    s.redis.Set(ctx, statusTimelineBackfillKey, "done", 0)
+    log.Printf("Status timeline backfilled for %d organizations in %s", organizations, time.Since(start).Round(time.Millisecond))
+}
+
+// Replay an organization's stored evaluations, newest first as History
+// returns them, into its timeline; false if it already had one
+func (s *ComplianceService) backfillOrganization(ctx context.Context, organizationID string, records []*EvaluationRecord, runtime *RuntimeConfig) bool {
+    var previous *LatestResult
+    var transitions []*StatusTransition
+    for i := len(records) - 1; i >= 0; i-- {
+        response := records[i].Response
+        if response == nil {
+            continue
+        }
+        if previous == nil || previous.Status != response.Status {
+            transitions = append(transitions, newStatusTransition(records[i].RequestId, previous, response, s.statusCause(previous, response, runtime)))
+        }
+        previous = timelineState(response)
+    }
+    if previous == nil {
+        return false
+    }
+    written, err := s.timeline.write(ctx, organizationID, "", previous, transitions...)
+    if err != nil {
+        log.Printf("Failed to backfill status timeline for %s: %v", redact(fieldOrganizationID, organizationID), err)
+    }
+    return written
+}
+
+// GetStatusTimeline - an organization's status transitions within a range,
+// oldest first, paginated by offset
+func (s *ComplianceService) GetStatusTimeline(ctx context.Context, req *StatusTimelineRequest) (*StatusTimelineResponse, error) {
+    if req.OrganizationId == "" {
+        return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+    }
+    if req.From < 0 || req.To < 0 || (req.To > 0 && req.From >= req.To) {
+        return nil, status.Error(codes.InvalidArgument, "range must satisfy 0 <= from < to")
+    }
+    organizationID, err := s.registry.ResolveID(ctx, req.OrganizationId)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkOrgAllowed(organizationID); err != nil {
+        return nil, err
+    }
+
+    to := time.Now().Add(time.Second)
+    if req.To > 0 {
+        to = time.Unix(req.To, 0)
+    }
+    pageSize := int64(req.PageSize)
+    if pageSize <= 0 {
+        pageSize = timelineDefaultPageSize
+    }
+    if pageSize > timelineMaxPageSize {
+        pageSize = timelineMaxPageSize
+    }
+    var offset int64
+    if req.PageToken != "" {
+        if offset, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil || offset < 0 {
+            return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+        }
+    }
+
+    transitions, more, err := s.timeline.Transitions(ctx, organizationID, time.Unix(req.From, 0), to, offset, pageSize)
+    if err != nil {
+        return nil, storeError(err, "failed to load status timeline")
+    }
+    response := &StatusTimelineResponse{Transitions: transitions}
+    if more {
+        response.NextPageToken = strconv.FormatInt(offset+pageSize, 10)
+    }
+    return response, nil
+}
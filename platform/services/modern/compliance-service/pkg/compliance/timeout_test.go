@@ -0,0 +1,83 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Replace a framework's checker with one that ignores cancellation and
+// blocks until the test ends
+func blockForever(t *testing.T, service *ComplianceService, framework string) {
+    block := make(chan struct{})
+    t.Cleanup(func() { close(block) })
+    service.engine.Register(framework, func(ctx context.Context, req *ComplianceRequest) *FrameworkResult {
+        <-block
+        return &FrameworkResult{Framework: framework, Score: 100}
+    })
+}
+
+// A runaway checker is abandoned at the compute timeout even when the
+// caller set no deadline, and the partial run is not cached
+func TestComputeTimeoutAbortsRunawayCheck(t *testing.T) {
+    const timeout = 100 * time.Millisecond
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeTimeout = timeout
+        config.WorkerPoolSize = 1
+    })
+    blockForever(t, service, "SAMA")
+
+    before := testutil.ToFloat64(computeTimeouts)
+    start := time.Now()
+    _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"SAMA"}})
+    elapsed := time.Since(start)
+
+    if status.Code(err) != codes.DeadlineExceeded {
+        t.Fatalf("CheckCompliance() = %v, want DeadlineExceeded", err)
+    }
+    if elapsed < timeout || elapsed > timeout+time.Second {
+        t.Errorf("aborted after %v, want about %v", elapsed, timeout)
+    }
+    if got := testutil.ToFloat64(computeTimeouts) - before; got != 1 {
+        t.Errorf("%v compute timeouts counted, want 1", got)
+    }
+    for _, key := range server.Keys() {
+        if strings.HasPrefix(key, responseKeyPrefix) {
+            t.Errorf("timed out response cached under %s", key)
+        }
+    }
+
+    // The abandoned checker no longer holds the only worker slot
+    if _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"NCA"}}); err != nil {
+        t.Errorf("check after the timeout = %v, want the worker released", err)
+    }
+}
+
+// A caller's own cancellation fails the check rather than scoring the
+// empty results, and is not counted as a compute timeout
+func TestCancelledCheckFails(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.ComputeTimeout = time.Minute
+    })
+    blockForever(t, service, "SAMA")
+
+    before := testutil.ToFloat64(computeTimeouts)
+    ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+    defer cancel()
+    _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1", Frameworks: []string{"SAMA"}})
+
+    if status.Code(err) != codes.DeadlineExceeded {
+        t.Errorf("CheckCompliance() past the caller's deadline = %v, want DeadlineExceeded", err)
+    }
+    if got := testutil.ToFloat64(computeTimeouts) - before; got != 0 {
+        t.Errorf("%v compute timeouts counted for the caller's deadline, want 0", got)
+    }
+    if len(cachedOrganizations(server)) != 0 {
+        t.Error("cancelled check cached a response")
+    }
+}
@@ -0,0 +1,167 @@
+package compliance
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/binary"
+    "encoding/hex"
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+    "sync/atomic"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/internal/events"
+    "github.com/prometheus/client_golang/prometheus"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/metadata"
+)
+
+// W3C trace context header, read from HTTP headers and gRPC metadata
+const traceparentHeader = "traceparent"
+
+// TraceSampler - deterministic head sampling keyed on the trace ID, plus
+// tail sampling of the spans recorded locally. Every service using the same
+// ratio reaches the same head decision for a trace, so a trace is either
+// complete or absent rather than partially recorded; the tail decision adds
+// errored, slow and degraded requests on top.
+type TraceSampler struct {
+    policy   atomic.Pointer[TraceSamplingConfig]
+    producer *events.Producer
+
+    // Spans buffered across in-flight traces, bounded by maxSpans
+    buffered atomic.Int64
+    maxSpans int64
+}
+
+// TraceSamplingConfig - reloadable sampling policy
+type TraceSamplingConfig struct {
+    // Fraction of traces sampled regardless of outcome
+    SampleRatio float64 `yaml:"sample_ratio" json:"sample_ratio"`
+
+    // Requests at least this slow are always exported; 0 disables
+    LatencyThreshold time.Duration `yaml:"latency_threshold" json:"latency_threshold"`
+}
+
+// Validate the ratio and threshold
+func (c TraceSamplingConfig) Validate() error {
+    if c.SampleRatio < 0 || c.SampleRatio > 1 || math.IsNaN(c.SampleRatio) {
+        return fmt.Errorf("trace sample ratio must be within [0, 1], got %v", c.SampleRatio)
+    }
+    if c.LatencyThreshold < 0 {
+        return fmt.Errorf("trace latency threshold must not be negative")
+    }
+    return nil
+}
+
+// TraceDecision - the effective sampling decision for a request
+type TraceDecision struct {
+    TraceID string `json:"trace_id"`
+    Sampled bool   `json:"sampled"`
+}
+
+// Create a sampler exporting traces through producer and buffering at most
+// maxSpans spans across in-flight traces
+func NewTraceSampler(policy TraceSamplingConfig, producer *events.Producer, maxSpans int) (*TraceSampler, error) {
+    t := &TraceSampler{producer: producer, maxSpans: int64(maxSpans)}
+    if err := t.Configure(policy); err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+// Configure swaps in a new sampling policy
+func (t *TraceSampler) Configure(policy TraceSamplingConfig) error {
+    if err := policy.Validate(); err != nil {
+        return err
+    }
+    t.policy.Store(&policy)
+    return nil
+}
+
+// Sampled reports whether a trace ID falls within the sampled ratio. W3C
+// trace IDs use their low 8 bytes, matching the OpenTelemetry ratio sampler;
+// any other ID is hashed with SHA-256, whose leading bits stay uniform for
+// sequential IDs such as "req-1", "req-2".
+func (t *TraceSampler) Sampled(traceID string) bool {
+    ratio := t.policy.Load().SampleRatio
+    if ratio >= 1 {
+        return true
+    }
+    if ratio <= 0 || traceID == "" {
+        return false
+    }
+
+    var x uint64
+    if raw, err := hex.DecodeString(traceID); err == nil && len(raw) == 16 {
+        x = binary.BigEndian.Uint64(raw[8:16])
+    } else {
+        sum := sha256.Sum256([]byte(traceID))
+        x = binary.BigEndian.Uint64(sum[:8])
+    }
+    return x>>1 < uint64(ratio*(1<<63))
+}
+
+// Decide for a trace ID, counting the outcome
+func (t *TraceSampler) Decide(traceID string) TraceDecision {
+    decision := TraceDecision{TraceID: traceID, Sampled: t.Sampled(traceID)}
+    traceDecisions.WithLabelValues(strconv.FormatBool(decision.Sampled)).Inc()
+    return decision
+}
+
+// UnaryInterceptor decides head sampling for every gRPC call, returning the
+// decision to the caller in the x-trace-id and x-trace-sampled headers, and
+// records the call's spans for the tail decision at completion
+func (t *TraceSampler) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    traceID := ""
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        if values := md.Get(traceparentHeader); len(values) > 0 {
+            traceID = traceIDFromTraceparent(values[0])
+        }
+    }
+    if traceID == "" {
+        traceID = requestID(ctx)
+    }
+
+    decision := t.Decide(traceID)
+    grpc.SetHeader(ctx, metadata.Pairs(
+        "x-trace-id", decision.TraceID,
+        "x-trace-sampled", strconv.FormatBool(decision.Sampled),
+    ))
+
+    ctx, trace := t.Begin(ctx, traceID)
+    startTime := time.Now()
+    resp, err := handler(ctx, req)
+    t.Finish(trace, rpcName(info.FullMethod), startTime, err)
+    return resp, err
+}
+
+// Trace ID from a W3C traceparent such as "00-<trace-id>-<span-id>-01";
+// empty when the header is malformed or carries the all-zero ID
+func traceIDFromTraceparent(value string) string {
+    parts := strings.Split(strings.TrimSpace(value), "-")
+    if len(parts) != 4 || len(parts[1]) != 32 {
+        return ""
+    }
+    traceID := strings.ToLower(parts[1])
+    if _, err := hex.DecodeString(traceID); err != nil || traceID == strings.Repeat("0", 32) {
+        return ""
+    }
+    return traceID
+}
+
+// Trace sampling metrics
+var (
+    traceDecisions = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_trace_sampling_decisions_total",
+            Help: "Trace sampling decisions by outcome",
+        },
+        []string{"sampled"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(traceDecisions)
+}
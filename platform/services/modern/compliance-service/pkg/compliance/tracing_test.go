@@ -0,0 +1,126 @@
+package compliance
+
+import (
+    "fmt"
+    "net/http"
+    "testing"
+)
+
+func newTestSampler(t *testing.T, ratio float64) *TraceSampler {
+    sampler, err := NewTraceSampler(TraceSamplingConfig{SampleRatio: ratio}, nil, 100)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return sampler
+}
+
+// Every replica with the same ratio reaches the same decision for a trace,
+// however often it is asked
+func TestTraceSamplingIsConsistent(t *testing.T) {
+    first, second := newTestSampler(t, 0.3), newTestSampler(t, 0.3)
+    for i := 0; i < 200; i++ {
+        for _, traceID := range []string{fmt.Sprintf("%032x", i*7919+1), fmt.Sprintf("req-%d", i)} {
+            want := first.Sampled(traceID)
+            if first.Sampled(traceID) != want || second.Sampled(traceID) != want {
+                t.Fatalf("trace %s sampled inconsistently", traceID)
+            }
+        }
+    }
+}
+
+func TestTraceSamplingRatio(t *testing.T) {
+    tests := []struct {
+        name    string
+        ratio   float64
+        traceID string
+        want    bool
+    }{
+        {name: "ratio 1 samples everything", ratio: 1, traceID: "ffffffffffffffffffffffffffffffff", want: true},
+        {name: "ratio 0 samples nothing", ratio: 0, traceID: "00000000000000010000000000000000", want: false},
+        {name: "no trace ID", ratio: 0.5, traceID: "", want: false},
+        {name: "low W3C bytes under the ratio", ratio: 0.5, traceID: "ffffffffffffffff0000000000000001", want: true},
+        {name: "low W3C bytes over the ratio", ratio: 0.5, traceID: "0000000000000000ffffffffffffffff", want: false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := newTestSampler(t, tt.ratio).Sampled(tt.traceID); got != tt.want {
+                t.Errorf("Sampled(%q) = %t, want %t", tt.traceID, got, tt.want)
+            }
+        })
+    }
+
+    // Across many IDs the sampled share tracks the ratio
+    sampler := newTestSampler(t, 0.25)
+    sampled := 0
+    for i := 0; i < 4000; i++ {
+        if sampler.Sampled(fmt.Sprintf("req-%d", i)) {
+            sampled++
+        }
+    }
+    if sampled < 800 || sampled > 1200 {
+        t.Errorf("%d of 4000 traces sampled at ratio 0.25, want about 1000", sampled)
+    }
+
+    if err := newTestSampler(t, 0).Configure(TraceSamplingConfig{SampleRatio: 1.5}); err == nil {
+        t.Error("Configure() accepted a ratio above 1")
+    }
+}
+
+func TestTraceIDFromTraceparent(t *testing.T) {
+    tests := []struct {
+        value string
+        want  string
+    }{
+        {value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+        {value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", want: ""},
+        {value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", want: ""},
+        {value: "00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: ""},
+        {value: "", want: ""},
+    }
+    for _, tt := range tests {
+        if got := traceIDFromTraceparent(tt.value); got != tt.want {
+            t.Errorf("traceIDFromTraceparent(%q) = %q, want %q", tt.value, got, tt.want)
+        }
+    }
+}
+
+// The gateway reports the decision in meta.debug.trace and X-Trace-Sampled,
+// the same for every request in a trace
+func TestGatewayReportsTraceDecision(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.TraceSampleRatio = 0.5
+    })
+    gateway := NewGateway(service)
+
+    tests := []struct {
+        name        string
+        traceparent string
+        traceID     string
+        sampled     bool
+    }{
+        {name: "sampled trace", traceparent: "00-ffffffffffffffff0000000000000001-00f067aa0ba902b7-01", traceID: "ffffffffffffffff0000000000000001", sampled: true},
+        {name: "unsampled trace", traceparent: "00-0000000000000000ffffffffffffffff-00f067aa0ba902b7-01", traceID: "0000000000000000ffffffffffffffff", sampled: false},
+        {name: "request ID without a traceparent", traceID: "req-1", sampled: newTestSampler(t, 0.5).Sampled("req-1")},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            header := http.Header{}
+            header.Set("X-Request-ID", "req-1")
+            if tt.traceparent != "" {
+                header.Set(traceparentHeader, tt.traceparent)
+            }
+            for i := 0; i < 2; i++ {
+                _, responseHeader, envelope := callGateway(t, gateway, http.MethodPost, "/v1/compliance/check", `{"organization_id":"org-1"}`, header)
+                if envelope.Meta.Debug == nil || envelope.Meta.Debug.Trace == nil {
+                    t.Fatal("meta.debug.trace missing")
+                }
+                if got := *envelope.Meta.Debug.Trace; got.TraceID != tt.traceID || got.Sampled != tt.sampled {
+                    t.Errorf("meta.debug.trace %+v, want %s sampled=%t", got, tt.traceID, tt.sampled)
+                }
+                if got := responseHeader.Get("X-Trace-Sampled"); got != fmt.Sprint(tt.sampled) {
+                    t.Errorf("X-Trace-Sampled %q, want %t", got, tt.sampled)
+                }
+            }
+        })
+    }
+}
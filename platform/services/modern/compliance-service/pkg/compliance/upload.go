@@ -0,0 +1,208 @@
+package compliance
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/proto"
+)
+
+// Redis key prefixes for uploaded evidence
+const (
+    evidenceKeyPrefix       = "evidence:"
+    evidenceUploadKeyPrefix = "evidence-upload:"
+)
+
+const (
+    // Partial uploads are staged with this TTL, so abandoned streams expire
+    evidenceUploadTTL = 15 * time.Minute
+    // Upper bound on the assembled evidence set
+    maxEvidenceUploadBytes = 32 << 20
+)
+
+// EvidenceStore - content-addressed storage of uploaded evidence sets
+type EvidenceStore struct {
+    redis *redis.Client
+    ttl   time.Duration
+}
+
+// Create an evidence store keeping completed sets for ttl
+func NewEvidenceStore(client *redis.Client, ttl time.Duration) *EvidenceStore {
+    return &EvidenceStore{redis: client, ttl: ttl}
+}
+
+// Save an assembled evidence set; identical sets for the same organization
+// and scope are stored once
+func (s *EvidenceStore) Save(ctx context.Context, bundle *EvidenceUploadMetadata) (ref, hash string, deduplicated bool, err error) {
+    hash = evidenceContentHash(bundle.Items)
+    ref = bundle.Scope + "." + hash
+
+    data, err := proto.Marshal(bundle)
+    if err != nil {
+        return "", "", false, fmt.Errorf("failed to encode evidence: %v", err)
+    }
+
+    key := evidenceKey(bundle.OrganizationId, ref)
+    created, err := s.redis.SetNX(ctx, key, data, s.ttl).Result()
+    if err != nil {
+        return "", "", false, err
+    }
+    if !created {
+        s.redis.Expire(ctx, key, s.ttl)
+    }
+    return ref, hash, !created, nil
+}
+
+// Load the evidence items behind a reference token for an organization
+func (s *EvidenceStore) Load(ctx context.Context, organizationID, ref string) ([]*EvidenceItem, error) {
+    data, err := s.redis.Get(ctx, evidenceKey(organizationID, ref)).Bytes()
+    if err == redis.Nil {
+        return nil, status.Errorf(codes.FailedPrecondition, "evidence reference %s not found or expired", ref)
+    }
+    if err != nil {
+        return nil, storeError(err, "evidence store unavailable")
+    }
+    bundle := &EvidenceUploadMetadata{}
+    if err := proto.Unmarshal(data, bundle); err != nil {
+        return nil, status.Errorf(codes.Internal, "corrupt evidence set %s", ref)
+    }
+    return bundle.Items, nil
+}
+
+func evidenceKey(organizationID, ref string) string {
+    return evidenceKeyPrefix + organizationID + ":" + ref
+}
+
+// SHA-256 over the items in key order, so the hash ignores upload order
+func evidenceContentHash(items []*EvidenceItem) string {
+    sorted := make([]*EvidenceItem, len(items))
+    copy(sorted, items)
+    sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+    h := sha256.New()
+    for _, item := range sorted {
+        collectedAt := int64(0)
+        if item.CollectedAt != nil {
+            collectedAt = item.CollectedAt.AsTime().UnixNano()
+        }
+        fmt.Fprintf(h, "%s\x00%s\x00%d\x00%d:%s\x00", item.Key, strings.ToUpper(item.Type), collectedAt, len(item.Value), item.Value)
+        // Provenance only when present, so hashes of uploads without it are unchanged
+        if item.SourceSystem != "" || item.Collector != "" || item.Sha256 != "" {
+            fmt.Fprintf(h, "%s\x00%s\x00%s\x00", item.SourceSystem, item.Collector, strings.ToLower(item.Sha256))
+        }
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// UploadEvidence - client-streaming evidence upload. The first message must
+// carry the metadata; value chunks are staged in Redis with a TTL so an
+// abandoned upload is garbage-collected without any sweeper.
+func (s *ComplianceService) UploadEvidence(stream Compliance_UploadEvidenceServer) error {
+    defer s.recordMetrics(time.Now(), "upload_evidence")
+    ctx := stream.Context()
+
+    first, err := stream.Recv()
+    if err != nil {
+        return status.Error(codes.InvalidArgument, "empty upload")
+    }
+    bundle := first.GetMetadata()
+    if bundle == nil || bundle.OrganizationId == "" {
+        return status.Error(codes.InvalidArgument, "first message must carry metadata with organization_id")
+    }
+    if err := s.checkOrgAllowed(bundle.OrganizationId); err != nil {
+        return err
+    }
+    if bundle.Scope == "" {
+        bundle.Scope = "default"
+    }
+
+    items := make(map[string]*EvidenceItem, len(bundle.Items))
+    size := 0
+    for _, item := range bundle.Items {
+        if item.Key == "" {
+            return status.Error(codes.InvalidArgument, "evidence item without key")
+        }
+        items[item.Key] = item
+        size += len(item.Value)
+    }
+
+    uploadID := newRequestID()
+    staged := make(map[string]bool)
+    defer func() {
+        // Staging keys expire on their own; this only speeds up cleanup
+        for key := range staged {
+            s.redis.Del(context.WithoutCancel(ctx), stagingKey(uploadID, key))
+        }
+    }()
+
+    for {
+        chunk, err := stream.Recv()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return err
+        }
+        value := chunk.GetValue()
+        if value == nil {
+            return status.Error(codes.InvalidArgument, "metadata may only be sent once")
+        }
+        if _, ok := items[value.Key]; !ok {
+            return status.Errorf(codes.InvalidArgument, "chunk for undeclared evidence key %s", value.Key)
+        }
+        if size += len(value.Data); size > maxEvidenceUploadBytes {
+            return status.Errorf(codes.ResourceExhausted, "evidence upload exceeds %d bytes", maxEvidenceUploadBytes)
+        }
+
+        key := stagingKey(uploadID, value.Key)
+        pipe := s.redis.TxPipeline()
+        pipe.Append(ctx, key, string(value.Data))
+        pipe.Expire(ctx, key, evidenceUploadTTL)
+        if _, err := pipe.Exec(ctx); err != nil {
+            return storeError(err, "failed to stage evidence")
+        }
+        staged[value.Key] = true
+    }
+
+    // Assemble staged values into their items
+    for key := range staged {
+        data, err := s.redis.Get(ctx, stagingKey(uploadID, key)).Result()
+        if err != nil {
+            return status.Errorf(codes.Aborted, "staged evidence for %s expired: %v", key, err)
+        }
+        items[key].Value += data
+    }
+
+    _, verdicts := s.engine.assembleEvidence(s.engine.Frameworks(), bundle.Items, time.Now())
+    for _, verdict := range verdicts {
+        if verdict.Verdict == verdictInvalidType {
+            return status.Errorf(codes.InvalidArgument, "evidence %s: %s", verdict.Key, verdict.Message)
+        }
+    }
+
+    ref, hash, deduplicated, err := s.evidence.Save(ctx, bundle)
+    if err != nil {
+        return storeError(err, "failed to store evidence")
+    }
+
+    return stream.SendAndClose(&EvidenceUploadResult{
+        EvidenceRef:  ref,
+        ContentHash:  hash,
+        Deduplicated: deduplicated,
+        ItemCount:    int32(len(bundle.Items)),
+        Verdicts:     verdicts,
+    })
+}
+
+func stagingKey(uploadID, key string) string {
+    return evidenceUploadKeyPrefix + uploadID + ":" + key
+}
@@ -0,0 +1,290 @@
+package compliance
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/redis/go-redis/v9"
+    "google.golang.org/grpc/codes"
+)
+
+// Usage counter names, stored as Redis hash fields
+const (
+    usageEvaluations    = "evaluations"
+    usageCacheHits      = "cache_hits"
+    usageBatches        = "batches"
+    usageBatchItems     = "batch_items"
+    usageReports        = "reports"
+    usageStreamedEvents = "streamed_events"
+)
+
+const (
+    usageBufferSize    = 4096
+    usageFlushInterval = 5 * time.Second
+    usageRetention     = 400 * 24 * time.Hour
+)
+
+// UsageTracker - per-tenant, per-day usage accounting for billing.
+//
+// Accounting is asynchronous and loss-tolerant: Record never blocks the
+// request path, events are dropped when the buffer is full, and buffered
+// counts are lost if the process dies between flushes. Counts (and therefore
+// quota enforcement) are approximate by up to one flush interval per replica.
+type UsageTracker struct {
+    redis  *redis.Client
+    events chan usageEvent
+    quotas map[string]int64
+
+    mu         sync.Mutex
+    monthTotal map[string]int64 // tenant -> evaluations this month, last flush
+    pending    map[string]int64 // tenant -> evaluations recorded since last flush
+}
+
+type usageEvent struct {
+    tenant  string
+    counter string
+    n       int64
+    at      time.Time
+}
+
+// Create a usage tracker; quotas maps tenant to max evaluations per month
+func NewUsageTracker(client *redis.Client, quotas map[string]int64) *UsageTracker {
+    return &UsageTracker{
+        redis:      client,
+        events:     make(chan usageEvent, usageBufferSize),
+        quotas:     quotas,
+        monthTotal: make(map[string]int64),
+        pending:    make(map[string]int64),
+    }
+}
+
+// Record usage without blocking; the event is dropped if the buffer is full
+func (u *UsageTracker) Record(tenant, counter string, n int64) {
+    if counter == usageEvaluations {
+        u.mu.Lock()
+        u.pending[tenant] += n
+        u.mu.Unlock()
+    }
+
+    select {
+    case u.events <- usageEvent{tenant: tenant, counter: counter, n: n, at: time.Now().UTC()}:
+    default:
+        usageEventsDropped.Inc()
+    }
+}
+
+// CheckQuota rejects the evaluation once the tenant exceeded its monthly
+// quota. Only full evaluations count towards the quota; responses served
+// from cache do not, and are served past it.
+func (u *UsageTracker) CheckQuota(tenant string) error {
+    quota, ok := u.quotas[tenant]
+    if !ok || quota <= 0 {
+        return nil
+    }
+
+    u.mu.Lock()
+    used := u.monthTotal[tenant] + u.pending[tenant]
+    u.mu.Unlock()
+
+    if used >= quota {
+        quotaRejections.WithLabelValues(tenant).Inc()
+        return reasonError(codes.ResourceExhausted, reasonQuotaExceeded, fmt.Sprintf("monthly evaluation quota of %d exceeded", quota))
+    }
+    return nil
+}
+
+// Run aggregates buffered events and flushes them to Redis until ctx is done
+func (u *UsageTracker) Run(ctx context.Context) {
+    ticker := time.NewTicker(usageFlushInterval)
+    defer ticker.Stop()
+
+    batch := make(map[string]map[string]int64)
+    for {
+        select {
+        case <-ctx.Done():
+            u.flush(context.Background(), batch)
+            return
+        case event := <-u.events:
+            for _, key := range []string{usageDayKey(event.tenant, event.at), usageMonthKey(event.tenant, event.at)} {
+                if batch[key] == nil {
+                    batch[key] = make(map[string]int64)
+                }
+                batch[key][event.counter] += event.n
+            }
+        case <-ticker.C:
+            u.flush(ctx, batch)
+            batch = make(map[string]map[string]int64)
+        }
+    }
+}
+
+func (u *UsageTracker) flush(ctx context.Context, batch map[string]map[string]int64) {
+    u.mu.Lock()
+    flushed := u.pending
+    u.pending = make(map[string]int64)
+    u.mu.Unlock()
+
+    if len(batch) > 0 {
+        pipe := u.redis.Pipeline()
+        for key, counters := range batch {
+            for counter, n := range counters {
+                pipe.HIncrBy(ctx, key, counter, n)
+            }
+            pipe.Expire(ctx, key, usageRetention)
+        }
+        if _, err := pipe.Exec(ctx); err != nil {
+            // Loss-tolerant by design: the batch is not retried
+            log.Printf("Usage flush failed, dropping %d day buckets: %v", len(batch), err)
+            usageFlushFailures.Inc()
+        }
+    }
+
+    // Refresh month-to-date totals for tenants with a quota
+    now := time.Now().UTC()
+    for tenant, quota := range u.quotas {
+        if quota <= 0 {
+            continue
+        }
+        fields, err := u.redis.HGetAll(ctx, usageMonthKey(tenant, now)).Result()
+        u.mu.Lock()
+        if err == nil {
+            totals := usageCountersFromHash(fields)
+            u.monthTotal[tenant] = totals.Evaluations
+        } else {
+            // Keep counting locally until Redis is reachable again
+            u.monthTotal[tenant] += flushed[tenant]
+        }
+        u.mu.Unlock()
+    }
+}
+
+// Report returns daily usage and totals for a tenant and month (YYYY-MM)
+func (u *UsageTracker) Report(ctx context.Context, tenant, month string) (*UsageReportResponse, error) {
+    start, err := time.Parse("2006-01", month)
+    if err != nil {
+        return nil, fmt.Errorf("invalid month %q: %v", month, err)
+    }
+
+    response := &UsageReportResponse{
+        TenantId:     tenant,
+        Month:        month,
+        Totals:       &UsageCounters{},
+        MonthlyQuota: u.quotas[tenant],
+        Approximate:  true,
+    }
+
+    pipe := u.redis.Pipeline()
+    var cmds []*redis.MapStringStringCmd
+    var days []time.Time
+    for day := start; day.Month() == start.Month(); day = day.AddDate(0, 0, 1) {
+        cmds = append(cmds, pipe.HGetAll(ctx, usageDayKey(tenant, day)))
+        days = append(days, day)
+    }
+    if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+        return nil, fmt.Errorf("failed to read usage: %v", err)
+    }
+
+    for i, cmd := range cmds {
+        fields, err := cmd.Result()
+        if err != nil || len(fields) == 0 {
+            continue
+        }
+        counters := usageCountersFromHash(fields)
+        response.Days = append(response.Days, &DailyUsage{
+            Date:     days[i].Format("2006-01-02"),
+            Counters: counters,
+        })
+        addUsageCounters(response.Totals, counters)
+    }
+    return response, nil
+}
+
+func usageDayKey(tenant string, day time.Time) string {
+    return "usage:" + tenant + ":" + day.UTC().Format("2006-01-02")
+}
+
+func usageMonthKey(tenant string, day time.Time) string {
+    return "usage:" + tenant + ":" + day.UTC().Format("2006-01")
+}
+
+func usageCountersFromHash(fields map[string]string) *UsageCounters {
+    value := func(name string) int64 {
+        n, _ := strconv.ParseInt(fields[name], 10, 64)
+        return n
+    }
+    return &UsageCounters{
+        Evaluations:    value(usageEvaluations),
+        CacheHits:      value(usageCacheHits),
+        Batches:        value(usageBatches),
+        BatchItems:     value(usageBatchItems),
+        Reports:        value(usageReports),
+        StreamedEvents: value(usageStreamedEvents),
+    }
+}
+
+func addUsageCounters(total, counters *UsageCounters) {
+    total.Evaluations += counters.Evaluations
+    total.CacheHits += counters.CacheHits
+    total.Batches += counters.Batches
+    total.BatchItems += counters.BatchItems
+    total.Reports += counters.Reports
+    total.StreamedEvents += counters.StreamedEvents
+}
+
+// Parse tenant quotas such as "tenant-a:10000,tenant-b:500"
+func parseTenantQuotas(value string) (map[string]int64, error) {
+    quotas := make(map[string]int64)
+    for _, entry := range strings.Split(value, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        parts := strings.SplitN(entry, ":", 2)
+        if len(parts) != 2 {
+            return nil, fmt.Errorf("invalid tenant quota %q", entry)
+        }
+        quota, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid tenant quota %q: %v", entry, err)
+        }
+        quotas[strings.TrimSpace(parts[0])] = quota
+    }
+    return quotas, nil
+}
+
+// Usage accounting metrics
+var (
+    usageEventsDropped = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_usage_events_dropped_total",
+            Help: "Usage events dropped because the accounting buffer was full",
+        },
+    )
+
+    usageFlushFailures = prometheus.NewCounter(
+        prometheus.CounterOpts{
+            Name: "compliance_usage_flush_failures_total",
+            Help: "Usage flushes to Redis that failed and were dropped",
+        },
+    )
+
+    quotaRejections = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_quota_rejections_total",
+            Help: "Evaluations rejected because the tenant quota was exceeded",
+        },
+        []string{"tenant"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(usageEventsDropped)
+    prometheus.MustRegister(usageFlushFailures)
+    prometheus.MustRegister(quotaRejections)
+}
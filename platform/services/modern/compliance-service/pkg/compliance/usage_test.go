@@ -0,0 +1,31 @@
+package compliance
+
+import (
+    "context"
+    "testing"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Only evaluations count towards the monthly quota: once it is used up,
+// cached responses are still served and only new evaluations are refused
+func TestQuotaCountsEvaluationsOnly(t *testing.T) {
+    service, _ := newTestService(t, func(config *ServiceConfig) {
+        config.TenantQuotas = defaultTenant + ":1"
+    })
+    ctx := context.Background()
+    if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"}); err != nil {
+        t.Fatal(err)
+    }
+
+    for i := 0; i < 3; i++ {
+        if _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-1"}); err != nil {
+            t.Fatalf("cache hit %d past the quota: %v", i+1, err)
+        }
+    }
+    _, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: "org-2"})
+    if st := status.Convert(err); st.Code() != codes.ResourceExhausted || errorReason(st) != reasonQuotaExceeded {
+        t.Errorf("evaluation past the quota = %v, want ResourceExhausted %s", err, reasonQuotaExceeded)
+    }
+}
@@ -0,0 +1,178 @@
+package compliance
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// WarmupConfig - cache priming before the service reports ready
+type WarmupConfig struct {
+    // Hot organizations to prime, as a count ("200") or a percentage of
+    // organizations checked within the rollup window ("25%"); empty disables
+    Organizations string `env:"WARMUP_ORGS"`
+
+    // Longest readiness is held back, whatever has been primed by then
+    MaxDuration time.Duration `env:"WARMUP_MAX_DURATION"`
+}
+
+// How many hot organizations to prime
+type warmupTarget struct {
+    count   int
+    percent float64
+}
+
+// Parse a warmup target such as "200" or "25%"
+func parseWarmupTarget(value string) (warmupTarget, error) {
+    value = strings.TrimSpace(value)
+    if value == "" {
+        return warmupTarget{}, nil
+    }
+    if strings.HasSuffix(value, "%") {
+        percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+        if err != nil || percent < 0 || percent > 100 {
+            return warmupTarget{}, fmt.Errorf("invalid warmup percentage %q", value)
+        }
+        return warmupTarget{percent: percent}, nil
+    }
+    count, err := strconv.Atoi(value)
+    if err != nil || count < 0 {
+        return warmupTarget{}, fmt.Errorf("invalid warmup organization count %q", value)
+    }
+    return warmupTarget{count: count}, nil
+}
+
+func (t warmupTarget) enabled() bool {
+    return t.count > 0 || t.percent > 0
+}
+
+// Organizations to prime out of the hot ones available
+func (t warmupTarget) of(available int) int {
+    n := t.count
+    if t.percent > 0 {
+        n = int(float64(available)*t.percent/100 + 0.5)
+    }
+    if n > available {
+        n = available
+    }
+    return n
+}
+
+// Organizations checked within the rollup window, most recent first
+func (a *RollupAggregator) HotOrganizations(ctx context.Context) ([]string, error) {
+    cutoff := time.Now().Add(-a.window)
+    checkedAt := make(map[string]time.Time)
+
+    iter := a.redis.HScan(ctx, rollupLatestKey, 0, "", 500).Iterator()
+    for iter.Next(ctx) {
+        organizationID := iter.Val()
+        if !iter.Next(ctx) {
+            break
+        }
+        var entry rollupEntry
+        if err := json.Unmarshal([]byte(iter.Val()), &entry); err == nil && !entry.At.Before(cutoff) {
+            checkedAt[organizationID] = entry.At
+        }
+    }
+    if err := iter.Err(); err != nil {
+        return nil, err
+    }
+
+    organizations := make([]string, 0, len(checkedAt))
+    for organizationID := range checkedAt {
+        organizations = append(organizations, organizationID)
+    }
+    sort.Slice(organizations, func(i, j int) bool {
+        return checkedAt[organizations[i]].After(checkedAt[organizations[j]])
+    })
+    return organizations, nil
+}
+
+// Prime the response cache with the latest stored evaluation of the hottest
+// organizations. Each response is cached only for what is left of its TTL,
+// so warmup never serves anything a warm cache would not have. Returns once
+// the target is primed or maxDuration elapses.
+func (s *ComplianceService) warmUp(ctx context.Context, target warmupTarget, maxDuration time.Duration) {
+    if !target.enabled() {
+        return
+    }
+    start := time.Now()
+    if maxDuration > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, maxDuration)
+        defer cancel()
+    }
+
+    hot, err := s.rollups.HotOrganizations(ctx)
+    if err != nil {
+        log.Printf("Cache warmup skipped: failed to list hot organizations: %v", err)
+        return
+    }
+    hot = hot[:target.of(len(hot))]
+
+    primed := 0
+    for _, organizationID := range hot {
+        if ctx.Err() != nil {
+            log.Printf("Cache warmup timed out after %s with %d of %d organizations primed", time.Since(start).Round(time.Millisecond), primed, len(hot))
+            return
+        }
+        outcome := s.primeOrganization(ctx, organizationID)
+        warmupOrganizations.WithLabelValues(outcome).Inc()
+        if outcome != warmupSkipped {
+            primed++
+        }
+    }
+    log.Printf("Cache warmup primed %d of %d hot organizations in %s", primed, len(hot), time.Since(start).Round(time.Millisecond))
+}
+
+// Warmup outcomes per organization
+const (
+    warmupPrimed  = "primed"
+    warmupCached  = "already_cached"
+    warmupSkipped = "skipped"
+)
+
+func (s *ComplianceService) primeOrganization(ctx context.Context, organizationID string) string {
+    now := time.Now()
+    record, err := s.replays.Latest(ctx, organizationID, now.Add(-s.config.ReplayRetention), now.Add(time.Second))
+    if err != nil || record.Format == evaluationFormatSummary || record.Response.SchemaVersion != responseSchemaVersion {
+        return warmupSkipped
+    }
+
+    runtime := s.runtimeConfig()
+    remaining := runtime.Cache.Aggregate(record.Response.FrameworkResults) - now.Sub(time.Unix(record.Response.Timestamp, 0))
+    if remaining <= 0 {
+        return warmupSkipped
+    }
+
+    key := responseKey(record.Request)
+    if cached, err := s.cache.Get(ctx, key); err == nil && cached != nil {
+        return warmupCached
+    }
+    if err := s.cache.Set(ctx, key, record.Response, remaining); err != nil {
+        return warmupSkipped
+    }
+    return warmupPrimed
+}
+
+// Warmup metrics
+var (
+    warmupOrganizations = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "compliance_warmup_organizations_total",
+            Help: "Hot organizations handled by cache warmup by outcome",
+        },
+        []string{"outcome"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(warmupOrganizations)
+}
@@ -0,0 +1,127 @@
+package compliance
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "google.golang.org/grpc/health"
+    "google.golang.org/grpc/health/grpc_health_v1"
+    "google.golang.org/protobuf/proto"
+)
+
+func TestParseWarmupTarget(t *testing.T) {
+    tests := []struct {
+        value     string
+        available int
+        want      int
+        err       bool
+    }{
+        {value: "", available: 10, want: 0},
+        {value: "3", available: 10, want: 3},
+        {value: "30", available: 10, want: 10},
+        {value: "25%", available: 10, want: 3},
+        {value: "100%", available: 7, want: 7},
+        {value: "150%", err: true},
+        {value: "-1", err: true},
+        {value: "lots", err: true},
+    }
+    for _, tt := range tests {
+        target, err := parseWarmupTarget(tt.value)
+        if (err != nil) != tt.err {
+            t.Errorf("parseWarmupTarget(%q) error %v, want error %t", tt.value, err, tt.err)
+            continue
+        }
+        if got := target.of(tt.available); err == nil && got != tt.want {
+            t.Errorf("%q of %d = %d, want %d", tt.value, tt.available, got, tt.want)
+        }
+    }
+}
+
+// Organizations with a cached response
+func cachedOrganizations(server *miniredis.Miniredis) map[string]bool {
+    cached := make(map[string]bool)
+    for _, key := range server.Keys() {
+        if rest, ok := strings.CutPrefix(key, responseKeyPrefix); ok {
+            cached[rest[:strings.Index(rest, ":")]] = true
+        }
+    }
+    return cached
+}
+
+func servingStatus(t *testing.T, healthServer *health.Server) grpc_health_v1.HealthCheckResponse_ServingStatus {
+    response, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "compliance"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    return response.Status
+}
+
+// After a restart with an empty cache, readiness waits for the hottest
+// organizations to be primed
+func TestReadyAfterWarmup(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.Warmup.Organizations = "2"
+    })
+    ctx := context.Background()
+    for i, org := range []string{"org-1", "org-2", "org-3"} {
+        response, err := service.CheckCompliance(ctx, &ComplianceRequest{OrganizationId: org})
+        if err != nil {
+            t.Fatal(err)
+        }
+        // Checked a minute apart, org-3 last
+        checked := proto.Clone(response).(*ComplianceResponse)
+        checked.Timestamp -= int64(60 * (2 - i))
+        service.rollups.Record(ctx, checked)
+    }
+    for _, key := range server.Keys() {
+        if strings.HasPrefix(key, responseKeyPrefix) {
+            server.Del(key)
+        }
+    }
+
+    service.ready.Store(false)
+    healthServer := health.NewServer()
+    healthServer.SetServingStatus("compliance", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+    service.reportReady(ctx, time.Minute, healthServer)
+
+    if !service.ready.Load() || servingStatus(t, healthServer) != grpc_health_v1.HealthCheckResponse_SERVING {
+        t.Fatal("not ready after warmup")
+    }
+    cached := cachedOrganizations(server)
+    if len(cached) != 2 || !cached["org-3"] || !cached["org-2"] {
+        t.Errorf("primed %v by the time readiness flipped, want org-2 and org-3", cached)
+    }
+}
+
+// Readiness is not held back past the warmup limit
+func TestReadyWhenWarmupTimesOut(t *testing.T) {
+    service, server := newTestService(t, func(config *ServiceConfig) {
+        config.Warmup.Organizations = "100%"
+    })
+    if _, err := service.CheckCompliance(context.Background(), &ComplianceRequest{OrganizationId: "org-1"}); err != nil {
+        t.Fatal(err)
+    }
+    for _, key := range server.Keys() {
+        if strings.HasPrefix(key, responseKeyPrefix) {
+            server.Del(key)
+        }
+    }
+
+    service.ready.Store(false)
+    healthServer := health.NewServer()
+    start := time.Now()
+    service.reportReady(context.Background(), time.Nanosecond, healthServer)
+
+    if !service.ready.Load() || servingStatus(t, healthServer) != grpc_health_v1.HealthCheckResponse_SERVING {
+        t.Error("not ready once the warmup limit elapsed")
+    }
+    if elapsed := time.Since(start); elapsed > time.Second {
+        t.Errorf("readiness held back %v past a 1ns warmup limit", elapsed)
+    }
+    if cached := cachedOrganizations(server); len(cached) != 0 {
+        t.Errorf("primed %v, want nothing within the limit", cached)
+    }
+}
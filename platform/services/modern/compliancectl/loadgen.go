@@ -0,0 +1,284 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "math/rand"
+    "os"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/doganai/platform/services/modern/compliance-service/pkg/compliance"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Load generator operations
+const (
+    opCheck  = "check"  // CheckCompliance for one organization
+    opLatest = "latest" // ListLatestResults, the tenant-wide current state
+)
+
+// How often server-side saturation is sampled during a run
+const capacityPollInterval = time.Second
+
+// Pacing granularity: requests due within one tick are released together,
+// which keeps the pacer cheap at thousands of requests per second
+const pacerTick = time.Millisecond
+
+// loadgenOptions - one load generation run
+type loadgenOptions struct {
+    Target      string
+    RPS         int
+    Duration    time.Duration
+    Orgs        int            // Distinct organization IDs requested
+    Bypass      float64        // Fraction of checks with force_refresh
+    Mix         map[string]int // Relative weight per operation
+    Concurrency int            // Requests in flight at most
+    Conns       int            // gRPC connections requests are spread over
+    Timeout     time.Duration
+    Tenant      string
+    Token       string
+    Format      string // table or json
+}
+
+func parseLoadgenOptions(args []string) (*loadgenOptions, error) {
+    fs := flag.NewFlagSet("loadgen", flag.ContinueOnError)
+    opts := &loadgenOptions{}
+    var mix string
+    fs.StringVar(&opts.Target, "target", "localhost:50051", "gRPC address of the compliance service")
+    fs.IntVar(&opts.RPS, "rps", 100, "requests per second to offer")
+    fs.DurationVar(&opts.Duration, "duration", 30*time.Second, "how long to offer load")
+    fs.IntVar(&opts.Orgs, "orgs", 1000, "distinct organization IDs to spread checks over")
+    fs.Float64Var(&opts.Bypass, "bypass", 0, "fraction of checks that bypass the cache, within [0, 1]")
+    fs.StringVar(&mix, "mix", "check=100", "operation weights, e.g. \"check=90,latest=10\"")
+    fs.IntVar(&opts.Concurrency, "concurrency", 512, "requests in flight at most")
+    fs.IntVar(&opts.Conns, "conns", 4, "gRPC connections to spread requests over")
+    fs.DurationVar(&opts.Timeout, "timeout", 10*time.Second, "per-request deadline")
+    fs.StringVar(&opts.Tenant, "tenant", "", "tenant sent as x-tenant-id")
+    fs.StringVar(&opts.Token, "token", "", "bearer token sent as authorization")
+    fs.StringVar(&opts.Format, "format", "table", "report format: table or json")
+    if err := fs.Parse(args); err != nil {
+        return nil, err
+    }
+
+    if opts.RPS <= 0 || opts.Duration <= 0 || opts.Orgs <= 0 || opts.Concurrency <= 0 || opts.Conns <= 0 {
+        return nil, fmt.Errorf("rps, duration, orgs, concurrency and conns must be positive")
+    }
+    if opts.Bypass < 0 || opts.Bypass > 1 {
+        return nil, fmt.Errorf("bypass must be within [0, 1]")
+    }
+    if opts.Format != "table" && opts.Format != "json" {
+        return nil, fmt.Errorf("unsupported format %q", opts.Format)
+    }
+    var err error
+    if opts.Mix, err = parseMix(mix); err != nil {
+        return nil, err
+    }
+    return opts, nil
+}
+
+// Parse operation weights such as "check=90,latest=10"
+func parseMix(value string) (map[string]int, error) {
+    mix := make(map[string]int)
+    for _, entry := range strings.Split(value, ",") {
+        name, weight, ok := strings.Cut(strings.TrimSpace(entry), "=")
+        if !ok {
+            return nil, fmt.Errorf("invalid mix entry %q, expected op=weight", entry)
+        }
+        if name != opCheck && name != opLatest {
+            return nil, fmt.Errorf("unknown operation %q", name)
+        }
+        w, err := strconv.Atoi(weight)
+        if err != nil || w < 0 {
+            return nil, fmt.Errorf("invalid weight for %s", name)
+        }
+        mix[name] = w
+    }
+    return mix, nil
+}
+
+// Per-operation outcomes collected by one worker, merged at the end so
+// workers never contend on shared state
+type workerStats struct {
+    latencies map[string][]time.Duration
+    errors    map[string]map[string]int
+}
+
+func runLoadgen(args []string) error {
+    opts, err := parseLoadgenOptions(args)
+    if err != nil {
+        return err
+    }
+
+    conns := make([]*grpc.ClientConn, opts.Conns)
+    for i := range conns {
+        if conns[i], err = grpc.NewClient(opts.Target, grpc.WithTransportCredentials(insecure.NewCredentials())); err != nil {
+            return fmt.Errorf("failed to connect to %s: %v", opts.Target, err)
+        }
+        defer conns[i].Close()
+    }
+
+    // Weighted operation table, indexed by a random draw
+    var ops []string
+    for _, name := range []string{opCheck, opLatest} {
+        for i := 0; i < opts.Mix[name]; i++ {
+            ops = append(ops, name)
+        }
+    }
+    if len(ops) == 0 {
+        return fmt.Errorf("mix has no operation with a positive weight")
+    }
+
+    ctx := context.Background()
+    if opts.Tenant != "" || opts.Token != "" {
+        md := metadata.MD{}
+        if opts.Tenant != "" {
+            md.Set("x-tenant-id", opts.Tenant)
+        }
+        if opts.Token != "" {
+            md.Set("authorization", "Bearer "+opts.Token)
+        }
+        ctx = metadata.NewOutgoingContext(ctx, md)
+    }
+
+    // Sample server-side saturation for the whole run
+    capacity := newCapacitySampler(compliance.NewComplianceClient(conns[0]))
+    pollCtx, stopPolling := context.WithCancel(ctx)
+    go capacity.run(pollCtx)
+
+    jobs := make(chan string, opts.Concurrency)
+    stats := make([]*workerStats, opts.Concurrency)
+    var wg sync.WaitGroup
+    for i := range stats {
+        stats[i] = &workerStats{latencies: make(map[string][]time.Duration), errors: make(map[string]map[string]int)}
+        client := compliance.NewComplianceClient(conns[i%len(conns)])
+        rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(i)))
+        wg.Add(1)
+        go func(ws *workerStats) {
+            defer wg.Done()
+            for op := range jobs {
+                start := time.Now()
+                err := issue(ctx, client, op, opts, rng)
+                ws.latencies[op] = append(ws.latencies[op], time.Since(start))
+                if err != nil {
+                    if ws.errors[op] == nil {
+                        ws.errors[op] = make(map[string]int)
+                    }
+                    ws.errors[op][status.Code(err).String()]++
+                }
+            }
+        }(stats[i])
+    }
+
+    // Open-loop pacing: the offered rate does not slow down when the
+    // service does. Requests that find every worker busy are counted as
+    // dropped by the generator rather than queued.
+    var offered, dropped int64
+    pick := rand.New(rand.NewSource(time.Now().UnixNano()))
+    start := time.Now()
+    ticker := time.NewTicker(pacerTick)
+    for now := range ticker.C {
+        elapsed := now.Sub(start)
+        if elapsed >= opts.Duration {
+            break
+        }
+        due := int64(elapsed.Seconds() * float64(opts.RPS))
+        for ; offered < due; offered++ {
+            select {
+            case jobs <- ops[pick.Intn(len(ops))]:
+            default:
+                dropped++
+            }
+        }
+    }
+    ticker.Stop()
+    close(jobs)
+    wg.Wait()
+    elapsed := time.Since(start)
+    stopPolling()
+
+    report := buildLoadReport(opts, stats, elapsed, offered, dropped, capacity.summary())
+    if opts.Format == "json" {
+        return report.writeJSON(os.Stdout)
+    }
+    return report.writeTable(os.Stdout)
+}
+
+// Issue one operation
+func issue(ctx context.Context, client compliance.ComplianceClient, op string, opts *loadgenOptions, rng *rand.Rand) error {
+    ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+    defer cancel()
+
+    switch op {
+    case opLatest:
+        _, err := client.ListLatestResults(ctx, &compliance.ListLatestResultsRequest{PageSize: 50})
+        return err
+    default:
+        _, err := client.CheckCompliance(ctx, &compliance.ComplianceRequest{
+            OrganizationId: "loadgen-" + strconv.Itoa(rng.Intn(opts.Orgs)),
+            ForceRefresh:   rng.Float64() < opts.Bypass,
+        })
+        return err
+    }
+}
+
+// Saturation samples from GetCapacity
+type capacitySampler struct {
+    client  compliance.ComplianceClient
+    mu      sync.Mutex
+    samples []float64
+    failed  atomic.Int64
+}
+
+func newCapacitySampler(client compliance.ComplianceClient) *capacitySampler {
+    return &capacitySampler{client: client}
+}
+
+func (c *capacitySampler) run(ctx context.Context) {
+    ticker := time.NewTicker(capacityPollInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            capacity, err := c.client.GetCapacity(ctx, &emptypb.Empty{})
+            if err != nil {
+                c.failed.Add(1)
+                continue
+            }
+            c.mu.Lock()
+            c.samples = append(c.samples, capacity.Saturation)
+            c.mu.Unlock()
+        }
+    }
+}
+
+func (c *capacitySampler) summary() *SaturationSummary {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if len(c.samples) == 0 {
+        return nil
+    }
+    sorted := append([]float64(nil), c.samples...)
+    sort.Float64s(sorted)
+    sum := 0.0
+    for _, s := range sorted {
+        sum += s
+    }
+    return &SaturationSummary{
+        Samples: len(sorted),
+        Min:     sorted[0],
+        Mean:    sum / float64(len(sorted)),
+        Max:     sorted[len(sorted)-1],
+    }
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+    "fmt"
+    "os"
+)
+
+// Subcommands by name
+var commands = map[string]func(args []string) error{
+    "loadgen": runLoadgen,
+}
+
+func usage() {
+    fmt.Fprintln(os.Stderr, "usage: compliancectl <command> [flags]")
+    fmt.Fprintln(os.Stderr, "")
+    fmt.Fprintln(os.Stderr, "commands:")
+    fmt.Fprintln(os.Stderr, "  loadgen   drive a request mix against a compliance service and report latency")
+}
+
+func main() {
+    if len(os.Args) < 2 {
+        usage()
+        os.Exit(2)
+    }
+    command, ok := commands[os.Args[1]]
+    if !ok {
+        usage()
+        os.Exit(2)
+    }
+    if err := command(os.Args[2:]); err != nil {
+        fmt.Fprintf(os.Stderr, "compliancectl %s: %v\n", os.Args[1], err)
+        os.Exit(1)
+    }
+}
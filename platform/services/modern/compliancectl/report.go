@@ -0,0 +1,146 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "sort"
+    "text/tabwriter"
+    "time"
+)
+
+// LoadReport - outcome of a load generation run, stable as JSON so runs can
+// be compared over time
+type LoadReport struct {
+    Target      string             `json:"target"`
+    StartedAt   time.Time          `json:"started_at"`
+    DurationS   float64            `json:"duration_s"`
+    OfferedRPS  int                `json:"offered_rps"`
+    AchievedRPS float64            `json:"achieved_rps"`
+    Orgs        int                `json:"orgs"`
+    Bypass      float64            `json:"bypass"`
+    Dropped     int64              `json:"dropped"` // Not sent: every worker was busy
+    Operations  []OperationStats   `json:"operations"`
+    Saturation  *SaturationSummary `json:"saturation,omitempty"`
+}
+
+// OperationStats - latency and errors of one operation
+type OperationStats struct {
+    Operation string       `json:"operation"`
+    Requests  int          `json:"requests"`
+    ErrorRate float64      `json:"error_rate"`
+    Errors    map[string]int `json:"errors"` // By gRPC status code
+    P50Ms     float64        `json:"p50_ms"`
+    P90Ms     float64        `json:"p90_ms"`
+    P99Ms     float64        `json:"p99_ms"`
+    MaxMs     float64      `json:"max_ms"`
+}
+
+// SaturationSummary - server-side saturation sampled during the run
+type SaturationSummary struct {
+    Samples int   `json:"samples"`
+    Min     float64 `json:"min"`
+    Mean    float64 `json:"mean"`
+    Max     float64 `json:"max"`
+}
+
+func buildLoadReport(opts *loadgenOptions, stats []*workerStats, elapsed time.Duration, offered, dropped int64, saturation *SaturationSummary) *LoadReport {
+    report := &LoadReport{
+        Target:     opts.Target,
+        StartedAt:  time.Now().Add(-elapsed).UTC(),
+        DurationS:  elapsed.Seconds(),
+        OfferedRPS: opts.RPS,
+        Orgs:       opts.Orgs,
+        Bypass:     opts.Bypass,
+        Dropped:    dropped,
+        Saturation: saturation,
+    }
+
+    completed := 0
+    for _, op := range []string{opCheck, opLatest} {
+        var latencies []time.Duration
+        errors := make(map[string]int)
+        failed := 0
+        for _, ws := range stats {
+            latencies = append(latencies, ws.latencies[op]...)
+            for code, n := range ws.errors[op] {
+                errors[code] += n
+                failed += n
+            }
+        }
+        if len(latencies) == 0 {
+            continue
+        }
+        completed += len(latencies)
+        sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+        report.Operations = append(report.Operations, OperationStats{
+            Operation: op,
+            Requests:  len(latencies),
+            ErrorRate: float64(failed) / float64(len(latencies)),
+            Errors:    errors,
+            P50Ms:     percentileMs(latencies, 0.50),
+            P90Ms:     percentileMs(latencies, 0.90),
+            P99Ms:     percentileMs(latencies, 0.99),
+            MaxMs:     milliseconds(latencies[len(latencies)-1]),
+        })
+    }
+    report.AchievedRPS = float64(completed) / elapsed.Seconds()
+    return report
+}
+
+// Nearest-rank percentile of sorted latencies, in milliseconds
+func percentileMs(sorted []time.Duration, p float64) float64 {
+    rank := int(p*float64(len(sorted))+0.5) - 1
+    if rank < 0 {
+        rank = 0
+    }
+    if rank >= len(sorted) {
+        rank = len(sorted) - 1
+    }
+    return milliseconds(sorted[rank])
+}
+
+func milliseconds(d time.Duration) float64 {
+    return float64(d.Microseconds()) / 1000
+}
+
+func (r *LoadReport) writeJSON(w io.Writer) error {
+    encoder := json.NewEncoder(w)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(r)
+}
+
+func (r *LoadReport) writeTable(w io.Writer) error {
+    fmt.Fprintf(w, "target %s, %.1fs, offered %d rps, achieved %.1f rps, dropped %d\n\n",
+        r.Target, r.DurationS, r.OfferedRPS, r.AchievedRPS, r.Dropped)
+
+    tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintln(tw, "operation\trequests\terror rate\tp50 ms\tp90 ms\tp99 ms\tmax ms\terrors\t")
+    for _, op := range r.Operations {
+        codes := make([]string, 0, len(op.Errors))
+        for code := range op.Errors {
+            codes = append(codes, code)
+        }
+        sort.Strings(codes)
+        errors := "-"
+        for i, code := range codes {
+            if i == 0 {
+                errors = ""
+            } else {
+                errors += " "
+            }
+            errors += fmt.Sprintf("%s=%d", code, op.Errors[code])
+        }
+        fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%.1f\t%.1f\t%.1f\t%.1f\t%s\t\n",
+            op.Operation, op.Requests, 100*op.ErrorRate, op.P50Ms, op.P90Ms, op.P99Ms, op.MaxMs, errors)
+    }
+    if err := tw.Flush(); err != nil {
+        return err
+    }
+
+    if r.Saturation != nil {
+        fmt.Fprintf(w, "\nserver saturation over %d samples: min %.2f, mean %.2f, max %.2f\n",
+            r.Saturation.Samples, r.Saturation.Min, r.Saturation.Mean, r.Saturation.Max)
+    }
+    return nil
+}